@@ -0,0 +1,61 @@
+package allowance
+
+import (
+	"fmt"
+
+	"bank-of-dad/models"
+)
+
+// ChoreRuleOutcome is the result of applying a schedule's chore rule to a payout.
+type ChoreRuleOutcome struct {
+	AmountCents int64
+	Approved    int64
+	Total       int64
+	// Reason explains a reduced or withheld payout. Empty when the full amount is paid.
+	Reason string
+}
+
+// ApplyChoreRule computes the payout for a schedule given the number of approved and
+// total chore instances in the period. Periods with no assigned chores pay in full.
+func ApplyChoreRule(sched *models.AllowanceSchedule, approved, total int64) ChoreRuleOutcome {
+	outcome := ChoreRuleOutcome{AmountCents: sched.AmountCents, Approved: approved, Total: total}
+	if total == 0 {
+		return outcome
+	}
+
+	ratePercent := approved * 100 / total
+	switch sched.ChoreRule {
+	case models.ChoreRuleThreshold:
+		if sched.ChoreThresholdPercent == nil || ratePercent >= int64(*sched.ChoreThresholdPercent) {
+			return outcome
+		}
+		outcome.AmountCents = 0
+		outcome.Reason = fmt.Sprintf("Allowance withheld: %d of %d chores approved (%d%%), %d%% required",
+			approved, total, ratePercent, *sched.ChoreThresholdPercent)
+	case models.ChoreRuleProportional:
+		if approved == total {
+			return outcome
+		}
+		outcome.AmountCents = sched.AmountCents * approved / total
+		outcome.Reason = fmt.Sprintf("Allowance prorated: %d of %d chores approved (%d%%)",
+			approved, total, ratePercent)
+	}
+	return outcome
+}
+
+// ValidateChoreRule returns an error message if the chore rule settings are invalid, or empty string if valid.
+func ValidateChoreRule(rule models.ChoreRule, thresholdPercent *int) string {
+	switch rule {
+	case "", models.ChoreRuleNone, models.ChoreRuleProportional:
+		if thresholdPercent != nil {
+			return "chore_threshold_percent is only allowed with the threshold chore rule."
+		}
+	case models.ChoreRuleThreshold:
+		if thresholdPercent == nil || *thresholdPercent < 1 || *thresholdPercent > 100 {
+			return "chore_threshold_percent must be between 1 and 100 for the threshold chore rule."
+		}
+	default:
+		return "Chore rule must be 'none', 'threshold', or 'proportional'."
+	}
+	return ""
+}
@@ -0,0 +1,75 @@
+package allowance
+
+import (
+	"testing"
+
+	"bank-of-dad/models"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func choreRuleSchedule(rule models.ChoreRule, threshold *int) *models.AllowanceSchedule {
+	return &models.AllowanceSchedule{
+		AmountCents:           1000,
+		ChoreRule:             rule,
+		ChoreThresholdPercent: threshold,
+	}
+}
+
+// === ApplyChoreRule tests ===
+
+func TestApplyChoreRule_NoneAlwaysPaysFull(t *testing.T) {
+	outcome := ApplyChoreRule(choreRuleSchedule(models.ChoreRuleNone, nil), 0, 5)
+	assert.Equal(t, int64(1000), outcome.AmountCents)
+	assert.Empty(t, outcome.Reason)
+}
+
+func TestApplyChoreRule_NoChoresPaysFull(t *testing.T) {
+	outcome := ApplyChoreRule(choreRuleSchedule(models.ChoreRuleThreshold, intPtr(80)), 0, 0)
+	assert.Equal(t, int64(1000), outcome.AmountCents)
+	assert.Empty(t, outcome.Reason)
+}
+
+func TestApplyChoreRule_ThresholdMet(t *testing.T) {
+	outcome := ApplyChoreRule(choreRuleSchedule(models.ChoreRuleThreshold, intPtr(80)), 4, 5)
+	assert.Equal(t, int64(1000), outcome.AmountCents)
+	assert.Empty(t, outcome.Reason)
+}
+
+func TestApplyChoreRule_ThresholdMissedWithholds(t *testing.T) {
+	outcome := ApplyChoreRule(choreRuleSchedule(models.ChoreRuleThreshold, intPtr(80)), 3, 5)
+	assert.Equal(t, int64(0), outcome.AmountCents)
+	assert.Equal(t, "Allowance withheld: 3 of 5 chores approved (60%), 80% required", outcome.Reason)
+}
+
+func TestApplyChoreRule_ProportionalProrates(t *testing.T) {
+	outcome := ApplyChoreRule(choreRuleSchedule(models.ChoreRuleProportional, nil), 2, 3)
+	assert.Equal(t, int64(666), outcome.AmountCents)
+	assert.Equal(t, "Allowance prorated: 2 of 3 chores approved (66%)", outcome.Reason)
+}
+
+func TestApplyChoreRule_ProportionalAllApproved(t *testing.T) {
+	outcome := ApplyChoreRule(choreRuleSchedule(models.ChoreRuleProportional, nil), 3, 3)
+	assert.Equal(t, int64(1000), outcome.AmountCents)
+	assert.Empty(t, outcome.Reason)
+}
+
+func TestApplyChoreRule_ProportionalNoneApproved(t *testing.T) {
+	outcome := ApplyChoreRule(choreRuleSchedule(models.ChoreRuleProportional, nil), 0, 4)
+	assert.Equal(t, int64(0), outcome.AmountCents)
+	assert.Equal(t, "Allowance prorated: 0 of 4 chores approved (0%)", outcome.Reason)
+}
+
+// === ValidateChoreRule tests ===
+
+func TestValidateChoreRule(t *testing.T) {
+	assert.Empty(t, ValidateChoreRule("", nil))
+	assert.Empty(t, ValidateChoreRule(models.ChoreRuleNone, nil))
+	assert.Empty(t, ValidateChoreRule(models.ChoreRuleProportional, nil))
+	assert.Empty(t, ValidateChoreRule(models.ChoreRuleThreshold, intPtr(80)))
+	assert.NotEmpty(t, ValidateChoreRule(models.ChoreRuleThreshold, nil))
+	assert.NotEmpty(t, ValidateChoreRule(models.ChoreRuleThreshold, intPtr(0)))
+	assert.NotEmpty(t, ValidateChoreRule(models.ChoreRuleThreshold, intPtr(101)))
+	assert.NotEmpty(t, ValidateChoreRule(models.ChoreRuleProportional, intPtr(50)))
+	assert.NotEmpty(t, ValidateChoreRule("sometimes", nil))
+}
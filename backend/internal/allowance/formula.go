@@ -0,0 +1,138 @@
+package allowance
+
+import (
+	"fmt"
+	"time"
+
+	"bank-of-dad/models"
+)
+
+const (
+	MaxAgeSteps = 20
+	MaxStepAge  = 30
+)
+
+// AgeStepRequest is one row of a stepped allowance table.
+type AgeStepRequest struct {
+	MinAge      int   `json:"min_age"`
+	AmountCents int64 `json:"amount_cents"`
+}
+
+// AgeOn returns a child's age in whole years at the given instant, counting birthdays in the
+// family's timezone (loc). Only the calendar date of birthdate is used. A February 29 birthday
+// is reached on March 1 in non-leap years.
+func AgeOn(birthdate time.Time, at time.Time, loc *time.Location) int {
+	by, bm, bd := birthdate.Date()
+	y, m, d := at.In(loc).Date()
+	age := y - by
+	if m < bm || (m == bm && d < bd) {
+		age--
+	}
+	if age < 0 {
+		return 0
+	}
+	return age
+}
+
+// FormulaAmount returns what an age-based schedule pays a child of the given age.
+// ok is false when the formula yields nothing at that age (age 0 per-year, or below the first step).
+// Flat schedules always return their fixed amount.
+func FormulaAmount(sched *models.AllowanceSchedule, age int) (amount int64, ok bool) {
+	switch sched.AmountFormula {
+	case models.AmountFormulaPerYearOfAge:
+		if sched.CentsPerYearOfAge == nil {
+			return 0, false
+		}
+		amount = int64(age) * *sched.CentsPerYearOfAge
+		if amount > MaxAmountCents {
+			amount = MaxAmountCents
+		}
+		return amount, amount > 0
+	case models.AmountFormulaStepped:
+		for _, step := range sched.AgeSteps {
+			if step.MinAge <= age {
+				amount, ok = step.AmountCents, true
+			}
+		}
+		return amount, ok
+	}
+	return sched.AmountCents, true
+}
+
+// ValidateAmountFormula returns an error message if the formula settings are invalid, or empty string if valid.
+// Steps must be listed in increasing order of min_age.
+func ValidateAmountFormula(formula models.AmountFormula, centsPerYear *int64, steps []AgeStepRequest) string {
+	switch formula {
+	case "", models.AmountFormulaFlat:
+		if centsPerYear != nil || len(steps) > 0 {
+			return "cents_per_year_of_age and age_steps are only allowed with an age-based amount formula."
+		}
+	case models.AmountFormulaPerYearOfAge:
+		if centsPerYear == nil || *centsPerYear <= 0 || *centsPerYear > MaxAmountCents {
+			return "cents_per_year_of_age must be between 1 cent and $999,999.99."
+		}
+		if len(steps) > 0 {
+			return "age_steps are only allowed with the stepped amount formula."
+		}
+	case models.AmountFormulaStepped:
+		if centsPerYear != nil {
+			return "cents_per_year_of_age is only allowed with the per_year_of_age amount formula."
+		}
+		if len(steps) == 0 || len(steps) > MaxAgeSteps {
+			return "Stepped allowances require between 1 and 20 age steps."
+		}
+		for i, step := range steps {
+			if step.MinAge < 0 || step.MinAge > MaxStepAge {
+				return "Age step min_age must be between 0 and 30."
+			}
+			if step.AmountCents <= 0 || step.AmountCents > MaxAmountCents {
+				return "Age step amount must be between 1 cent and $999,999.99."
+			}
+			if i > 0 && step.MinAge <= steps[i-1].MinAge {
+				return "Age steps must be listed in increasing order of min_age."
+			}
+		}
+	default:
+		return "Amount formula must be 'flat', 'per_year_of_age', or 'stepped'."
+	}
+	return ""
+}
+
+// applyAmountFormula sets an age-based schedule's amount for the child's current age in the
+// family's timezone (loc). Returns an error code and message, or empty strings on success.
+func applyAmountFormula(sched *models.AllowanceSchedule, child *models.Child, loc *time.Location) (string, string) {
+	if sched.AmountFormula == "" || sched.AmountFormula == models.AmountFormulaFlat {
+		return "", ""
+	}
+	if child.Birthdate == nil {
+		return "birthdate_required", "Set the child's birthdate before using an age-based allowance."
+	}
+	age := AgeOn(*child.Birthdate, time.Now(), loc)
+	amount, ok := FormulaAmount(sched, age)
+	if !ok {
+		return "invalid_formula", fmt.Sprintf("The amount formula pays nothing at the child's current age (%d).", age)
+	}
+	sched.AmountCents = amount
+	return "", ""
+}
+
+// toAgeSteps converts validated step requests into models.
+func toAgeSteps(steps []AgeStepRequest) []models.AllowanceAgeStep {
+	if len(steps) == 0 {
+		return nil
+	}
+	result := make([]models.AllowanceAgeStep, len(steps))
+	for i, step := range steps {
+		result[i] = models.AllowanceAgeStep{MinAge: step.MinAge, AmountCents: step.AmountCents}
+	}
+	return result
+}
+
+// toAgeStepRequests converts stored age steps back into requests, for re-validation on update.
+func toAgeStepRequests(steps []models.AllowanceAgeStep) []AgeStepRequest {
+	result := make([]AgeStepRequest, len(steps))
+	for i, step := range steps {
+		result[i] = AgeStepRequest{MinAge: step.MinAge, AmountCents: step.AmountCents}
+	}
+	return result
+}
@@ -0,0 +1,107 @@
+package allowance
+
+import (
+	"testing"
+	"time"
+
+	"bank-of-dad/models"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func int64Ptr(i int64) *int64 {
+	return &i
+}
+
+// === AgeOn tests ===
+
+func TestAgeOn_BeforeAndOnBirthday(t *testing.T) {
+	birthdate := date(2016, time.May, 10)
+	assert.Equal(t, 9, AgeOn(birthdate, date(2026, time.May, 9), time.UTC))
+	assert.Equal(t, 10, AgeOn(birthdate, date(2026, time.May, 10), time.UTC))
+	assert.Equal(t, 10, AgeOn(birthdate, date(2026, time.December, 31), time.UTC))
+}
+
+func TestAgeOn_UsesFamilyTimezone(t *testing.T) {
+	tokyo, err := time.LoadLocation("Asia/Tokyo")
+	require.NoError(t, err)
+	birthdate := date(2016, time.May, 10)
+	// 20:00 UTC on May 9 is already May 10 in Tokyo
+	at := time.Date(2026, time.May, 9, 20, 0, 0, 0, time.UTC)
+	assert.Equal(t, 9, AgeOn(birthdate, at, time.UTC))
+	assert.Equal(t, 10, AgeOn(birthdate, at, tokyo))
+}
+
+func TestAgeOn_LeapDayBirthday(t *testing.T) {
+	birthdate := date(2016, time.February, 29)
+	assert.Equal(t, 9, AgeOn(birthdate, date(2026, time.February, 28), time.UTC))
+	assert.Equal(t, 10, AgeOn(birthdate, date(2026, time.March, 1), time.UTC))
+	assert.Equal(t, 12, AgeOn(birthdate, date(2028, time.February, 29), time.UTC))
+}
+
+func TestAgeOn_BeforeBirth(t *testing.T) {
+	assert.Equal(t, 0, AgeOn(date(2026, time.June, 1), date(2026, time.January, 1), time.UTC))
+}
+
+// === FormulaAmount tests ===
+
+func TestFormulaAmount_Flat(t *testing.T) {
+	sched := &models.AllowanceSchedule{AmountCents: 500, AmountFormula: models.AmountFormulaFlat}
+	amount, ok := FormulaAmount(sched, 8)
+	assert.True(t, ok)
+	assert.Equal(t, int64(500), amount)
+}
+
+func TestFormulaAmount_PerYearOfAge(t *testing.T) {
+	sched := &models.AllowanceSchedule{
+		AmountFormula:     models.AmountFormulaPerYearOfAge,
+		CentsPerYearOfAge: int64Ptr(100),
+	}
+	amount, ok := FormulaAmount(sched, 9)
+	assert.True(t, ok)
+	assert.Equal(t, int64(900), amount)
+
+	_, ok = FormulaAmount(sched, 0)
+	assert.False(t, ok)
+}
+
+func TestFormulaAmount_Stepped(t *testing.T) {
+	sched := &models.AllowanceSchedule{
+		AmountFormula: models.AmountFormulaStepped,
+		AgeSteps: []models.AllowanceAgeStep{
+			{MinAge: 5, AmountCents: 300},
+			{MinAge: 10, AmountCents: 800},
+			{MinAge: 13, AmountCents: 1500},
+		},
+	}
+	_, ok := FormulaAmount(sched, 4)
+	assert.False(t, ok)
+
+	cases := map[int]int64{5: 300, 9: 300, 10: 800, 12: 800, 13: 1500, 17: 1500}
+	for age, want := range cases {
+		amount, ok := FormulaAmount(sched, age)
+		assert.True(t, ok, "age %d", age)
+		assert.Equal(t, want, amount, "age %d", age)
+	}
+}
+
+// === ValidateAmountFormula tests ===
+
+func TestValidateAmountFormula(t *testing.T) {
+	steps := []AgeStepRequest{{MinAge: 5, AmountCents: 300}, {MinAge: 10, AmountCents: 800}}
+
+	assert.Empty(t, ValidateAmountFormula(models.AmountFormulaFlat, nil, nil))
+	assert.Empty(t, ValidateAmountFormula(models.AmountFormulaPerYearOfAge, int64Ptr(100), nil))
+	assert.Empty(t, ValidateAmountFormula(models.AmountFormulaStepped, nil, steps))
+
+	assert.NotEmpty(t, ValidateAmountFormula(models.AmountFormulaFlat, int64Ptr(100), nil))
+	assert.NotEmpty(t, ValidateAmountFormula(models.AmountFormulaPerYearOfAge, nil, nil))
+	assert.NotEmpty(t, ValidateAmountFormula(models.AmountFormulaPerYearOfAge, int64Ptr(0), nil))
+	assert.NotEmpty(t, ValidateAmountFormula(models.AmountFormulaStepped, nil, nil))
+	assert.NotEmpty(t, ValidateAmountFormula(models.AmountFormulaStepped, nil,
+		[]AgeStepRequest{{MinAge: 10, AmountCents: 800}, {MinAge: 5, AmountCents: 300}}))
+	assert.NotEmpty(t, ValidateAmountFormula(models.AmountFormulaStepped, nil,
+		[]AgeStepRequest{{MinAge: 5, AmountCents: 0}}))
+	assert.NotEmpty(t, ValidateAmountFormula("percentage", nil, nil))
+}
@@ -11,18 +11,45 @@ import (
 	"bank-of-dad/repositories"
 
 	"bank-of-dad/internal/middleware"
+	"bank-of-dad/internal/recurrence"
 )
 
 const (
-	MaxAmountCents = 99999999 // $999,999.99
-	MaxNoteLength  = 500
+	MaxAmountCents       = 99999999 // $999,999.99
+	MaxNoteLength        = 500
+	MaxSchedulesPerChild = 10
+	MaxSplitsPerSchedule = 5
 )
 
 // UpcomingAllowance represents a child's next scheduled allowance deposit.
 type UpcomingAllowance struct {
-	AmountCents int64     `json:"amount_cents"`
-	NextDate    time.Time `json:"next_date"`
-	Note        *string   `json:"note,omitempty"`
+	ScheduleID  int64                   `json:"schedule_id"`
+	AmountCents int64                   `json:"amount_cents"`
+	NextDate    time.Time               `json:"next_date"`
+	Note        *string                 `json:"note,omitempty"`
+	Splits      []models.AllowanceSplit `json:"splits,omitempty"`
+
+	// ChoreProgress previews the chore rule for the current period, if the schedule has one.
+	ChoreProgress *ChoreProgress `json:"chore_progress,omitempty"`
+
+	// Override is the parent's skip, hold, or custom override for this run, if any.
+	Override *models.AllowanceOverride `json:"override,omitempty"`
+	// HeldCents is held allowance that will be paid with this run; included in AmountCents.
+	HeldCents int64 `json:"held_cents,omitempty"`
+
+	// IsFinal marks the last run before the schedule completes; RemainingRuns counts down a run limit.
+	IsFinal       bool `json:"is_final,omitempty"`
+	RemainingRuns *int `json:"remaining_runs,omitempty"`
+}
+
+// ChoreProgress shows how a chore-conditional allowance is tracking before payday.
+type ChoreProgress struct {
+	Rule                 models.ChoreRule `json:"rule"`
+	ThresholdPercent     *int             `json:"threshold_percent,omitempty"`
+	ApprovedCount        int64            `json:"approved_count"`
+	TotalCount           int64            `json:"total_count"`
+	ProjectedAmountCents int64            `json:"projected_amount_cents"`
+	Reason               string           `json:"reason,omitempty"`
 }
 
 // Handler handles allowance schedule HTTP requests.
@@ -30,6 +57,9 @@ type Handler struct {
 	scheduleRepo *repositories.ScheduleRepo
 	childRepo    *repositories.ChildRepo
 	familyRepo   *repositories.FamilyRepo
+	goalRepo     *repositories.SavingsGoalRepo
+
+	choreInstanceRepo *repositories.ChoreInstanceRepo
 }
 
 // NewHandler creates a new allowance handler.
@@ -41,6 +71,16 @@ func NewHandler(scheduleRepo *repositories.ScheduleRepo, childRepo *repositories
 	}
 }
 
+// SetGoalRepo sets the savings goal repo used to validate split destinations.
+func (h *Handler) SetGoalRepo(goalRepo *repositories.SavingsGoalRepo) {
+	h.goalRepo = goalRepo
+}
+
+// SetChoreInstanceRepo sets the chore instance repo used to preview chore-conditional payouts.
+func (h *Handler) SetChoreInstanceRepo(choreInstanceRepo *repositories.ChoreInstanceRepo) {
+	h.choreInstanceRepo = choreInstanceRepo
+}
+
 // getFamilyTimezone loads the *time.Location for a family, falling back to UTC.
 func (h *Handler) getFamilyTimezone(familyID int64) *time.Location {
 	tz, err := h.familyRepo.GetTimezone(familyID)
@@ -60,23 +100,59 @@ type ErrorResponse struct {
 	Message string `json:"message,omitempty"`
 }
 
+// SplitRequest directs a percentage of each payout to a savings goal.
+type SplitRequest struct {
+	GoalID  int64 `json:"goal_id"`
+	Percent int   `json:"percent"`
+}
+
 // CreateScheduleRequest represents a request to create a schedule.
+// AmountCents is ignored for age-based amount formulas; it is derived from the child's age.
 type CreateScheduleRequest struct {
 	ChildID     int64            `json:"child_id"`
 	AmountCents int64            `json:"amount_cents"`
 	Frequency   models.Frequency `json:"frequency"`
 	DayOfWeek   *int             `json:"day_of_week,omitempty"`
 	DayOfMonth  *int             `json:"day_of_month,omitempty"`
+	RRule       *string          `json:"rrule,omitempty"`
 	Note        string           `json:"note,omitempty"`
+	Splits      []SplitRequest   `json:"splits,omitempty"`
+
+	ChoreRule             models.ChoreRule `json:"chore_rule,omitempty"`
+	ChoreThresholdPercent *int             `json:"chore_threshold_percent,omitempty"`
+
+	AmountFormula     models.AmountFormula `json:"amount_formula,omitempty"`
+	CentsPerYearOfAge *int64               `json:"cents_per_year_of_age,omitempty"`
+	AgeSteps          []AgeStepRequest     `json:"age_steps,omitempty"`
+
+	// EndDate (YYYY-MM-DD) and MaxRuns optionally end the schedule; it completes at whichever comes first.
+	EndDate string `json:"end_date,omitempty"`
+	MaxRuns *int   `json:"max_runs,omitempty"`
 }
 
 // UpdateScheduleRequest represents a request to update a schedule.
+// A non-nil Splits replaces the schedule's destinations; an empty list sends everything to the main balance.
 type UpdateScheduleRequest struct {
 	AmountCents *int64            `json:"amount_cents,omitempty"`
 	Frequency   *models.Frequency `json:"frequency,omitempty"`
 	DayOfWeek   *int              `json:"day_of_week,omitempty"`
 	DayOfMonth  *int              `json:"day_of_month,omitempty"`
+	RRule       *string           `json:"rrule,omitempty"`
 	Note        *string           `json:"note,omitempty"`
+	Splits      *[]SplitRequest   `json:"splits,omitempty"`
+
+	ChoreRule             *models.ChoreRule `json:"chore_rule,omitempty"`
+	ChoreThresholdPercent *int              `json:"chore_threshold_percent,omitempty"`
+
+	// Changing AmountFormula clears parameters that do not apply to the new formula.
+	AmountFormula     *models.AmountFormula `json:"amount_formula,omitempty"`
+	CentsPerYearOfAge *int64                `json:"cents_per_year_of_age,omitempty"`
+	AgeSteps          *[]AgeStepRequest     `json:"age_steps,omitempty"`
+
+	// An empty EndDate or a zero MaxRuns removes that limit. Raising the limits of a
+	// completed schedule makes it active again.
+	EndDate *string `json:"end_date,omitempty"`
+	MaxRuns *int    `json:"max_runs,omitempty"`
 }
 
 // ScheduleListResponse wraps a list of schedules with child names.
@@ -89,6 +165,16 @@ type UpcomingAllowancesResponse struct {
 	Allowances []UpcomingAllowance `json:"allowances"`
 }
 
+// ChildAllowancesResponse wraps all of a child's allowance schedules.
+type ChildAllowancesResponse struct {
+	Schedules []models.AllowanceSchedule `json:"schedules"`
+}
+
+// RaiseHistoryResponse wraps a child's allowance raise history.
+type RaiseHistoryResponse struct {
+	Raises []models.AllowanceRaise `json:"raises"`
+}
+
 func writeJSON(w http.ResponseWriter, status int, v interface{}) {
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(status)
@@ -115,8 +201,18 @@ func (h *Handler) HandleCreateSchedule(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	// Validate amount
-	if req.AmountCents <= 0 || req.AmountCents > MaxAmountCents {
+	// Validate amount formula, then the fixed amount for flat schedules
+	if req.AmountFormula == "" {
+		req.AmountFormula = models.AmountFormulaFlat
+	}
+	if errMsg := ValidateAmountFormula(req.AmountFormula, req.CentsPerYearOfAge, req.AgeSteps); errMsg != "" {
+		writeJSON(w, http.StatusBadRequest, ErrorResponse{
+			Error:   "invalid_formula",
+			Message: errMsg,
+		})
+		return
+	}
+	if req.AmountFormula == models.AmountFormulaFlat && (req.AmountCents <= 0 || req.AmountCents > MaxAmountCents) {
 		writeJSON(w, http.StatusBadRequest, ErrorResponse{
 			Error:   "invalid_amount",
 			Message: "Amount must be between 1 cent and $999,999.99.",
@@ -132,6 +228,13 @@ func (h *Handler) HandleCreateSchedule(w http.ResponseWriter, r *http.Request) {
 		})
 		return
 	}
+	if err := ValidateRRule(req.Frequency, req.RRule); err != "" {
+		writeJSON(w, http.StatusBadRequest, ErrorResponse{
+			Error:   "invalid_rrule",
+			Message: err,
+		})
+		return
+	}
 
 	// Validate note
 	note := strings.TrimSpace(req.Note)
@@ -143,6 +246,27 @@ func (h *Handler) HandleCreateSchedule(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	// Validate chore rule
+	if req.ChoreRule == "" {
+		req.ChoreRule = models.ChoreRuleNone
+	}
+	if errMsg := ValidateChoreRule(req.ChoreRule, req.ChoreThresholdPercent); errMsg != "" {
+		writeJSON(w, http.StatusBadRequest, ErrorResponse{
+			Error:   "invalid_chore_rule",
+			Message: errMsg,
+		})
+		return
+	}
+
+	endDate, errMsg := parseEndDate(req.EndDate)
+	if errMsg != "" {
+		writeJSON(w, http.StatusBadRequest, ErrorResponse{
+			Error:   "invalid_limits",
+			Message: errMsg,
+		})
+		return
+	}
+
 	// Verify child exists and belongs to parent's family
 	child, dbErr := h.childRepo.GetByID(req.ChildID)
 	if dbErr != nil {
@@ -169,6 +293,31 @@ func (h *Handler) HandleCreateSchedule(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	count, dbErr := h.scheduleRepo.CountByChild(req.ChildID)
+	if dbErr != nil {
+		writeJSON(w, http.StatusInternalServerError, ErrorResponse{
+			Error:   "internal_error",
+			Message: "Failed to check existing schedules.",
+		})
+		return
+	}
+	if count >= MaxSchedulesPerChild {
+		writeJSON(w, http.StatusConflict, ErrorResponse{
+			Error:   "max_schedules_reached",
+			Message: "Maximum of 10 allowance schedules per child reached.",
+		})
+		return
+	}
+
+	splits, errMsg := h.validateSplits(req.ChildID, req.Splits)
+	if errMsg != "" {
+		writeJSON(w, http.StatusBadRequest, ErrorResponse{
+			Error:   "invalid_splits",
+			Message: errMsg,
+		})
+		return
+	}
+
 	parentID := middleware.GetUserID(r)
 
 	// Build schedule
@@ -179,16 +328,56 @@ func (h *Handler) HandleCreateSchedule(w http.ResponseWriter, r *http.Request) {
 		Frequency:   req.Frequency,
 		DayOfWeek:   req.DayOfWeek,
 		DayOfMonth:  req.DayOfMonth,
+		RRule:       req.RRule,
 		Status:      models.ScheduleStatusActive,
+		Splits:      splits,
+
+		ChoreRule:             req.ChoreRule,
+		ChoreThresholdPercent: req.ChoreThresholdPercent,
+
+		AmountFormula:     req.AmountFormula,
+		CentsPerYearOfAge: req.CentsPerYearOfAge,
+		AgeSteps:          toAgeSteps(req.AgeSteps),
+
+		EndDate: endDate,
+		MaxRuns: req.MaxRuns,
 	}
 	if note != "" {
 		sched.Note = &note
 	}
 
-	// Calculate next run using family timezone
+	// Derive the amount and calculate next run using family timezone
 	loc := h.getFamilyTimezone(familyID)
-	nextRun := CalculateNextRun(sched, time.Now().UTC(), loc)
+	if code, errMsg := applyAmountFormula(sched, child, loc); errMsg != "" {
+		writeJSON(w, http.StatusBadRequest, ErrorResponse{
+			Error:   code,
+			Message: errMsg,
+		})
+		return
+	}
+	nextRun, dbErr := NextRunAfter(sched, time.Now().UTC(), loc)
+	if dbErr != nil {
+		writeJSON(w, http.StatusInternalServerError, ErrorResponse{
+			Error:   "internal_error",
+			Message: "Failed to calculate next run.",
+		})
+		return
+	}
 	sched.NextRunAt = &nextRun
+	if errMsg := ValidateLimits(sched.EndDate, sched.MaxRuns, 0, RunDate(time.Now(), loc)); errMsg != "" {
+		writeJSON(w, http.StatusBadRequest, ErrorResponse{
+			Error:   "invalid_limits",
+			Message: errMsg,
+		})
+		return
+	}
+	if limitReached(sched, 0, nextRun, loc) {
+		writeJSON(w, http.StatusBadRequest, ErrorResponse{
+			Error:   "invalid_limits",
+			Message: "End date is before the schedule's first run.",
+		})
+		return
+	}
 
 	created, dbErr := h.scheduleRepo.Create(sched)
 	if dbErr != nil {
@@ -335,7 +524,46 @@ func (h *Handler) HandleUpdateSchedule(w http.ResponseWriter, r *http.Request) {
 	}
 
 	// Apply updates
+	formulaChanged := req.AmountFormula != nil || req.CentsPerYearOfAge != nil || req.AgeSteps != nil
+	if formulaChanged {
+		formula := sched.AmountFormula
+		centsPerYear := sched.CentsPerYearOfAge
+		steps := toAgeStepRequests(sched.AgeSteps)
+		if req.AmountFormula != nil {
+			formula = *req.AmountFormula
+			if formula != models.AmountFormulaPerYearOfAge {
+				centsPerYear = nil
+			}
+			if formula != models.AmountFormulaStepped {
+				steps = nil
+			}
+		}
+		if req.CentsPerYearOfAge != nil {
+			centsPerYear = req.CentsPerYearOfAge
+		}
+		if req.AgeSteps != nil {
+			steps = *req.AgeSteps
+		}
+		if errMsg := ValidateAmountFormula(formula, centsPerYear, steps); errMsg != "" {
+			writeJSON(w, http.StatusBadRequest, ErrorResponse{
+				Error:   "invalid_formula",
+				Message: errMsg,
+			})
+			return
+		}
+		sched.AmountFormula = formula
+		sched.CentsPerYearOfAge = centsPerYear
+		sched.AgeSteps = toAgeSteps(steps)
+	}
+
 	if req.AmountCents != nil {
+		if sched.AmountFormula != "" && sched.AmountFormula != models.AmountFormulaFlat {
+			writeJSON(w, http.StatusBadRequest, ErrorResponse{
+				Error:   "invalid_amount",
+				Message: "The amount of an age-based allowance is derived from the child's age.",
+			})
+			return
+		}
 		if *req.AmountCents <= 0 || *req.AmountCents > MaxAmountCents {
 			writeJSON(w, http.StatusBadRequest, ErrorResponse{
 				Error:   "invalid_amount",
@@ -355,6 +583,12 @@ func (h *Handler) HandleUpdateSchedule(w http.ResponseWriter, r *http.Request) {
 	if req.DayOfMonth != nil {
 		sched.DayOfMonth = req.DayOfMonth
 	}
+	if req.RRule != nil {
+		sched.RRule = req.RRule
+	}
+	if sched.Frequency != models.FrequencyCustom {
+		sched.RRule = nil
+	}
 
 	// Validate frequency/day combination
 	if errMsg := ValidateFrequencyAndDay(sched.Frequency, sched.DayOfWeek, sched.DayOfMonth); errMsg != "" {
@@ -364,6 +598,13 @@ func (h *Handler) HandleUpdateSchedule(w http.ResponseWriter, r *http.Request) {
 		})
 		return
 	}
+	if errMsg := ValidateRRule(sched.Frequency, sched.RRule); errMsg != "" {
+		writeJSON(w, http.StatusBadRequest, ErrorResponse{
+			Error:   "invalid_rrule",
+			Message: errMsg,
+		})
+		return
+	}
 
 	if req.Note != nil {
 		trimmed := strings.TrimSpace(*req.Note)
@@ -381,12 +622,118 @@ func (h *Handler) HandleUpdateSchedule(w http.ResponseWriter, r *http.Request) {
 		}
 	}
 
-	// Recalculate next_run_at if frequency or day changed
-	if req.Frequency != nil || req.DayOfWeek != nil || req.DayOfMonth != nil {
-		loc := h.getFamilyTimezone(middleware.GetFamilyID(r))
-		nextRun := CalculateNextRun(sched, time.Now().UTC(), loc)
+	if req.ChoreRule != nil {
+		sched.ChoreRule = *req.ChoreRule
+		if sched.ChoreRule != models.ChoreRuleThreshold {
+			sched.ChoreThresholdPercent = nil
+		}
+	}
+	if req.ChoreThresholdPercent != nil {
+		sched.ChoreThresholdPercent = req.ChoreThresholdPercent
+	}
+	if errMsg := ValidateChoreRule(sched.ChoreRule, sched.ChoreThresholdPercent); errMsg != "" {
+		writeJSON(w, http.StatusBadRequest, ErrorResponse{
+			Error:   "invalid_chore_rule",
+			Message: errMsg,
+		})
+		return
+	}
+
+	var splits []models.AllowanceSplit
+	if req.Splits != nil {
+		var errMsg string
+		splits, errMsg = h.validateSplits(sched.ChildID, *req.Splits)
+		if errMsg != "" {
+			writeJSON(w, http.StatusBadRequest, ErrorResponse{
+				Error:   "invalid_splits",
+				Message: errMsg,
+			})
+			return
+		}
+	}
+
+	loc := h.getFamilyTimezone(middleware.GetFamilyID(r))
+	limitsChanged := req.EndDate != nil || req.MaxRuns != nil
+	if limitsChanged {
+		var endDate *time.Time
+		if req.EndDate != nil {
+			var errMsg string
+			endDate, errMsg = parseEndDate(*req.EndDate)
+			if errMsg != "" {
+				writeJSON(w, http.StatusBadRequest, ErrorResponse{
+					Error:   "invalid_limits",
+					Message: errMsg,
+				})
+				return
+			}
+			sched.EndDate = endDate
+		}
+		var maxRuns *int
+		if req.MaxRuns != nil {
+			maxRuns = req.MaxRuns
+			sched.MaxRuns = req.MaxRuns
+			if *req.MaxRuns == 0 {
+				maxRuns, sched.MaxRuns = nil, nil
+			}
+		}
+		if errMsg := ValidateLimits(endDate, maxRuns, sched.RunCount, RunDate(time.Now(), loc)); errMsg != "" {
+			writeJSON(w, http.StatusBadRequest, ErrorResponse{
+				Error:   "invalid_limits",
+				Message: errMsg,
+			})
+			return
+		}
+	}
+
+	if formulaChanged {
+		if code, errMsg := applyAmountFormula(sched, child, loc); errMsg != "" {
+			writeJSON(w, http.StatusBadRequest, ErrorResponse{
+				Error:   code,
+				Message: errMsg,
+			})
+			return
+		}
+	}
+
+	// Recalculate next_run_at if frequency or day changed, or a completed schedule is reactivated
+	reactivate := limitsChanged && sched.Status == models.ScheduleStatusCompleted
+	if req.Frequency != nil || req.DayOfWeek != nil || req.DayOfMonth != nil || req.RRule != nil || reactivate {
+		nextRun, err := NextRunAfter(sched, time.Now().UTC(), loc)
+		if err != nil {
+			writeJSON(w, http.StatusInternalServerError, ErrorResponse{
+				Error:   "internal_error",
+				Message: "Failed to calculate next run.",
+			})
+			return
+		}
 		sched.NextRunAt = &nextRun
 	}
+	if limitsChanged && sched.NextRunAt != nil && limitReached(sched, sched.RunCount, *sched.NextRunAt, loc) {
+		writeJSON(w, http.StatusBadRequest, ErrorResponse{
+			Error:   "invalid_limits",
+			Message: "End date is before the schedule's next run.",
+		})
+		return
+	}
+
+	if req.Splits != nil {
+		if err := h.scheduleRepo.ReplaceSplits(sched.ID, splits); err != nil {
+			writeJSON(w, http.StatusInternalServerError, ErrorResponse{
+				Error:   "internal_error",
+				Message: "Failed to update schedule destinations.",
+			})
+			return
+		}
+	}
+	if formulaChanged {
+		if err := h.scheduleRepo.ReplaceAgeSteps(sched.ID, sched.AgeSteps); err != nil {
+			writeJSON(w, http.StatusInternalServerError, ErrorResponse{
+				Error:   "internal_error",
+				Message: "Failed to update age steps.",
+			})
+			return
+		}
+	}
 
 	updated, err := h.scheduleRepo.Update(sched)
 	if err != nil {
@@ -396,6 +743,16 @@ func (h *Handler) HandleUpdateSchedule(w http.ResponseWriter, r *http.Request) {
 		})
 		return
 	}
+	if reactivate {
+		if err := h.scheduleRepo.UpdateStatus(sched.ID, models.ScheduleStatusActive); err != nil {
+			writeJSON(w, http.StatusInternalServerError, ErrorResponse{
+				Error:   "internal_error",
+				Message: "Failed to reactivate schedule.",
+			})
+			return
+		}
+		updated.Status = models.ScheduleStatusActive
+	}
 
 	writeJSON(w, http.StatusOK, updated)
 }
@@ -508,6 +865,13 @@ func (h *Handler) HandlePauseSchedule(w http.ResponseWriter, r *http.Request) {
 		})
 		return
 	}
+	if sched.Status == models.ScheduleStatusCompleted {
+		writeJSON(w, http.StatusBadRequest, ErrorResponse{
+			Error:   "schedule_completed",
+			Message: "Schedule has completed.",
+		})
+		return
+	}
 
 	if err := h.scheduleRepo.UpdateStatus(scheduleID, models.ScheduleStatusPaused); err != nil {
 		writeJSON(w, http.StatusInternalServerError, ErrorResponse{
@@ -573,6 +937,13 @@ func (h *Handler) HandleResumeSchedule(w http.ResponseWriter, r *http.Request) {
 		})
 		return
 	}
+	if sched.Status == models.ScheduleStatusCompleted {
+		writeJSON(w, http.StatusBadRequest, ErrorResponse{
+			Error:   "schedule_completed",
+			Message: "Schedule has completed. Extend its end date or max runs to restart it.",
+		})
+		return
+	}
 
 	if err := h.scheduleRepo.UpdateStatus(scheduleID, models.ScheduleStatusActive); err != nil {
 		writeJSON(w, http.StatusInternalServerError, ErrorResponse{
@@ -584,7 +955,14 @@ func (h *Handler) HandleResumeSchedule(w http.ResponseWriter, r *http.Request) {
 
 	// Recalculate next_run_at from now using family timezone
 	loc := h.getFamilyTimezone(middleware.GetFamilyID(r))
-	nextRun := CalculateNextRun(sched, time.Now().UTC(), loc)
+	nextRun, err := NextRunAfter(sched, time.Now().UTC(), loc)
+	if err != nil {
+		writeJSON(w, http.StatusInternalServerError, ErrorResponse{
+			Error:   "internal_error",
+			Message: "Failed to calculate next run.",
+		})
+		return
+	}
 	if err := h.scheduleRepo.UpdateNextRunAt(scheduleID, nextRun); err != nil {
 		writeJSON(w, http.StatusInternalServerError, ErrorResponse{
 			Error:   "internal_error",
@@ -597,6 +975,164 @@ func (h *Handler) HandleResumeSchedule(w http.ResponseWriter, r *http.Request) {
 	writeJSON(w, http.StatusOK, updated)
 }
 
+// SetOverrideRequest represents a request to skip, hold, or customize a single run of a schedule.
+type SetOverrideRequest struct {
+	Action      models.OverrideAction `json:"action"`
+	AmountCents *int64                `json:"amount_cents,omitempty"`
+	Note        *string               `json:"note,omitempty"`
+}
+
+// OverrideListResponse wraps a schedule's upcoming run overrides.
+type OverrideListResponse struct {
+	Overrides []models.AllowanceOverride `json:"overrides"`
+}
+
+// familySchedule looks up the schedule named by the {id} path value and checks it belongs to
+// the caller's family. On failure it writes the error response and returns nil.
+func (h *Handler) familySchedule(w http.ResponseWriter, r *http.Request) *models.AllowanceSchedule {
+	scheduleID, err := strconv.ParseInt(r.PathValue("id"), 10, 64)
+	if err != nil {
+		writeJSON(w, http.StatusBadRequest, ErrorResponse{Error: "invalid_id", Message: "Invalid schedule ID."})
+		return nil
+	}
+
+	sched, err := h.scheduleRepo.GetByID(scheduleID)
+	if err != nil {
+		writeJSON(w, http.StatusInternalServerError, ErrorResponse{Error: "internal_error", Message: "Failed to get schedule."})
+		return nil
+	}
+	if sched == nil {
+		writeJSON(w, http.StatusNotFound, ErrorResponse{Error: "not_found", Message: "Schedule not found."})
+		return nil
+	}
+
+	child, err := h.childRepo.GetByID(sched.ChildID)
+	if err != nil || child == nil || child.FamilyID != middleware.GetFamilyID(r) {
+		writeJSON(w, http.StatusNotFound, ErrorResponse{Error: "not_found", Message: "Schedule not found."})
+		return nil
+	}
+	return sched
+}
+
+// HandleListOverrides handles GET /api/schedules/{id}/overrides
+func (h *Handler) HandleListOverrides(w http.ResponseWriter, r *http.Request) {
+	if middleware.GetUserType(r) != "parent" {
+		writeJSON(w, http.StatusForbidden, ErrorResponse{Error: "forbidden", Message: "Only parents can view schedule overrides."})
+		return
+	}
+
+	sched := h.familySchedule(w, r)
+	if sched == nil {
+		return
+	}
+
+	loc := h.getFamilyTimezone(middleware.GetFamilyID(r))
+	from := time.Now()
+	if sched.NextRunAt != nil {
+		from = *sched.NextRunAt
+	}
+	overrides, err := h.scheduleRepo.ListOverrides(sched.ID, RunDate(from, loc))
+	if err != nil {
+		writeJSON(w, http.StatusInternalServerError, ErrorResponse{Error: "internal_error", Message: "Failed to list overrides."})
+		return
+	}
+	if overrides == nil {
+		overrides = []models.AllowanceOverride{}
+	}
+
+	writeJSON(w, http.StatusOK, OverrideListResponse{Overrides: overrides})
+}
+
+// HandleSetOverride handles PUT /api/schedules/{id}/overrides/{date}
+// The date must be one of the schedule's upcoming runs, in the family's timezone.
+func (h *Handler) HandleSetOverride(w http.ResponseWriter, r *http.Request) {
+	if middleware.GetUserType(r) != "parent" {
+		writeJSON(w, http.StatusForbidden, ErrorResponse{Error: "forbidden", Message: "Only parents can override schedule runs."})
+		return
+	}
+
+	sched := h.familySchedule(w, r)
+	if sched == nil {
+		return
+	}
+
+	runDate, err := time.Parse(time.DateOnly, r.PathValue("date"))
+	if err != nil {
+		writeJSON(w, http.StatusBadRequest, ErrorResponse{Error: "invalid_date", Message: "Date must be in YYYY-MM-DD format."})
+		return
+	}
+
+	var req SetOverrideRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeJSON(w, http.StatusBadRequest, ErrorResponse{Error: "invalid_request", Message: "Invalid request body."})
+		return
+	}
+	if req.Note != nil {
+		trimmed := strings.TrimSpace(*req.Note)
+		req.Note = &trimmed
+	}
+	if msg := ValidateOverride(req.Action, req.AmountCents, req.Note); msg != "" {
+		writeJSON(w, http.StatusBadRequest, ErrorResponse{Error: "invalid_override", Message: msg})
+		return
+	}
+
+	if sched.Status != models.ScheduleStatusActive {
+		writeJSON(w, http.StatusBadRequest, ErrorResponse{Error: "invalid_date", Message: "Paused schedules have no upcoming runs to override."})
+		return
+	}
+	loc := h.getFamilyTimezone(middleware.GetFamilyID(r))
+	if !IsUpcomingRun(sched, runDate, loc) {
+		writeJSON(w, http.StatusBadRequest, ErrorResponse{Error: "invalid_date", Message: "Date is not an upcoming run of this schedule."})
+		return
+	}
+
+	override, err := h.scheduleRepo.SetOverride(&models.AllowanceOverride{
+		ScheduleID:        sched.ID,
+		RunDate:           runDate,
+		Action:            req.Action,
+		AmountCents:       req.AmountCents,
+		Note:              req.Note,
+		CreatedByParentID: middleware.GetUserID(r),
+	})
+	if err != nil {
+		writeJSON(w, http.StatusInternalServerError, ErrorResponse{Error: "internal_error", Message: "Failed to save override."})
+		return
+	}
+
+	writeJSON(w, http.StatusOK, override)
+}
+
+// HandleDeleteOverride handles DELETE /api/schedules/{id}/overrides/{date}
+func (h *Handler) HandleDeleteOverride(w http.ResponseWriter, r *http.Request) {
+	if middleware.GetUserType(r) != "parent" {
+		writeJSON(w, http.StatusForbidden, ErrorResponse{Error: "forbidden", Message: "Only parents can remove schedule overrides."})
+		return
+	}
+
+	sched := h.familySchedule(w, r)
+	if sched == nil {
+		return
+	}
+
+	runDate, err := time.Parse(time.DateOnly, r.PathValue("date"))
+	if err != nil {
+		writeJSON(w, http.StatusBadRequest, ErrorResponse{Error: "invalid_date", Message: "Date must be in YYYY-MM-DD format."})
+		return
+	}
+
+	deleted, err := h.scheduleRepo.DeleteOverride(sched.ID, runDate)
+	if err != nil {
+		writeJSON(w, http.StatusInternalServerError, ErrorResponse{Error: "internal_error", Message: "Failed to remove override."})
+		return
+	}
+	if !deleted {
+		writeJSON(w, http.StatusNotFound, ErrorResponse{Error: "not_found", Message: "Override not found."})
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
 // HandleGetUpcomingAllowances handles GET /api/children/{childId}/upcoming-allowances
 func (h *Handler) HandleGetUpcomingAllowances(w http.ResponseWriter, r *http.Request) {
 	childIDStr := r.PathValue("childId")
@@ -655,27 +1191,112 @@ func (h *Handler) HandleGetUpcomingAllowances(w http.ResponseWriter, r *http.Req
 		return
 	}
 
+	loc := h.getFamilyTimezone(familyID)
 	allowances := make([]UpcomingAllowance, 0, len(schedules))
-	for _, s := range schedules {
-		if s.NextRunAt != nil {
-			allowances = append(allowances, UpcomingAllowance{
-				AmountCents: s.AmountCents,
-				NextDate:    *s.NextRunAt,
-				Note:        s.Note,
+	for i := range schedules {
+		s := &schedules[i]
+		if s.NextRunAt == nil {
+			continue
+		}
+		override, err := h.scheduleRepo.GetOverride(s.ID, RunDate(*s.NextRunAt, loc))
+		if err != nil {
+			writeJSON(w, http.StatusInternalServerError, ErrorResponse{
+				Error:   "internal_error",
+				Message: "Failed to get upcoming allowances.",
 			})
+			return
+		}
+		run := withOverride(s, override)
+		upcoming := UpcomingAllowance{
+			ScheduleID: s.ID,
+			NextDate:   *s.NextRunAt,
+			Note:       run.Note,
+			Splits:     s.Splits,
+			Override:   override,
 		}
+		// Skipped and held runs pay nothing; held allowance rolls over to the next paid run
+		if override == nil || override.Action == models.OverrideActionCustom {
+			upcoming.AmountCents = run.AmountCents + s.HeldCents
+			upcoming.HeldCents = s.HeldCents
+			upcoming.ChoreProgress = h.previewChoreRule(&run, loc)
+		}
+		if final, err := IsFinalRun(s, *s.NextRunAt, loc); err == nil && final {
+			upcoming.IsFinal = true
+			// No later run remains, so a held final run is paid as usual and a skipped one releases held allowance
+			if override != nil && override.Action == models.OverrideActionHold {
+				upcoming.AmountCents = s.AmountCents + s.HeldCents
+				upcoming.HeldCents = s.HeldCents
+			} else if override != nil && override.Action == models.OverrideActionSkip {
+				upcoming.AmountCents = s.HeldCents
+				upcoming.HeldCents = s.HeldCents
+			}
+		}
+		upcoming.RemainingRuns = RemainingRuns(s)
+		allowances = append(allowances, upcoming)
 	}
 
 	writeJSON(w, http.StatusOK, UpcomingAllowancesResponse{Allowances: allowances})
 }
 
-// SetChildAllowanceRequest represents a request to create or update a child's allowance.
+// SetChildAllowanceRequest represents a request to create or update a child's primary allowance.
+// Splits is left unchanged on update when omitted.
 type SetChildAllowanceRequest struct {
 	AmountCents int64            `json:"amount_cents"`
 	Frequency   models.Frequency `json:"frequency"`
 	DayOfWeek   *int             `json:"day_of_week,omitempty"`
 	DayOfMonth  *int             `json:"day_of_month,omitempty"`
+	RRule       *string          `json:"rrule,omitempty"`
 	Note        string           `json:"note,omitempty"`
+	Splits      *[]SplitRequest  `json:"splits,omitempty"`
+
+	// ChoreRule and ChoreThresholdPercent are left unchanged on update when ChoreRule is omitted.
+	ChoreRule             *models.ChoreRule `json:"chore_rule,omitempty"`
+	ChoreThresholdPercent *int              `json:"chore_threshold_percent,omitempty"`
+
+	// AmountCents is ignored for age-based amount formulas; it is derived from the child's age.
+	AmountFormula     models.AmountFormula `json:"amount_formula,omitempty"`
+	CentsPerYearOfAge *int64               `json:"cents_per_year_of_age,omitempty"`
+	AgeSteps          []AgeStepRequest     `json:"age_steps,omitempty"`
+}
+
+// HandleListChildAllowances handles GET /api/children/{childId}/allowances
+func (h *Handler) HandleListChildAllowances(w http.ResponseWriter, r *http.Request) {
+	childID, err := strconv.ParseInt(r.PathValue("childId"), 10, 64)
+	if err != nil {
+		writeJSON(w, http.StatusBadRequest, ErrorResponse{Error: "invalid_child_id", Message: "Invalid child ID."})
+		return
+	}
+
+	child, err := h.childRepo.GetByID(childID)
+	if err != nil {
+		writeJSON(w, http.StatusInternalServerError, ErrorResponse{Error: "internal_error", Message: "Failed to lookup child."})
+		return
+	}
+	if child == nil {
+		writeJSON(w, http.StatusNotFound, ErrorResponse{Error: "not_found", Message: "Child not found."})
+		return
+	}
+
+	if child.FamilyID != middleware.GetFamilyID(r) {
+		writeJSON(w, http.StatusForbidden, ErrorResponse{Error: "forbidden", Message: "You do not have permission to view this child's allowances."})
+		return
+	}
+
+	if middleware.GetUserType(r) == "child" && middleware.GetUserID(r) != childID {
+		writeJSON(w, http.StatusForbidden, ErrorResponse{Error: "forbidden", Message: "You can only view your own allowances."})
+		return
+	}
+
+	schedules, err := h.scheduleRepo.ListByChild(childID)
+	if err != nil {
+		writeJSON(w, http.StatusInternalServerError, ErrorResponse{Error: "internal_error", Message: "Failed to list allowances."})
+		return
+	}
+	if schedules == nil {
+		schedules = []models.AllowanceSchedule{}
+	}
+
+	writeJSON(w, http.StatusOK, ChildAllowancesResponse{Schedules: schedules})
 }
 
 // HandleGetChildAllowance handles GET /api/children/{childId}/allowance
@@ -754,7 +1375,14 @@ func (h *Handler) HandleSetChildAllowance(w http.ResponseWriter, r *http.Request
 		return
 	}
 
-	if req.AmountCents <= 0 || req.AmountCents > MaxAmountCents {
+	if req.AmountFormula == "" {
+		req.AmountFormula = models.AmountFormulaFlat
+	}
+	if errMsg := ValidateAmountFormula(req.AmountFormula, req.CentsPerYearOfAge, req.AgeSteps); errMsg != "" {
+		writeJSON(w, http.StatusBadRequest, ErrorResponse{Error: "invalid_formula", Message: errMsg})
+		return
+	}
+	if req.AmountFormula == models.AmountFormulaFlat && (req.AmountCents <= 0 || req.AmountCents > MaxAmountCents) {
 		writeJSON(w, http.StatusBadRequest, ErrorResponse{Error: "invalid_amount", Message: "Amount must be between 1 cent and $999,999.99."})
 		return
 	}
@@ -763,6 +1391,10 @@ func (h *Handler) HandleSetChildAllowance(w http.ResponseWriter, r *http.Request
 		writeJSON(w, http.StatusBadRequest, ErrorResponse{Error: "invalid_frequency", Message: errMsg})
 		return
 	}
+	if errMsg := ValidateRRule(req.Frequency, req.RRule); errMsg != "" {
+		writeJSON(w, http.StatusBadRequest, ErrorResponse{Error: "invalid_rrule", Message: errMsg})
+		return
+	}
 
 	note := strings.TrimSpace(req.Note)
 	if len(note) > MaxNoteLength {
@@ -770,6 +1402,23 @@ func (h *Handler) HandleSetChildAllowance(w http.ResponseWriter, r *http.Request
 		return
 	}
 
+	if req.ChoreRule != nil {
+		if errMsg := ValidateChoreRule(*req.ChoreRule, req.ChoreThresholdPercent); errMsg != "" {
+			writeJSON(w, http.StatusBadRequest, ErrorResponse{Error: "invalid_chore_rule", Message: errMsg})
+			return
+		}
+	}
+
+	var splits []models.AllowanceSplit
+	if req.Splits != nil {
+		var errMsg string
+		splits, errMsg = h.validateSplits(childID, *req.Splits)
+		if errMsg != "" {
+			writeJSON(w, http.StatusBadRequest, ErrorResponse{Error: "invalid_splits", Message: errMsg})
+			return
+		}
+	}
+
 	parentID := middleware.GetUserID(r)
 
 	// Check if schedule already exists for this child
@@ -787,14 +1436,41 @@ func (h *Handler) HandleSetChildAllowance(w http.ResponseWriter, r *http.Request
 		existing.Frequency = req.Frequency
 		existing.DayOfWeek = req.DayOfWeek
 		existing.DayOfMonth = req.DayOfMonth
+		existing.RRule = req.RRule
 		if note != "" {
 			existing.Note = &note
 		} else {
 			existing.Note = nil
 		}
-		nextRun := CalculateNextRun(existing, time.Now().UTC(), loc)
+		if req.ChoreRule != nil {
+			existing.ChoreRule = *req.ChoreRule
+			existing.ChoreThresholdPercent = req.ChoreThresholdPercent
+		}
+		existing.AmountFormula = req.AmountFormula
+		existing.CentsPerYearOfAge = req.CentsPerYearOfAge
+		existing.AgeSteps = toAgeSteps(req.AgeSteps)
+		if code, errMsg := applyAmountFormula(existing, child, loc); errMsg != "" {
+			writeJSON(w, http.StatusBadRequest, ErrorResponse{Error: code, Message: errMsg})
+			return
+		}
+		nextRun, err := NextRunAfter(existing, time.Now().UTC(), loc)
+		if err != nil {
+			writeJSON(w, http.StatusInternalServerError, ErrorResponse{Error: "internal_error", Message: "Failed to calculate next run."})
+			return
+		}
 		existing.NextRunAt = &nextRun
 
+		if req.Splits != nil {
+			if err := h.scheduleRepo.ReplaceSplits(existing.ID, splits); err != nil {
+				writeJSON(w, http.StatusInternalServerError, ErrorResponse{Error: "internal_error", Message: "Failed to update allowance destinations."})
+				return
+			}
+		}
+		if err := h.scheduleRepo.ReplaceAgeSteps(existing.ID, existing.AgeSteps); err != nil {
+			writeJSON(w, http.StatusInternalServerError, ErrorResponse{Error: "internal_error", Message: "Failed to update age steps."})
+			return
+		}
+
 		updated, err := h.scheduleRepo.Update(existing)
 		if err != nil {
 			writeJSON(w, http.StatusInternalServerError, ErrorResponse{Error: "internal_error", Message: "Failed to update allowance."})
@@ -810,12 +1486,30 @@ func (h *Handler) HandleSetChildAllowance(w http.ResponseWriter, r *http.Request
 			Frequency:   req.Frequency,
 			DayOfWeek:   req.DayOfWeek,
 			DayOfMonth:  req.DayOfMonth,
+			RRule:       req.RRule,
 			Status:      models.ScheduleStatusActive,
+			Splits:      splits,
+
+			AmountFormula:     req.AmountFormula,
+			CentsPerYearOfAge: req.CentsPerYearOfAge,
+			AgeSteps:          toAgeSteps(req.AgeSteps),
+		}
+		if req.ChoreRule != nil {
+			sched.ChoreRule = *req.ChoreRule
+			sched.ChoreThresholdPercent = req.ChoreThresholdPercent
 		}
 		if note != "" {
 			sched.Note = &note
 		}
-		nextRun := CalculateNextRun(sched, time.Now().UTC(), loc)
+		if code, errMsg := applyAmountFormula(sched, child, loc); errMsg != "" {
+			writeJSON(w, http.StatusBadRequest, ErrorResponse{Error: code, Message: errMsg})
+			return
+		}
+		nextRun, err := NextRunAfter(sched, time.Now().UTC(), loc)
+		if err != nil {
+			writeJSON(w, http.StatusInternalServerError, ErrorResponse{Error: "internal_error", Message: "Failed to calculate next run."})
+			return
+		}
 		sched.NextRunAt = &nextRun
 
 		created, err := h.scheduleRepo.Create(sched)
@@ -827,6 +1521,46 @@ func (h *Handler) HandleSetChildAllowance(w http.ResponseWriter, r *http.Request
 	}
 }
 
+// HandleListChildRaises handles GET /api/children/{childId}/allowance/raises
+func (h *Handler) HandleListChildRaises(w http.ResponseWriter, r *http.Request) {
+	childID, err := strconv.ParseInt(r.PathValue("childId"), 10, 64)
+	if err != nil {
+		writeJSON(w, http.StatusBadRequest, ErrorResponse{Error: "invalid_child_id", Message: "Invalid child ID."})
+		return
+	}
+
+	child, err := h.childRepo.GetByID(childID)
+	if err != nil {
+		writeJSON(w, http.StatusInternalServerError, ErrorResponse{Error: "internal_error", Message: "Failed to lookup child."})
+		return
+	}
+	if child == nil {
+		writeJSON(w, http.StatusNotFound, ErrorResponse{Error: "not_found", Message: "Child not found."})
+		return
+	}
+
+	if child.FamilyID != middleware.GetFamilyID(r) {
+		writeJSON(w, http.StatusForbidden, ErrorResponse{Error: "forbidden", Message: "You do not have permission to view this child's raises."})
+		return
+	}
+
+	if middleware.GetUserType(r) == "child" && middleware.GetUserID(r) != childID {
+		writeJSON(w, http.StatusForbidden, ErrorResponse{Error: "forbidden", Message: "You can only view your own raises."})
+		return
+	}
+
+	raises, err := h.scheduleRepo.ListRaisesByChild(childID)
+	if err != nil {
+		writeJSON(w, http.StatusInternalServerError, ErrorResponse{Error: "internal_error", Message: "Failed to list raises."})
+		return
+	}
+	if raises == nil {
+		raises = []models.AllowanceRaise{}
+	}
+
+	writeJSON(w, http.StatusOK, RaiseHistoryResponse{Raises: raises})
+}
+
 // HandleDeleteChildAllowance handles DELETE /api/children/{childId}/allowance
 func (h *Handler) HandleDeleteChildAllowance(w http.ResponseWriter, r *http.Request) {
 	userType := middleware.GetUserType(r)
@@ -903,6 +1637,10 @@ func (h *Handler) HandlePauseChildAllowance(w http.ResponseWriter, r *http.Reque
 		return
 	}
 
+	if sched.Status == models.ScheduleStatusCompleted {
+		writeJSON(w, http.StatusBadRequest, ErrorResponse{Error: "schedule_completed", Message: "Allowance has completed."})
+		return
+	}
 	if sched.Status == models.ScheduleStatusPaused {
 		writeJSON(w, http.StatusBadRequest, ErrorResponse{Error: "already_paused", Message: "Allowance is already paused."})
 		return
@@ -955,6 +1693,10 @@ func (h *Handler) HandleResumeChildAllowance(w http.ResponseWriter, r *http.Requ
 		writeJSON(w, http.StatusBadRequest, ErrorResponse{Error: "already_active", Message: "Allowance is already active."})
 		return
 	}
+	if sched.Status == models.ScheduleStatusCompleted {
+		writeJSON(w, http.StatusBadRequest, ErrorResponse{Error: "schedule_completed", Message: "Allowance has completed."})
+		return
+	}
 
 	if err := h.scheduleRepo.UpdateStatus(sched.ID, models.ScheduleStatusActive); err != nil {
 		writeJSON(w, http.StatusInternalServerError, ErrorResponse{Error: "internal_error", Message: "Failed to resume allowance."})
@@ -962,7 +1704,11 @@ func (h *Handler) HandleResumeChildAllowance(w http.ResponseWriter, r *http.Requ
 	}
 
 	loc := h.getFamilyTimezone(middleware.GetFamilyID(r))
-	nextRun := CalculateNextRun(sched, time.Now().UTC(), loc)
+	nextRun, err := NextRunAfter(sched, time.Now().UTC(), loc)
+	if err != nil {
+		writeJSON(w, http.StatusInternalServerError, ErrorResponse{Error: "internal_error", Message: "Failed to calculate next run."})
+		return
+	}
 	if err := h.scheduleRepo.UpdateNextRunAt(sched.ID, nextRun); err != nil {
 		writeJSON(w, http.StatusInternalServerError, ErrorResponse{Error: "internal_error", Message: "Failed to update allowance."})
 		return
@@ -972,6 +1718,68 @@ func (h *Handler) HandleResumeChildAllowance(w http.ResponseWriter, r *http.Requ
 	writeJSON(w, http.StatusOK, updated)
 }
 
+// previewChoreRule applies the schedule's chore rule to the chores approved so far this period.
+// Returns nil if the schedule has no chore rule or chore data is unavailable.
+func (h *Handler) previewChoreRule(sched *models.AllowanceSchedule, loc *time.Location) *ChoreProgress {
+	if h.choreInstanceRepo == nil || sched.ChoreRule == "" || sched.ChoreRule == models.ChoreRuleNone {
+		return nil
+	}
+
+	periodStart := CalculatePeriodStart(sched, *sched.NextRunAt, loc)
+	approved, total, err := h.choreInstanceRepo.CountApprovedInRange(sched.ChildID, periodStart.In(loc), sched.NextRunAt.In(loc))
+	if err != nil {
+		return nil
+	}
+
+	outcome := ApplyChoreRule(sched, approved, total)
+	return &ChoreProgress{
+		Rule:                 sched.ChoreRule,
+		ThresholdPercent:     sched.ChoreThresholdPercent,
+		ApprovedCount:        approved,
+		TotalCount:           total,
+		ProjectedAmountCents: outcome.AmountCents,
+		Reason:               outcome.Reason,
+	}
+}
+
+// validateSplits checks a schedule's savings goal destinations and converts them to models.
+// Returns an error message if the splits are invalid.
+func (h *Handler) validateSplits(childID int64, splits []SplitRequest) ([]models.AllowanceSplit, string) {
+	if len(splits) == 0 {
+		return nil, ""
+	}
+	if h.goalRepo == nil {
+		return nil, "Savings goal destinations are not available."
+	}
+	if len(splits) > MaxSplitsPerSchedule {
+		return nil, "A schedule can have at most 5 savings goal destinations."
+	}
+
+	totalPercent := 0
+	seen := make(map[int64]bool, len(splits))
+	result := make([]models.AllowanceSplit, 0, len(splits))
+	for _, split := range splits {
+		if split.Percent < 1 || split.Percent > 100 {
+			return nil, "Each split percent must be between 1 and 100."
+		}
+		if seen[split.GoalID] {
+			return nil, "Each savings goal can only appear once per schedule."
+		}
+		seen[split.GoalID] = true
+		totalPercent += split.Percent
+
+		goal, err := h.goalRepo.GetByID(split.GoalID)
+		if err != nil || goal == nil || goal.ChildID != childID || goal.Status != "active" {
+			return nil, "Split destinations must be active savings goals belonging to the child."
+		}
+		result = append(result, models.AllowanceSplit{GoalID: split.GoalID, Percent: split.Percent})
+	}
+	if totalPercent > 100 {
+		return nil, "Split percentages must not add up to more than 100."
+	}
+	return result, ""
+}
+
 // ValidateFrequencyAndDay returns an error message if the frequency/day combination is invalid.
 func ValidateFrequencyAndDay(freq models.Frequency, dayOfWeek *int, dayOfMonth *int) string {
 	switch freq {
@@ -989,8 +1797,28 @@ func ValidateFrequencyAndDay(freq models.Frequency, dayOfWeek *int, dayOfMonth *
 		if *dayOfMonth < 1 || *dayOfMonth > 31 {
 			return "day_of_month must be between 1 and 31."
 		}
+	case models.FrequencyCustom:
+		// Checked by ValidateRRule
 	default:
-		return "Frequency must be 'weekly', 'biweekly', or 'monthly'."
+		return "Frequency must be 'weekly', 'biweekly', 'monthly', or 'custom'."
+	}
+	return ""
+}
+
+// ValidateRRule returns an error message if the rrule does not suit the frequency.
+// Custom schedules require a valid RRULE; other frequencies must not have one.
+func ValidateRRule(freq models.Frequency, rrule *string) string {
+	if freq != models.FrequencyCustom {
+		if rrule != nil {
+			return "rrule is only allowed with the 'custom' frequency."
+		}
+		return ""
+	}
+	if rrule == nil {
+		return "rrule is required for custom schedules."
+	}
+	if _, err := recurrence.Parse(*rrule); err != nil {
+		return "Invalid rrule: " + err.Error() + "."
 	}
 	return ""
 }
@@ -7,6 +7,7 @@ import (
 	"net/http"
 	"net/http/httptest"
 	"testing"
+	"time"
 
 	"bank-of-dad/models"
 	"bank-of-dad/repositories"
@@ -67,6 +68,105 @@ func TestHandleCreateSchedule_InvalidFrequency(t *testing.T) {
 	assert.Equal(t, http.StatusBadRequest, rr.Code)
 }
 
+func TestHandleCreateSchedule_Success_Custom(t *testing.T) {
+	db := testutil.SetupTestDB(t)
+	family := testutil.CreateTestFamily(t, db)
+	parent := testutil.CreateTestParent(t, db, family.ID)
+	child := testutil.CreateTestChild(t, db, family.ID, "Emma")
+
+	handler := NewHandler(repositories.NewScheduleRepo(db), repositories.NewChildRepo(db), repositories.NewFamilyRepo(db))
+
+	body := fmt.Sprintf(`{"child_id":%d,"amount_cents":500,"frequency":"custom","rrule":"FREQ=MONTHLY;BYMONTHDAY=1,15"}`, child.ID)
+	req := httptest.NewRequest("POST", "/api/schedules", bytes.NewBufferString(body))
+	req = testutil.SetRequestContext(req, "parent", parent.ID, family.ID)
+
+	rr := httptest.NewRecorder()
+	handler.HandleCreateSchedule(rr, req)
+
+	assert.Equal(t, http.StatusCreated, rr.Code)
+
+	var sched models.AllowanceSchedule
+	err := json.Unmarshal(rr.Body.Bytes(), &sched)
+	require.NoError(t, err)
+	assert.Equal(t, models.FrequencyCustom, sched.Frequency)
+	require.NotNil(t, sched.RRule)
+	assert.Equal(t, "FREQ=MONTHLY;BYMONTHDAY=1,15", *sched.RRule)
+	require.NotNil(t, sched.NextRunAt)
+	assert.Contains(t, []int{1, 15}, sched.NextRunAt.Day())
+}
+
+func TestHandleCreateSchedule_CustomInvalidRRule(t *testing.T) {
+	db := testutil.SetupTestDB(t)
+	family := testutil.CreateTestFamily(t, db)
+	parent := testutil.CreateTestParent(t, db, family.ID)
+	child := testutil.CreateTestChild(t, db, family.ID, "Emma")
+
+	handler := NewHandler(repositories.NewScheduleRepo(db), repositories.NewChildRepo(db), repositories.NewFamilyRepo(db))
+
+	body := fmt.Sprintf(`{"child_id":%d,"amount_cents":500,"frequency":"custom","rrule":"FREQ=MONTHLY;BYMONTHDAY=40"}`, child.ID)
+	req := httptest.NewRequest("POST", "/api/schedules", bytes.NewBufferString(body))
+	req = testutil.SetRequestContext(req, "parent", parent.ID, family.ID)
+
+	rr := httptest.NewRecorder()
+	handler.HandleCreateSchedule(rr, req)
+
+	assert.Equal(t, http.StatusBadRequest, rr.Code)
+
+	var errResp ErrorResponse
+	require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &errResp))
+	assert.Equal(t, "invalid_rrule", errResp.Error)
+}
+
+func TestHandleCreateSchedule_PerYearOfAge(t *testing.T) {
+	db := testutil.SetupTestDB(t)
+	family := testutil.CreateTestFamily(t, db)
+	parent := testutil.CreateTestParent(t, db, family.ID)
+	child := testutil.CreateTestChild(t, db, family.ID, "Emma")
+
+	childRepo := repositories.NewChildRepo(db)
+	today := time.Now().UTC()
+	birthdate := time.Date(today.Year()-9, 1, 1, 0, 0, 0, 0, time.UTC)
+	require.NoError(t, childRepo.UpdateBirthdate(child.ID, &birthdate))
+
+	handler := NewHandler(repositories.NewScheduleRepo(db), childRepo, repositories.NewFamilyRepo(db))
+
+	body := fmt.Sprintf(`{"child_id":%d,"frequency":"weekly","day_of_week":5,"amount_formula":"per_year_of_age","cents_per_year_of_age":100}`, child.ID)
+	req := httptest.NewRequest("POST", "/api/schedules", bytes.NewBufferString(body))
+	req = testutil.SetRequestContext(req, "parent", parent.ID, family.ID)
+
+	rr := httptest.NewRecorder()
+	handler.HandleCreateSchedule(rr, req)
+
+	assert.Equal(t, http.StatusCreated, rr.Code)
+
+	var sched models.AllowanceSchedule
+	require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &sched))
+	assert.Equal(t, models.AmountFormulaPerYearOfAge, sched.AmountFormula)
+	assert.Equal(t, int64(900), sched.AmountCents)
+}
+
+func TestHandleCreateSchedule_SteppedRequiresBirthdate(t *testing.T) {
+	db := testutil.SetupTestDB(t)
+	family := testutil.CreateTestFamily(t, db)
+	parent := testutil.CreateTestParent(t, db, family.ID)
+	child := testutil.CreateTestChild(t, db, family.ID, "Emma")
+
+	handler := NewHandler(repositories.NewScheduleRepo(db), repositories.NewChildRepo(db), repositories.NewFamilyRepo(db))
+
+	body := fmt.Sprintf(`{"child_id":%d,"frequency":"weekly","day_of_week":5,"amount_formula":"stepped","age_steps":[{"min_age":5,"amount_cents":300},{"min_age":10,"amount_cents":800}]}`, child.ID)
+	req := httptest.NewRequest("POST", "/api/schedules", bytes.NewBufferString(body))
+	req = testutil.SetRequestContext(req, "parent", parent.ID, family.ID)
+
+	rr := httptest.NewRecorder()
+	handler.HandleCreateSchedule(rr, req)
+
+	assert.Equal(t, http.StatusBadRequest, rr.Code)
+
+	var errResp ErrorResponse
+	require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &errResp))
+	assert.Equal(t, "birthdate_required", errResp.Error)
+}
+
 func TestHandleCreateSchedule_MissingDayOfWeek(t *testing.T) {
 	db := testutil.SetupTestDB(t)
 	family := testutil.CreateTestFamily(t, db)
@@ -896,3 +996,351 @@ func TestHandleResumeChildAllowance_Success(t *testing.T) {
 	assert.Equal(t, models.ScheduleStatusActive, sched.Status)
 	assert.NotNil(t, sched.NextRunAt)
 }
+
+// =====================================================
+// Multiple schedules per child with goal splits
+// =====================================================
+
+func TestHandleCreateSchedule_SecondScheduleForChild(t *testing.T) {
+	db := testutil.SetupTestDB(t)
+	family := testutil.CreateTestFamily(t, db)
+	parent := testutil.CreateTestParent(t, db, family.ID)
+	child := testutil.CreateTestChild(t, db, family.ID, "Emma")
+
+	handler := NewHandler(repositories.NewScheduleRepo(db), repositories.NewChildRepo(db), repositories.NewFamilyRepo(db))
+
+	dow := 5
+	createScheduleViaHandler(t, db, parent.ID, family.ID, child.ID, "weekly", &dow, nil)
+	dom := 1
+	createScheduleViaHandler(t, db, parent.ID, family.ID, child.ID, "monthly", nil, &dom)
+
+	req := httptest.NewRequest("GET", fmt.Sprintf("/api/children/%d/allowances", child.ID), nil)
+	req.SetPathValue("childId", fmt.Sprintf("%d", child.ID))
+	req = testutil.SetRequestContext(req, "child", child.ID, family.ID)
+	rr := httptest.NewRecorder()
+	handler.HandleListChildAllowances(rr, req)
+
+	assert.Equal(t, http.StatusOK, rr.Code)
+	var resp ChildAllowancesResponse
+	err := json.Unmarshal(rr.Body.Bytes(), &resp)
+	require.NoError(t, err)
+	require.Len(t, resp.Schedules, 2)
+	assert.Equal(t, models.FrequencyWeekly, resp.Schedules[0].Frequency)
+	assert.Equal(t, models.FrequencyMonthly, resp.Schedules[1].Frequency)
+}
+
+func TestHandleCreateSchedule_WithSplits(t *testing.T) {
+	db := testutil.SetupTestDB(t)
+	family := testutil.CreateTestFamily(t, db)
+	parent := testutil.CreateTestParent(t, db, family.ID)
+	child := testutil.CreateTestChild(t, db, family.ID, "Emma")
+
+	goalRepo := repositories.NewSavingsGoalRepo(db)
+	goal, err := goalRepo.Create(child.ID, "Bike", 10000, nil)
+	require.NoError(t, err)
+
+	handler := NewHandler(repositories.NewScheduleRepo(db), repositories.NewChildRepo(db), repositories.NewFamilyRepo(db))
+	handler.SetGoalRepo(goalRepo)
+
+	body := fmt.Sprintf(`{"child_id":%d,"amount_cents":1000,"frequency":"weekly","day_of_week":5,"splits":[{"goal_id":%d,"percent":40}]}`, child.ID, goal.ID)
+	req := httptest.NewRequest("POST", "/api/schedules", bytes.NewBufferString(body))
+	req = testutil.SetRequestContext(req, "parent", parent.ID, family.ID)
+	rr := httptest.NewRecorder()
+	handler.HandleCreateSchedule(rr, req)
+
+	require.Equal(t, http.StatusCreated, rr.Code)
+	var sched models.AllowanceSchedule
+	err = json.Unmarshal(rr.Body.Bytes(), &sched)
+	require.NoError(t, err)
+	require.Len(t, sched.Splits, 1)
+	assert.Equal(t, goal.ID, sched.Splits[0].GoalID)
+	assert.Equal(t, 40, sched.Splits[0].Percent)
+}
+
+func TestHandleCreateSchedule_InvalidSplits(t *testing.T) {
+	db := testutil.SetupTestDB(t)
+	family := testutil.CreateTestFamily(t, db)
+	parent := testutil.CreateTestParent(t, db, family.ID)
+	child := testutil.CreateTestChild(t, db, family.ID, "Emma")
+	sibling := testutil.CreateTestChild(t, db, family.ID, "Liam")
+
+	goalRepo := repositories.NewSavingsGoalRepo(db)
+	goal, err := goalRepo.Create(child.ID, "Bike", 10000, nil)
+	require.NoError(t, err)
+	siblingGoal, err := goalRepo.Create(sibling.ID, "Drone", 10000, nil)
+	require.NoError(t, err)
+
+	handler := NewHandler(repositories.NewScheduleRepo(db), repositories.NewChildRepo(db), repositories.NewFamilyRepo(db))
+	handler.SetGoalRepo(goalRepo)
+
+	cases := map[string]string{
+		"over 100 percent": fmt.Sprintf(`[{"goal_id":%d,"percent":101}]`, goal.ID),
+		"zero percent":     fmt.Sprintf(`[{"goal_id":%d,"percent":0}]`, goal.ID),
+		"duplicate goal":   fmt.Sprintf(`[{"goal_id":%d,"percent":30},{"goal_id":%d,"percent":30}]`, goal.ID, goal.ID),
+		"sibling's goal":   fmt.Sprintf(`[{"goal_id":%d,"percent":30}]`, siblingGoal.ID),
+		"nonexistent goal": `[{"goal_id":99999,"percent":30}]`,
+	}
+	for name, splits := range cases {
+		t.Run(name, func(t *testing.T) {
+			body := fmt.Sprintf(`{"child_id":%d,"amount_cents":1000,"frequency":"weekly","day_of_week":5,"splits":%s}`, child.ID, splits)
+			req := httptest.NewRequest("POST", "/api/schedules", bytes.NewBufferString(body))
+			req = testutil.SetRequestContext(req, "parent", parent.ID, family.ID)
+			rr := httptest.NewRecorder()
+			handler.HandleCreateSchedule(rr, req)
+
+			assert.Equal(t, http.StatusBadRequest, rr.Code)
+			var errResp ErrorResponse
+			require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &errResp))
+			assert.Equal(t, "invalid_splits", errResp.Error)
+		})
+	}
+}
+
+func TestHandleListChildAllowances_ChildCannotSeeOther(t *testing.T) {
+	db := testutil.SetupTestDB(t)
+	family := testutil.CreateTestFamily(t, db)
+	child := testutil.CreateTestChild(t, db, family.ID, "Emma")
+	sibling := testutil.CreateTestChild(t, db, family.ID, "Liam")
+
+	handler := NewHandler(repositories.NewScheduleRepo(db), repositories.NewChildRepo(db), repositories.NewFamilyRepo(db))
+
+	req := httptest.NewRequest("GET", fmt.Sprintf("/api/children/%d/allowances", child.ID), nil)
+	req.SetPathValue("childId", fmt.Sprintf("%d", child.ID))
+	req = testutil.SetRequestContext(req, "child", sibling.ID, family.ID)
+	rr := httptest.NewRecorder()
+	handler.HandleListChildAllowances(rr, req)
+
+	assert.Equal(t, http.StatusForbidden, rr.Code)
+}
+
+// =====================================================
+// Per-run override tests
+// =====================================================
+
+func TestHandleSetOverride_SkipShowsInUpcoming(t *testing.T) {
+	db := testutil.SetupTestDB(t)
+	family := testutil.CreateTestFamily(t, db)
+	parent := testutil.CreateTestParent(t, db, family.ID)
+	child := testutil.CreateTestChild(t, db, family.ID, "Emma")
+
+	handler := NewHandler(repositories.NewScheduleRepo(db), repositories.NewChildRepo(db), repositories.NewFamilyRepo(db))
+	dow := 5
+	sched := createScheduleViaHandler(t, db, parent.ID, family.ID, child.ID, "weekly", &dow, nil)
+	est, _ := time.LoadLocation("America/New_York")
+	runDate := sched.NextRunAt.In(est).Format(time.DateOnly)
+
+	req := httptest.NewRequest("PUT", fmt.Sprintf("/api/schedules/%d/overrides/%s", sched.ID, runDate), bytes.NewBufferString(`{"action":"skip"}`))
+	req.SetPathValue("id", fmt.Sprintf("%d", sched.ID))
+	req.SetPathValue("date", runDate)
+	req = testutil.SetRequestContext(req, "parent", parent.ID, family.ID)
+	rr := httptest.NewRecorder()
+	handler.HandleSetOverride(rr, req)
+	require.Equal(t, http.StatusOK, rr.Code, rr.Body.String())
+
+	req = httptest.NewRequest("GET", fmt.Sprintf("/api/children/%d/upcoming-allowances", child.ID), nil)
+	req.SetPathValue("childId", fmt.Sprintf("%d", child.ID))
+	req = testutil.SetRequestContext(req, "child", child.ID, family.ID)
+	rr = httptest.NewRecorder()
+	handler.HandleGetUpcomingAllowances(rr, req)
+
+	require.Equal(t, http.StatusOK, rr.Code)
+	var resp UpcomingAllowancesResponse
+	require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &resp))
+	require.Len(t, resp.Allowances, 1)
+	require.NotNil(t, resp.Allowances[0].Override)
+	assert.Equal(t, models.OverrideActionSkip, resp.Allowances[0].Override.Action)
+	assert.Equal(t, int64(0), resp.Allowances[0].AmountCents)
+}
+
+func TestHandleSetOverride_CustomAmountInUpcoming(t *testing.T) {
+	db := testutil.SetupTestDB(t)
+	family := testutil.CreateTestFamily(t, db)
+	parent := testutil.CreateTestParent(t, db, family.ID)
+	child := testutil.CreateTestChild(t, db, family.ID, "Emma")
+
+	handler := NewHandler(repositories.NewScheduleRepo(db), repositories.NewChildRepo(db), repositories.NewFamilyRepo(db))
+	dow := 5
+	sched := createScheduleViaHandler(t, db, parent.ID, family.ID, child.ID, "weekly", &dow, nil)
+	est, _ := time.LoadLocation("America/New_York")
+	runDate := sched.NextRunAt.In(est).Format(time.DateOnly)
+
+	body := `{"action":"custom","amount_cents":2500,"note":"  Birthday week  "}`
+	req := httptest.NewRequest("PUT", fmt.Sprintf("/api/schedules/%d/overrides/%s", sched.ID, runDate), bytes.NewBufferString(body))
+	req.SetPathValue("id", fmt.Sprintf("%d", sched.ID))
+	req.SetPathValue("date", runDate)
+	req = testutil.SetRequestContext(req, "parent", parent.ID, family.ID)
+	rr := httptest.NewRecorder()
+	handler.HandleSetOverride(rr, req)
+	require.Equal(t, http.StatusOK, rr.Code, rr.Body.String())
+
+	req = httptest.NewRequest("GET", fmt.Sprintf("/api/children/%d/upcoming-allowances", child.ID), nil)
+	req.SetPathValue("childId", fmt.Sprintf("%d", child.ID))
+	req = testutil.SetRequestContext(req, "parent", parent.ID, family.ID)
+	rr = httptest.NewRecorder()
+	handler.HandleGetUpcomingAllowances(rr, req)
+
+	var resp UpcomingAllowancesResponse
+	require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &resp))
+	require.Len(t, resp.Allowances, 1)
+	assert.Equal(t, int64(2500), resp.Allowances[0].AmountCents)
+	require.NotNil(t, resp.Allowances[0].Note)
+	assert.Equal(t, "Birthday week", *resp.Allowances[0].Note)
+}
+
+func TestHandleSetOverride_NotARunDate(t *testing.T) {
+	db := testutil.SetupTestDB(t)
+	family := testutil.CreateTestFamily(t, db)
+	parent := testutil.CreateTestParent(t, db, family.ID)
+	child := testutil.CreateTestChild(t, db, family.ID, "Emma")
+
+	handler := NewHandler(repositories.NewScheduleRepo(db), repositories.NewChildRepo(db), repositories.NewFamilyRepo(db))
+	dow := 5
+	sched := createScheduleViaHandler(t, db, parent.ID, family.ID, child.ID, "weekly", &dow, nil)
+	est, _ := time.LoadLocation("America/New_York")
+	runDate := sched.NextRunAt.In(est).AddDate(0, 0, 1).Format(time.DateOnly)
+
+	req := httptest.NewRequest("PUT", fmt.Sprintf("/api/schedules/%d/overrides/%s", sched.ID, runDate), bytes.NewBufferString(`{"action":"skip"}`))
+	req.SetPathValue("id", fmt.Sprintf("%d", sched.ID))
+	req.SetPathValue("date", runDate)
+	req = testutil.SetRequestContext(req, "parent", parent.ID, family.ID)
+	rr := httptest.NewRecorder()
+	handler.HandleSetOverride(rr, req)
+
+	assert.Equal(t, http.StatusBadRequest, rr.Code)
+	var errResp ErrorResponse
+	require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &errResp))
+	assert.Equal(t, "invalid_date", errResp.Error)
+}
+
+func TestHandleDeleteOverride_NotFound(t *testing.T) {
+	db := testutil.SetupTestDB(t)
+	family := testutil.CreateTestFamily(t, db)
+	parent := testutil.CreateTestParent(t, db, family.ID)
+	child := testutil.CreateTestChild(t, db, family.ID, "Emma")
+
+	handler := NewHandler(repositories.NewScheduleRepo(db), repositories.NewChildRepo(db), repositories.NewFamilyRepo(db))
+	dow := 5
+	sched := createScheduleViaHandler(t, db, parent.ID, family.ID, child.ID, "weekly", &dow, nil)
+
+	req := httptest.NewRequest("DELETE", fmt.Sprintf("/api/schedules/%d/overrides/2026-02-06", sched.ID), nil)
+	req.SetPathValue("id", fmt.Sprintf("%d", sched.ID))
+	req.SetPathValue("date", "2026-02-06")
+	req = testutil.SetRequestContext(req, "parent", parent.ID, family.ID)
+	rr := httptest.NewRecorder()
+	handler.HandleDeleteOverride(rr, req)
+
+	assert.Equal(t, http.StatusNotFound, rr.Code)
+}
+
+// =====================================================
+// Schedule limit tests
+// =====================================================
+
+func TestHandleCreateSchedule_MaxRunsShowsFinalPayment(t *testing.T) {
+	db := testutil.SetupTestDB(t)
+	family := testutil.CreateTestFamily(t, db)
+	parent := testutil.CreateTestParent(t, db, family.ID)
+	child := testutil.CreateTestChild(t, db, family.ID, "Emma")
+
+	handler := NewHandler(repositories.NewScheduleRepo(db), repositories.NewChildRepo(db), repositories.NewFamilyRepo(db))
+
+	body := fmt.Sprintf(`{"child_id":%d,"amount_cents":2000,"frequency":"weekly","day_of_week":5,"max_runs":1}`, child.ID)
+	req := httptest.NewRequest("POST", "/api/schedules", bytes.NewBufferString(body))
+	req = testutil.SetRequestContext(req, "parent", parent.ID, family.ID)
+	rr := httptest.NewRecorder()
+	handler.HandleCreateSchedule(rr, req)
+	require.Equal(t, http.StatusCreated, rr.Code, rr.Body.String())
+
+	req = httptest.NewRequest("GET", fmt.Sprintf("/api/children/%d/upcoming-allowances", child.ID), nil)
+	req.SetPathValue("childId", fmt.Sprintf("%d", child.ID))
+	req = testutil.SetRequestContext(req, "parent", parent.ID, family.ID)
+	rr = httptest.NewRecorder()
+	handler.HandleGetUpcomingAllowances(rr, req)
+
+	require.Equal(t, http.StatusOK, rr.Code)
+	var resp UpcomingAllowancesResponse
+	require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &resp))
+	require.Len(t, resp.Allowances, 1)
+	assert.True(t, resp.Allowances[0].IsFinal)
+	require.NotNil(t, resp.Allowances[0].RemainingRuns)
+	assert.Equal(t, 1, *resp.Allowances[0].RemainingRuns)
+}
+
+func TestHandleCreateSchedule_EndDateBeforeFirstRun(t *testing.T) {
+	db := testutil.SetupTestDB(t)
+	family := testutil.CreateTestFamily(t, db)
+	parent := testutil.CreateTestParent(t, db, family.ID)
+	child := testutil.CreateTestChild(t, db, family.ID, "Emma")
+
+	handler := NewHandler(repositories.NewScheduleRepo(db), repositories.NewChildRepo(db), repositories.NewFamilyRepo(db))
+
+	// Monthly on the 28th never runs today, so ending today is before the first run
+	est, _ := time.LoadLocation("America/New_York")
+	today := time.Now().In(est)
+	dom := 28
+	if today.Day() == 28 {
+		dom = 27
+	}
+	body := fmt.Sprintf(`{"child_id":%d,"amount_cents":1000,"frequency":"monthly","day_of_month":%d,"end_date":"%s"}`,
+		child.ID, dom, today.Format(time.DateOnly))
+	req := httptest.NewRequest("POST", "/api/schedules", bytes.NewBufferString(body))
+	req = testutil.SetRequestContext(req, "parent", parent.ID, family.ID)
+	rr := httptest.NewRecorder()
+	handler.HandleCreateSchedule(rr, req)
+
+	assert.Equal(t, http.StatusBadRequest, rr.Code)
+	var errResp ErrorResponse
+	require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &errResp))
+	assert.Equal(t, "invalid_limits", errResp.Error)
+}
+
+func TestHandleResumeSchedule_Completed(t *testing.T) {
+	db := testutil.SetupTestDB(t)
+	family := testutil.CreateTestFamily(t, db)
+	parent := testutil.CreateTestParent(t, db, family.ID)
+	child := testutil.CreateTestChild(t, db, family.ID, "Emma")
+
+	schedRepo := repositories.NewScheduleRepo(db)
+	handler := NewHandler(schedRepo, repositories.NewChildRepo(db), repositories.NewFamilyRepo(db))
+	dow := 5
+	sched := createScheduleViaHandler(t, db, parent.ID, family.ID, child.ID, "weekly", &dow, nil)
+	require.NoError(t, schedRepo.UpdateStatus(sched.ID, models.ScheduleStatusCompleted))
+
+	req := httptest.NewRequest("POST", fmt.Sprintf("/api/schedules/%d/resume", sched.ID), nil)
+	req.SetPathValue("id", fmt.Sprintf("%d", sched.ID))
+	req = testutil.SetRequestContext(req, "parent", parent.ID, family.ID)
+	rr := httptest.NewRecorder()
+	handler.HandleResumeSchedule(rr, req)
+
+	assert.Equal(t, http.StatusBadRequest, rr.Code)
+	var errResp ErrorResponse
+	require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &errResp))
+	assert.Equal(t, "schedule_completed", errResp.Error)
+}
+
+func TestHandleUpdateSchedule_ExtendingLimitsReactivates(t *testing.T) {
+	db := testutil.SetupTestDB(t)
+	family := testutil.CreateTestFamily(t, db)
+	parent := testutil.CreateTestParent(t, db, family.ID)
+	child := testutil.CreateTestChild(t, db, family.ID, "Emma")
+
+	schedRepo := repositories.NewScheduleRepo(db)
+	handler := NewHandler(schedRepo, repositories.NewChildRepo(db), repositories.NewFamilyRepo(db))
+	dow := 5
+	sched := createScheduleViaHandler(t, db, parent.ID, family.ID, child.ID, "weekly", &dow, nil)
+	require.NoError(t, db.Model(&models.AllowanceSchedule{}).Where("id = ?", sched.ID).
+		Updates(map[string]interface{}{"max_runs": 2, "run_count": 2, "status": "completed", "next_run_at": nil}).Error)
+
+	req := httptest.NewRequest("PUT", fmt.Sprintf("/api/schedules/%d", sched.ID), bytes.NewBufferString(`{"max_runs":4}`))
+	req.SetPathValue("id", fmt.Sprintf("%d", sched.ID))
+	req = testutil.SetRequestContext(req, "parent", parent.ID, family.ID)
+	rr := httptest.NewRecorder()
+	handler.HandleUpdateSchedule(rr, req)
+	require.Equal(t, http.StatusOK, rr.Code, rr.Body.String())
+
+	updated, err := schedRepo.GetByID(sched.ID)
+	require.NoError(t, err)
+	assert.Equal(t, models.ScheduleStatusActive, updated.Status)
+	assert.NotNil(t, updated.NextRunAt)
+	assert.Equal(t, 4, *updated.MaxRuns)
+}
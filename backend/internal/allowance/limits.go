@@ -0,0 +1,96 @@
+package allowance
+
+import (
+	"time"
+
+	"bank-of-dad/models"
+)
+
+// MaxRunsLimit caps the max_runs a schedule can be given.
+const MaxRunsLimit = 1000
+
+// ValidateLimits returns an error message if the end date or run limit is invalid, or empty string if valid.
+// today is the current date in the family's timezone, as from RunDate.
+func ValidateLimits(endDate *time.Time, maxRuns *int, runCount int, today time.Time) string {
+	if endDate != nil && endDate.Before(today) {
+		return "End date cannot be in the past."
+	}
+	if maxRuns != nil {
+		if *maxRuns <= 0 || *maxRuns > MaxRunsLimit {
+			return "max_runs must be between 1 and 1000."
+		}
+		if *maxRuns <= runCount {
+			return "max_runs must be greater than the number of runs so far."
+		}
+	}
+	return ""
+}
+
+// parseEndDate parses an end_date request value. An empty string clears the end date.
+func parseEndDate(value string) (*time.Time, string) {
+	if value == "" {
+		return nil, ""
+	}
+	endDate, err := time.Parse(time.DateOnly, value)
+	if err != nil {
+		return nil, "End date must be in YYYY-MM-DD format."
+	}
+	return &endDate, ""
+}
+
+// limitReached reports whether a schedule that has made runCount runs, and would next run at
+// nextRun, is done: it has used up MaxRuns or nextRun falls after EndDate.
+func limitReached(sched *models.AllowanceSchedule, runCount int, nextRun time.Time, loc *time.Location) bool {
+	if sched.MaxRuns != nil && runCount >= *sched.MaxRuns {
+		return true
+	}
+	return sched.EndDate != nil && RunDate(nextRun, loc).After(*sched.EndDate)
+}
+
+// IsFinalRun reports whether the run at runAt is the schedule's last before it completes.
+func IsFinalRun(sched *models.AllowanceSchedule, runAt time.Time, loc *time.Location) (bool, error) {
+	if sched.MaxRuns == nil && sched.EndDate == nil {
+		return false, nil
+	}
+	next, err := NextRunAfter(sched, runAt, loc)
+	if err != nil {
+		return false, err
+	}
+	return limitReached(sched, sched.RunCount+1, next, loc), nil
+}
+
+// RemainingRuns returns how many runs are left before MaxRuns, or nil if the schedule has no run limit.
+func RemainingRuns(sched *models.AllowanceSchedule) *int {
+	if sched.MaxRuns == nil {
+		return nil
+	}
+	remaining := *sched.MaxRuns - sched.RunCount
+	if remaining < 0 {
+		remaining = 0
+	}
+	return &remaining
+}
+
+// UpcomingRuns returns the schedule's runs from its next run onwards that fall in [from, to),
+// stopping at its end date or run limit. Returns nil if the schedule has no next run.
+func UpcomingRuns(sched *models.AllowanceSchedule, from, to time.Time, loc *time.Location) ([]time.Time, error) {
+	if sched.NextRunAt == nil {
+		return nil, nil
+	}
+	var runs []time.Time
+	run := *sched.NextRunAt
+	for runCount := sched.RunCount; run.Before(to); runCount++ {
+		if limitReached(sched, runCount, run, loc) {
+			break
+		}
+		if !run.Before(from) {
+			runs = append(runs, run)
+		}
+		next, err := NextRunAfter(sched, run, loc)
+		if err != nil {
+			return nil, err
+		}
+		run = next
+	}
+	return runs, nil
+}
@@ -0,0 +1,124 @@
+package allowance
+
+import (
+	"testing"
+	"time"
+
+	"bank-of-dad/models"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func weeklyFriday(next time.Time) *models.AllowanceSchedule {
+	return &models.AllowanceSchedule{
+		Frequency: models.FrequencyWeekly,
+		DayOfWeek: intPtr(5),
+		NextRunAt: &next,
+	}
+}
+
+// === ValidateLimits tests ===
+
+func TestValidateLimits(t *testing.T) {
+	today := date(2026, time.February, 2)
+	past := date(2026, time.February, 1)
+	future := date(2026, time.April, 10)
+
+	assert.Empty(t, ValidateLimits(nil, nil, 0, today))
+	assert.Empty(t, ValidateLimits(&future, intPtr(10), 0, today))
+	assert.Empty(t, ValidateLimits(&today, nil, 0, today))
+
+	assert.NotEmpty(t, ValidateLimits(&past, nil, 0, today))
+	assert.NotEmpty(t, ValidateLimits(nil, intPtr(0), 0, today))
+	assert.NotEmpty(t, ValidateLimits(nil, intPtr(MaxRunsLimit+1), 0, today))
+	assert.NotEmpty(t, ValidateLimits(nil, intPtr(3), 3, today), "already made 3 runs")
+}
+
+// === IsFinalRun tests ===
+
+func TestIsFinalRun_MaxRuns(t *testing.T) {
+	sched := weeklyFriday(date(2026, time.February, 6))
+	sched.MaxRuns = intPtr(10)
+
+	sched.RunCount = 8
+	final, err := IsFinalRun(sched, *sched.NextRunAt, time.UTC)
+	require.NoError(t, err)
+	assert.False(t, final)
+
+	sched.RunCount = 9
+	final, err = IsFinalRun(sched, *sched.NextRunAt, time.UTC)
+	require.NoError(t, err)
+	assert.True(t, final)
+}
+
+func TestIsFinalRun_EndDate(t *testing.T) {
+	sched := weeklyFriday(date(2026, time.February, 6))
+	endDate := date(2026, time.February, 12)
+	sched.EndDate = &endDate
+
+	// The run after Feb 6 is Feb 13, past the end date
+	final, err := IsFinalRun(sched, *sched.NextRunAt, time.UTC)
+	require.NoError(t, err)
+	assert.True(t, final)
+
+	endDate = date(2026, time.February, 13)
+	final, err = IsFinalRun(sched, *sched.NextRunAt, time.UTC)
+	require.NoError(t, err)
+	assert.False(t, final, "a run on the end date is still paid")
+}
+
+func TestIsFinalRun_NoLimits(t *testing.T) {
+	sched := weeklyFriday(date(2026, time.February, 6))
+	final, err := IsFinalRun(sched, *sched.NextRunAt, time.UTC)
+	require.NoError(t, err)
+	assert.False(t, final)
+}
+
+// === RemainingRuns tests ===
+
+func TestRemainingRuns(t *testing.T) {
+	sched := &models.AllowanceSchedule{RunCount: 4}
+	assert.Nil(t, RemainingRuns(sched))
+
+	sched.MaxRuns = intPtr(10)
+	require.NotNil(t, RemainingRuns(sched))
+	assert.Equal(t, 6, *RemainingRuns(sched))
+}
+
+// === IsUpcomingRun with limits ===
+
+func TestIsUpcomingRun_RespectsLimits(t *testing.T) {
+	sched := weeklyFriday(date(2026, time.February, 6))
+	sched.MaxRuns = intPtr(3)
+	sched.RunCount = 1
+
+	// Runs 2 and 3 are Feb 6 and Feb 13
+	assert.True(t, IsUpcomingRun(sched, date(2026, time.February, 13), time.UTC))
+	assert.False(t, IsUpcomingRun(sched, date(2026, time.February, 20), time.UTC))
+
+	sched.MaxRuns = nil
+	endDate := date(2026, time.February, 10)
+	sched.EndDate = &endDate
+	assert.True(t, IsUpcomingRun(sched, date(2026, time.February, 6), time.UTC))
+	assert.False(t, IsUpcomingRun(sched, date(2026, time.February, 13), time.UTC))
+}
+
+// === UpcomingRuns tests ===
+
+func TestUpcomingRuns_WithinRangeAndLimits(t *testing.T) {
+	sched := weeklyFriday(date(2026, time.February, 6))
+
+	runs, err := UpcomingRuns(sched, date(2026, time.February, 10), date(2026, time.March, 1), time.UTC)
+	require.NoError(t, err)
+	assert.Equal(t, []time.Time{
+		date(2026, time.February, 13),
+		date(2026, time.February, 20),
+		date(2026, time.February, 27),
+	}, runs)
+
+	sched.MaxRuns = intPtr(2)
+	runs, err = UpcomingRuns(sched, date(2026, time.February, 1), date(2026, time.March, 1), time.UTC)
+	require.NoError(t, err)
+	assert.Equal(t, []time.Time{date(2026, time.February, 6), date(2026, time.February, 13)}, runs)
+}
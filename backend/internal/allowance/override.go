@@ -0,0 +1,83 @@
+package allowance
+
+import (
+	"time"
+
+	"bank-of-dad/models"
+)
+
+// MaxOverrideDaysAhead limits how far ahead a run can be overridden.
+const MaxOverrideDaysAhead = 366
+
+// RunDate returns the calendar date of a run in the family's timezone (loc),
+// as midnight UTC — the form in which override dates are stored.
+func RunDate(runAt time.Time, loc *time.Location) time.Time {
+	y, m, d := runAt.In(loc).Date()
+	return time.Date(y, m, d, 0, 0, 0, 0, time.UTC)
+}
+
+// IsUpcomingRun reports whether date (a calendar date, as from RunDate) is one of the
+// schedule's runs from its next run onwards, within MaxOverrideDaysAhead and the schedule's limits.
+func IsUpcomingRun(sched *models.AllowanceSchedule, date time.Time, loc *time.Location) bool {
+	if sched.NextRunAt == nil {
+		return false
+	}
+	horizon := RunDate(*sched.NextRunAt, loc).AddDate(0, 0, MaxOverrideDaysAhead)
+	run := *sched.NextRunAt
+	for runCount := sched.RunCount; ; runCount++ {
+		if limitReached(sched, runCount, run, loc) {
+			return false
+		}
+		rd := RunDate(run, loc)
+		if rd.Equal(date) {
+			return true
+		}
+		if rd.After(date) || rd.After(horizon) {
+			return false
+		}
+		next, err := NextRunAfter(sched, run, loc)
+		if err != nil {
+			return false
+		}
+		run = next
+	}
+}
+
+// ValidateOverride returns an error message if the override settings are invalid, or empty string if valid.
+func ValidateOverride(action models.OverrideAction, amountCents *int64, note *string) string {
+	switch action {
+	case models.OverrideActionSkip, models.OverrideActionHold:
+		if amountCents != nil || note != nil {
+			return "amount_cents and note are only allowed with the custom override action."
+		}
+	case models.OverrideActionCustom:
+		if amountCents == nil && note == nil {
+			return "Custom overrides require amount_cents, note, or both."
+		}
+		if amountCents != nil && (*amountCents <= 0 || *amountCents > MaxAmountCents) {
+			return "Amount must be between 1 cent and $999,999.99."
+		}
+		if note != nil && len(*note) > MaxNoteLength {
+			return "Note must be 500 characters or less."
+		}
+	default:
+		return "Override action must be 'skip', 'hold', or 'custom'."
+	}
+	return ""
+}
+
+// withOverride returns a copy of sched with a custom override's amount and note applied.
+// Skip and hold overrides leave the copy unchanged; callers handle them before paying.
+func withOverride(sched *models.AllowanceSchedule, override *models.AllowanceOverride) models.AllowanceSchedule {
+	run := *sched
+	if override == nil || override.Action != models.OverrideActionCustom {
+		return run
+	}
+	if override.AmountCents != nil {
+		run.AmountCents = *override.AmountCents
+	}
+	if override.Note != nil {
+		run.Note = override.Note
+	}
+	return run
+}
@@ -0,0 +1,117 @@
+package allowance
+
+import (
+	"testing"
+	"time"
+
+	"bank-of-dad/models"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func strPtr(s string) *string {
+	return &s
+}
+
+// === RunDate tests ===
+
+func TestRunDate_UsesFamilyTimezone(t *testing.T) {
+	ny, err := time.LoadLocation("America/New_York")
+	require.NoError(t, err)
+	// Midnight Friday in New York is 05:00 UTC the same day
+	runAt := dateIn(2026, time.February, 6, ny)
+	assert.Equal(t, date(2026, time.February, 6), RunDate(runAt, ny))
+
+	// 02:00 UTC on Feb 7 is still Feb 6 in New York
+	late := time.Date(2026, time.February, 7, 2, 0, 0, 0, time.UTC)
+	assert.Equal(t, date(2026, time.February, 6), RunDate(late, ny))
+	assert.Equal(t, date(2026, time.February, 7), RunDate(late, time.UTC))
+}
+
+// === IsUpcomingRun tests ===
+
+func TestIsUpcomingRun_Weekly(t *testing.T) {
+	next := date(2026, time.February, 6) // Friday
+	sched := &models.AllowanceSchedule{
+		Frequency: models.FrequencyWeekly,
+		DayOfWeek: intPtr(5),
+		NextRunAt: &next,
+	}
+
+	assert.True(t, IsUpcomingRun(sched, date(2026, time.February, 6), time.UTC))
+	assert.True(t, IsUpcomingRun(sched, date(2026, time.February, 20), time.UTC))
+	assert.False(t, IsUpcomingRun(sched, date(2026, time.February, 7), time.UTC), "not a Friday")
+	assert.False(t, IsUpcomingRun(sched, date(2026, time.January, 30), time.UTC), "already past")
+}
+
+func TestIsUpcomingRun_Biweekly(t *testing.T) {
+	next := date(2026, time.February, 6)
+	sched := &models.AllowanceSchedule{
+		Frequency: models.FrequencyBiweekly,
+		DayOfWeek: intPtr(5),
+		NextRunAt: &next,
+	}
+
+	assert.True(t, IsUpcomingRun(sched, date(2026, time.February, 20), time.UTC))
+	assert.False(t, IsUpcomingRun(sched, date(2026, time.February, 13), time.UTC), "off week")
+}
+
+func TestIsUpcomingRun_BeyondHorizon(t *testing.T) {
+	next := date(2026, time.February, 6)
+	sched := &models.AllowanceSchedule{
+		Frequency: models.FrequencyWeekly,
+		DayOfWeek: intPtr(5),
+		NextRunAt: &next,
+	}
+
+	assert.False(t, IsUpcomingRun(sched, date(2028, time.February, 4), time.UTC))
+}
+
+func TestIsUpcomingRun_NoNextRun(t *testing.T) {
+	sched := &models.AllowanceSchedule{Frequency: models.FrequencyWeekly, DayOfWeek: intPtr(5)}
+	assert.False(t, IsUpcomingRun(sched, date(2026, time.February, 6), time.UTC))
+}
+
+// === ValidateOverride tests ===
+
+func TestValidateOverride(t *testing.T) {
+	assert.Empty(t, ValidateOverride(models.OverrideActionSkip, nil, nil))
+	assert.Empty(t, ValidateOverride(models.OverrideActionHold, nil, nil))
+	assert.Empty(t, ValidateOverride(models.OverrideActionCustom, int64Ptr(2500), nil))
+	assert.Empty(t, ValidateOverride(models.OverrideActionCustom, nil, strPtr("Birthday week")))
+
+	assert.NotEmpty(t, ValidateOverride(models.OverrideActionSkip, int64Ptr(500), nil))
+	assert.NotEmpty(t, ValidateOverride(models.OverrideActionHold, nil, strPtr("note")))
+	assert.NotEmpty(t, ValidateOverride(models.OverrideActionCustom, nil, nil))
+	assert.NotEmpty(t, ValidateOverride(models.OverrideActionCustom, int64Ptr(0), nil))
+	assert.NotEmpty(t, ValidateOverride(models.OverrideActionCustom, int64Ptr(MaxAmountCents+1), nil))
+	assert.NotEmpty(t, ValidateOverride("double", nil, nil))
+}
+
+// === withOverride tests ===
+
+func TestWithOverride_Custom(t *testing.T) {
+	sched := &models.AllowanceSchedule{AmountCents: 1000, Note: strPtr("Weekly allowance")}
+
+	run := withOverride(sched, &models.AllowanceOverride{
+		Action:      models.OverrideActionCustom,
+		AmountCents: int64Ptr(2500),
+	})
+	assert.Equal(t, int64(2500), run.AmountCents)
+	assert.Equal(t, "Weekly allowance", *run.Note)
+	assert.Equal(t, int64(1000), sched.AmountCents, "schedule itself is unchanged")
+
+	run = withOverride(sched, &models.AllowanceOverride{
+		Action: models.OverrideActionCustom,
+		Note:   strPtr("Birthday bonus"),
+	})
+	assert.Equal(t, int64(1000), run.AmountCents)
+	assert.Equal(t, "Birthday bonus", *run.Note)
+}
+
+func TestWithOverride_NoneOrSkip(t *testing.T) {
+	sched := &models.AllowanceSchedule{AmountCents: 1000}
+	assert.Equal(t, int64(1000), withOverride(sched, nil).AmountCents)
+	assert.Equal(t, int64(1000), withOverride(sched, &models.AllowanceOverride{Action: models.OverrideActionSkip}).AmountCents)
+}
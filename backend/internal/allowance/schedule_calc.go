@@ -1,96 +1,69 @@
 package allowance
 
 import (
+	"fmt"
 	"time"
 
+	"bank-of-dad/internal/recurrence"
 	"bank-of-dad/models"
 )
 
-// CalculateNextRun determines the next run time for a schedule based on its frequency.
-// Used when creating a new schedule or resuming a paused one.
-// The loc parameter specifies the family's timezone — next_run_at is computed as
-// midnight in that timezone, returned as the equivalent UTC time.
-func CalculateNextRun(sched *models.AllowanceSchedule, after time.Time, loc *time.Location) time.Time {
+// ScheduleRule returns the recurrence rule for a schedule's frequency.
+// Weekly, biweekly and monthly schedules map onto equivalent rules; monthly days past
+// the end of a short month fall back to its last day. Custom schedules use their stored RRULE.
+func ScheduleRule(sched *models.AllowanceSchedule) (recurrence.Rule, error) {
 	switch sched.Frequency {
-	case models.FrequencyWeekly:
-		return nextWeeklyDate(*sched.DayOfWeek, after, loc)
-	case models.FrequencyBiweekly:
-		return nextBiweeklyDate(*sched.DayOfWeek, after, loc)
+	case models.FrequencyWeekly, models.FrequencyBiweekly:
+		if sched.DayOfWeek == nil {
+			return recurrence.Rule{}, fmt.Errorf("%s schedule has no day_of_week", sched.Frequency)
+		}
+		interval := 1
+		if sched.Frequency == models.FrequencyBiweekly {
+			interval = 2
+		}
+		return recurrence.WeeklyOn(time.Weekday(*sched.DayOfWeek), interval), nil
 	case models.FrequencyMonthly:
-		return nextMonthlyDate(*sched.DayOfMonth, after, loc)
+		if sched.DayOfMonth == nil {
+			return recurrence.Rule{}, fmt.Errorf("monthly schedule has no day_of_month")
+		}
+		return recurrence.MonthlyOn(*sched.DayOfMonth), nil
+	case models.FrequencyCustom:
+		if sched.RRule == nil {
+			return recurrence.Rule{}, fmt.Errorf("custom schedule has no rrule")
+		}
+		return recurrence.Parse(*sched.RRule)
 	}
-	return after
+	return recurrence.Rule{}, fmt.Errorf("unsupported frequency %q", sched.Frequency)
 }
 
-// CalculateNextRunAfterExecution determines the next run time after a schedule has just executed.
-// For weekly: next week same day. For biweekly: 14 days later. For monthly: same day next month.
-// The loc parameter specifies the family's timezone for midnight calculation.
-func CalculateNextRunAfterExecution(sched *models.AllowanceSchedule, executedAt time.Time, loc *time.Location) time.Time {
-	// Convert executedAt to the family's timezone to get the correct calendar date
-	localExecuted := executedAt.In(loc)
-	switch sched.Frequency {
-	case models.FrequencyWeekly:
-		next := localExecuted.AddDate(0, 0, 7)
-		return time.Date(next.Year(), next.Month(), next.Day(), 0, 0, 0, 0, loc)
-	case models.FrequencyBiweekly:
-		next := localExecuted.AddDate(0, 0, 14)
-		return time.Date(next.Year(), next.Month(), next.Day(), 0, 0, 0, 0, loc)
-	case models.FrequencyMonthly:
-		return nextMonthlyDate(*sched.DayOfMonth, executedAt, loc)
+// NextRunAfter returns the schedule's first run date after the date of after,
+// as midnight in the family's timezone (loc). It is used both when creating or
+// resuming a schedule and when advancing one that has just executed.
+//
+// Intervals are counted from the date of after, so a biweekly schedule created on
+// its weekday first runs two weeks later, and one that executes runs again two weeks later.
+func NextRunAfter(sched *models.AllowanceSchedule, after time.Time, loc *time.Location) (time.Time, error) {
+	rule, err := ScheduleRule(sched)
+	if err != nil {
+		return time.Time{}, err
 	}
-	return executedAt
-}
-
-// nextWeeklyDate returns the next occurrence of the given weekday strictly after 'after'.
-// The result is midnight in the given timezone on that day.
-func nextWeeklyDate(dayOfWeek int, after time.Time, loc *time.Location) time.Time {
-	after = after.In(loc)
-	daysUntil := (dayOfWeek - int(after.Weekday()) + 7) % 7
-	if daysUntil == 0 {
-		daysUntil = 7 // If today is the day, go to next week
+	next := rule.Next(after, loc)
+	if next.IsZero() {
+		return time.Time{}, recurrence.ErrNoOccurrence
 	}
-	return time.Date(after.Year(), after.Month(), after.Day()+daysUntil,
-		0, 0, 0, 0, loc)
+	return next, nil
 }
 
-// nextBiweeklyDate returns the next matching weekday. If after is already on that day,
-// it advances 14 days. Otherwise it finds the next occurrence (which will be the first one).
-// The result is midnight in the given timezone on that day.
-func nextBiweeklyDate(dayOfWeek int, after time.Time, loc *time.Location) time.Time {
-	after = after.In(loc)
-	daysUntil := (dayOfWeek - int(after.Weekday()) + 7) % 7
-	if daysUntil == 0 {
-		daysUntil = 14 // Same day → 14 days out
+// CalculatePeriodStart returns the start of the allowance period that ends at runAt,
+// i.e. the run before it, at midnight in loc. Falls back to runAt if the schedule has no earlier run.
+func CalculatePeriodStart(sched *models.AllowanceSchedule, runAt time.Time, loc *time.Location) time.Time {
+	rule, err := ScheduleRule(sched)
+	if err != nil {
+		return runAt
 	}
-	return time.Date(after.Year(), after.Month(), after.Day()+daysUntil,
-		0, 0, 0, 0, loc)
-}
-
-// nextMonthlyDate returns the next occurrence of the given day-of-month strictly after 'after'.
-// If the target day exceeds the days in a month, it clamps to the last day.
-// The result is midnight in the given timezone on that day.
-func nextMonthlyDate(dayOfMonth int, after time.Time, loc *time.Location) time.Time {
-	after = after.In(loc)
-	year, month, day := after.Date()
-
-	// Try this month first
-	clampedDay := min(dayOfMonth, daysInMonth(year, month))
-	target := time.Date(year, month, clampedDay, 0, 0, 0, 0, loc)
-	if target.After(after) || (clampedDay > day) {
-		return target
-	}
-
-	// Otherwise next month
-	month++
-	if month > 12 {
-		month = 1
-		year++
+	prev := rule.Prev(runAt, loc)
+	if prev.IsZero() {
+		return runAt
 	}
-	clampedDay = min(dayOfMonth, daysInMonth(year, month))
-	return time.Date(year, month, clampedDay, 0, 0, 0, 0, loc)
-}
-
-// daysInMonth returns the number of days in the given month.
-func daysInMonth(year int, month time.Month) int {
-	return time.Date(year, month+1, 0, 0, 0, 0, 0, time.UTC).Day()
+	return prev
 }
@@ -4,6 +4,7 @@ import (
 	"testing"
 	"time"
 
+	"bank-of-dad/internal/recurrence"
 	"bank-of-dad/models"
 
 	"github.com/stretchr/testify/assert"
@@ -22,149 +23,142 @@ func intPtr(i int) *int {
 	return &i
 }
 
-// === nextWeeklyDate tests (UTC) ===
+func mustNextRun(t *testing.T, sched *models.AllowanceSchedule, after time.Time, loc *time.Location) time.Time {
+	t.Helper()
+	next, err := NextRunAfter(sched, after, loc)
+	require.NoError(t, err)
+	return next
+}
+
+// === weekly rule tests (UTC) ===
 
 func TestNextWeeklyDate_NormalCase(t *testing.T) {
 	// Wednesday 2026-02-04, looking for Friday (5)
 	after := date(2026, time.February, 4)
-	result := nextWeeklyDate(5, after, time.UTC) // Friday
+	result := recurrence.WeeklyOn(5, 1).Next(after, time.UTC) // Friday
 	assert.Equal(t, date(2026, time.February, 6), result)
 }
 
 func TestNextWeeklyDate_SameDayGoesToNextWeek(t *testing.T) {
 	// Friday 2026-02-06, looking for Friday (5)
 	after := date(2026, time.February, 6)
-	result := nextWeeklyDate(5, after, time.UTC) // Friday
+	result := recurrence.WeeklyOn(5, 1).Next(after, time.UTC) // Friday
 	assert.Equal(t, date(2026, time.February, 13), result)
 }
 
 func TestNextWeeklyDate_Sunday(t *testing.T) {
 	// Wednesday 2026-02-04, looking for Sunday (0)
 	after := date(2026, time.February, 4)
-	result := nextWeeklyDate(0, after, time.UTC)
+	result := recurrence.WeeklyOn(0, 1).Next(after, time.UTC)
 	assert.Equal(t, date(2026, time.February, 8), result)
 }
 
 func TestNextWeeklyDate_Monday(t *testing.T) {
 	// Wednesday 2026-02-04, looking for Monday (1)
 	after := date(2026, time.February, 4)
-	result := nextWeeklyDate(1, after, time.UTC)
+	result := recurrence.WeeklyOn(1, 1).Next(after, time.UTC)
 	assert.Equal(t, date(2026, time.February, 9), result)
 }
 
 func TestNextWeeklyDate_Tomorrow(t *testing.T) {
 	// Wednesday 2026-02-04, looking for Thursday (4)
 	after := date(2026, time.February, 4)
-	result := nextWeeklyDate(4, after, time.UTC)
+	result := recurrence.WeeklyOn(4, 1).Next(after, time.UTC)
 	assert.Equal(t, date(2026, time.February, 5), result)
 }
 
-// === nextBiweeklyDate tests (UTC) ===
+// === biweekly rule tests (UTC) ===
 
 func TestNextBiweeklyDate_14DaysFromAfter(t *testing.T) {
 	// Friday 2026-02-06, looking for next biweekly Friday
 	after := date(2026, time.February, 6)
-	result := nextBiweeklyDate(5, after, time.UTC)
+	result := recurrence.WeeklyOn(5, 2).Next(after, time.UTC)
 	assert.Equal(t, date(2026, time.February, 20), result)
 }
 
 func TestNextBiweeklyDate_SameDay(t *testing.T) {
 	// If after is a Friday and day_of_week is Friday, next occurrence is in 14 days
 	after := date(2026, time.February, 6) // Friday
-	result := nextBiweeklyDate(5, after, time.UTC)
+	result := recurrence.WeeklyOn(5, 2).Next(after, time.UTC)
 	assert.Equal(t, date(2026, time.February, 20), result)
 }
 
 func TestNextBiweeklyDate_DifferentDay(t *testing.T) {
 	// Wednesday 2026-02-04, looking for biweekly Friday
 	after := date(2026, time.February, 4)
-	result := nextBiweeklyDate(5, after, time.UTC)
+	result := recurrence.WeeklyOn(5, 2).Next(after, time.UTC)
 	assert.Equal(t, date(2026, time.February, 6), result)
 }
 
-// === nextMonthlyDate tests (UTC) ===
+// === monthly rule tests (UTC) ===
 
 func TestNextMonthlyDate_FutureThisMonth(t *testing.T) {
 	// Feb 4, target day 15
 	after := date(2026, time.February, 4)
-	result := nextMonthlyDate(15, after, time.UTC)
+	result := recurrence.MonthlyOn(15).Next(after, time.UTC)
 	assert.Equal(t, date(2026, time.February, 15), result)
 }
 
 func TestNextMonthlyDate_PastThisMonth(t *testing.T) {
 	// Feb 15, target day 1 → goes to March 1
 	after := date(2026, time.February, 15)
-	result := nextMonthlyDate(1, after, time.UTC)
+	result := recurrence.MonthlyOn(1).Next(after, time.UTC)
 	assert.Equal(t, date(2026, time.March, 1), result)
 }
 
 func TestNextMonthlyDate_SameDay(t *testing.T) {
 	// Feb 15, target day 15 → goes to March 15
 	after := date(2026, time.February, 15)
-	result := nextMonthlyDate(15, after, time.UTC)
+	result := recurrence.MonthlyOn(15).Next(after, time.UTC)
 	assert.Equal(t, date(2026, time.March, 15), result)
 }
 
 func TestNextMonthlyDate_EndOfMonthClamping_31InFeb(t *testing.T) {
 	// Jan 15, target day 31 → Jan 31
 	after := date(2026, time.January, 15)
-	result := nextMonthlyDate(31, after, time.UTC)
+	result := recurrence.MonthlyOn(31).Next(after, time.UTC)
 	assert.Equal(t, date(2026, time.January, 31), result)
 
 	// Feb 1, target day 31 → Feb 28 (2026 is not a leap year)
 	after = date(2026, time.February, 1)
-	result = nextMonthlyDate(31, after, time.UTC)
+	result = recurrence.MonthlyOn(31).Next(after, time.UTC)
 	assert.Equal(t, date(2026, time.February, 28), result)
 }
 
 func TestNextMonthlyDate_EndOfMonthClamping_31InApr(t *testing.T) {
 	// March 31, target day 31 → April 30
 	after := date(2026, time.March, 31)
-	result := nextMonthlyDate(31, after, time.UTC)
+	result := recurrence.MonthlyOn(31).Next(after, time.UTC)
 	assert.Equal(t, date(2026, time.April, 30), result)
 }
 
 func TestNextMonthlyDate_EndOfMonthClamping_31InJun(t *testing.T) {
 	// May 31, target day 31 → June 30
 	after := date(2026, time.May, 31)
-	result := nextMonthlyDate(31, after, time.UTC)
+	result := recurrence.MonthlyOn(31).Next(after, time.UTC)
 	assert.Equal(t, date(2026, time.June, 30), result)
 }
 
 func TestNextMonthlyDate_LeapYear(t *testing.T) {
 	// Feb 29 in leap year 2028
 	after := date(2028, time.February, 1)
-	result := nextMonthlyDate(29, after, time.UTC)
+	result := recurrence.MonthlyOn(29).Next(after, time.UTC)
 	assert.Equal(t, date(2028, time.February, 29), result)
 
 	// Feb 29 in non-leap year 2026 → Feb 28
 	after = date(2026, time.February, 1)
-	result = nextMonthlyDate(29, after, time.UTC)
+	result = recurrence.MonthlyOn(29).Next(after, time.UTC)
 	assert.Equal(t, date(2026, time.February, 28), result)
 }
 
 func TestNextMonthlyDate_DecemberToJanuary(t *testing.T) {
 	// Dec 25, target day 1 → Jan 1 next year
 	after := date(2026, time.December, 25)
-	result := nextMonthlyDate(1, after, time.UTC)
+	result := recurrence.MonthlyOn(1).Next(after, time.UTC)
 	assert.Equal(t, date(2027, time.January, 1), result)
 }
 
-// === daysInMonth tests ===
-
-func TestDaysInMonth(t *testing.T) {
-	assert.Equal(t, 31, daysInMonth(2026, time.January))
-	assert.Equal(t, 28, daysInMonth(2026, time.February))
-	assert.Equal(t, 29, daysInMonth(2028, time.February)) // leap year
-	assert.Equal(t, 31, daysInMonth(2026, time.March))
-	assert.Equal(t, 30, daysInMonth(2026, time.April))
-	assert.Equal(t, 30, daysInMonth(2026, time.June))
-	assert.Equal(t, 30, daysInMonth(2026, time.September))
-	assert.Equal(t, 30, daysInMonth(2026, time.November))
-	assert.Equal(t, 31, daysInMonth(2026, time.December))
-}
-
-// === calculateNextRun tests (UTC) ===
+// === NextRunAfter tests (UTC) ===
 
 func TestCalculateNextRun_Weekly(t *testing.T) {
 	now := date(2026, time.February, 4) // Wednesday
@@ -172,7 +166,7 @@ func TestCalculateNextRun_Weekly(t *testing.T) {
 		Frequency: models.FrequencyWeekly,
 		DayOfWeek: intPtr(5), // Friday
 	}
-	result := CalculateNextRun(sched, now, time.UTC)
+	result := mustNextRun(t, sched, now, time.UTC)
 	assert.Equal(t, date(2026, time.February, 6), result)
 }
 
@@ -182,7 +176,7 @@ func TestCalculateNextRun_Biweekly(t *testing.T) {
 		Frequency: models.FrequencyBiweekly,
 		DayOfWeek: intPtr(5), // Friday
 	}
-	result := CalculateNextRun(sched, now, time.UTC)
+	result := mustNextRun(t, sched, now, time.UTC)
 	assert.Equal(t, date(2026, time.February, 20), result)
 }
 
@@ -192,7 +186,7 @@ func TestCalculateNextRun_Monthly(t *testing.T) {
 		Frequency:  models.FrequencyMonthly,
 		DayOfMonth: intPtr(15),
 	}
-	result := CalculateNextRun(sched, now, time.UTC)
+	result := mustNextRun(t, sched, now, time.UTC)
 	assert.Equal(t, date(2026, time.February, 15), result)
 }
 
@@ -204,7 +198,7 @@ func TestCalculateNextRunAfterExecution_Weekly(t *testing.T) {
 		Frequency: models.FrequencyWeekly,
 		DayOfWeek: intPtr(5),
 	}
-	result := CalculateNextRunAfterExecution(sched, executedAt, time.UTC)
+	result := mustNextRun(t, sched, executedAt, time.UTC)
 	assert.Equal(t, date(2026, time.February, 13), result) // Next Friday
 }
 
@@ -214,7 +208,7 @@ func TestCalculateNextRunAfterExecution_Biweekly(t *testing.T) {
 		Frequency: models.FrequencyBiweekly,
 		DayOfWeek: intPtr(5),
 	}
-	result := CalculateNextRunAfterExecution(sched, executedAt, time.UTC)
+	result := mustNextRun(t, sched, executedAt, time.UTC)
 	assert.Equal(t, date(2026, time.February, 20), result) // 14 days later
 }
 
@@ -224,7 +218,7 @@ func TestCalculateNextRunAfterExecution_Monthly(t *testing.T) {
 		Frequency:  models.FrequencyMonthly,
 		DayOfMonth: intPtr(15),
 	}
-	result := CalculateNextRunAfterExecution(sched, executedAt, time.UTC)
+	result := mustNextRun(t, sched, executedAt, time.UTC)
 	assert.Equal(t, date(2026, time.February, 15), result)
 }
 
@@ -234,7 +228,7 @@ func TestCalculateNextRunAfterExecution_Monthly_31st(t *testing.T) {
 		Frequency:  models.FrequencyMonthly,
 		DayOfMonth: intPtr(31),
 	}
-	result := CalculateNextRunAfterExecution(sched, executedAt, time.UTC)
+	result := mustNextRun(t, sched, executedAt, time.UTC)
 	// Feb 2026 has 28 days
 	assert.Equal(t, date(2026, time.February, 28), result)
 }
@@ -249,7 +243,7 @@ func TestNextWeeklyDate_NewYork(t *testing.T) {
 
 	// Tuesday Feb 17, 2026 in New York, looking for Wednesday (3)
 	after := dateIn(2026, time.February, 17, loc)
-	result := nextWeeklyDate(3, after, loc)
+	result := recurrence.WeeklyOn(3, 1).Next(after, loc)
 
 	// Should be Wednesday Feb 18 at midnight New York time
 	expected := dateIn(2026, time.February, 18, loc)
@@ -266,7 +260,7 @@ func TestNextWeeklyDate_LosAngeles(t *testing.T) {
 
 	// Tuesday Feb 17, 2026 in LA, looking for Wednesday (3)
 	after := dateIn(2026, time.February, 17, loc)
-	result := nextWeeklyDate(3, after, loc)
+	result := recurrence.WeeklyOn(3, 1).Next(after, loc)
 
 	// Should be Wednesday Feb 18 at midnight Pacific time
 	expected := dateIn(2026, time.February, 18, loc)
@@ -284,7 +278,7 @@ func TestNextWeeklyDate_Kolkata(t *testing.T) {
 
 	// Tuesday Feb 17 in Kolkata, looking for Wednesday (3)
 	after := dateIn(2026, time.February, 17, loc)
-	result := nextWeeklyDate(3, after, loc)
+	result := recurrence.WeeklyOn(3, 1).Next(after, loc)
 
 	expected := dateIn(2026, time.February, 18, loc)
 	assert.Equal(t, expected, result)
@@ -301,7 +295,7 @@ func TestNextMonthlyDate_NewYork(t *testing.T) {
 
 	// Feb 4 in New York, target day 1 → March 1
 	after := dateIn(2026, time.February, 4, loc)
-	result := nextMonthlyDate(1, after, loc)
+	result := recurrence.MonthlyOn(1).Next(after, loc)
 
 	expected := dateIn(2026, time.March, 1, loc)
 	assert.Equal(t, expected, result)
@@ -321,7 +315,7 @@ func TestCalculateNextRun_Weekly_NewYork(t *testing.T) {
 		Frequency: models.FrequencyWeekly,
 		DayOfWeek: intPtr(3), // Wednesday
 	}
-	result := CalculateNextRun(sched, now, loc)
+	result := mustNextRun(t, sched, now, loc)
 
 	// Should be Wednesday Feb 18 at midnight New York
 	expected := dateIn(2026, time.February, 18, loc)
@@ -339,7 +333,7 @@ func TestCalculateNextRunAfterExecution_Weekly_NewYork(t *testing.T) {
 		Frequency: models.FrequencyWeekly,
 		DayOfWeek: intPtr(3), // Wednesday
 	}
-	result := CalculateNextRunAfterExecution(sched, executedAt, loc)
+	result := mustNextRun(t, sched, executedAt, loc)
 
 	// Should be next Wednesday Feb 25 at midnight EST
 	expected := dateIn(2026, time.February, 25, loc)
@@ -357,7 +351,7 @@ func TestCalculateNextRunAfterExecution_Monthly_NewYork(t *testing.T) {
 		Frequency:  models.FrequencyMonthly,
 		DayOfMonth: intPtr(15),
 	}
-	result := CalculateNextRunAfterExecution(sched, executedAt, loc)
+	result := mustNextRun(t, sched, executedAt, loc)
 
 	// Should be Feb 15 at midnight EST
 	expected := dateIn(2026, time.February, 15, loc)
@@ -374,7 +368,7 @@ func TestNextWeeklyDate_DSTSpringForward(t *testing.T) {
 
 	// March 7, 2026 (Saturday) in New York, looking for Sunday (0) = March 8
 	after := dateIn(2026, time.March, 7, loc)
-	result := nextWeeklyDate(0, after, loc)
+	result := recurrence.WeeklyOn(0, 1).Next(after, loc)
 
 	// Should be Sunday March 8 at midnight EDT (which is 4am UTC because clocks spring forward)
 	// Actually March 8 midnight is still EST (before 2am), so 5am UTC
@@ -385,7 +379,7 @@ func TestNextWeeklyDate_DSTSpringForward(t *testing.T) {
 
 	// Now test a date AFTER DST change: March 14 (Saturday), looking for Sunday (0) = March 15
 	after = dateIn(2026, time.March, 14, loc)
-	result = nextWeeklyDate(0, after, loc)
+	result = recurrence.WeeklyOn(0, 1).Next(after, loc)
 
 	// March 15 is now EDT (UTC-4), so midnight EDT = 4am UTC
 	expected = dateIn(2026, time.March, 15, loc)
@@ -400,7 +394,7 @@ func TestNextWeeklyDate_DSTFallBack(t *testing.T) {
 
 	// Oct 31, 2026 (Saturday) in New York, looking for Sunday (0) = Nov 1
 	after := dateIn(2026, time.October, 31, loc)
-	result := nextWeeklyDate(0, after, loc)
+	result := recurrence.WeeklyOn(0, 1).Next(after, loc)
 
 	// November 1, midnight EDT (before fall back at 2am) = 4am UTC
 	expected := dateIn(2026, time.November, 1, loc)
@@ -408,7 +402,7 @@ func TestNextWeeklyDate_DSTFallBack(t *testing.T) {
 
 	// Nov 7, 2026 (Saturday), looking for Sunday (0) = Nov 8
 	after = dateIn(2026, time.November, 7, loc)
-	result = nextWeeklyDate(0, after, loc)
+	result = recurrence.WeeklyOn(0, 1).Next(after, loc)
 
 	// November 8 is now EST (UTC-5), so midnight EST = 5am UTC
 	expected = dateIn(2026, time.November, 8, loc)
@@ -428,7 +422,7 @@ func TestCalculateNextRun_UTCInput_NonUTCLocation(t *testing.T) {
 		Frequency: models.FrequencyWeekly,
 		DayOfWeek: intPtr(3), // Wednesday
 	}
-	result := CalculateNextRun(sched, now, loc)
+	result := mustNextRun(t, sched, now, loc)
 
 	// In EST, it's Tuesday 10am → next Wednesday is Feb 18
 	expected := dateIn(2026, time.February, 18, loc)
@@ -442,7 +436,75 @@ func TestCalculateNextRun_UTCTimezoneMatchesOldBehavior(t *testing.T) {
 		Frequency: models.FrequencyWeekly,
 		DayOfWeek: intPtr(5), // Friday
 	}
-	result := CalculateNextRun(sched, now, time.UTC)
+	result := mustNextRun(t, sched, now, time.UTC)
 	assert.Equal(t, date(2026, time.February, 6), result)
 	assert.Equal(t, 0, result.UTC().Hour()) // Midnight UTC
 }
+
+// === CalculatePeriodStart tests ===
+
+func TestCalculatePeriodStart_Weekly(t *testing.T) {
+	sched := &models.AllowanceSchedule{Frequency: models.FrequencyWeekly, DayOfWeek: intPtr(5)}
+	result := CalculatePeriodStart(sched, date(2026, time.February, 13), time.UTC)
+	assert.Equal(t, date(2026, time.February, 6), result)
+}
+
+func TestCalculatePeriodStart_Biweekly(t *testing.T) {
+	sched := &models.AllowanceSchedule{Frequency: models.FrequencyBiweekly, DayOfWeek: intPtr(5)}
+	result := CalculatePeriodStart(sched, date(2026, time.February, 13), time.UTC)
+	assert.Equal(t, date(2026, time.January, 30), result)
+}
+
+func TestCalculatePeriodStart_MonthlyClampsToShortMonth(t *testing.T) {
+	// Run on Mar 31 for a 31st-of-month schedule; previous run was Feb 28
+	sched := &models.AllowanceSchedule{Frequency: models.FrequencyMonthly, DayOfMonth: intPtr(31)}
+	result := CalculatePeriodStart(sched, date(2026, time.March, 31), time.UTC)
+	assert.Equal(t, date(2026, time.February, 28), result)
+}
+
+func TestCalculatePeriodStart_MonthlyAcrossYear(t *testing.T) {
+	sched := &models.AllowanceSchedule{Frequency: models.FrequencyMonthly, DayOfMonth: intPtr(15)}
+	result := CalculatePeriodStart(sched, date(2026, time.January, 15), time.UTC)
+	assert.Equal(t, date(2025, time.December, 15), result)
+}
+
+func TestCalculatePeriodStart_NewYork(t *testing.T) {
+	ny, err := time.LoadLocation("America/New_York")
+	require.NoError(t, err)
+	sched := &models.AllowanceSchedule{Frequency: models.FrequencyWeekly, DayOfWeek: intPtr(5)}
+	// Midnight Friday in New York, expressed in UTC
+	runAt := dateIn(2026, time.February, 13, ny).UTC()
+	result := CalculatePeriodStart(sched, runAt, ny)
+	assert.Equal(t, dateIn(2026, time.February, 6, ny), result)
+}
+
+// === custom rrule tests ===
+
+func TestNextRunAfter_CustomSemiMonthly(t *testing.T) {
+	rrule := "FREQ=MONTHLY;BYMONTHDAY=1,15"
+	sched := &models.AllowanceSchedule{Frequency: models.FrequencyCustom, RRule: &rrule}
+	assert.Equal(t, date(2026, time.February, 15), mustNextRun(t, sched, date(2026, time.February, 4), time.UTC))
+	assert.Equal(t, date(2026, time.March, 1), mustNextRun(t, sched, date(2026, time.February, 15), time.UTC))
+}
+
+func TestNextRunAfter_CustomLastDayOfMonth(t *testing.T) {
+	rrule := "FREQ=MONTHLY;BYMONTHDAY=-1"
+	sched := &models.AllowanceSchedule{Frequency: models.FrequencyCustom, RRule: &rrule}
+	assert.Equal(t, date(2026, time.February, 28), mustNextRun(t, sched, date(2026, time.January, 31), time.UTC))
+}
+
+func TestNextRunAfter_CustomMissingRRule(t *testing.T) {
+	sched := &models.AllowanceSchedule{Frequency: models.FrequencyCustom}
+	_, err := NextRunAfter(sched, date(2026, time.February, 4), time.UTC)
+	assert.Error(t, err)
+}
+
+func TestValidateRRule(t *testing.T) {
+	valid := "FREQ=WEEKLY;BYDAY=MO,WE,FR"
+	invalid := "FREQ=HOURLY"
+	assert.Empty(t, ValidateRRule(models.FrequencyCustom, &valid))
+	assert.NotEmpty(t, ValidateRRule(models.FrequencyCustom, &invalid))
+	assert.NotEmpty(t, ValidateRRule(models.FrequencyCustom, nil))
+	assert.NotEmpty(t, ValidateRRule(models.FrequencyWeekly, &valid))
+	assert.Empty(t, ValidateRRule(models.FrequencyWeekly, nil))
+}
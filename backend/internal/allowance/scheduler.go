@@ -4,6 +4,7 @@ import (
 	"log"
 	"time"
 
+	"bank-of-dad/models"
 	"bank-of-dad/repositories"
 )
 
@@ -12,6 +13,8 @@ type Scheduler struct {
 	scheduleRepo *repositories.ScheduleRepo
 	txRepo       *repositories.TransactionRepo
 	childRepo    *repositories.ChildRepo
+
+	choreInstanceRepo *repositories.ChoreInstanceRepo
 }
 
 // NewScheduler creates a new Scheduler.
@@ -23,6 +26,11 @@ func NewScheduler(scheduleRepo *repositories.ScheduleRepo, txRepo *repositories.
 	}
 }
 
+// SetChoreInstanceRepo sets the chore instance repo used to apply chore-conditional payout rules.
+func (s *Scheduler) SetChoreInstanceRepo(choreInstanceRepo *repositories.ChoreInstanceRepo) {
+	s.choreInstanceRepo = choreInstanceRepo
+}
+
 // RecalculateAllNextRuns recalculates next_run_at for all active schedules
 // using timezone-aware logic. Called on startup to correct existing UTC-midnight values.
 func (s *Scheduler) RecalculateAllNextRuns() {
@@ -35,7 +43,11 @@ func (s *Scheduler) RecalculateAllNextRuns() {
 	now := time.Now().UTC()
 	for _, ds := range schedules {
 		loc := loadTimezone(ds.FamilyTimezone)
-		nextRun := CalculateNextRun(&ds.AllowanceSchedule, now, loc)
+		nextRun, err := NextRunAfter(&ds.AllowanceSchedule, now, loc)
+		if err != nil {
+			log.Printf("Error calculating next_run_at for schedule %d: %v", ds.ID, err)
+			continue
+		}
 		if err := s.scheduleRepo.UpdateNextRunAt(ds.ID, nextRun); err != nil {
 			log.Printf("Error recalculating next_run_at for schedule %d: %v", ds.ID, err)
 		}
@@ -53,11 +65,13 @@ func (s *Scheduler) Start(interval time.Duration, stop <-chan struct{}) {
 		s.RecalculateAllNextRuns()
 
 		// Process immediately on start (catch any missed while down)
+		s.ApplyAgeRaises()
 		s.ProcessDueSchedules()
 
 		for {
 			select {
 			case <-ticker.C:
+				s.ApplyAgeRaises()
 				s.ProcessDueSchedules()
 			case <-stop:
 				return
@@ -81,6 +95,36 @@ func (s *Scheduler) ProcessDueSchedules() {
 	}
 }
 
+// ApplyAgeRaises recalculates the amount of every age-based schedule from the child's age
+// in the family's timezone, recording a raise for each increase. Runs before due schedules are
+// processed so a payout on the child's birthday already uses the new amount.
+func (s *Scheduler) ApplyAgeRaises() {
+	schedules, err := s.scheduleRepo.ListAgeBased()
+	if err != nil {
+		log.Printf("Error listing age-based schedules: %v", err)
+		return
+	}
+
+	now := time.Now()
+	for _, as := range schedules {
+		loc := loadTimezone(as.FamilyTimezone)
+		age := AgeOn(as.ChildBirthdate, now, loc)
+		amount, ok := FormulaAmount(&as.AllowanceSchedule, age)
+		if !ok || amount == as.AmountCents {
+			continue
+		}
+		applied, err := s.scheduleRepo.ApplyRaise(&as.AllowanceSchedule, age, as.AmountCents, amount)
+		if err != nil {
+			log.Printf("Error applying age raise for schedule %d: %v", as.ID, err)
+			continue
+		}
+		if applied {
+			log.Printf("Applied age-based amount for schedule %d (child %d, age %d): %d -> %d cents",
+				as.ID, as.ChildID, age, as.AmountCents, amount)
+		}
+	}
+}
+
 // loadTimezone parses a timezone string into a *time.Location, falling back to UTC.
 func loadTimezone(tz string) *time.Location {
 	if tz != "" {
@@ -92,39 +136,147 @@ func loadTimezone(tz string) *time.Location {
 }
 
 // executeSchedule creates a deposit transaction and advances the schedule's next_run_at.
+// A skip or hold override for the run replaces the deposit; a custom override changes its amount or note.
 func (s *Scheduler) executeSchedule(sched repositories.DueAllowanceSchedule) error {
+	loc := loadTimezone(sched.FamilyTimezone)
+	executedAt := time.Now().UTC()
+	if sched.NextRunAt != nil {
+		executedAt = *sched.NextRunAt
+	}
+
+	// The limits may have been lowered since this run was scheduled; held allowance is still paid
+	if limitReached(&sched.AllowanceSchedule, sched.RunCount, executedAt, loc) {
+		log.Printf("Schedule %d reached its end date or run limit, completing (releasing %d held cents)", sched.ID, sched.HeldCents)
+		return s.scheduleRepo.Complete(&sched.AllowanceSchedule, "Held allowance")
+	}
+
+	override, err := s.scheduleRepo.GetOverride(sched.ID, RunDate(executedAt, loc))
+	if err != nil {
+		return err
+	}
+	if override != nil && override.Action != models.OverrideActionCustom {
+		// There is no later run to pay a hold on the final run, so it is paid as usual
+		final, err := IsFinalRun(&sched.AllowanceSchedule, executedAt, loc)
+		if err != nil {
+			return err
+		}
+		if override.Action == models.OverrideActionSkip || !final {
+			return s.skipRun(sched, override.Action, final, executedAt, loc)
+		}
+		override = nil
+	}
+	run := withOverride(&sched.AllowanceSchedule, override)
+
 	// Build note from schedule
 	var note string
-	if sched.Note != nil {
-		note = *sched.Note
-	}
-
-	// Create allowance deposit
-	_, _, err := s.txRepo.DepositAllowance(
-		sched.ChildID,
-		sched.ParentID,
-		sched.AmountCents,
-		sched.ID,
-		note,
-	)
+	if run.Note != nil {
+		note = *run.Note
+	}
+
+	amountCents, err := s.applyChoreRule(&run, executedAt, loc, &note)
 	if err != nil {
 		return err
 	}
 
-	// Calculate and set next run time using family timezone
-	loc := loadTimezone(sched.FamilyTimezone)
-	executedAt := time.Now().UTC()
-	if sched.NextRunAt != nil {
-		executedAt = *sched.NextRunAt
+	// Payouts held from earlier runs are paid with this one
+	if sched.HeldCents > 0 {
+		amountCents += sched.HeldCents
+		if note != "" {
+			note += " — includes held allowance"
+		} else {
+			note = "Includes held allowance"
+		}
+	}
+
+	if amountCents <= 0 {
+		// Nothing is posted; the child saw the reason ahead of time in their upcoming allowance
+		log.Printf("Schedule %d: withheld payout to child %d: %s", sched.ID, sched.ChildID, note)
 	}
-	nextRun := CalculateNextRunAfterExecution(&sched.AllowanceSchedule, executedAt, loc)
 
-	if err := s.scheduleRepo.UpdateNextRunAt(sched.ID, nextRun); err != nil {
+	nextRun, completed, err := s.finishRun(sched, repositories.AllowanceRun{DepositCents: amountCents, Note: note}, executedAt, loc)
+	if err != nil {
 		return err
 	}
 
-	log.Printf("Executed schedule %d: deposited %d cents to child %d, next run: %s",
-		sched.ID, sched.AmountCents, sched.ChildID, nextRun.Format(time.DateOnly))
+	if completed {
+		log.Printf("Executed final run of schedule %d: deposited %d cents to child %d, schedule completed",
+			sched.ID, amountCents, sched.ChildID)
+	} else {
+		log.Printf("Executed schedule %d: deposited %d cents to child %d, next run: %s",
+			sched.ID, amountCents, sched.ChildID, nextRun.Format(time.DateOnly))
+	}
 
 	return nil
 }
+
+// finishRun records the run at executedAt with its payout and held total, and calculates the next
+// run using the family timezone. The schedule is completed instead when the run used up its max
+// runs or the next run is past its end date.
+func (s *Scheduler) finishRun(sched repositories.DueAllowanceSchedule, run repositories.AllowanceRun, executedAt time.Time, loc *time.Location) (time.Time, bool, error) {
+	nextRun, err := NextRunAfter(&sched.AllowanceSchedule, executedAt, loc)
+	if err != nil {
+		return time.Time{}, false, err
+	}
+	run.NextRunAt = nextRun
+	run.Completed = limitReached(&sched.AllowanceSchedule, sched.RunCount+1, nextRun, loc)
+	if err := s.scheduleRepo.RecordRun(&sched.AllowanceSchedule, run); err != nil {
+		return time.Time{}, false, err
+	}
+	return nextRun, run.Completed, nil
+}
+
+// skipRun advances a schedule past a skipped or held run without paying it.
+// A held run's amount is added to the schedule's held total for the next paid run.
+// final is true when this is the schedule's last run before it completes.
+func (s *Scheduler) skipRun(sched repositories.DueAllowanceSchedule, action models.OverrideAction, final bool, executedAt time.Time, loc *time.Location) error {
+	run := repositories.AllowanceRun{HeldCents: sched.HeldCents}
+	if action == models.OverrideActionHold {
+		run.HeldCents += sched.AmountCents
+	}
+
+	// Skipping the final run still releases allowance held from earlier runs
+	if final && run.HeldCents > 0 {
+		run.DepositCents = run.HeldCents
+		run.Note = "Held allowance"
+		run.HeldCents = 0
+	}
+
+	nextRun, completed, err := s.finishRun(sched, run, executedAt, loc)
+	if err != nil {
+		return err
+	}
+
+	if completed {
+		log.Printf("Schedule %d: %s final run on %s for child %d, schedule completed",
+			sched.ID, action, executedAt.In(loc).Format(time.DateOnly), sched.ChildID)
+	} else {
+		log.Printf("Schedule %d: %s run on %s for child %d, next run: %s",
+			sched.ID, action, executedAt.In(loc).Format(time.DateOnly), sched.ChildID, nextRun.Format(time.DateOnly))
+	}
+	return nil
+}
+
+// applyChoreRule returns the payout for a run after applying the schedule's chore rule
+// to the period ending at runAt. When the payout is reduced or withheld, the reason is
+// appended to note.
+func (s *Scheduler) applyChoreRule(sched *models.AllowanceSchedule, runAt time.Time, loc *time.Location, note *string) (int64, error) {
+	if s.choreInstanceRepo == nil || sched.ChoreRule == "" || sched.ChoreRule == models.ChoreRuleNone {
+		return sched.AmountCents, nil
+	}
+
+	periodStart := CalculatePeriodStart(sched, runAt, loc)
+	approved, total, err := s.choreInstanceRepo.CountApprovedInRange(sched.ChildID, periodStart.In(loc), runAt.In(loc))
+	if err != nil {
+		return 0, err
+	}
+
+	outcome := ApplyChoreRule(sched, approved, total)
+	if outcome.Reason != "" {
+		if *note != "" {
+			*note = *note + " — " + outcome.Reason
+		} else {
+			*note = outcome.Reason
+		}
+	}
+	return outcome.AmountCents, nil
+}
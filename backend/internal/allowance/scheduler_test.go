@@ -10,6 +10,7 @@ import (
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
 )
 
 // =====================================================
@@ -409,3 +410,535 @@ func TestScheduler_RecalculateAllNextRuns(t *testing.T) {
 	assert.Equal(t, 0, localTime.Minute())
 	assert.Equal(t, time.Friday, localTime.Weekday(), "should still be a Friday")
 }
+
+func TestScheduler_ProcessDueSchedules_AllocatesSplitsToGoals(t *testing.T) {
+	db := testutil.SetupTestDB(t)
+	family := testutil.CreateTestFamily(t, db)
+	parent := testutil.CreateTestParent(t, db, family.ID)
+	child := testutil.CreateTestChild(t, db, family.ID, "Emma")
+
+	schedRepo := repositories.NewScheduleRepo(db)
+	txRepo := repositories.NewTransactionRepo(db)
+	childRepo := repositories.NewChildRepo(db)
+	goalRepo := repositories.NewSavingsGoalRepo(db)
+
+	bike, err := goalRepo.Create(child.ID, "Bike", 10000, nil)
+	require.NoError(t, err)
+	// Nearly complete goal: share should be capped at the remaining 100 cents
+	game, err := goalRepo.Create(child.ID, "Game", 100, nil)
+	require.NoError(t, err)
+
+	pastTime := time.Date(2026, time.February, 1, 0, 0, 0, 0, time.UTC)
+	_, err = schedRepo.Create(&models.AllowanceSchedule{
+		ChildID:     child.ID,
+		ParentID:    parent.ID,
+		AmountCents: 1000,
+		Frequency:   models.FrequencyWeekly,
+		DayOfWeek:   intPtr(5),
+		Status:      models.ScheduleStatusActive,
+		NextRunAt:   &pastTime,
+		Splits: []models.AllowanceSplit{
+			{GoalID: bike.ID, Percent: 30},
+			{GoalID: game.ID, Percent: 50},
+		},
+	})
+	require.NoError(t, err)
+
+	scheduler := NewScheduler(schedRepo, txRepo, childRepo)
+	scheduler.ProcessDueSchedules()
+
+	updatedBike, err := goalRepo.GetByID(bike.ID)
+	require.NoError(t, err)
+	assert.Equal(t, int64(300), updatedBike.SavedCents)
+
+	updatedGame, err := goalRepo.GetByID(game.ID)
+	require.NoError(t, err)
+	assert.Equal(t, int64(100), updatedGame.SavedCents)
+	assert.Equal(t, "completed", updatedGame.Status)
+
+	// Full amount still lands in the balance; the remainder is available to spend
+	balance, err := childRepo.GetBalance(child.ID)
+	require.NoError(t, err)
+	assert.Equal(t, int64(1000), balance)
+
+	available, err := goalRepo.GetAvailableBalance(child.ID)
+	require.NoError(t, err)
+	assert.Equal(t, int64(600), available)
+}
+
+// createChoreInstancesForChild creates instances for a child with the given statuses,
+// all created at createdAt.
+func createChoreInstancesForChild(t *testing.T, db *gorm.DB, familyID, parentID, childID int64, createdAt time.Time, statuses ...models.ChoreInstanceStatus) {
+	t.Helper()
+	chore, err := repositories.NewChoreRepo(db).Create(&models.Chore{
+		FamilyID:          familyID,
+		CreatedByParentID: parentID,
+		Name:              "Make Bed",
+		RewardCents:       0,
+		Recurrence:        models.ChoreRecurrenceOneTime,
+		IsActive:          true,
+	})
+	require.NoError(t, err)
+
+	instanceRepo := repositories.NewChoreInstanceRepo(db)
+	for _, status := range statuses {
+		_, err := instanceRepo.CreateInstance(&models.ChoreInstance{
+			ChoreID:   chore.ID,
+			ChildID:   childID,
+			Status:    status,
+			CreatedAt: createdAt,
+		})
+		require.NoError(t, err)
+	}
+}
+
+func TestScheduler_ProcessDueSchedules_ChoreThresholdWithholds(t *testing.T) {
+	db := testutil.SetupTestDB(t)
+	family := testutil.CreateTestFamily(t, db)
+	parent := testutil.CreateTestParent(t, db, family.ID)
+	child := testutil.CreateTestChild(t, db, family.ID, "Emma")
+
+	schedRepo := repositories.NewScheduleRepo(db)
+	txRepo := repositories.NewTransactionRepo(db)
+	childRepo := repositories.NewChildRepo(db)
+
+	pastTime := time.Date(2026, time.February, 6, 0, 0, 0, 0, time.UTC)
+	createChoreInstancesForChild(t, db, family.ID, parent.ID, child.ID, pastTime.AddDate(0, 0, -2),
+		models.ChoreInstanceStatusApproved, models.ChoreInstanceStatusExpired)
+
+	sched, err := schedRepo.Create(&models.AllowanceSchedule{
+		ChildID:               child.ID,
+		ParentID:              parent.ID,
+		AmountCents:           1000,
+		Frequency:             models.FrequencyWeekly,
+		DayOfWeek:             intPtr(5),
+		Status:                models.ScheduleStatusActive,
+		NextRunAt:             &pastTime,
+		ChoreRule:             models.ChoreRuleThreshold,
+		ChoreThresholdPercent: intPtr(80),
+	})
+	require.NoError(t, err)
+
+	scheduler := NewScheduler(schedRepo, txRepo, childRepo)
+	scheduler.SetChoreInstanceRepo(repositories.NewChoreInstanceRepo(db))
+	scheduler.ProcessDueSchedules()
+
+	// A withheld payout posts no transaction
+	txns, err := txRepo.ListByChild(child.ID)
+	require.NoError(t, err)
+	assert.Empty(t, txns)
+
+	balance, err := childRepo.GetBalance(child.ID)
+	require.NoError(t, err)
+	assert.Equal(t, int64(0), balance)
+
+	updated, err := schedRepo.GetByID(sched.ID)
+	require.NoError(t, err)
+	require.NotNil(t, updated.NextRunAt)
+	assert.True(t, updated.NextRunAt.After(pastTime))
+}
+
+func TestScheduler_ProcessDueSchedules_ChoreProportionalProrates(t *testing.T) {
+	db := testutil.SetupTestDB(t)
+	family := testutil.CreateTestFamily(t, db)
+	parent := testutil.CreateTestParent(t, db, family.ID)
+	child := testutil.CreateTestChild(t, db, family.ID, "Emma")
+
+	schedRepo := repositories.NewScheduleRepo(db)
+	txRepo := repositories.NewTransactionRepo(db)
+	childRepo := repositories.NewChildRepo(db)
+
+	pastTime := time.Date(2026, time.February, 6, 0, 0, 0, 0, time.UTC)
+	createChoreInstancesForChild(t, db, family.ID, parent.ID, child.ID, pastTime.AddDate(0, 0, -2),
+		models.ChoreInstanceStatusApproved, models.ChoreInstanceStatusApproved,
+		models.ChoreInstanceStatusApproved, models.ChoreInstanceStatusExpired,
+		// Still waiting for review, so neither done nor missed
+		models.ChoreInstanceStatusPendingApproval)
+	// Instances from an earlier period do not count
+	createChoreInstancesForChild(t, db, family.ID, parent.ID, child.ID, pastTime.AddDate(0, 0, -10),
+		models.ChoreInstanceStatusExpired)
+
+	note := "Weekly allowance"
+	_, err := schedRepo.Create(&models.AllowanceSchedule{
+		ChildID:     child.ID,
+		ParentID:    parent.ID,
+		AmountCents: 1000,
+		Frequency:   models.FrequencyWeekly,
+		DayOfWeek:   intPtr(5),
+		Note:        &note,
+		Status:      models.ScheduleStatusActive,
+		NextRunAt:   &pastTime,
+		ChoreRule:   models.ChoreRuleProportional,
+	})
+	require.NoError(t, err)
+
+	scheduler := NewScheduler(schedRepo, txRepo, childRepo)
+	scheduler.SetChoreInstanceRepo(repositories.NewChoreInstanceRepo(db))
+	scheduler.ProcessDueSchedules()
+
+	txns, err := txRepo.ListByChild(child.ID)
+	require.NoError(t, err)
+	require.Len(t, txns, 1)
+	assert.Equal(t, int64(750), txns[0].AmountCents)
+	assert.Equal(t, "Weekly allowance — Allowance prorated: 3 of 4 chores approved (75%)", *txns[0].Note)
+}
+
+// =====================================================
+// Tests for Scheduler.ApplyAgeRaises
+// =====================================================
+
+func TestScheduler_ApplyAgeRaises_RecordsRaiseOnBirthday(t *testing.T) {
+	db := testutil.SetupTestDB(t)
+	family := testutil.CreateTestFamily(t, db)
+	parent := testutil.CreateTestParent(t, db, family.ID)
+	child := testutil.CreateTestChild(t, db, family.ID, "Emma")
+
+	schedRepo := repositories.NewScheduleRepo(db)
+	childRepo := repositories.NewChildRepo(db)
+
+	// Turned 10 today; the schedule still pays the 9-year-old amount
+	today := time.Now().UTC()
+	birthdate := time.Date(today.Year()-10, today.Month(), today.Day(), 0, 0, 0, 0, time.UTC)
+	require.NoError(t, childRepo.UpdateBirthdate(child.ID, &birthdate))
+
+	nextRun := today.AddDate(0, 0, 3)
+	sched, err := schedRepo.Create(&models.AllowanceSchedule{
+		ChildID:           child.ID,
+		ParentID:          parent.ID,
+		AmountCents:       900,
+		Frequency:         models.FrequencyWeekly,
+		DayOfWeek:         intPtr(5),
+		Status:            models.ScheduleStatusActive,
+		NextRunAt:         &nextRun,
+		AmountFormula:     models.AmountFormulaPerYearOfAge,
+		CentsPerYearOfAge: int64Ptr(100),
+	})
+	require.NoError(t, err)
+
+	scheduler := NewScheduler(schedRepo, repositories.NewTransactionRepo(db), childRepo)
+	scheduler.ApplyAgeRaises()
+
+	updated, err := schedRepo.GetByID(sched.ID)
+	require.NoError(t, err)
+	assert.Equal(t, int64(1000), updated.AmountCents)
+
+	raises, err := schedRepo.ListRaisesByChild(child.ID)
+	require.NoError(t, err)
+	require.Len(t, raises, 1)
+	assert.Equal(t, sched.ID, raises[0].ScheduleID)
+	assert.Equal(t, 10, raises[0].Age)
+	assert.Equal(t, int64(900), raises[0].OldAmountCents)
+	assert.Equal(t, int64(1000), raises[0].NewAmountCents)
+
+	// Running again is a no-op
+	scheduler.ApplyAgeRaises()
+	raises, err = schedRepo.ListRaisesByChild(child.ID)
+	require.NoError(t, err)
+	assert.Len(t, raises, 1)
+}
+
+func TestScheduler_ApplyAgeRaises_IgnoresFlatSchedules(t *testing.T) {
+	db := testutil.SetupTestDB(t)
+	family := testutil.CreateTestFamily(t, db)
+	parent := testutil.CreateTestParent(t, db, family.ID)
+	child := testutil.CreateTestChild(t, db, family.ID, "Emma")
+
+	schedRepo := repositories.NewScheduleRepo(db)
+	childRepo := repositories.NewChildRepo(db)
+
+	birthdate := time.Date(2015, time.March, 1, 0, 0, 0, 0, time.UTC)
+	require.NoError(t, childRepo.UpdateBirthdate(child.ID, &birthdate))
+
+	nextRun := time.Now().UTC().AddDate(0, 0, 3)
+	sched, err := schedRepo.Create(&models.AllowanceSchedule{
+		ChildID:     child.ID,
+		ParentID:    parent.ID,
+		AmountCents: 700,
+		Frequency:   models.FrequencyWeekly,
+		DayOfWeek:   intPtr(5),
+		Status:      models.ScheduleStatusActive,
+		NextRunAt:   &nextRun,
+	})
+	require.NoError(t, err)
+
+	scheduler := NewScheduler(schedRepo, repositories.NewTransactionRepo(db), childRepo)
+	scheduler.ApplyAgeRaises()
+
+	updated, err := schedRepo.GetByID(sched.ID)
+	require.NoError(t, err)
+	assert.Equal(t, int64(700), updated.AmountCents)
+
+	raises, err := schedRepo.ListRaisesByChild(child.ID)
+	require.NoError(t, err)
+	assert.Empty(t, raises)
+}
+
+func TestScheduler_ProcessDueSchedules_SkipOverride(t *testing.T) {
+	db := testutil.SetupTestDB(t)
+	family := testutil.CreateTestFamily(t, db)
+	parent := testutil.CreateTestParent(t, db, family.ID)
+	child := testutil.CreateTestChild(t, db, family.ID, "Emma")
+
+	schedRepo := repositories.NewScheduleRepo(db)
+	txRepo := repositories.NewTransactionRepo(db)
+	childRepo := repositories.NewChildRepo(db)
+
+	est, _ := time.LoadLocation("America/New_York")
+	pastTime := time.Date(2026, time.January, 30, 0, 0, 0, 0, est)
+	created, err := schedRepo.Create(&models.AllowanceSchedule{
+		ChildID:     child.ID,
+		ParentID:    parent.ID,
+		AmountCents: 1000,
+		Frequency:   models.FrequencyWeekly,
+		DayOfWeek:   intPtr(5),
+		Status:      models.ScheduleStatusActive,
+		NextRunAt:   &pastTime,
+	})
+	require.NoError(t, err)
+	_, err = schedRepo.SetOverride(&models.AllowanceOverride{
+		ScheduleID:        created.ID,
+		RunDate:           date(2026, time.January, 30),
+		Action:            models.OverrideActionSkip,
+		CreatedByParentID: parent.ID,
+	})
+	require.NoError(t, err)
+
+	scheduler := NewScheduler(schedRepo, txRepo, childRepo)
+	scheduler.ProcessDueSchedules()
+
+	txns, err := txRepo.ListByChild(child.ID)
+	require.NoError(t, err)
+	assert.Empty(t, txns)
+
+	// The schedule resumes its normal cadence
+	updated, err := schedRepo.GetByID(created.ID)
+	require.NoError(t, err)
+	assert.Equal(t, time.Date(2026, time.February, 6, 0, 0, 0, 0, est).UTC(), updated.NextRunAt.UTC())
+	assert.Equal(t, int64(0), updated.HeldCents)
+}
+
+func TestScheduler_ProcessDueSchedules_HoldOverridePaysNextRun(t *testing.T) {
+	db := testutil.SetupTestDB(t)
+	family := testutil.CreateTestFamily(t, db)
+	parent := testutil.CreateTestParent(t, db, family.ID)
+	child := testutil.CreateTestChild(t, db, family.ID, "Emma")
+
+	schedRepo := repositories.NewScheduleRepo(db)
+	txRepo := repositories.NewTransactionRepo(db)
+	childRepo := repositories.NewChildRepo(db)
+
+	est, _ := time.LoadLocation("America/New_York")
+	pastTime := time.Date(2026, time.January, 30, 0, 0, 0, 0, est)
+	created, err := schedRepo.Create(&models.AllowanceSchedule{
+		ChildID:     child.ID,
+		ParentID:    parent.ID,
+		AmountCents: 1000,
+		Frequency:   models.FrequencyWeekly,
+		DayOfWeek:   intPtr(5),
+		Status:      models.ScheduleStatusActive,
+		NextRunAt:   &pastTime,
+	})
+	require.NoError(t, err)
+	_, err = schedRepo.SetOverride(&models.AllowanceOverride{
+		ScheduleID:        created.ID,
+		RunDate:           date(2026, time.January, 30),
+		Action:            models.OverrideActionHold,
+		CreatedByParentID: parent.ID,
+	})
+	require.NoError(t, err)
+
+	scheduler := NewScheduler(schedRepo, txRepo, childRepo)
+	scheduler.ProcessDueSchedules()
+
+	updated, err := schedRepo.GetByID(created.ID)
+	require.NoError(t, err)
+	assert.Equal(t, int64(1000), updated.HeldCents)
+	txns, err := txRepo.ListByChild(child.ID)
+	require.NoError(t, err)
+	assert.Empty(t, txns)
+
+	// The next run (Feb 6, also in the past) pays the held amount on top of the regular one
+	scheduler.ProcessDueSchedules()
+
+	txns, err = txRepo.ListByChild(child.ID)
+	require.NoError(t, err)
+	require.Len(t, txns, 1)
+	assert.Equal(t, int64(2000), txns[0].AmountCents)
+	assert.Equal(t, "Includes held allowance", *txns[0].Note)
+
+	updated, err = schedRepo.GetByID(created.ID)
+	require.NoError(t, err)
+	assert.Equal(t, int64(0), updated.HeldCents)
+}
+
+func TestScheduler_ProcessDueSchedules_CustomOverride(t *testing.T) {
+	db := testutil.SetupTestDB(t)
+	family := testutil.CreateTestFamily(t, db)
+	parent := testutil.CreateTestParent(t, db, family.ID)
+	child := testutil.CreateTestChild(t, db, family.ID, "Emma")
+
+	schedRepo := repositories.NewScheduleRepo(db)
+	txRepo := repositories.NewTransactionRepo(db)
+	childRepo := repositories.NewChildRepo(db)
+
+	est, _ := time.LoadLocation("America/New_York")
+	pastTime := time.Date(2026, time.January, 30, 0, 0, 0, 0, est)
+	note := "Weekly allowance"
+	created, err := schedRepo.Create(&models.AllowanceSchedule{
+		ChildID:     child.ID,
+		ParentID:    parent.ID,
+		AmountCents: 1000,
+		Frequency:   models.FrequencyWeekly,
+		DayOfWeek:   intPtr(5),
+		Note:        &note,
+		Status:      models.ScheduleStatusActive,
+		NextRunAt:   &pastTime,
+	})
+	require.NoError(t, err)
+	_, err = schedRepo.SetOverride(&models.AllowanceOverride{
+		ScheduleID:        created.ID,
+		RunDate:           date(2026, time.January, 30),
+		Action:            models.OverrideActionCustom,
+		AmountCents:       int64Ptr(2500),
+		Note:              strPtr("Birthday week"),
+		CreatedByParentID: parent.ID,
+	})
+	require.NoError(t, err)
+
+	scheduler := NewScheduler(schedRepo, txRepo, childRepo)
+	scheduler.ProcessDueSchedules()
+
+	txns, err := txRepo.ListByChild(child.ID)
+	require.NoError(t, err)
+	require.Len(t, txns, 1)
+	assert.Equal(t, int64(2500), txns[0].AmountCents)
+	assert.Equal(t, "Birthday week", *txns[0].Note)
+
+	// The override applies to one run only
+	updated, err := schedRepo.GetByID(created.ID)
+	require.NoError(t, err)
+	assert.Equal(t, int64(1000), updated.AmountCents)
+	assert.Equal(t, "Weekly allowance", *updated.Note)
+}
+
+func TestScheduler_ProcessDueSchedules_CompletesAtMaxRuns(t *testing.T) {
+	db := testutil.SetupTestDB(t)
+	family := testutil.CreateTestFamily(t, db)
+	parent := testutil.CreateTestParent(t, db, family.ID)
+	child := testutil.CreateTestChild(t, db, family.ID, "Emma")
+
+	schedRepo := repositories.NewScheduleRepo(db)
+	txRepo := repositories.NewTransactionRepo(db)
+	childRepo := repositories.NewChildRepo(db)
+
+	// Three runs in the past (Jan 16, 23, 30), but only two allowed
+	est, _ := time.LoadLocation("America/New_York")
+	pastTime := time.Date(2026, time.January, 16, 0, 0, 0, 0, est)
+	created, err := schedRepo.Create(&models.AllowanceSchedule{
+		ChildID:     child.ID,
+		ParentID:    parent.ID,
+		AmountCents: 1000,
+		Frequency:   models.FrequencyWeekly,
+		DayOfWeek:   intPtr(5),
+		Status:      models.ScheduleStatusActive,
+		NextRunAt:   &pastTime,
+		MaxRuns:     intPtr(2),
+	})
+	require.NoError(t, err)
+
+	scheduler := NewScheduler(schedRepo, txRepo, childRepo)
+	for i := 0; i < 3; i++ {
+		scheduler.ProcessDueSchedules()
+	}
+
+	txns, err := txRepo.ListByChild(child.ID)
+	require.NoError(t, err)
+	assert.Len(t, txns, 2)
+
+	updated, err := schedRepo.GetByID(created.ID)
+	require.NoError(t, err)
+	assert.Equal(t, models.ScheduleStatusCompleted, updated.Status)
+	assert.Equal(t, 2, updated.RunCount)
+	assert.Nil(t, updated.NextRunAt)
+}
+
+func TestScheduler_ProcessDueSchedules_CompletesAtEndDate(t *testing.T) {
+	db := testutil.SetupTestDB(t)
+	family := testutil.CreateTestFamily(t, db)
+	parent := testutil.CreateTestParent(t, db, family.ID)
+	child := testutil.CreateTestChild(t, db, family.ID, "Emma")
+
+	schedRepo := repositories.NewScheduleRepo(db)
+	txRepo := repositories.NewTransactionRepo(db)
+	childRepo := repositories.NewChildRepo(db)
+
+	est, _ := time.LoadLocation("America/New_York")
+	pastTime := time.Date(2026, time.January, 30, 0, 0, 0, 0, est)
+	endDate := date(2026, time.February, 1)
+	created, err := schedRepo.Create(&models.AllowanceSchedule{
+		ChildID:     child.ID,
+		ParentID:    parent.ID,
+		AmountCents: 1000,
+		Frequency:   models.FrequencyWeekly,
+		DayOfWeek:   intPtr(5),
+		Status:      models.ScheduleStatusActive,
+		NextRunAt:   &pastTime,
+		EndDate:     &endDate,
+	})
+	require.NoError(t, err)
+
+	scheduler := NewScheduler(schedRepo, txRepo, childRepo)
+	scheduler.ProcessDueSchedules()
+
+	txns, err := txRepo.ListByChild(child.ID)
+	require.NoError(t, err)
+	require.Len(t, txns, 1, "the final payment is made")
+
+	updated, err := schedRepo.GetByID(created.ID)
+	require.NoError(t, err)
+	assert.Equal(t, models.ScheduleStatusCompleted, updated.Status)
+}
+
+func TestScheduler_ProcessDueSchedules_LoweredLimitReleasesHeld(t *testing.T) {
+	db := testutil.SetupTestDB(t)
+	family := testutil.CreateTestFamily(t, db)
+	parent := testutil.CreateTestParent(t, db, family.ID)
+	child := testutil.CreateTestChild(t, db, family.ID, "Emma")
+
+	schedRepo := repositories.NewScheduleRepo(db)
+	txRepo := repositories.NewTransactionRepo(db)
+	childRepo := repositories.NewChildRepo(db)
+
+	pastTime := time.Date(2026, time.February, 6, 0, 0, 0, 0, time.UTC)
+	created, err := schedRepo.Create(&models.AllowanceSchedule{
+		ChildID:     child.ID,
+		ParentID:    parent.ID,
+		AmountCents: 1000,
+		Frequency:   models.FrequencyWeekly,
+		DayOfWeek:   intPtr(5),
+		Status:      models.ScheduleStatusActive,
+		NextRunAt:   &pastTime,
+	})
+	require.NoError(t, err)
+
+	// Two runs were held, then max_runs was lowered to the runs already made
+	require.NoError(t, db.Model(&models.AllowanceSchedule{}).Where("id = ?", created.ID).
+		Updates(map[string]interface{}{"run_count": 2, "held_cents": 2000, "max_runs": 2}).Error)
+
+	scheduler := NewScheduler(schedRepo, txRepo, childRepo)
+	scheduler.ProcessDueSchedules()
+
+	txns, err := txRepo.ListByChild(child.ID)
+	require.NoError(t, err)
+	require.Len(t, txns, 1)
+	assert.Equal(t, int64(2000), txns[0].AmountCents)
+	require.NotNil(t, txns[0].Note)
+	assert.Equal(t, "Held allowance", *txns[0].Note)
+
+	updated, err := schedRepo.GetByID(created.ID)
+	require.NoError(t, err)
+	assert.Equal(t, models.ScheduleStatusCompleted, updated.Status)
+	assert.Equal(t, int64(0), updated.HeldCents)
+	assert.Equal(t, 2, updated.RunCount)
+	assert.Nil(t, updated.NextRunAt)
+}
@@ -0,0 +1,226 @@
+package calendar
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"time"
+
+	"bank-of-dad/internal/allowance"
+	"bank-of-dad/models"
+)
+
+// EventType identifies what a calendar event represents.
+type EventType string
+
+const (
+	EventTypeAllowance EventType = "allowance"
+	EventTypeInterest  EventType = "interest"
+	EventTypeChoreDue  EventType = "chore_due"
+)
+
+// Event is one upcoming money event for a child, on a date in the family's timezone.
+type Event struct {
+	Type        EventType `json:"type"`
+	Date        string    `json:"date"`
+	At          time.Time `json:"at"`
+	Title       string    `json:"title"`
+	ChildID     int64     `json:"child_id"`
+	ChildName   string    `json:"child_name"`
+	AmountCents *int64    `json:"amount_cents,omitempty"`
+
+	// SourceID is the allowance schedule, interest schedule, or chore instance the event comes from.
+	SourceID int64 `json:"source_id"`
+	// Status notes a skipped or held allowance run, if any.
+	Status string `json:"status,omitempty"`
+}
+
+// UID returns a stable identifier for the event, used by calendar feeds.
+func (e Event) UID() string {
+	return fmt.Sprintf("%s-%d-%s@bankofdad", e.Type, e.SourceID, e.Date)
+}
+
+// buildEvents collects a family's allowance runs, interest payouts and chore deadlines in [from, to).
+func (h *Handler) buildEvents(familyID int64, from, to time.Time, loc *time.Location) ([]Event, error) {
+	var events []Event
+
+	allowanceEvents, err := h.allowanceEvents(familyID, from, to, loc)
+	if err != nil {
+		return nil, err
+	}
+	events = append(events, allowanceEvents...)
+
+	interestEvents, err := h.interestEvents(familyID, from, to, loc)
+	if err != nil {
+		return nil, err
+	}
+	events = append(events, interestEvents...)
+
+	choreEvents, err := h.choreEvents(familyID, from, to, loc)
+	if err != nil {
+		return nil, err
+	}
+	events = append(events, choreEvents...)
+
+	sort.SliceStable(events, func(i, j int) bool {
+		if !events[i].At.Equal(events[j].At) {
+			return events[i].At.Before(events[j].At)
+		}
+		return events[i].ChildName < events[j].ChildName
+	})
+	return events, nil
+}
+
+// allowanceEvents projects each active allowance schedule's runs, honoring per-run overrides.
+func (h *Handler) allowanceEvents(familyID int64, from, to time.Time, loc *time.Location) ([]Event, error) {
+	schedules, err := h.scheduleRepo.ListByParentFamily(familyID)
+	if err != nil {
+		return nil, err
+	}
+
+	var events []Event
+	for i := range schedules {
+		s := &schedules[i]
+		if s.Status != models.ScheduleStatusActive {
+			continue
+		}
+		runs, err := allowance.UpcomingRuns(&s.AllowanceSchedule, from, to, loc)
+		if err != nil {
+			return nil, err
+		}
+		if len(runs) == 0 {
+			continue
+		}
+
+		overrides, err := h.scheduleRepo.ListOverrides(s.ID, allowance.RunDate(runs[0], loc))
+		if err != nil {
+			return nil, err
+		}
+		byDate := make(map[string]models.AllowanceOverride, len(overrides))
+		for _, o := range overrides {
+			byDate[o.RunDate.Format(time.DateOnly)] = o
+		}
+
+		for _, run := range runs {
+			date := allowance.RunDate(run, loc).Format(time.DateOnly)
+			amount := s.AmountCents
+			title := "Allowance"
+			if s.Note != nil {
+				title = *s.Note
+			}
+			var status string
+			if o, ok := byDate[date]; ok {
+				switch o.Action {
+				case models.OverrideActionSkip, models.OverrideActionHold:
+					amount = 0
+					status = string(o.Action)
+				case models.OverrideActionCustom:
+					if o.AmountCents != nil {
+						amount = *o.AmountCents
+					}
+					if o.Note != nil {
+						title = *o.Note
+					}
+				}
+			}
+			events = append(events, Event{
+				Type:        EventTypeAllowance,
+				Date:        date,
+				At:          run,
+				Title:       title,
+				ChildID:     s.ChildID,
+				ChildName:   s.ChildFirstName,
+				AmountCents: &amount,
+				SourceID:    s.ID,
+				Status:      status,
+			})
+		}
+	}
+	return events, nil
+}
+
+// interestEvents projects each active interest schedule's payouts. Amounts depend on the
+// balance at the time, so they are left out.
+func (h *Handler) interestEvents(familyID int64, from, to time.Time, loc *time.Location) ([]Event, error) {
+	schedules, err := h.interestScheduleRepo.ListActiveByFamily(familyID)
+	if err != nil {
+		return nil, err
+	}
+	if len(schedules) == 0 {
+		return nil, nil
+	}
+	names, err := h.childNames(familyID)
+	if err != nil {
+		return nil, err
+	}
+
+	var events []Event
+	for _, is := range schedules {
+		tmpSched := &models.AllowanceSchedule{
+			Frequency:  is.Frequency,
+			DayOfWeek:  is.DayOfWeek,
+			DayOfMonth: is.DayOfMonth,
+			RRule:      is.RRule,
+			NextRunAt:  is.NextRunAt,
+		}
+		runs, err := allowance.UpcomingRuns(tmpSched, from, to, loc)
+		if err != nil {
+			return nil, err
+		}
+		for _, run := range runs {
+			events = append(events, Event{
+				Type:      EventTypeInterest,
+				Date:      allowance.RunDate(run, loc).Format(time.DateOnly),
+				At:        run,
+				Title:     "Interest payout",
+				ChildID:   is.ChildID,
+				ChildName: names[is.ChildID],
+				SourceID:  is.ID,
+			})
+		}
+	}
+	return events, nil
+}
+
+// choreEvents lists the deadlines of chores that are still open.
+func (h *Handler) choreEvents(familyID int64, from, to time.Time, loc *time.Location) ([]Event, error) {
+	instances, err := h.choreInstanceRepo.ListOpenByFamilyDue(familyID, from, to)
+	if err != nil {
+		return nil, err
+	}
+
+	events := make([]Event, 0, len(instances))
+	for _, inst := range instances {
+		y, m, d := inst.PeriodEnd.Date()
+		reward := int64(inst.RewardCents)
+		events = append(events, Event{
+			Type:        EventTypeChoreDue,
+			Date:        inst.PeriodEnd.Format(time.DateOnly),
+			At:          time.Date(y, m, d, 0, 0, 0, 0, loc),
+			Title:       "Chore due: " + inst.ChoreName,
+			ChildID:     inst.ChildID,
+			ChildName:   inst.ChildName,
+			AmountCents: &reward,
+			SourceID:    inst.ID,
+		})
+	}
+	return events, nil
+}
+
+// childNames maps a family's child IDs to their first names.
+func (h *Handler) childNames(familyID int64) (map[int64]string, error) {
+	children, err := h.childRepo.ListByFamily(familyID)
+	if err != nil {
+		return nil, err
+	}
+	names := make(map[int64]string, len(children))
+	for _, c := range children {
+		names[c.ID] = c.FirstName
+	}
+	return names, nil
+}
+
+// formatCents renders an amount in cents as dollars, e.g. 1050 as "$10.50".
+func formatCents(cents int64) string {
+	return "$" + strconv.FormatFloat(float64(cents)/100, 'f', 2, 64)
+}
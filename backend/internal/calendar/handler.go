@@ -0,0 +1,221 @@
+package calendar
+
+import (
+	"encoding/json"
+	"log"
+	"net/http"
+	"strings"
+	"time"
+
+	"bank-of-dad/internal/middleware"
+	"bank-of-dad/repositories"
+)
+
+const (
+	// DefaultRangeDays is the range returned when no end date is given.
+	DefaultRangeDays = 31
+	// MaxRangeDays limits the range of a single calendar request.
+	MaxRangeDays = 366
+
+	// The feed reaches back so recently overdue chores stay visible in subscribed calendars.
+	feedDaysBack  = 30
+	feedDaysAhead = 180
+)
+
+// Handler handles family calendar HTTP requests.
+type Handler struct {
+	familyRepo           *repositories.FamilyRepo
+	childRepo            *repositories.ChildRepo
+	scheduleRepo         *repositories.ScheduleRepo
+	interestScheduleRepo *repositories.InterestScheduleRepo
+	choreInstanceRepo    *repositories.ChoreInstanceRepo
+}
+
+// NewHandler creates a new calendar handler.
+func NewHandler(familyRepo *repositories.FamilyRepo, childRepo *repositories.ChildRepo, scheduleRepo *repositories.ScheduleRepo, interestScheduleRepo *repositories.InterestScheduleRepo, choreInstanceRepo *repositories.ChoreInstanceRepo) *Handler {
+	return &Handler{
+		familyRepo:           familyRepo,
+		childRepo:            childRepo,
+		scheduleRepo:         scheduleRepo,
+		interestScheduleRepo: interestScheduleRepo,
+		choreInstanceRepo:    choreInstanceRepo,
+	}
+}
+
+// ErrorResponse represents an error response.
+type ErrorResponse struct {
+	Error   string `json:"error"`
+	Message string `json:"message,omitempty"`
+}
+
+// CalendarResponse lists the events in a date range.
+type CalendarResponse struct {
+	From   string  `json:"from"`
+	To     string  `json:"to"`
+	Events []Event `json:"events"`
+}
+
+// FeedTokenResponse returns a newly issued calendar feed token and the path to subscribe to.
+type FeedTokenResponse struct {
+	Token    string `json:"token"`
+	FeedPath string `json:"feed_path"`
+}
+
+func writeJSON(w http.ResponseWriter, status int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(v)
+}
+
+// loadTimezone parses a timezone string into a *time.Location, falling back to UTC.
+func loadTimezone(tz string) *time.Location {
+	if tz != "" {
+		if loc, err := time.LoadLocation(tz); err == nil {
+			return loc
+		}
+	}
+	return time.UTC
+}
+
+// startOfDay returns midnight in loc on the date of t in loc.
+func startOfDay(t time.Time, loc *time.Location) time.Time {
+	y, m, d := t.In(loc).Date()
+	return time.Date(y, m, d, 0, 0, 0, 0, loc)
+}
+
+// parseRange reads the from and to query parameters (YYYY-MM-DD, to exclusive) as dates in loc.
+// from defaults to today and to defaults to DefaultRangeDays after from.
+func parseRange(r *http.Request, loc *time.Location) (from, to time.Time, errMsg string) {
+	from = startOfDay(time.Now(), loc)
+	if v := r.URL.Query().Get("from"); v != "" {
+		parsed, err := time.ParseInLocation(time.DateOnly, v, loc)
+		if err != nil {
+			return from, to, "from must be a date in YYYY-MM-DD format."
+		}
+		from = parsed
+	}
+	to = from.AddDate(0, 0, DefaultRangeDays)
+	if v := r.URL.Query().Get("to"); v != "" {
+		parsed, err := time.ParseInLocation(time.DateOnly, v, loc)
+		if err != nil {
+			return from, to, "to must be a date in YYYY-MM-DD format."
+		}
+		to = parsed
+	}
+	if !to.After(from) {
+		return from, to, "to must be after from."
+	}
+	if to.After(from.AddDate(0, 0, MaxRangeDays)) {
+		return from, to, "The date range cannot exceed 366 days."
+	}
+	return from, to, ""
+}
+
+// HandleGetCalendar handles GET /api/calendar?from=YYYY-MM-DD&to=YYYY-MM-DD
+func (h *Handler) HandleGetCalendar(w http.ResponseWriter, r *http.Request) {
+	if middleware.GetUserType(r) != "parent" {
+		writeJSON(w, http.StatusForbidden, ErrorResponse{Error: "forbidden", Message: "Only parents can view the family calendar."})
+		return
+	}
+
+	familyID := middleware.GetFamilyID(r)
+	family, err := h.familyRepo.GetByID(familyID)
+	if err != nil || family == nil {
+		writeJSON(w, http.StatusInternalServerError, ErrorResponse{Error: "internal_error", Message: "Failed to load family."})
+		return
+	}
+	loc := loadTimezone(family.Timezone)
+
+	from, to, errMsg := parseRange(r, loc)
+	if errMsg != "" {
+		writeJSON(w, http.StatusBadRequest, ErrorResponse{Error: "invalid_range", Message: errMsg})
+		return
+	}
+
+	events, err := h.buildEvents(familyID, from, to, loc)
+	if err != nil {
+		log.Printf("Error building calendar for family %d: %v", familyID, err)
+		writeJSON(w, http.StatusInternalServerError, ErrorResponse{Error: "internal_error", Message: "Failed to build calendar."})
+		return
+	}
+	if events == nil {
+		events = []Event{}
+	}
+
+	writeJSON(w, http.StatusOK, CalendarResponse{
+		From:   from.Format(time.DateOnly),
+		To:     to.Format(time.DateOnly),
+		Events: events,
+	})
+}
+
+// HandleCreateFeedToken handles POST /api/calendar/feed
+// Issuing a new token revokes the previous one.
+func (h *Handler) HandleCreateFeedToken(w http.ResponseWriter, r *http.Request) {
+	if middleware.GetUserType(r) != "parent" {
+		writeJSON(w, http.StatusForbidden, ErrorResponse{Error: "forbidden", Message: "Only parents can publish the family calendar."})
+		return
+	}
+
+	token, err := h.familyRepo.RotateCalendarToken(middleware.GetFamilyID(r))
+	if err != nil {
+		writeJSON(w, http.StatusInternalServerError, ErrorResponse{Error: "internal_error", Message: "Failed to create calendar feed."})
+		return
+	}
+
+	writeJSON(w, http.StatusCreated, FeedTokenResponse{
+		Token:    token,
+		FeedPath: "/api/calendar/feed/" + token + ".ics",
+	})
+}
+
+// HandleDeleteFeedToken handles DELETE /api/calendar/feed
+func (h *Handler) HandleDeleteFeedToken(w http.ResponseWriter, r *http.Request) {
+	if middleware.GetUserType(r) != "parent" {
+		writeJSON(w, http.StatusForbidden, ErrorResponse{Error: "forbidden", Message: "Only parents can unpublish the family calendar."})
+		return
+	}
+
+	if err := h.familyRepo.ClearCalendarToken(middleware.GetFamilyID(r)); err != nil {
+		writeJSON(w, http.StatusInternalServerError, ErrorResponse{Error: "internal_error", Message: "Failed to revoke calendar feed."})
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// HandleFeed handles GET /api/calendar/feed/{token}
+// Public: the token in the URL is the credential, so calendar apps can subscribe without logging in.
+func (h *Handler) HandleFeed(w http.ResponseWriter, r *http.Request) {
+	token := strings.TrimSuffix(r.PathValue("token"), ".ics")
+	if token == "" {
+		http.NotFound(w, r)
+		return
+	}
+
+	family, err := h.familyRepo.GetByCalendarToken(token)
+	if err != nil {
+		http.Error(w, "Failed to load calendar.", http.StatusInternalServerError)
+		return
+	}
+	if family == nil {
+		http.NotFound(w, r)
+		return
+	}
+
+	loc := loadTimezone(family.Timezone)
+	today := startOfDay(time.Now(), loc)
+	events, err := h.buildEvents(family.ID, today.AddDate(0, 0, -feedDaysBack), today.AddDate(0, 0, feedDaysAhead), loc)
+	if err != nil {
+		log.Printf("Error building calendar feed for family %d: %v", family.ID, err)
+		http.Error(w, "Failed to build calendar.", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/calendar; charset=utf-8")
+	w.Header().Set("Cache-Control", "private, max-age=900")
+	w.WriteHeader(http.StatusOK)
+	if err := WriteICS(w, "Bank of "+family.BankName, events, time.Now()); err != nil {
+		log.Printf("Error writing calendar feed for family %d: %v", family.ID, err)
+	}
+}
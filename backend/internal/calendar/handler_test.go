@@ -0,0 +1,151 @@
+package calendar
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"bank-of-dad/internal/testutil"
+	"bank-of-dad/models"
+	"bank-of-dad/repositories"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+)
+
+func newTestHandler(t *testing.T, db *gorm.DB) *Handler {
+	t.Helper()
+	return NewHandler(
+		repositories.NewFamilyRepo(db),
+		repositories.NewChildRepo(db),
+		repositories.NewScheduleRepo(db),
+		repositories.NewInterestScheduleRepo(db),
+		repositories.NewChoreInstanceRepo(db),
+	)
+}
+
+func intPtr(i int) *int {
+	return &i
+}
+
+// createWeeklySchedule creates an active Friday allowance whose next run is Feb 6, 2026 in New York.
+func createWeeklySchedule(t *testing.T, db *gorm.DB, childID, parentID int64) *models.AllowanceSchedule {
+	t.Helper()
+	est, _ := time.LoadLocation("America/New_York")
+	nextRun := time.Date(2026, time.February, 6, 0, 0, 0, 0, est)
+	sched, err := repositories.NewScheduleRepo(db).Create(&models.AllowanceSchedule{
+		ChildID:     childID,
+		ParentID:    parentID,
+		AmountCents: 1000,
+		Frequency:   models.FrequencyWeekly,
+		DayOfWeek:   intPtr(5),
+		Status:      models.ScheduleStatusActive,
+		NextRunAt:   &nextRun,
+	})
+	require.NoError(t, err)
+	return sched
+}
+
+func TestHandleGetCalendar_ProjectsAllowanceRuns(t *testing.T) {
+	db := testutil.SetupTestDB(t)
+	family := testutil.CreateTestFamily(t, db)
+	parent := testutil.CreateTestParent(t, db, family.ID)
+	child := testutil.CreateTestChild(t, db, family.ID, "Emma")
+	sched := createWeeklySchedule(t, db, child.ID, parent.ID)
+
+	_, err := repositories.NewScheduleRepo(db).SetOverride(&models.AllowanceOverride{
+		ScheduleID:        sched.ID,
+		RunDate:           time.Date(2026, time.February, 13, 0, 0, 0, 0, time.UTC),
+		Action:            models.OverrideActionSkip,
+		CreatedByParentID: parent.ID,
+	})
+	require.NoError(t, err)
+
+	handler := newTestHandler(t, db)
+	req := httptest.NewRequest("GET", "/api/calendar?from=2026-02-01&to=2026-02-21", nil)
+	req = testutil.SetRequestContext(req, "parent", parent.ID, family.ID)
+	rr := httptest.NewRecorder()
+	handler.HandleGetCalendar(rr, req)
+
+	require.Equal(t, http.StatusOK, rr.Code, rr.Body.String())
+	var resp CalendarResponse
+	require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &resp))
+	require.Len(t, resp.Events, 3)
+	assert.Equal(t, "2026-02-06", resp.Events[0].Date)
+	assert.Equal(t, EventTypeAllowance, resp.Events[0].Type)
+	assert.Equal(t, "Emma", resp.Events[0].ChildName)
+	assert.Equal(t, int64(1000), *resp.Events[0].AmountCents)
+	assert.Equal(t, "2026-02-13", resp.Events[1].Date)
+	assert.Equal(t, "skip", resp.Events[1].Status)
+	assert.Equal(t, "2026-02-20", resp.Events[2].Date)
+}
+
+func TestHandleGetCalendar_InvalidRange(t *testing.T) {
+	db := testutil.SetupTestDB(t)
+	family := testutil.CreateTestFamily(t, db)
+	parent := testutil.CreateTestParent(t, db, family.ID)
+
+	handler := newTestHandler(t, db)
+	for _, query := range []string{"from=2026-02-10&to=2026-02-01", "from=2026-01-01&to=2027-06-01", "from=soon"} {
+		req := httptest.NewRequest("GET", "/api/calendar?"+query, nil)
+		req = testutil.SetRequestContext(req, "parent", parent.ID, family.ID)
+		rr := httptest.NewRecorder()
+		handler.HandleGetCalendar(rr, req)
+		assert.Equal(t, http.StatusBadRequest, rr.Code, query)
+	}
+}
+
+func TestHandleFeed_TokenLifecycle(t *testing.T) {
+	db := testutil.SetupTestDB(t)
+	family := testutil.CreateTestFamily(t, db)
+	parent := testutil.CreateTestParent(t, db, family.ID)
+	child := testutil.CreateTestChild(t, db, family.ID, "Emma")
+	createWeeklySchedule(t, db, child.ID, parent.ID)
+
+	handler := newTestHandler(t, db)
+	req := httptest.NewRequest("POST", "/api/calendar/feed", nil)
+	req = testutil.SetRequestContext(req, "parent", parent.ID, family.ID)
+	rr := httptest.NewRecorder()
+	handler.HandleCreateFeedToken(rr, req)
+	require.Equal(t, http.StatusCreated, rr.Code)
+
+	var tokenResp FeedTokenResponse
+	require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &tokenResp))
+	require.NotEmpty(t, tokenResp.Token)
+
+	req = httptest.NewRequest("GET", tokenResp.FeedPath, nil)
+	req.SetPathValue("token", tokenResp.Token+".ics")
+	rr = httptest.NewRecorder()
+	handler.HandleFeed(rr, req)
+	require.Equal(t, http.StatusOK, rr.Code)
+	assert.True(t, strings.HasPrefix(rr.Header().Get("Content-Type"), "text/calendar"))
+	assert.Contains(t, rr.Body.String(), "BEGIN:VCALENDAR")
+
+	// Revoking the token disables the feed
+	req = httptest.NewRequest("DELETE", "/api/calendar/feed", nil)
+	req = testutil.SetRequestContext(req, "parent", parent.ID, family.ID)
+	rr = httptest.NewRecorder()
+	handler.HandleDeleteFeedToken(rr, req)
+	require.Equal(t, http.StatusNoContent, rr.Code)
+
+	req = httptest.NewRequest("GET", tokenResp.FeedPath, nil)
+	req.SetPathValue("token", tokenResp.Token+".ics")
+	rr = httptest.NewRecorder()
+	handler.HandleFeed(rr, req)
+	assert.Equal(t, http.StatusNotFound, rr.Code)
+}
+
+func TestHandleFeed_UnknownToken(t *testing.T) {
+	db := testutil.SetupTestDB(t)
+	handler := newTestHandler(t, db)
+
+	req := httptest.NewRequest("GET", "/api/calendar/feed/nope.ics", nil)
+	req.SetPathValue("token", "nope.ics")
+	rr := httptest.NewRecorder()
+	handler.HandleFeed(rr, req)
+	assert.Equal(t, http.StatusNotFound, rr.Code)
+}
@@ -0,0 +1,89 @@
+package calendar
+
+import (
+	"io"
+	"strings"
+	"time"
+)
+
+// icsLineLimit is the maximum length of a content line in octets, excluding the CRLF (RFC 5545 §3.1).
+const icsLineLimit = 75
+
+// WriteICS writes events as an RFC 5545 iCalendar document. Every event is an all-day
+// event on its date in the family's timezone. now stamps each event's DTSTAMP.
+func WriteICS(w io.Writer, calendarName string, events []Event, now time.Time) error {
+	var b strings.Builder
+	writeLine(&b, "BEGIN:VCALENDAR")
+	writeLine(&b, "VERSION:2.0")
+	writeLine(&b, "PRODID:-//Bank of Dad//Family Calendar//EN")
+	writeLine(&b, "CALSCALE:GREGORIAN")
+	writeLine(&b, "METHOD:PUBLISH")
+	writeLine(&b, "X-WR-CALNAME:"+escapeText(calendarName))
+
+	stamp := now.UTC().Format("20060102T150405Z")
+	for _, e := range events {
+		start, err := time.Parse(time.DateOnly, e.Date)
+		if err != nil {
+			continue
+		}
+		writeLine(&b, "BEGIN:VEVENT")
+		writeLine(&b, "UID:"+e.UID())
+		writeLine(&b, "DTSTAMP:"+stamp)
+		writeLine(&b, "DTSTART;VALUE=DATE:"+start.Format("20060102"))
+		writeLine(&b, "DTEND;VALUE=DATE:"+start.AddDate(0, 0, 1).Format("20060102"))
+		writeLine(&b, "SUMMARY:"+escapeText(summary(e)))
+		writeLine(&b, "CATEGORIES:"+escapeText(string(e.Type)))
+		writeLine(&b, "TRANSP:TRANSPARENT")
+		writeLine(&b, "END:VEVENT")
+	}
+	writeLine(&b, "END:VCALENDAR")
+
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+// summary is the one-line title shown in calendar apps, e.g. "Emma: Allowance $10.00".
+func summary(e Event) string {
+	s := e.ChildName + ": " + e.Title
+	if e.AmountCents != nil && *e.AmountCents > 0 {
+		s += " " + formatCents(*e.AmountCents)
+	}
+	if e.Status != "" {
+		s += " (" + e.Status + ")"
+	}
+	return s
+}
+
+// escapeText escapes a TEXT property value (RFC 5545 §3.3.11).
+func escapeText(s string) string {
+	return strings.NewReplacer(
+		`\`, `\\`,
+		`;`, `\;`,
+		`,`, `\,`,
+		"\r\n", `\n`,
+		"\n", `\n`,
+	).Replace(s)
+}
+
+// writeLine writes a content line, folding it at icsLineLimit octets without splitting
+// a UTF-8 sequence. Continuation lines start with a single space.
+func writeLine(b *strings.Builder, line string) {
+	limit := icsLineLimit
+	for len(line) > limit {
+		cut := limit
+		for cut > 0 && !isRuneStart(line[cut]) {
+			cut--
+		}
+		b.WriteString(line[:cut])
+		b.WriteString("\r\n ")
+		line = line[cut:]
+		// The leading space counts towards the continuation line's length
+		limit = icsLineLimit - 1
+	}
+	b.WriteString(line)
+	b.WriteString("\r\n")
+}
+
+func isRuneStart(c byte) bool {
+	return c&0xC0 != 0x80
+}
@@ -0,0 +1,80 @@
+package calendar
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func int64Ptr(i int64) *int64 {
+	return &i
+}
+
+func TestEscapeText(t *testing.T) {
+	assert.Equal(t, `Chores\, snacks\; more\\less\nnext`, escapeText("Chores, snacks; more\\less\nnext"))
+}
+
+func TestWriteLine_FoldsLongLines(t *testing.T) {
+	var b strings.Builder
+	writeLine(&b, "SUMMARY:"+strings.Repeat("a", 200))
+
+	lines := strings.Split(strings.TrimSuffix(b.String(), "\r\n"), "\r\n")
+	require.Greater(t, len(lines), 1)
+	for i, line := range lines {
+		assert.LessOrEqual(t, len(line), icsLineLimit)
+		if i > 0 {
+			assert.True(t, strings.HasPrefix(line, " "))
+		}
+	}
+	unfolded := strings.ReplaceAll(strings.TrimSuffix(b.String(), "\r\n"), "\r\n ", "")
+	assert.Equal(t, "SUMMARY:"+strings.Repeat("a", 200), unfolded)
+}
+
+func TestWriteLine_DoesNotSplitRunes(t *testing.T) {
+	var b strings.Builder
+	writeLine(&b, "SUMMARY:"+strings.Repeat("é", 60))
+
+	for _, line := range strings.Split(strings.TrimSuffix(b.String(), "\r\n"), "\r\n") {
+		assert.True(t, strings.ToValidUTF8(line, "?") == line, "line %q is not valid UTF-8", line)
+	}
+}
+
+func TestWriteICS(t *testing.T) {
+	events := []Event{
+		{
+			Type:        EventTypeAllowance,
+			Date:        "2026-02-06",
+			Title:       "Weekly allowance",
+			ChildName:   "Emma",
+			AmountCents: int64Ptr(1050),
+			SourceID:    7,
+		},
+		{
+			Type:        EventTypeAllowance,
+			Date:        "2026-02-13",
+			Title:       "Weekly allowance",
+			ChildName:   "Emma",
+			AmountCents: int64Ptr(0),
+			SourceID:    7,
+			Status:      "skip",
+		},
+	}
+
+	var buf bytes.Buffer
+	now := time.Date(2026, time.February, 1, 12, 0, 0, 0, time.UTC)
+	require.NoError(t, WriteICS(&buf, "Bank of Dad", events, now))
+	out := buf.String()
+
+	assert.True(t, strings.HasPrefix(out, "BEGIN:VCALENDAR\r\nVERSION:2.0\r\n"))
+	assert.True(t, strings.HasSuffix(out, "END:VCALENDAR\r\n"))
+	assert.Equal(t, 2, strings.Count(out, "BEGIN:VEVENT"))
+	assert.Contains(t, out, "UID:allowance-7-2026-02-06@bankofdad\r\n")
+	assert.Contains(t, out, "DTSTAMP:20260201T120000Z\r\n")
+	assert.Contains(t, out, "DTSTART;VALUE=DATE:20260206\r\nDTEND;VALUE=DATE:20260207\r\n")
+	assert.Contains(t, out, "SUMMARY:Emma: Weekly allowance $10.50\r\n")
+	assert.Contains(t, out, "SUMMARY:Emma: Weekly allowance (skip)\r\n")
+}
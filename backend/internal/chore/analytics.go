@@ -0,0 +1,172 @@
+package chore
+
+import (
+	"log"
+	"net/http"
+	"strconv"
+	"time"
+
+	"bank-of-dad/internal/middleware"
+	"bank-of-dad/repositories"
+)
+
+const (
+	// DefaultAnalyticsDays is how many days chore analytics cover when no from date is given.
+	DefaultAnalyticsDays = 30
+	// MaxAnalyticsDays is the longest date range chore analytics can cover.
+	MaxAnalyticsDays = 366
+)
+
+// ChoreAnalytics is one row of chore analytics: a chore for one child, or for all children when
+// ChildID is zero. CompletionRate is approved instances over those approved or expired, and
+// ApprovalRate is approvals over all review rounds; each is nil when there is nothing to divide by.
+type ChoreAnalytics struct {
+	repositories.ChoreStats
+	CompletionRate *float64 `json:"completion_rate,omitempty"`
+	ApprovalRate   *float64 `json:"approval_rate,omitempty"`
+}
+
+// ratio returns n/d, or nil if d is zero.
+func ratio(n, d int64) *float64 {
+	if d == 0 {
+		return nil
+	}
+	v := float64(n) / float64(d)
+	return &v
+}
+
+// withRates adds completion and approval rates to chore stats.
+func withRates(s repositories.ChoreStats) ChoreAnalytics {
+	return ChoreAnalytics{
+		ChoreStats:     s,
+		CompletionRate: ratio(s.ApprovedCount, s.ApprovedCount+s.ExpiredCount),
+		ApprovalRate:   ratio(s.Approvals, s.Approvals+s.Rejections+s.Redos),
+	}
+}
+
+// summarizeByChore rolls per-child stats up into one row per chore, in the order chores first
+// appear. The average time to complete is weighted by each child's completed count.
+func summarizeByChore(rows []repositories.ChoreStats) []ChoreAnalytics {
+	order := []int64{}
+	totals := make(map[int64]*repositories.ChoreStats)
+	weighted := make(map[int64]float64)
+	timed := make(map[int64]int64)
+	for _, row := range rows {
+		t, ok := totals[row.ChoreID]
+		if !ok {
+			t = &repositories.ChoreStats{ChoreID: row.ChoreID, ChoreName: row.ChoreName, RewardCents: row.RewardCents}
+			totals[row.ChoreID] = t
+			order = append(order, row.ChoreID)
+		}
+		t.InstanceCount += row.InstanceCount
+		t.ApprovedCount += row.ApprovedCount
+		t.ExpiredCount += row.ExpiredCount
+		t.PendingCount += row.PendingCount
+		t.CompletedCount += row.CompletedCount
+		t.Approvals += row.Approvals
+		t.Rejections += row.Rejections
+		t.Redos += row.Redos
+		t.EarnedCents += row.EarnedCents
+		if row.AvgSecondsToComplete != nil {
+			weighted[row.ChoreID] += *row.AvgSecondsToComplete * float64(row.CompletedCount)
+			timed[row.ChoreID] += row.CompletedCount
+		}
+	}
+
+	summary := make([]ChoreAnalytics, len(order))
+	for i, choreID := range order {
+		t := totals[choreID]
+		if timed[choreID] > 0 {
+			avg := weighted[choreID] / float64(timed[choreID])
+			t.AvgSecondsToComplete = &avg
+		}
+		summary[i] = withRates(*t)
+	}
+	return summary
+}
+
+// analyticsRange reads the from and to query parameters (YYYY-MM-DD, to exclusive) as dates in loc.
+// to defaults to tomorrow, so today is included, and from to DefaultAnalyticsDays before to.
+func analyticsRange(r *http.Request, now time.Time, loc *time.Location) (from, to time.Time, errMsg string) {
+	y, m, d := now.In(loc).Date()
+	to = time.Date(y, m, d+1, 0, 0, 0, 0, loc)
+	if v := r.URL.Query().Get("to"); v != "" {
+		parsed, err := time.ParseInLocation(time.DateOnly, v, loc)
+		if err != nil {
+			return from, to, "to must be a date in YYYY-MM-DD format."
+		}
+		to = parsed
+	}
+	from = to.AddDate(0, 0, -DefaultAnalyticsDays)
+	if v := r.URL.Query().Get("from"); v != "" {
+		parsed, err := time.ParseInLocation(time.DateOnly, v, loc)
+		if err != nil {
+			return from, to, "from must be a date in YYYY-MM-DD format."
+		}
+		from = parsed
+	}
+	if !to.After(from) {
+		return from, to, "to must be after from."
+	}
+	if to.After(from.AddDate(0, 0, MaxAnalyticsDays)) {
+		return from, to, "The date range cannot exceed 366 days."
+	}
+	return from, to, ""
+}
+
+// HandleChoreAnalytics handles GET /api/chores/analytics?from=YYYY-MM-DD&to=YYYY-MM-DD
+// Reports each chore's statistics per child and in total for instances created in the range,
+// optionally narrowed with child_id or chore_id.
+func (h *Handler) HandleChoreAnalytics(w http.ResponseWriter, r *http.Request) {
+	if middleware.GetUserType(r) != "parent" {
+		writeJSON(w, http.StatusForbidden, ErrorResponse{Error: "forbidden", Message: "Only parents can view chore analytics."})
+		return
+	}
+
+	familyID := middleware.GetFamilyID(r)
+	loc := time.UTC
+	if h.familyRepo != nil {
+		tz, err := h.familyRepo.GetTimezone(familyID)
+		if err != nil {
+			log.Printf("Error loading timezone for family %d: %v", familyID, err)
+		}
+		loc = loadTimezone(tz)
+	}
+
+	from, to, errMsg := analyticsRange(r, time.Now(), loc)
+	if errMsg != "" {
+		writeJSON(w, http.StatusBadRequest, ErrorResponse{Error: "invalid_range", Message: errMsg})
+		return
+	}
+
+	var childID, choreID *int64
+	for param, dest := range map[string]**int64{"child_id": &childID, "chore_id": &choreID} {
+		v := r.URL.Query().Get(param)
+		if v == "" {
+			continue
+		}
+		id, err := strconv.ParseInt(v, 10, 64)
+		if err != nil {
+			writeJSON(w, http.StatusBadRequest, ErrorResponse{Error: "invalid_request", Message: "Invalid " + param + "."})
+			return
+		}
+		*dest = &id
+	}
+
+	stats, err := h.choreInstanceRepo.ListChoreStats(familyID, from, to, childID, choreID)
+	if err != nil {
+		writeJSON(w, http.StatusInternalServerError, ErrorResponse{Error: "internal_error", Message: "Failed to load chore analytics."})
+		return
+	}
+
+	byChild := make([]ChoreAnalytics, len(stats))
+	for i, s := range stats {
+		byChild[i] = withRates(s)
+	}
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"from":     from.Format(time.DateOnly),
+		"to":       to.Format(time.DateOnly),
+		"by_child": byChild,
+		"by_chore": summarizeByChore(stats),
+	})
+}
@@ -0,0 +1,75 @@
+package chore
+
+import (
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"bank-of-dad/repositories"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func floatPtr(f float64) *float64 { return &f }
+
+func TestWithRates(t *testing.T) {
+	a := withRates(repositories.ChoreStats{ApprovedCount: 3, ExpiredCount: 1, Approvals: 3, Rejections: 1, Redos: 2})
+	require.NotNil(t, a.CompletionRate)
+	assert.InDelta(t, 0.75, *a.CompletionRate, 1e-9)
+	require.NotNil(t, a.ApprovalRate)
+	assert.InDelta(t, 0.5, *a.ApprovalRate, 1e-9)
+
+	empty := withRates(repositories.ChoreStats{InstanceCount: 2, PendingCount: 2})
+	assert.Nil(t, empty.CompletionRate)
+	assert.Nil(t, empty.ApprovalRate)
+}
+
+func TestSummarizeByChore(t *testing.T) {
+	rows := []repositories.ChoreStats{
+		{ChoreID: 2, ChoreName: "Dishes", ChildID: 10, ChildName: "Alice", RewardCents: 100, InstanceCount: 4, ApprovedCount: 3, ExpiredCount: 1, CompletedCount: 3, AvgSecondsToComplete: floatPtr(100), Approvals: 3, EarnedCents: 300},
+		{ChoreID: 2, ChoreName: "Dishes", ChildID: 11, ChildName: "Bob", RewardCents: 100, InstanceCount: 4, ApprovedCount: 1, ExpiredCount: 3, CompletedCount: 1, AvgSecondsToComplete: floatPtr(500), Approvals: 1, Rejections: 1, EarnedCents: 50},
+		{ChoreID: 1, ChoreName: "Trash", ChildID: 10, ChildName: "Alice", RewardCents: 200, InstanceCount: 1, PendingCount: 1},
+	}
+
+	summary := summarizeByChore(rows)
+	require.Len(t, summary, 2)
+
+	dishes := summary[0]
+	assert.Equal(t, int64(2), dishes.ChoreID)
+	assert.Zero(t, dishes.ChildID)
+	assert.Equal(t, int64(8), dishes.InstanceCount)
+	assert.Equal(t, int64(350), dishes.EarnedCents)
+	require.NotNil(t, dishes.AvgSecondsToComplete)
+	assert.InDelta(t, 200, *dishes.AvgSecondsToComplete, 1e-9)
+	assert.InDelta(t, 0.5, *dishes.CompletionRate, 1e-9)
+	assert.InDelta(t, 0.8, *dishes.ApprovalRate, 1e-9)
+
+	trash := summary[1]
+	assert.Equal(t, "Trash", trash.ChoreName)
+	assert.Nil(t, trash.AvgSecondsToComplete)
+	assert.Nil(t, trash.CompletionRate)
+}
+
+func TestAnalyticsRange(t *testing.T) {
+	loc, err := time.LoadLocation("America/New_York")
+	require.NoError(t, err)
+	now := time.Date(2026, time.March, 10, 23, 30, 0, 0, loc)
+
+	from, to, errMsg := analyticsRange(httptest.NewRequest("GET", "/api/chores/analytics", nil), now, loc)
+	require.Empty(t, errMsg)
+	assert.Equal(t, time.Date(2026, time.March, 11, 0, 0, 0, 0, loc), to)
+	assert.Equal(t, time.Date(2026, time.February, 9, 0, 0, 0, 0, loc), from)
+
+	from, to, errMsg = analyticsRange(httptest.NewRequest("GET", "/api/chores/analytics?from=2026-01-01&to=2026-02-01", nil), now, loc)
+	require.Empty(t, errMsg)
+	assert.Equal(t, time.Date(2026, time.January, 1, 0, 0, 0, 0, loc), from)
+	assert.Equal(t, time.Date(2026, time.February, 1, 0, 0, 0, 0, loc), to)
+
+	_, _, errMsg = analyticsRange(httptest.NewRequest("GET", "/api/chores/analytics?from=2026-02-01&to=2026-01-01", nil), now, loc)
+	assert.Equal(t, "to must be after from.", errMsg)
+	_, _, errMsg = analyticsRange(httptest.NewRequest("GET", "/api/chores/analytics?from=2024-01-01&to=2026-01-01", nil), now, loc)
+	assert.Equal(t, "The date range cannot exceed 366 days.", errMsg)
+	_, _, errMsg = analyticsRange(httptest.NewRequest("GET", "/api/chores/analytics?from=yesterday", nil), now, loc)
+	assert.Equal(t, "from must be a date in YYYY-MM-DD format.", errMsg)
+}
@@ -0,0 +1,161 @@
+package chore
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"bank-of-dad/internal/middleware"
+	"bank-of-dad/models"
+)
+
+// MaxBatchSize is the most chore instances that can be reviewed in one batch.
+const MaxBatchSize = 50
+
+// Batch review actions.
+const (
+	BatchActionApprove = "approve"
+	BatchActionReject  = "reject"
+	BatchActionRedo    = "redo"
+)
+
+// BatchReviewItem is one chore instance in a batch review, with its own outcome.
+// Rating, PayoutPercent and BonusCents apply to approvals; Reason to rejections and redos.
+type BatchReviewItem struct {
+	ID            int64  `json:"id"`
+	Action        string `json:"action"`
+	Rating        *int   `json:"rating,omitempty"`
+	PayoutPercent *int   `json:"payout_percent,omitempty"`
+	BonusCents    int    `json:"bonus_cents,omitempty"`
+	Reason        string `json:"reason,omitempty"`
+}
+
+// BatchReviewRequest represents the request body for reviewing several chore instances at once.
+type BatchReviewRequest struct {
+	Items []BatchReviewItem `json:"items"`
+}
+
+// BatchReviewResult reports how one item of a batch review went. Failed items carry the same
+// error and message as the single-instance endpoints.
+type BatchReviewResult struct {
+	ID               int64             `json:"id"`
+	Action           string            `json:"action"`
+	Success          bool              `json:"success"`
+	Instance         *InstanceResponse `json:"instance,omitempty"`
+	NewBalance       *int64            `json:"new_balance,omitempty"`
+	StreakBonusCents int64             `json:"streak_bonus_cents,omitempty"`
+	Error            string            `json:"error,omitempty"`
+	Message          string            `json:"message,omitempty"`
+}
+
+// reviewFailure is why a chore instance could not be reviewed, with the status and body to report.
+type reviewFailure struct {
+	status int
+	resp   ErrorResponse
+}
+
+func failure(status int, code, message string) *reviewFailure {
+	return &reviewFailure{status: status, resp: ErrorResponse{Error: code, Message: message}}
+}
+
+// familyInstance loads a chore instance and the child it belongs to, checking the child is in the family.
+func (h *Handler) familyInstance(familyID, instanceID int64) (*models.ChoreInstance, *models.Child, *reviewFailure) {
+	instance, err := h.choreInstanceRepo.GetByID(instanceID)
+	if err != nil {
+		return nil, nil, failure(http.StatusInternalServerError, "internal_error", "Failed to retrieve instance.")
+	}
+	if instance == nil {
+		return nil, nil, failure(http.StatusNotFound, "not_found", "Chore instance not found.")
+	}
+
+	child, err := h.childRepo.GetByID(instance.ChildID)
+	if err != nil || child == nil {
+		return nil, nil, failure(http.StatusInternalServerError, "internal_error", "Failed to verify family ownership.")
+	}
+	if child.FamilyID != familyID {
+		return nil, nil, failure(http.StatusForbidden, "forbidden", "Instance does not belong to your family.")
+	}
+	return instance, child, nil
+}
+
+// validateBatch checks the shape of a batch review. Returns an error message or empty string.
+func validateBatch(items []BatchReviewItem) string {
+	if len(items) == 0 || len(items) > MaxBatchSize {
+		return "A batch must contain between 1 and 50 chores."
+	}
+	seen := make(map[int64]bool, len(items))
+	for _, item := range items {
+		switch item.Action {
+		case BatchActionApprove, BatchActionReject, BatchActionRedo:
+		default:
+			return "Each action must be one of: approve, reject, redo."
+		}
+		if seen[item.ID] {
+			return "Each chore can only appear once in a batch."
+		}
+		seen[item.ID] = true
+	}
+	return ""
+}
+
+// HandleBatchReview handles POST /api/chore-instances/batch
+// Each instance is approved, rejected or sent back on its own, so one failure doesn't hold up the rest.
+func (h *Handler) HandleBatchReview(w http.ResponseWriter, r *http.Request) {
+	if middleware.GetUserType(r) != "parent" {
+		writeJSON(w, http.StatusForbidden, ErrorResponse{Error: "forbidden", Message: "Only parents can review chores."})
+		return
+	}
+
+	var req BatchReviewRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeJSON(w, http.StatusBadRequest, ErrorResponse{Error: "invalid_request", Message: "Invalid request body."})
+		return
+	}
+	if errMsg := validateBatch(req.Items); errMsg != "" {
+		writeJSON(w, http.StatusBadRequest, ErrorResponse{Error: "invalid_request", Message: errMsg})
+		return
+	}
+
+	familyID := middleware.GetFamilyID(r)
+	parentID := middleware.GetUserID(r)
+
+	results := make([]BatchReviewResult, len(req.Items))
+	succeeded := 0
+	for i, item := range req.Items {
+		result := BatchReviewResult{ID: item.ID, Action: item.Action}
+
+		var fail *reviewFailure
+		switch item.Action {
+		case BatchActionApprove:
+			var approved *approveResult
+			approved, fail = h.approveInstance(r.Context(), familyID, parentID, item.ID, ApproveRequest{
+				Rating:        item.Rating,
+				PayoutPercent: item.PayoutPercent,
+				BonusCents:    item.BonusCents,
+			})
+			if fail == nil {
+				result.Instance = &approved.Instance
+				result.NewBalance = &approved.NewBalance
+				result.StreakBonusCents = approved.StreakBonusCents
+			}
+		case BatchActionReject:
+			result.Instance, fail = h.rejectInstance(familyID, parentID, item.ID, item.Reason)
+		case BatchActionRedo:
+			result.Instance, fail = h.redoInstance(familyID, parentID, item.ID, item.Reason)
+		}
+
+		if fail != nil {
+			result.Error = fail.resp.Error
+			result.Message = fail.resp.Message
+		} else {
+			result.Success = true
+			succeeded++
+		}
+		results[i] = result
+	}
+
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"results":   results,
+		"succeeded": succeeded,
+		"failed":    len(results) - succeeded,
+	})
+}
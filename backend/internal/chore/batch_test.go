@@ -0,0 +1,26 @@
+package chore
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidateBatch(t *testing.T) {
+	valid := []BatchReviewItem{
+		{ID: 1, Action: BatchActionApprove},
+		{ID: 2, Action: BatchActionReject, Reason: "Not done"},
+		{ID: 3, Action: BatchActionRedo, Reason: "Try again"},
+	}
+	assert.Empty(t, validateBatch(valid))
+
+	assert.NotEmpty(t, validateBatch(nil))
+	assert.NotEmpty(t, validateBatch([]BatchReviewItem{{ID: 1, Action: "skip"}}))
+	assert.NotEmpty(t, validateBatch([]BatchReviewItem{{ID: 1, Action: BatchActionApprove}, {ID: 1, Action: BatchActionReject}}))
+
+	tooMany := make([]BatchReviewItem, MaxBatchSize+1)
+	for i := range tooMany {
+		tooMany[i] = BatchReviewItem{ID: int64(i + 1), Action: BatchActionApprove}
+	}
+	assert.NotEmpty(t, validateBatch(tooMany))
+}
@@ -0,0 +1,123 @@
+package chore
+
+import (
+	"time"
+
+	"bank-of-dad/models"
+)
+
+// validateDue checks a chore's due time and late payout settings. Returns an error message or empty string.
+func validateDue(c *models.Chore) string {
+	if c.DueTime != nil {
+		if _, _, ok := parseDueTime(*c.DueTime); !ok {
+			return "due_time must be a time of day as HH:MM."
+		}
+	}
+	if c.DueWeekday != nil && (*c.DueWeekday < 0 || *c.DueWeekday > 6) {
+		return "due_weekday must be between 0 and 6."
+	}
+	if c.LatePayoutPercent != nil && (*c.LatePayoutPercent < 0 || *c.LatePayoutPercent > 100) {
+		return "late_payout_percent must be between 0 and 100."
+	}
+
+	hasDue := c.DueTime != nil || c.DueWeekday != nil
+	if hasDue && c.Recurrence == models.ChoreRecurrenceOneTime {
+		return "Due times are only available for recurring chores."
+	}
+	if c.DueWeekday != nil && !periodsSpanWeek(c) {
+		return "due_weekday is only available for chores that repeat weekly or less often."
+	}
+	if c.LatePayoutPercent != nil && !hasDue {
+		return "A late payout needs a due_time or due_weekday."
+	}
+	return ""
+}
+
+// periodsSpanWeek reports whether every period of a recurring chore is at least a week long, so that
+// each weekday falls within it. Custom rules are checked over their next year of occurrences.
+func periodsSpanWeek(c *models.Chore) bool {
+	switch c.Recurrence {
+	case models.ChoreRecurrenceWeekly, models.ChoreRecurrenceMonthly:
+		return true
+	case models.ChoreRecurrenceCustom:
+	default:
+		return false
+	}
+
+	rule, err := RecurrenceRule(c)
+	if err != nil {
+		return false
+	}
+	start := time.Now().UTC()
+	prev := rule.Next(start.AddDate(0, 0, -1), time.UTC)
+	for !prev.IsZero() && prev.Before(start.AddDate(1, 0, 0)) {
+		next := rule.Next(prev, time.UTC)
+		if next.IsZero() {
+			break
+		}
+		if next.Before(prev.AddDate(0, 0, 7)) {
+			return false
+		}
+		prev = next
+	}
+	return true
+}
+
+// parseDueTime parses an "HH:MM" time of day.
+func parseDueTime(s string) (hour, minute int, ok bool) {
+	if len(s) != len("15:04") {
+		return 0, 0, false
+	}
+	t, err := time.Parse("15:04", s)
+	if err != nil {
+		return 0, 0, false
+	}
+	return t.Hour(), t.Minute(), true
+}
+
+// DueAt returns when a chore is due in the period from periodStart to periodEnd, in loc.
+// The due day is the first day of the period falling on DueWeekday, or the period's last day
+// (validateDue only accepts DueWeekday when every period contains each weekday);
+// the chore is due at DueTime on that day, or by the end of it. Returns nil if the chore has no due settings.
+func DueAt(c *models.Chore, periodStart, periodEnd time.Time, loc *time.Location) *time.Time {
+	if c.DueTime == nil && c.DueWeekday == nil {
+		return nil
+	}
+
+	day := periodEnd.In(loc)
+	if c.DueWeekday != nil {
+		for d := periodStart.In(loc); !d.After(periodEnd); d = d.AddDate(0, 0, 1) {
+			if d.Weekday() == time.Weekday(*c.DueWeekday) {
+				day = d
+				break
+			}
+		}
+	}
+
+	hour, minute, second := 23, 59, 59
+	if c.DueTime != nil {
+		if h, m, ok := parseDueTime(*c.DueTime); ok {
+			hour, minute, second = h, m, 0
+		}
+	}
+	due := time.Date(day.Year(), day.Month(), day.Day(), hour, minute, second, 0, loc)
+	return &due
+}
+
+// isOverdue reports whether an instance the child can still complete is past its due time.
+func isOverdue(instance *models.ChoreInstance, now time.Time) bool {
+	if instance.DueAt == nil || !now.After(*instance.DueAt) {
+		return false
+	}
+	return instance.Status == models.ChoreInstanceStatusAvailable || instance.Status == models.ChoreInstanceStatusClaimed
+}
+
+// latePayout applies a chore's late payout to the approval of a late instance, unless the parent
+// chose a payout percentage themselves.
+func latePayout(req ApproveRequest, instance *models.ChoreInstance, c *models.Chore) ApproveRequest {
+	if instance.CompletedLate && c.LatePayoutPercent != nil && req.PayoutPercent == nil {
+		percent := *c.LatePayoutPercent
+		req.PayoutPercent = &percent
+	}
+	return req
+}
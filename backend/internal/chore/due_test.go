@@ -0,0 +1,113 @@
+package chore
+
+import (
+	"testing"
+	"time"
+
+	"bank-of-dad/models"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func strPtr(s string) *string { return &s }
+
+func TestDueAt(t *testing.T) {
+	loc, err := time.LoadLocation("America/New_York")
+	require.NoError(t, err)
+
+	// Week of Sunday Mar 1 - Saturday Mar 7 2026
+	start := time.Date(2026, time.March, 1, 0, 0, 0, 0, loc)
+	end := time.Date(2026, time.March, 7, 23, 59, 59, 0, loc)
+
+	tuesday := int(time.Tuesday)
+	due := DueAt(&models.Chore{DueTime: strPtr("19:00"), DueWeekday: &tuesday}, start, end, loc)
+	require.NotNil(t, due)
+	assert.Equal(t, time.Date(2026, time.March, 3, 19, 0, 0, 0, loc), *due)
+
+	// Without a weekday the chore is due on the period's last day
+	due = DueAt(&models.Chore{DueTime: strPtr("07:30")}, start, end, loc)
+	require.NotNil(t, due)
+	assert.Equal(t, time.Date(2026, time.March, 7, 7, 30, 0, 0, loc), *due)
+
+	// Without a time the chore is due by the end of the day
+	due = DueAt(&models.Chore{DueWeekday: &tuesday}, start, end, loc)
+	require.NotNil(t, due)
+	assert.Equal(t, time.Date(2026, time.March, 3, 23, 59, 59, 0, loc), *due)
+
+	// A weekday outside the period falls back to the last day
+	day := time.Date(2026, time.March, 2, 0, 0, 0, 0, loc)
+	due = DueAt(&models.Chore{DueTime: strPtr("18:00"), DueWeekday: &tuesday}, day, day.Add(24*time.Hour-time.Second), loc)
+	require.NotNil(t, due)
+	assert.Equal(t, time.Date(2026, time.March, 2, 18, 0, 0, 0, loc), *due)
+
+	// Across the spring DST change the time of day holds
+	due = DueAt(&models.Chore{DueTime: strPtr("19:00")}, time.Date(2026, time.March, 8, 0, 0, 0, 0, loc), time.Date(2026, time.March, 8, 23, 59, 59, 0, loc), loc)
+	require.NotNil(t, due)
+	assert.Equal(t, 19, due.In(loc).Hour())
+
+	assert.Nil(t, DueAt(&models.Chore{}, start, end, loc))
+}
+
+func TestValidateDue(t *testing.T) {
+	half := 50
+	badPercent := 150
+	badDay := 7
+	weekly := models.ChoreRecurrenceWeekly
+
+	assert.Empty(t, validateDue(&models.Chore{Recurrence: weekly}))
+	assert.Empty(t, validateDue(&models.Chore{Recurrence: weekly, DueTime: strPtr("19:00"), LatePayoutPercent: &half}))
+
+	assert.NotEmpty(t, validateDue(&models.Chore{Recurrence: weekly, DueTime: strPtr("7pm")}))
+	assert.NotEmpty(t, validateDue(&models.Chore{Recurrence: weekly, DueTime: strPtr("24:00")}))
+	assert.NotEmpty(t, validateDue(&models.Chore{Recurrence: weekly, DueTime: strPtr("9:00")}))
+	assert.NotEmpty(t, validateDue(&models.Chore{Recurrence: weekly, DueWeekday: &badDay}))
+	assert.NotEmpty(t, validateDue(&models.Chore{Recurrence: weekly, DueTime: strPtr("19:00"), LatePayoutPercent: &badPercent}))
+	assert.NotEmpty(t, validateDue(&models.Chore{Recurrence: weekly, LatePayoutPercent: &half}))
+	assert.NotEmpty(t, validateDue(&models.Chore{Recurrence: models.ChoreRecurrenceOneTime, DueTime: strPtr("19:00")}))
+}
+
+func TestValidateDue_WeekdayNeedsWeekLongPeriods(t *testing.T) {
+	tuesday := int(time.Tuesday)
+	custom := func(rrule string) *models.Chore {
+		return &models.Chore{Recurrence: models.ChoreRecurrenceCustom, RRule: strPtr(rrule), DueWeekday: &tuesday}
+	}
+
+	assert.Empty(t, validateDue(&models.Chore{Recurrence: models.ChoreRecurrenceWeekly, DueWeekday: &tuesday}))
+	assert.Empty(t, validateDue(&models.Chore{Recurrence: models.ChoreRecurrenceMonthly, DueWeekday: &tuesday}))
+	assert.Empty(t, validateDue(custom("FREQ=WEEKLY;INTERVAL=2;BYDAY=SA")))
+	assert.Empty(t, validateDue(custom("FREQ=MONTHLY;BYMONTHDAY=15")))
+
+	assert.NotEmpty(t, validateDue(&models.Chore{Recurrence: models.ChoreRecurrenceDaily, DueWeekday: &tuesday}))
+	assert.NotEmpty(t, validateDue(custom("FREQ=DAILY;INTERVAL=3")))
+	assert.NotEmpty(t, validateDue(custom("FREQ=WEEKLY;BYDAY=MO,TH")))
+
+	// A due time alone works for any recurring chore
+	assert.Empty(t, validateDue(&models.Chore{Recurrence: models.ChoreRecurrenceDaily, DueTime: strPtr("19:00")}))
+}
+
+func TestIsOverdue(t *testing.T) {
+	due := time.Date(2026, time.March, 3, 19, 0, 0, 0, time.UTC)
+	before, after := due.Add(-time.Minute), due.Add(time.Minute)
+
+	instance := &models.ChoreInstance{Status: open, DueAt: &due}
+	assert.False(t, isOverdue(instance, before))
+	assert.True(t, isOverdue(instance, after))
+
+	instance.Status = pending
+	assert.False(t, isOverdue(instance, after))
+	assert.False(t, isOverdue(&models.ChoreInstance{Status: open}, after))
+}
+
+func TestLatePayout(t *testing.T) {
+	half := 50
+	chore := &models.Chore{LatePayoutPercent: &half}
+
+	req := latePayout(ApproveRequest{}, &models.ChoreInstance{CompletedLate: true}, chore)
+	require.NotNil(t, req.PayoutPercent)
+	assert.Equal(t, 50, *req.PayoutPercent)
+
+	// On time, or the parent's own choice, is left alone
+	assert.Nil(t, latePayout(ApproveRequest{}, &models.ChoreInstance{}, chore).PayoutPercent)
+	assert.Equal(t, 80, *latePayout(ApproveRequest{PayoutPercent: intPtr(80)}, &models.ChoreInstance{CompletedLate: true}, chore).PayoutPercent)
+}
@@ -0,0 +1,233 @@
+package chore
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+	"time"
+
+	"bank-of-dad/internal/middleware"
+	"bank-of-dad/models"
+	"bank-of-dad/repositories"
+)
+
+// ExportVersion is the version of the chore export format.
+const ExportVersion = 1
+
+// MaxImportChores is the most chores a single import can contain.
+const MaxImportChores = 200
+
+// ChoreExport is a family's chores in a form that can be imported into another family.
+type ChoreExport struct {
+	Version    int             `json:"version"`
+	ExportedAt time.Time       `json:"exported_at"`
+	Chores     []ExportedChore `json:"chores"`
+}
+
+// ExportedChore is one chore's settings in an export. Children are assigned by first name, in
+// rotation order, since child IDs mean nothing to another family.
+type ExportedChore struct {
+	Name              string   `json:"name"`
+	Description       *string  `json:"description,omitempty"`
+	RewardCents       int      `json:"reward_cents"`
+	Recurrence        string   `json:"recurrence"`
+	DayOfWeek         *int     `json:"day_of_week,omitempty"`
+	DayOfMonth        *int     `json:"day_of_month,omitempty"`
+	RRule             *string  `json:"rrule,omitempty"`
+	IsActive          bool     `json:"is_active"`
+	IsBounty          bool     `json:"is_bounty,omitempty"`
+	ClaimHours        *int     `json:"claim_hours,omitempty"`
+	IsRotation        bool     `json:"is_rotation,omitempty"`
+	PenaltyCents      int      `json:"penalty_cents,omitempty"`
+	MaxRedos          *int     `json:"max_redos,omitempty"`
+	DueTime           *string  `json:"due_time,omitempty"`
+	DueWeekday        *int     `json:"due_weekday,omitempty"`
+	LatePayoutPercent *int     `json:"late_payout_percent,omitempty"`
+	Children          []string `json:"children"`
+}
+
+// SkippedChore reports an imported chore that was not created.
+type SkippedChore struct {
+	Name   string `json:"name"`
+	Reason string `json:"reason"`
+}
+
+// HandleExportChores handles GET /api/chores/export
+func (h *Handler) HandleExportChores(w http.ResponseWriter, r *http.Request) {
+	if middleware.GetUserType(r) != "parent" {
+		writeJSON(w, http.StatusForbidden, ErrorResponse{Error: "forbidden", Message: "Only parents can export chores."})
+		return
+	}
+
+	chores, err := h.choreRepo.ListByFamily(middleware.GetFamilyID(r))
+	if err != nil {
+		writeJSON(w, http.StatusInternalServerError, ErrorResponse{Error: "internal_error", Message: "Failed to list chores."})
+		return
+	}
+
+	// Chores are listed newest first; export them oldest first so an import recreates them in order.
+	export := ChoreExport{
+		Version:    ExportVersion,
+		ExportedAt: time.Now().UTC(),
+		Chores:     make([]ExportedChore, len(chores)),
+	}
+	for i, cwa := range chores {
+		names := make([]string, len(cwa.Assignments))
+		for j, a := range cwa.Assignments {
+			names[j] = a.ChildName
+		}
+		export.Chores[len(chores)-1-i] = ExportedChore{
+			Name:              cwa.Name,
+			Description:       cwa.Description,
+			RewardCents:       cwa.RewardCents,
+			Recurrence:        string(cwa.Recurrence),
+			DayOfWeek:         cwa.DayOfWeek,
+			DayOfMonth:        cwa.DayOfMonth,
+			RRule:             cwa.RRule,
+			IsActive:          cwa.IsActive,
+			IsBounty:          cwa.IsBounty,
+			ClaimHours:        cwa.ClaimHours,
+			IsRotation:        cwa.IsRotation,
+			PenaltyCents:      cwa.PenaltyCents,
+			MaxRedos:          cwa.MaxRedos,
+			DueTime:           cwa.DueTime,
+			DueWeekday:        cwa.DueWeekday,
+			LatePayoutPercent: cwa.LatePayoutPercent,
+			Children:          names,
+		}
+	}
+
+	w.Header().Set("Content-Disposition", `attachment; filename="chores.json"`)
+	writeJSON(w, http.StatusOK, export)
+}
+
+// HandleImportChores handles POST /api/chores/import
+// Children are matched to the importing family's children by first name, ignoring case. Names with
+// no match are reported, and chores left with no children are skipped. A name shared by more than
+// one child can't be matched, so an import that uses one is rejected. The chores are checked as if
+// created one by one; if any is invalid, nothing is imported.
+func (h *Handler) HandleImportChores(w http.ResponseWriter, r *http.Request) {
+	if middleware.GetUserType(r) != "parent" {
+		writeJSON(w, http.StatusForbidden, ErrorResponse{Error: "forbidden", Message: "Only parents can import chores."})
+		return
+	}
+
+	var req ChoreExport
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeJSON(w, http.StatusBadRequest, ErrorResponse{Error: "invalid_request", Message: "Invalid request body."})
+		return
+	}
+	if req.Version != ExportVersion {
+		writeJSON(w, http.StatusBadRequest, ErrorResponse{Error: "invalid_request", Message: "Unsupported export version."})
+		return
+	}
+	if len(req.Chores) == 0 || len(req.Chores) > MaxImportChores {
+		writeJSON(w, http.StatusBadRequest, ErrorResponse{Error: "invalid_request", Message: "An import must contain between 1 and 200 chores."})
+		return
+	}
+
+	familyID := middleware.GetFamilyID(r)
+	parentID := middleware.GetUserID(r)
+
+	children, err := h.familyChildren(familyID)
+	if err != nil {
+		writeJSON(w, http.StatusInternalServerError, ErrorResponse{Error: "internal_error", Message: "Failed to list children."})
+		return
+	}
+	byName := make(map[string]int64, len(children))
+	ambiguous := make(map[string]bool)
+	for _, c := range children {
+		key := strings.ToLower(strings.TrimSpace(c.FirstName))
+		if _, ok := byName[key]; ok {
+			ambiguous[key] = true
+		}
+		byName[key] = c.ID
+	}
+
+	newChores := []repositories.NewChore{}
+	skipped := []SkippedChore{}
+	unmatched := []string{}
+	seenUnmatched := make(map[string]bool)
+	for _, ec := range req.Chores {
+		chore := importedChore(ec, familyID, parentID)
+		if code, errMsg := validateChore(chore); code != "" {
+			writeJSON(w, http.StatusBadRequest, ErrorResponse{Error: code, Message: "Chore \"" + ec.Name + "\": " + errMsg})
+			return
+		}
+
+		childIDs := []int64{}
+		assigned := make(map[int64]bool)
+		for _, name := range ec.Children {
+			key := strings.ToLower(strings.TrimSpace(name))
+			if ambiguous[key] {
+				writeJSON(w, http.StatusBadRequest, ErrorResponse{
+					Error:   "invalid_children",
+					Message: "Chore \"" + ec.Name + "\": more than one child is named \"" + strings.TrimSpace(name) + "\".",
+				})
+				return
+			}
+			childID, ok := byName[key]
+			if !ok {
+				if !seenUnmatched[key] {
+					seenUnmatched[key] = true
+					unmatched = append(unmatched, strings.TrimSpace(name))
+				}
+				continue
+			}
+			if !assigned[childID] {
+				assigned[childID] = true
+				childIDs = append(childIDs, childID)
+			}
+		}
+		if len(childIDs) == 0 {
+			skipped = append(skipped, SkippedChore{Name: chore.Name, Reason: "No matching children in this family."})
+			continue
+		}
+		newChores = append(newChores, repositories.NewChore{Chore: chore, ChildIDs: childIDs})
+	}
+
+	if err := h.choreRepo.CreateMany(newChores); err != nil {
+		writeJSON(w, http.StatusInternalServerError, ErrorResponse{Error: "internal_error", Message: "Failed to import chores."})
+		return
+	}
+
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"chores":             newChoreResponses(newChores, children),
+		"skipped":            skipped,
+		"unmatched_children": unmatched,
+	})
+}
+
+// importedChore builds a chore for the family from an exported one, dropping settings that don't
+// apply to its recurrence or mode, as creating a chore does.
+func importedChore(ec ExportedChore, familyID, parentID int64) *models.Chore {
+	chore := &models.Chore{
+		FamilyID:          familyID,
+		CreatedByParentID: parentID,
+		Name:              strings.TrimSpace(ec.Name),
+		RewardCents:       ec.RewardCents,
+		Recurrence:        models.ChoreRecurrence(ec.Recurrence),
+		DayOfWeek:         ec.DayOfWeek,
+		DayOfMonth:        ec.DayOfMonth,
+		IsActive:          ec.IsActive,
+		IsBounty:          ec.IsBounty,
+		IsRotation:        ec.IsRotation,
+		PenaltyCents:      ec.PenaltyCents,
+		MaxRedos:          ec.MaxRedos,
+		DueTime:           ec.DueTime,
+		DueWeekday:        ec.DueWeekday,
+		LatePayoutPercent: ec.LatePayoutPercent,
+	}
+	if ec.Description != nil {
+		if desc := strings.TrimSpace(*ec.Description); desc != "" {
+			chore.Description = &desc
+		}
+	}
+	if chore.Recurrence == models.ChoreRecurrenceCustom {
+		chore.RRule = ec.RRule
+	}
+	if chore.IsBounty {
+		chore.ClaimHours = ec.ClaimHours
+	}
+	return chore
+}
@@ -1,14 +1,19 @@
 package chore
 
 import (
+	"context"
 	"encoding/json"
 	"errors"
+	"io"
+	"log"
 	"net/http"
 	"strconv"
 	"strings"
 	"time"
 
 	"bank-of-dad/internal/middleware"
+	"bank-of-dad/internal/recurrence"
+	"bank-of-dad/internal/storage"
 	"bank-of-dad/models"
 	"bank-of-dad/repositories"
 )
@@ -17,6 +22,7 @@ const (
 	MaxNameLength        = 100
 	MaxDescriptionLength = 500
 	MaxRewardCents       = 99999999
+	MaxClaimHours        = 168
 )
 
 // Handler handles chore-related HTTP requests.
@@ -25,6 +31,9 @@ type Handler struct {
 	choreInstanceRepo *repositories.ChoreInstanceRepo
 	txRepo            *repositories.TransactionRepo
 	childRepo         *repositories.ChildRepo
+	streakRepo        *repositories.ChoreStreakRepo
+	photoStore        storage.Store
+	familyRepo        *repositories.FamilyRepo
 }
 
 // NewHandler creates a new chore handler.
@@ -37,32 +46,60 @@ func NewHandler(choreRepo *repositories.ChoreRepo, choreInstanceRepo *repositori
 	}
 }
 
+// SetStreakRepo sets the streak repo used to configure and pay streak bonuses.
+func (h *Handler) SetStreakRepo(repo *repositories.ChoreStreakRepo) {
+	h.streakRepo = repo
+}
+
+// SetFamilyRepo sets the family repo used to read the family's timezone for date ranges.
+func (h *Handler) SetFamilyRepo(repo *repositories.FamilyRepo) {
+	h.familyRepo = repo
+}
+
 // CreateChoreRequest represents the request body for creating a chore.
 type CreateChoreRequest struct {
-	Name        string  `json:"name"`
-	Description string  `json:"description,omitempty"`
-	RewardCents int     `json:"reward_cents"`
-	Recurrence  string  `json:"recurrence"`
-	DayOfWeek   *int    `json:"day_of_week,omitempty"`
-	DayOfMonth  *int    `json:"day_of_month,omitempty"`
-	ChildIDs    []int64 `json:"child_ids"`
+	Name              string  `json:"name"`
+	Description       string  `json:"description,omitempty"`
+	RewardCents       int     `json:"reward_cents"`
+	Recurrence        string  `json:"recurrence"`
+	DayOfWeek         *int    `json:"day_of_week,omitempty"`
+	DayOfMonth        *int    `json:"day_of_month,omitempty"`
+	RRule             *string `json:"rrule,omitempty"`
+	IsBounty          bool    `json:"is_bounty,omitempty"`
+	ClaimHours        *int    `json:"claim_hours,omitempty"`
+	IsRotation        bool    `json:"is_rotation,omitempty"`
+	PenaltyCents      int     `json:"penalty_cents,omitempty"`
+	MaxRedos          *int    `json:"max_redos,omitempty"`
+	DueTime           *string `json:"due_time,omitempty"`
+	DueWeekday        *int    `json:"due_weekday,omitempty"`
+	LatePayoutPercent *int    `json:"late_payout_percent,omitempty"`
+	ChildIDs          []int64 `json:"child_ids"`
 }
 
 // ChoreResponse represents a chore in API responses.
 type ChoreResponse struct {
-	ID          int64                `json:"id"`
-	FamilyID    int64                `json:"family_id"`
-	Name        string               `json:"name"`
-	Description *string              `json:"description,omitempty"`
-	RewardCents int                  `json:"reward_cents"`
-	Recurrence  string               `json:"recurrence"`
-	DayOfWeek   *int                 `json:"day_of_week,omitempty"`
-	DayOfMonth  *int                 `json:"day_of_month,omitempty"`
-	IsActive    bool                 `json:"is_active"`
-	CreatedAt   time.Time            `json:"created_at"`
-	UpdatedAt   time.Time            `json:"updated_at"`
-	Assignments []AssignmentResponse `json:"assignments"`
-	PendingCount int                 `json:"pending_count,omitempty"`
+	ID                int64                `json:"id"`
+	FamilyID          int64                `json:"family_id"`
+	Name              string               `json:"name"`
+	Description       *string              `json:"description,omitempty"`
+	RewardCents       int                  `json:"reward_cents"`
+	Recurrence        string               `json:"recurrence"`
+	DayOfWeek         *int                 `json:"day_of_week,omitempty"`
+	DayOfMonth        *int                 `json:"day_of_month,omitempty"`
+	RRule             *string              `json:"rrule,omitempty"`
+	IsActive          bool                 `json:"is_active"`
+	IsBounty          bool                 `json:"is_bounty"`
+	ClaimHours        *int                 `json:"claim_hours,omitempty"`
+	IsRotation        bool                 `json:"is_rotation"`
+	PenaltyCents      int                  `json:"penalty_cents"`
+	MaxRedos          *int                 `json:"max_redos,omitempty"`
+	DueTime           *string              `json:"due_time,omitempty"`
+	DueWeekday        *int                 `json:"due_weekday,omitempty"`
+	LatePayoutPercent *int                 `json:"late_payout_percent,omitempty"`
+	CreatedAt         time.Time            `json:"created_at"`
+	UpdatedAt         time.Time            `json:"updated_at"`
+	Assignments       []AssignmentResponse `json:"assignments"`
+	PendingCount      int                  `json:"pending_count,omitempty"`
 }
 
 // AssignmentResponse represents a chore assignment in API responses.
@@ -83,6 +120,36 @@ func writeJSON(w http.ResponseWriter, status int, v interface{}) {
 	json.NewEncoder(w).Encode(v)
 }
 
+// validateChoreRRule checks the rrule of a custom chore. Returns an error message or empty string.
+func validateChoreRRule(rrule *string) string {
+	if rrule == nil {
+		return "Custom chores require an rrule."
+	}
+	if _, err := recurrence.Parse(*rrule); err != nil {
+		return "Invalid rrule: " + err.Error() + "."
+	}
+	return ""
+}
+
+// validateClaimHours checks the claim duration of a bounty chore. Returns an error message or empty string.
+func validateClaimHours(claimHours *int) string {
+	if claimHours != nil && (*claimHours < 1 || *claimHours > MaxClaimHours) {
+		return "claim_hours must be between 1 and 168."
+	}
+	return ""
+}
+
+// validatePenalty checks a chore's missed-chore penalty. Returns an error message or empty string.
+func validatePenalty(c *models.Chore) string {
+	if c.PenaltyCents < 0 || c.PenaltyCents > MaxRewardCents {
+		return "Penalty must be between 0 and $999,999.99."
+	}
+	if c.PenaltyCents > 0 && c.IsBounty {
+		return "Bounty chores cannot have a penalty."
+	}
+	return ""
+}
+
 // HandleCreateChore handles POST /api/chores
 func (h *Handler) HandleCreateChore(w http.ResponseWriter, r *http.Request) {
 	// Auth: parent only
@@ -104,72 +171,40 @@ func (h *Handler) HandleCreateChore(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	// Validate name
-	name := strings.TrimSpace(req.Name)
-	if len(name) == 0 || len(name) > MaxNameLength {
-		writeJSON(w, http.StatusBadRequest, ErrorResponse{
-			Error:   "invalid_name",
-			Message: "Name must be between 1 and 100 characters.",
-		})
-		return
+	// Build the chore, dropping settings that don't apply to its recurrence or mode
+	chore := &models.Chore{
+		Name:              strings.TrimSpace(req.Name),
+		RewardCents:       req.RewardCents,
+		Recurrence:        models.ChoreRecurrence(req.Recurrence),
+		DayOfWeek:         req.DayOfWeek,
+		DayOfMonth:        req.DayOfMonth,
+		IsActive:          true,
+		IsBounty:          req.IsBounty,
+		IsRotation:        req.IsRotation,
+		PenaltyCents:      req.PenaltyCents,
+		MaxRedos:          req.MaxRedos,
+		DueTime:           req.DueTime,
+		DueWeekday:        req.DueWeekday,
+		LatePayoutPercent: req.LatePayoutPercent,
 	}
-
-	// Validate description
-	desc := strings.TrimSpace(req.Description)
-	if len(desc) > MaxDescriptionLength {
-		writeJSON(w, http.StatusBadRequest, ErrorResponse{
-			Error:   "invalid_request",
-			Message: "Description must be 500 characters or less.",
-		})
-		return
+	if desc := strings.TrimSpace(req.Description); desc != "" {
+		chore.Description = &desc
 	}
-
-	// Validate reward_cents
-	if req.RewardCents < 0 || req.RewardCents > MaxRewardCents {
-		writeJSON(w, http.StatusBadRequest, ErrorResponse{
-			Error:   "invalid_amount",
-			Message: "Reward must be between 0 and $999,999.99.",
-		})
-		return
+	if chore.Recurrence == models.ChoreRecurrenceCustom {
+		chore.RRule = req.RRule
 	}
-
-	// Validate recurrence
-	validRecurrences := map[string]bool{
-		"one_time": true,
-		"daily":    true,
-		"weekly":   true,
-		"monthly":  true,
+	if chore.IsBounty {
+		chore.ClaimHours = req.ClaimHours
 	}
-	if !validRecurrences[req.Recurrence] {
+
+	if code, errMsg := validateChore(chore); code != "" {
 		writeJSON(w, http.StatusBadRequest, ErrorResponse{
-			Error:   "invalid_recurrence",
-			Message: "Recurrence must be one of: one_time, daily, weekly, monthly.",
+			Error:   code,
+			Message: errMsg,
 		})
 		return
 	}
 
-	// Validate day_of_week for weekly
-	if req.Recurrence == "weekly" {
-		if req.DayOfWeek == nil || *req.DayOfWeek < 0 || *req.DayOfWeek > 6 {
-			writeJSON(w, http.StatusBadRequest, ErrorResponse{
-				Error:   "invalid_recurrence",
-				Message: "Weekly chores require day_of_week (0-6).",
-			})
-			return
-		}
-	}
-
-	// Validate day_of_month for monthly
-	if req.Recurrence == "monthly" {
-		if req.DayOfMonth == nil || *req.DayOfMonth < 1 || *req.DayOfMonth > 31 {
-			writeJSON(w, http.StatusBadRequest, ErrorResponse{
-				Error:   "invalid_recurrence",
-				Message: "Monthly chores require day_of_month (1-31).",
-			})
-			return
-		}
-	}
-
 	// Validate child_ids
 	if len(req.ChildIDs) == 0 {
 		writeJSON(w, http.StatusBadRequest, ErrorResponse{
@@ -210,25 +245,9 @@ func (h *Handler) HandleCreateChore(w http.ResponseWriter, r *http.Request) {
 		childNames[childID] = child.FirstName
 	}
 
-	// Build description pointer
-	var descPtr *string
-	if desc != "" {
-		descPtr = &desc
-	}
-
 	// Create chore
-	chore := &models.Chore{
-		FamilyID:          familyID,
-		CreatedByParentID: parentID,
-		Name:              name,
-		Description:       descPtr,
-		RewardCents:       req.RewardCents,
-		Recurrence:        models.ChoreRecurrence(req.Recurrence),
-		DayOfWeek:         req.DayOfWeek,
-		DayOfMonth:        req.DayOfMonth,
-		IsActive:          true,
-	}
-
+	chore.FamilyID = familyID
+	chore.CreatedByParentID = parentID
 	createdChore, err := h.choreRepo.Create(chore)
 	if err != nil {
 		writeJSON(w, http.StatusInternalServerError, ErrorResponse{
@@ -238,12 +257,13 @@ func (h *Handler) HandleCreateChore(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	// Create assignments
+	// Create assignments; their order is the rotation order
 	assignments := make([]AssignmentResponse, 0, len(req.ChildIDs))
-	for _, childID := range req.ChildIDs {
+	for i, childID := range req.ChildIDs {
 		assignment := &models.ChoreAssignment{
-			ChoreID: createdChore.ID,
-			ChildID: childID,
+			ChoreID:  createdChore.ID,
+			ChildID:  childID,
+			Position: i,
 		}
 		if _, err := h.choreRepo.CreateAssignment(assignment); err != nil {
 			writeJSON(w, http.StatusInternalServerError, ErrorResponse{
@@ -279,18 +299,27 @@ func (h *Handler) HandleCreateChore(w http.ResponseWriter, r *http.Request) {
 
 	// Build response
 	resp := ChoreResponse{
-		ID:           createdChore.ID,
-		FamilyID:     createdChore.FamilyID,
-		Name:         createdChore.Name,
-		Description:  createdChore.Description,
-		RewardCents:  createdChore.RewardCents,
-		Recurrence:   string(createdChore.Recurrence),
-		DayOfWeek:    createdChore.DayOfWeek,
-		DayOfMonth:   createdChore.DayOfMonth,
-		IsActive:     createdChore.IsActive,
-		CreatedAt:    createdChore.CreatedAt,
-		UpdatedAt:    createdChore.UpdatedAt,
-		Assignments:  assignments,
+		ID:                createdChore.ID,
+		FamilyID:          createdChore.FamilyID,
+		Name:              createdChore.Name,
+		Description:       createdChore.Description,
+		RewardCents:       createdChore.RewardCents,
+		Recurrence:        string(createdChore.Recurrence),
+		DayOfWeek:         createdChore.DayOfWeek,
+		DayOfMonth:        createdChore.DayOfMonth,
+		RRule:             createdChore.RRule,
+		IsActive:          createdChore.IsActive,
+		IsBounty:          createdChore.IsBounty,
+		ClaimHours:        createdChore.ClaimHours,
+		IsRotation:        createdChore.IsRotation,
+		PenaltyCents:      createdChore.PenaltyCents,
+		MaxRedos:          createdChore.MaxRedos,
+		DueTime:           createdChore.DueTime,
+		DueWeekday:        createdChore.DueWeekday,
+		LatePayoutPercent: createdChore.LatePayoutPercent,
+		CreatedAt:         createdChore.CreatedAt,
+		UpdatedAt:         createdChore.UpdatedAt,
+		Assignments:       assignments,
 	}
 
 	writeJSON(w, http.StatusCreated, map[string]interface{}{"chore": resp})
@@ -330,19 +359,28 @@ func (h *Handler) HandleListChores(w http.ResponseWriter, r *http.Request) {
 		}
 
 		chores[i] = ChoreResponse{
-			ID:           cwa.ID,
-			FamilyID:     cwa.FamilyID,
-			Name:         cwa.Name,
-			Description:  cwa.Description,
-			RewardCents:  cwa.RewardCents,
-			Recurrence:   string(cwa.Recurrence),
-			DayOfWeek:    cwa.DayOfWeek,
-			DayOfMonth:   cwa.DayOfMonth,
-			IsActive:     cwa.IsActive,
-			CreatedAt:    cwa.CreatedAt,
-			UpdatedAt:    cwa.UpdatedAt,
-			Assignments:  assignments,
-			PendingCount: cwa.PendingCount,
+			ID:                cwa.ID,
+			FamilyID:          cwa.FamilyID,
+			Name:              cwa.Name,
+			Description:       cwa.Description,
+			RewardCents:       cwa.RewardCents,
+			Recurrence:        string(cwa.Recurrence),
+			DayOfWeek:         cwa.DayOfWeek,
+			DayOfMonth:        cwa.DayOfMonth,
+			RRule:             cwa.RRule,
+			IsActive:          cwa.IsActive,
+			IsBounty:          cwa.IsBounty,
+			ClaimHours:        cwa.ClaimHours,
+			IsRotation:        cwa.IsRotation,
+			PenaltyCents:      cwa.PenaltyCents,
+			MaxRedos:          cwa.MaxRedos,
+			DueTime:           cwa.DueTime,
+			DueWeekday:        cwa.DueWeekday,
+			LatePayoutPercent: cwa.LatePayoutPercent,
+			CreatedAt:         cwa.CreatedAt,
+			UpdatedAt:         cwa.UpdatedAt,
+			Assignments:       assignments,
+			PendingCount:      cwa.PendingCount,
 		}
 	}
 
@@ -365,6 +403,20 @@ type InstanceResponse struct {
 	ReviewedAt       *time.Time                 `json:"reviewed_at,omitempty"`
 	RejectionReason  *string                    `json:"rejection_reason,omitempty"`
 	TransactionID    *int64                     `json:"transaction_id,omitempty"`
+	IsBounty         bool                       `json:"is_bounty,omitempty"`
+	PenaltyCents     int                        `json:"penalty_cents,omitempty"`
+	RedoCount        int                        `json:"redo_count,omitempty"`
+	DueAt            *time.Time                 `json:"due_at,omitempty"`
+	IsOverdue        bool                       `json:"is_overdue,omitempty"`
+	CompletedLate    bool                       `json:"completed_late,omitempty"`
+	Rating           *int                       `json:"rating,omitempty"`
+	PayoutPercent    *int                       `json:"payout_percent,omitempty"`
+	BonusCents       int                        `json:"bonus_cents,omitempty"`
+	PaidCents        *int                       `json:"paid_cents,omitempty"`
+	ClaimExpiresAt   *time.Time                 `json:"claim_expires_at,omitempty"`
+	CurrentStreak    int                        `json:"current_streak,omitempty"`
+	BestStreak       int                        `json:"best_streak,omitempty"`
+	Photos           []PhotoResponse            `json:"photos,omitempty"`
 	CreatedAt        time.Time                  `json:"created_at"`
 }
 
@@ -394,9 +446,21 @@ func (h *Handler) HandleChildListChores(w http.ResponseWriter, r *http.Request)
 		return
 	}
 
+	periods, err := h.choreInstanceRepo.ListPeriodsByChild(childID)
+	if err != nil {
+		writeJSON(w, http.StatusInternalServerError, ErrorResponse{
+			Error:   "internal_error",
+			Message: "Failed to list chores.",
+		})
+		return
+	}
+	streaks := streaksByChore(periods)
+	now := time.Now()
+
 	toInstanceResponses := func(items []repositories.ChoreInstanceWithDetails) []InstanceResponse {
 		result := make([]InstanceResponse, len(items))
 		for i, item := range items {
+			streak := streaks[item.ChoreID]
 			result[i] = InstanceResponse{
 				ID:               item.ID,
 				ChoreID:          item.ChoreID,
@@ -411,6 +475,15 @@ func (h *Handler) HandleChildListChores(w http.ResponseWriter, r *http.Request)
 				ReviewedAt:       item.ReviewedAt,
 				RejectionReason:  item.RejectionReason,
 				TransactionID:    item.TransactionID,
+				IsBounty:         item.IsBounty,
+				ClaimExpiresAt:   item.ClaimExpiresAt,
+				PenaltyCents:     item.PenaltyCents,
+				RedoCount:        item.RedoCount,
+				DueAt:            item.DueAt,
+				IsOverdue:        isOverdue(&item.ChoreInstance, now),
+				CompletedLate:    item.CompletedLate,
+				CurrentStreak:    streak.Current,
+				BestStreak:       streak.Best,
 				CreatedAt:        item.CreatedAt,
 			}
 		}
@@ -445,7 +518,59 @@ func (h *Handler) HandleCompleteChore(w http.ResponseWriter, r *http.Request) {
 
 	childID := middleware.GetUserID(r)
 
-	if err := h.choreInstanceRepo.MarkComplete(instanceID, childID); err != nil {
+	uploads, errMsg := readPhotos(w, r)
+	if errMsg != "" {
+		writeJSON(w, http.StatusBadRequest, ErrorResponse{
+			Error:   "invalid_photo",
+			Message: errMsg,
+		})
+		return
+	}
+	if len(uploads) > 0 && h.photoStore == nil {
+		writeJSON(w, http.StatusBadRequest, ErrorResponse{
+			Error:   "photos_unavailable",
+			Message: "Photo attachments are not available.",
+		})
+		return
+	}
+
+	isBounty, err := h.isBountyInstance(instanceID)
+	if err != nil {
+		writeJSON(w, http.StatusInternalServerError, ErrorResponse{
+			Error:   "internal_error",
+			Message: "Failed to retrieve instance.",
+		})
+		return
+	}
+
+	// Photos are stored before the instance changes status, so a failed upload leaves the chore
+	// available to complete again
+	var photos []models.ChorePhoto
+	if len(uploads) > 0 {
+		photos, err = h.storePhotos(r.Context(), instanceID, uploads)
+		if err != nil {
+			log.Printf("Error storing photos for chore instance %d: %v", instanceID, err)
+			writeJSON(w, http.StatusInternalServerError, ErrorResponse{
+				Error:   "photo_upload_failed",
+				Message: "The photos could not be saved. Please try again.",
+			})
+			return
+		}
+	}
+
+	// Photos from an earlier, rejected completion are replaced
+	replaced, err := h.choreInstanceRepo.CompleteWithPhotos(instanceID, childID, isBounty, photos)
+	if err != nil {
+		if h.photoStore != nil {
+			removeStoredPhotos(r.Context(), h.photoStore, photos)
+		}
+		if errors.Is(err, repositories.ErrBountyTaken) {
+			writeJSON(w, http.StatusConflict, ErrorResponse{
+				Error:   "bounty_taken",
+				Message: "A sibling already took this chore.",
+			})
+			return
+		}
 		if errors.Is(err, repositories.ErrInvalidStatusTransition) {
 			writeJSON(w, http.StatusBadRequest, ErrorResponse{
 				Error:   "invalid_status",
@@ -460,8 +585,148 @@ func (h *Handler) HandleCompleteChore(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	if h.photoStore != nil {
+		removeStoredPhotos(r.Context(), h.photoStore, replaced)
+	}
+
+	instance, err := h.choreInstanceRepo.GetByID(instanceID)
+	if err != nil || instance == nil {
+		writeJSON(w, http.StatusInternalServerError, ErrorResponse{
+			Error:   "internal_error",
+			Message: "Failed to retrieve updated instance.",
+		})
+		return
+	}
+	byInstance, err := h.choreInstanceRepo.ListPhotos([]int64{instanceID})
+	if err != nil {
+		writeJSON(w, http.StatusInternalServerError, ErrorResponse{
+			Error:   "internal_error",
+			Message: "Failed to retrieve photos.",
+		})
+		return
+	}
+
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"instance": InstanceResponse{
+			ID:            instance.ID,
+			ChoreID:       instance.ChoreID,
+			ChildID:       instance.ChildID,
+			RewardCents:   instance.RewardCents,
+			Status:        instance.Status,
+			PeriodStart:   instance.PeriodStart,
+			PeriodEnd:     instance.PeriodEnd,
+			CompletedAt:   instance.CompletedAt,
+			ReviewedAt:    instance.ReviewedAt,
+			DueAt:         instance.DueAt,
+			CompletedLate: instance.CompletedLate,
+			IsBounty:      isBounty,
+			Photos:        photoResponses(byInstance[instanceID]),
+			CreatedAt:     instance.CreatedAt,
+		},
+	})
+}
+
+// isBountyInstance reports whether a chore instance belongs to a bounty chore.
+// Missing instances are reported as not bounties so the status transition fails as usual.
+func (h *Handler) isBountyInstance(instanceID int64) (bool, error) {
 	instance, err := h.choreInstanceRepo.GetByID(instanceID)
 	if err != nil || instance == nil {
+		return false, err
+	}
+	chore, err := h.choreRepo.GetByID(instance.ChoreID)
+	if err != nil || chore == nil {
+		return false, err
+	}
+	return chore.IsBounty, nil
+}
+
+// HandleClaimChore handles POST /api/child/chores/{id}/claim
+// Claiming a bounty reserves it for the child and hides it from their siblings until the claim expires.
+func (h *Handler) HandleClaimChore(w http.ResponseWriter, r *http.Request) {
+	if middleware.GetUserType(r) != "child" {
+		writeJSON(w, http.StatusForbidden, ErrorResponse{
+			Error:   "forbidden",
+			Message: "Only children can claim chores.",
+		})
+		return
+	}
+
+	instanceID, err := strconv.ParseInt(r.PathValue("id"), 10, 64)
+	if err != nil {
+		writeJSON(w, http.StatusBadRequest, ErrorResponse{
+			Error:   "invalid_request",
+			Message: "Invalid instance ID.",
+		})
+		return
+	}
+
+	childID := middleware.GetUserID(r)
+
+	instance, err := h.choreInstanceRepo.GetByID(instanceID)
+	if err != nil {
+		writeJSON(w, http.StatusInternalServerError, ErrorResponse{
+			Error:   "internal_error",
+			Message: "Failed to retrieve instance.",
+		})
+		return
+	}
+	if instance == nil || instance.ChildID != childID {
+		writeJSON(w, http.StatusNotFound, ErrorResponse{
+			Error:   "not_found",
+			Message: "Chore instance not found.",
+		})
+		return
+	}
+
+	chore, err := h.choreRepo.GetByID(instance.ChoreID)
+	if err != nil || chore == nil {
+		writeJSON(w, http.StatusInternalServerError, ErrorResponse{
+			Error:   "internal_error",
+			Message: "Failed to retrieve chore.",
+		})
+		return
+	}
+	if !chore.IsBounty {
+		writeJSON(w, http.StatusBadRequest, ErrorResponse{
+			Error:   "not_bounty",
+			Message: "Only bounty chores can be claimed.",
+		})
+		return
+	}
+
+	expiresAt := time.Now().Add(chore.ClaimDuration())
+	// A claim never outlasts the chore's period
+	if instance.PeriodEnd != nil {
+		periodEnd := instance.PeriodEnd.AddDate(0, 0, 1)
+		if expiresAt.After(periodEnd) {
+			expiresAt = periodEnd
+		}
+	}
+
+	if err := h.choreInstanceRepo.ClaimBounty(instanceID, childID, expiresAt); err != nil {
+		if errors.Is(err, repositories.ErrBountyTaken) {
+			writeJSON(w, http.StatusConflict, ErrorResponse{
+				Error:   "bounty_taken",
+				Message: "A sibling already took this chore.",
+			})
+			return
+		}
+		if errors.Is(err, repositories.ErrInvalidStatusTransition) {
+			writeJSON(w, http.StatusBadRequest, ErrorResponse{
+				Error:   "invalid_status",
+				Message: "Cannot claim this chore.",
+			})
+			return
+		}
+		writeJSON(w, http.StatusInternalServerError, ErrorResponse{
+			Error:   "internal_error",
+			Message: "Failed to claim chore.",
+		})
+		return
+	}
+
+	updated, err := h.choreInstanceRepo.GetByID(instanceID)
+	if err != nil || updated == nil {
 		writeJSON(w, http.StatusInternalServerError, ErrorResponse{
 			Error:   "internal_error",
 			Message: "Failed to retrieve updated instance.",
@@ -471,16 +736,17 @@ func (h *Handler) HandleCompleteChore(w http.ResponseWriter, r *http.Request) {
 
 	writeJSON(w, http.StatusOK, map[string]interface{}{
 		"instance": InstanceResponse{
-			ID:          instance.ID,
-			ChoreID:     instance.ChoreID,
-			ChildID:     instance.ChildID,
-			RewardCents: instance.RewardCents,
-			Status:      instance.Status,
-			PeriodStart: instance.PeriodStart,
-			PeriodEnd:   instance.PeriodEnd,
-			CompletedAt: instance.CompletedAt,
-			ReviewedAt:  instance.ReviewedAt,
-			CreatedAt:   instance.CreatedAt,
+			ID:             updated.ID,
+			ChoreID:        updated.ChoreID,
+			ChoreName:      chore.Name,
+			ChildID:        updated.ChildID,
+			RewardCents:    updated.RewardCents,
+			Status:         updated.Status,
+			PeriodStart:    updated.PeriodStart,
+			PeriodEnd:      updated.PeriodEnd,
+			IsBounty:       true,
+			ClaimExpiresAt: updated.ClaimExpiresAt,
+			CreatedAt:      updated.CreatedAt,
 		},
 	})
 }
@@ -506,6 +772,19 @@ func (h *Handler) HandleListPending(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	instanceIDs := make([]int64, len(pendingInstances))
+	for i, p := range pendingInstances {
+		instanceIDs[i] = p.ID
+	}
+	photos, err := h.choreInstanceRepo.ListPhotos(instanceIDs)
+	if err != nil {
+		writeJSON(w, http.StatusInternalServerError, ErrorResponse{
+			Error:   "internal_error",
+			Message: "Failed to list pending chores.",
+		})
+		return
+	}
+
 	instances := make([]InstanceResponse, len(pendingInstances))
 	for i, p := range pendingInstances {
 		instances[i] = InstanceResponse{
@@ -522,6 +801,10 @@ func (h *Handler) HandleListPending(w http.ResponseWriter, r *http.Request) {
 			ReviewedAt:      p.ReviewedAt,
 			RejectionReason: p.RejectionReason,
 			TransactionID:   p.TransactionID,
+			RedoCount:       p.RedoCount,
+			DueAt:           p.DueAt,
+			CompletedLate:   p.CompletedLate,
+			Photos:          photoResponses(photos[p.ID]),
 			CreatedAt:       p.CreatedAt,
 		}
 	}
@@ -566,18 +849,23 @@ func (h *Handler) HandleListCompleted(w http.ResponseWriter, r *http.Request) {
 	instances := make([]InstanceResponse, len(completedInstances))
 	for i, p := range completedInstances {
 		instances[i] = InstanceResponse{
-			ID:          p.ID,
-			ChoreID:     p.ChoreID,
-			ChoreName:   p.ChoreName,
-			ChildID:     p.ChildID,
-			ChildName:   p.ChildName,
-			RewardCents: p.RewardCents,
-			Status:      p.Status,
-			PeriodStart: p.PeriodStart,
-			PeriodEnd:   p.PeriodEnd,
-			CompletedAt: p.CompletedAt,
-			ReviewedAt:  p.ReviewedAt,
-			CreatedAt:   p.CreatedAt,
+			ID:            p.ID,
+			ChoreID:       p.ChoreID,
+			ChoreName:     p.ChoreName,
+			ChildID:       p.ChildID,
+			ChildName:     p.ChildName,
+			RewardCents:   p.RewardCents,
+			Status:        p.Status,
+			PeriodStart:   p.PeriodStart,
+			PeriodEnd:     p.PeriodEnd,
+			CompletedAt:   p.CompletedAt,
+			ReviewedAt:    p.ReviewedAt,
+			Rating:        p.Rating,
+			BonusCents:    p.BonusCents,
+			PaidCents:     p.PaidCents,
+			DueAt:         p.DueAt,
+			CompletedLate: p.CompletedLate,
+			CreatedAt:     p.CreatedAt,
 		}
 	}
 
@@ -603,106 +891,95 @@ func (h *Handler) HandleApprove(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	parentID := middleware.GetUserID(r)
-	familyID := middleware.GetFamilyID(r)
-
-	instance, err := h.choreInstanceRepo.GetByID(instanceID)
-	if err != nil {
-		writeJSON(w, http.StatusInternalServerError, ErrorResponse{
-			Error:   "internal_error",
-			Message: "Failed to retrieve instance.",
-		})
-		return
-	}
-	if instance == nil {
-		writeJSON(w, http.StatusNotFound, ErrorResponse{
-			Error:   "not_found",
-			Message: "Chore instance not found.",
+	var req ApproveRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil && !errors.Is(err, io.EOF) {
+		writeJSON(w, http.StatusBadRequest, ErrorResponse{
+			Error:   "invalid_request",
+			Message: "Invalid request body.",
 		})
 		return
 	}
 
-	// Verify instance belongs to parent's family
-	child, err := h.childRepo.GetByID(instance.ChildID)
-	if err != nil || child == nil {
-		writeJSON(w, http.StatusInternalServerError, ErrorResponse{
-			Error:   "internal_error",
-			Message: "Failed to verify family ownership.",
-		})
+	result, fail := h.approveInstance(r.Context(), middleware.GetFamilyID(r), middleware.GetUserID(r), instanceID, req)
+	if fail != nil {
+		writeJSON(w, fail.status, fail.resp)
 		return
 	}
-	if child.FamilyID != familyID {
-		writeJSON(w, http.StatusForbidden, ErrorResponse{
-			Error:   "forbidden",
-			Message: "Instance does not belong to your family.",
-		})
-		return
+
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"instance":           result.Instance,
+		"new_balance":        result.NewBalance,
+		"streak_bonus_cents": result.StreakBonusCents,
+	})
+}
+
+// approveResult is the outcome of approving one chore instance.
+type approveResult struct {
+	Instance         InstanceResponse
+	NewBalance       int64
+	StreakBonusCents int64
+}
+
+// approveInstance approves one of the family's pending chore instances and pays the child.
+func (h *Handler) approveInstance(ctx context.Context, familyID, parentID, instanceID int64, req ApproveRequest) (*approveResult, *reviewFailure) {
+	instance, child, fail := h.familyInstance(familyID, instanceID)
+	if fail != nil {
+		return nil, fail
 	}
 
 	// Verify status is pending_approval
 	if instance.Status != models.ChoreInstanceStatusPendingApproval {
-		writeJSON(w, http.StatusBadRequest, ErrorResponse{
-			Error:   "invalid_status",
-			Message: "Instance is not pending approval.",
-		})
-		return
+		return nil, failure(http.StatusBadRequest, "invalid_status", "Instance is not pending approval.")
 	}
 
 	// Check child is not disabled
 	if child.IsDisabled {
-		writeJSON(w, http.StatusForbidden, ErrorResponse{
-			Error:   "child_disabled",
-			Message: "Child account is disabled.",
-		})
-		return
+		return nil, failure(http.StatusForbidden, "child_disabled", "Child account is disabled.")
 	}
 
 	// Get chore name for transaction note
 	chore, err := h.choreRepo.GetByID(instance.ChoreID)
 	if err != nil || chore == nil {
-		writeJSON(w, http.StatusInternalServerError, ErrorResponse{
-			Error:   "internal_error",
-			Message: "Failed to retrieve chore.",
-		})
-		return
+		return nil, failure(http.StatusInternalServerError, "internal_error", "Failed to retrieve chore.")
 	}
 
-	var transactionID *int64
-	var newBalance int64
+	// Late completions are paid the chore's late payout unless the parent says otherwise
+	req = latePayout(req, instance, chore)
+	paidCents, errMsg := reviewPayout(instance.RewardCents, req)
+	if errMsg != "" {
+		return nil, failure(http.StatusBadRequest, "validation_error", errMsg)
+	}
 
-	if instance.RewardCents > 0 {
-		tx, balance, err := h.txRepo.DepositChore(instance.ChildID, parentID, int64(instance.RewardCents), "Chore: "+chore.Name)
-		if err != nil {
-			writeJSON(w, http.StatusInternalServerError, ErrorResponse{
-				Error:   "internal_error",
-				Message: "Failed to create deposit.",
-			})
-			return
-		}
-		transactionID = &tx.ID
-		newBalance = balance
+	review := &repositories.ChoreReview{
+		Rating:        req.Rating,
+		PayoutPercent: req.PayoutPercent,
+		BonusCents:    req.BonusCents,
+		PaidCents:     paidCents,
+	}
+	_, newBalance, err := h.choreInstanceRepo.ApproveAndPay(instanceID, parentID, "Chore: "+chore.Name, review)
+	if errors.Is(err, repositories.ErrInvalidStatusTransition) {
+		return nil, failure(http.StatusBadRequest, "invalid_status", "Instance is not pending approval.")
+	}
+	if err != nil {
+		return nil, failure(http.StatusInternalServerError, "internal_error", "Failed to approve instance.")
 	}
 
-	if err := h.choreInstanceRepo.Approve(instanceID, parentID, transactionID); err != nil {
-		writeJSON(w, http.StatusInternalServerError, ErrorResponse{
-			Error:   "internal_error",
-			Message: "Failed to approve instance.",
-		})
-		return
+	// Photo proof is no longer needed once the chore is approved
+	h.deleteInstancePhotos(ctx, instanceID)
+
+	streak, bonusCents, balance := h.payStreakBonus(instance, chore, parentID)
+	if bonusCents > 0 {
+		newBalance = balance
 	}
 
 	// Get updated instance
 	updated, err := h.choreInstanceRepo.GetByID(instanceID)
 	if err != nil || updated == nil {
-		writeJSON(w, http.StatusInternalServerError, ErrorResponse{
-			Error:   "internal_error",
-			Message: "Failed to retrieve updated instance.",
-		})
-		return
+		return nil, failure(http.StatusInternalServerError, "internal_error", "Failed to retrieve updated instance.")
 	}
 
-	writeJSON(w, http.StatusOK, map[string]interface{}{
-		"instance": InstanceResponse{
+	return &approveResult{
+		Instance: InstanceResponse{
 			ID:            updated.ID,
 			ChoreID:       updated.ChoreID,
 			ChildID:       updated.ChildID,
@@ -713,10 +990,19 @@ func (h *Handler) HandleApprove(w http.ResponseWriter, r *http.Request) {
 			CompletedAt:   updated.CompletedAt,
 			ReviewedAt:    updated.ReviewedAt,
 			TransactionID: updated.TransactionID,
+			Rating:        updated.Rating,
+			PayoutPercent: updated.PayoutPercent,
+			BonusCents:    updated.BonusCents,
+			PaidCents:     updated.PaidCents,
+			DueAt:         updated.DueAt,
+			CompletedLate: updated.CompletedLate,
+			CurrentStreak: streak.Current,
+			BestStreak:    streak.Best,
 			CreatedAt:     updated.CreatedAt,
 		},
-		"new_balance": newBalance,
-	})
+		NewBalance:       newBalance,
+		StreakBonusCents: bonusCents,
+	}, nil
 }
 
 // HandleReject handles POST /api/chore-instances/{id}/reject
@@ -743,93 +1029,68 @@ func (h *Handler) HandleReject(w http.ResponseWriter, r *http.Request) {
 	if r.Body != nil {
 		_ = json.NewDecoder(r.Body).Decode(&reqBody)
 	}
-	reason := strings.TrimSpace(reqBody.Reason)
-	if len(reason) > MaxDescriptionLength {
-		writeJSON(w, http.StatusBadRequest, ErrorResponse{
-			Error:   "invalid_request",
-			Message: "Reason must be 500 characters or less.",
-		})
+
+	resp, fail := h.rejectInstance(middleware.GetFamilyID(r), middleware.GetUserID(r), instanceID, reqBody.Reason)
+	if fail != nil {
+		writeJSON(w, fail.status, fail.resp)
 		return
 	}
 
-	parentID := middleware.GetUserID(r)
-	familyID := middleware.GetFamilyID(r)
+	writeJSON(w, http.StatusOK, map[string]interface{}{"instance": resp})
+}
 
-	instance, err := h.choreInstanceRepo.GetByID(instanceID)
-	if err != nil {
-		writeJSON(w, http.StatusInternalServerError, ErrorResponse{
-			Error:   "internal_error",
-			Message: "Failed to retrieve instance.",
-		})
-		return
-	}
-	if instance == nil {
-		writeJSON(w, http.StatusNotFound, ErrorResponse{
-			Error:   "not_found",
-			Message: "Chore instance not found.",
-		})
-		return
+// rejectInstance rejects one of the family's pending chore instances with an optional reason.
+func (h *Handler) rejectInstance(familyID, parentID, instanceID int64, reason string) (*InstanceResponse, *reviewFailure) {
+	reason = strings.TrimSpace(reason)
+	if len(reason) > MaxDescriptionLength {
+		return nil, failure(http.StatusBadRequest, "invalid_request", "Reason must be 500 characters or less.")
 	}
 
-	// Verify family ownership
-	child, err := h.childRepo.GetByID(instance.ChildID)
-	if err != nil || child == nil {
-		writeJSON(w, http.StatusInternalServerError, ErrorResponse{
-			Error:   "internal_error",
-			Message: "Failed to verify family ownership.",
-		})
-		return
-	}
-	if child.FamilyID != familyID {
-		writeJSON(w, http.StatusForbidden, ErrorResponse{
-			Error:   "forbidden",
-			Message: "Instance does not belong to your family.",
-		})
-		return
+	instance, _, fail := h.familyInstance(familyID, instanceID)
+	if fail != nil {
+		return nil, fail
 	}
 
 	// Verify status is pending_approval
 	if instance.Status != models.ChoreInstanceStatusPendingApproval {
-		writeJSON(w, http.StatusBadRequest, ErrorResponse{
-			Error:   "invalid_status",
-			Message: "Instance is not pending approval.",
-		})
-		return
+		return nil, failure(http.StatusBadRequest, "invalid_status", "Instance is not pending approval.")
 	}
 
-	if err := h.choreInstanceRepo.Reject(instanceID, parentID, reason); err != nil {
-		writeJSON(w, http.StatusInternalServerError, ErrorResponse{
-			Error:   "internal_error",
-			Message: "Failed to reject instance.",
-		})
-		return
+	err := h.choreInstanceRepo.Reject(instanceID, parentID, reason)
+	if errors.Is(err, repositories.ErrInvalidStatusTransition) {
+		return nil, failure(http.StatusBadRequest, "invalid_status", "Instance is not pending approval.")
+	}
+	if err != nil {
+		return nil, failure(http.StatusInternalServerError, "internal_error", "Failed to reject instance.")
+	}
+
+	// A rejected bounty is up for grabs again
+	chore, err := h.choreRepo.GetByID(instance.ChoreID)
+	if err == nil && chore != nil && chore.IsBounty {
+		if err := h.choreInstanceRepo.ReopenBounty(instance.ChoreID, instance.PeriodStart); err != nil {
+			log.Printf("Error reopening bounty for chore %d: %v", instance.ChoreID, err)
+		}
 	}
 
 	// Get updated instance
 	updated, err := h.choreInstanceRepo.GetByID(instanceID)
 	if err != nil || updated == nil {
-		writeJSON(w, http.StatusInternalServerError, ErrorResponse{
-			Error:   "internal_error",
-			Message: "Failed to retrieve updated instance.",
-		})
-		return
-	}
-
-	writeJSON(w, http.StatusOK, map[string]interface{}{
-		"instance": InstanceResponse{
-			ID:              updated.ID,
-			ChoreID:         updated.ChoreID,
-			ChildID:         updated.ChildID,
-			RewardCents:     updated.RewardCents,
-			Status:          updated.Status,
-			PeriodStart:     updated.PeriodStart,
-			PeriodEnd:       updated.PeriodEnd,
-			CompletedAt:     updated.CompletedAt,
-			ReviewedAt:      updated.ReviewedAt,
-			RejectionReason: updated.RejectionReason,
-			CreatedAt:       updated.CreatedAt,
-		},
-	})
+		return nil, failure(http.StatusInternalServerError, "internal_error", "Failed to retrieve updated instance.")
+	}
+
+	return &InstanceResponse{
+		ID:              updated.ID,
+		ChoreID:         updated.ChoreID,
+		ChildID:         updated.ChildID,
+		RewardCents:     updated.RewardCents,
+		Status:          updated.Status,
+		PeriodStart:     updated.PeriodStart,
+		PeriodEnd:       updated.PeriodEnd,
+		CompletedAt:     updated.CompletedAt,
+		ReviewedAt:      updated.ReviewedAt,
+		RejectionReason: updated.RejectionReason,
+		CreatedAt:       updated.CreatedAt,
+	}, nil
 }
 
 // HandleActivate handles PATCH /api/chores/{id}/activate
@@ -878,29 +1139,63 @@ func (h *Handler) setChoreActive(w http.ResponseWriter, r *http.Request, active
 	updated, _ := h.choreRepo.GetByID(choreID)
 	writeJSON(w, http.StatusOK, map[string]interface{}{
 		"chore": ChoreResponse{
-			ID:          updated.ID,
-			FamilyID:    updated.FamilyID,
-			Name:        updated.Name,
-			Description: updated.Description,
-			RewardCents: updated.RewardCents,
-			Recurrence:  string(updated.Recurrence),
-			DayOfWeek:   updated.DayOfWeek,
-			DayOfMonth:  updated.DayOfMonth,
-			IsActive:    updated.IsActive,
-			CreatedAt:   updated.CreatedAt,
-			UpdatedAt:   updated.UpdatedAt,
+			ID:                updated.ID,
+			FamilyID:          updated.FamilyID,
+			Name:              updated.Name,
+			Description:       updated.Description,
+			RewardCents:       updated.RewardCents,
+			Recurrence:        string(updated.Recurrence),
+			DayOfWeek:         updated.DayOfWeek,
+			DayOfMonth:        updated.DayOfMonth,
+			RRule:             updated.RRule,
+			IsActive:          updated.IsActive,
+			IsBounty:          updated.IsBounty,
+			ClaimHours:        updated.ClaimHours,
+			IsRotation:        updated.IsRotation,
+			PenaltyCents:      updated.PenaltyCents,
+			MaxRedos:          updated.MaxRedos,
+			DueTime:           updated.DueTime,
+			DueWeekday:        updated.DueWeekday,
+			LatePayoutPercent: updated.LatePayoutPercent,
+			CreatedAt:         updated.CreatedAt,
+			UpdatedAt:         updated.UpdatedAt,
 		},
 	})
 }
 
 // UpdateChoreRequest represents the request body for updating a chore.
 type UpdateChoreRequest struct {
-	Name        *string `json:"name,omitempty"`
-	Description *string `json:"description,omitempty"`
-	RewardCents *int    `json:"reward_cents,omitempty"`
-	Recurrence  *string `json:"recurrence,omitempty"`
-	DayOfWeek   *int    `json:"day_of_week,omitempty"`
-	DayOfMonth  *int    `json:"day_of_month,omitempty"`
+	Name              *string `json:"name,omitempty"`
+	Description       *string `json:"description,omitempty"`
+	RewardCents       *int    `json:"reward_cents,omitempty"`
+	Recurrence        *string `json:"recurrence,omitempty"`
+	DayOfWeek         *int    `json:"day_of_week,omitempty"`
+	DayOfMonth        *int    `json:"day_of_month,omitempty"`
+	RRule             *string `json:"rrule,omitempty"`
+	IsBounty          *bool   `json:"is_bounty,omitempty"`
+	ClaimHours        *int    `json:"claim_hours,omitempty"`
+	IsRotation        *bool   `json:"is_rotation,omitempty"`
+	PenaltyCents      *int    `json:"penalty_cents,omitempty"`
+	MaxRedos          *int    `json:"max_redos,omitempty"`
+	MaxRedosSet       bool    `json:"-"` // if true and MaxRedos is nil, clears the redo limit
+	DueTime           *string `json:"due_time,omitempty"`
+	DueWeekday        *int    `json:"due_weekday,omitempty"`
+	LatePayoutPercent *int    `json:"late_payout_percent,omitempty"`
+}
+
+// UnmarshalJSON decodes an update request, recording whether max_redos was present so that an
+// explicit null can set the chore back to unlimited redos.
+func (req *UpdateChoreRequest) UnmarshalJSON(data []byte) error {
+	type plain UpdateChoreRequest
+	if err := json.Unmarshal(data, (*plain)(req)); err != nil {
+		return err
+	}
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return err
+	}
+	_, req.MaxRedosSet = fields["max_redos"]
+	return nil
 }
 
 // HandleUpdateChore handles PUT /api/chores/{id}
@@ -971,7 +1266,7 @@ func (h *Handler) HandleUpdateChore(w http.ResponseWriter, r *http.Request) {
 	if req.Recurrence != nil {
 		recurrence := models.ChoreRecurrence(*req.Recurrence)
 		switch recurrence {
-		case models.ChoreRecurrenceOneTime, models.ChoreRecurrenceDaily, models.ChoreRecurrenceWeekly, models.ChoreRecurrenceMonthly:
+		case models.ChoreRecurrenceOneTime, models.ChoreRecurrenceDaily, models.ChoreRecurrenceWeekly, models.ChoreRecurrenceMonthly, models.ChoreRecurrenceCustom:
 			existingChore.Recurrence = recurrence
 		default:
 			writeJSON(w, http.StatusBadRequest, ErrorResponse{Error: "validation_error", Message: "Invalid recurrence type."})
@@ -985,6 +1280,58 @@ func (h *Handler) HandleUpdateChore(w http.ResponseWriter, r *http.Request) {
 	if req.DayOfMonth != nil {
 		existingChore.DayOfMonth = req.DayOfMonth
 	}
+	if req.RRule != nil {
+		existingChore.RRule = req.RRule
+	}
+	if existingChore.Recurrence == models.ChoreRecurrenceCustom {
+		if errMsg := validateChoreRRule(existingChore.RRule); errMsg != "" {
+			writeJSON(w, http.StatusBadRequest, ErrorResponse{Error: "validation_error", Message: errMsg})
+			return
+		}
+	} else {
+		existingChore.RRule = nil
+	}
+
+	wasBounty := existingChore.IsBounty
+	if req.IsBounty != nil {
+		existingChore.IsBounty = *req.IsBounty
+	}
+	if req.ClaimHours != nil {
+		existingChore.ClaimHours = req.ClaimHours
+	}
+	if existingChore.IsBounty {
+		if errMsg := validateClaimHours(existingChore.ClaimHours); errMsg != "" {
+			writeJSON(w, http.StatusBadRequest, ErrorResponse{Error: "validation_error", Message: errMsg})
+			return
+		}
+	} else {
+		existingChore.ClaimHours = nil
+	}
+
+	if req.IsRotation != nil {
+		existingChore.IsRotation = *req.IsRotation
+	}
+	if req.PenaltyCents != nil {
+		existingChore.PenaltyCents = *req.PenaltyCents
+	}
+	if req.MaxRedosSet {
+		existingChore.MaxRedos = req.MaxRedos
+	}
+	if req.DueTime != nil {
+		existingChore.DueTime = req.DueTime
+	}
+	if req.DueWeekday != nil {
+		existingChore.DueWeekday = req.DueWeekday
+	}
+	if req.LatePayoutPercent != nil {
+		existingChore.LatePayoutPercent = req.LatePayoutPercent
+	}
+	for _, errMsg := range []string{validateRotation(existingChore), validatePenalty(existingChore), validateMaxRedos(existingChore), validateDue(existingChore)} {
+		if errMsg != "" {
+			writeJSON(w, http.StatusBadRequest, ErrorResponse{Error: "validation_error", Message: errMsg})
+			return
+		}
+	}
 
 	updated, err := h.choreRepo.Update(existingChore)
 	if err != nil {
@@ -992,19 +1339,35 @@ func (h *Handler) HandleUpdateChore(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	// Siblings get back the instances a former bounty had hidden from them
+	if wasBounty && !updated.IsBounty {
+		if err := h.choreInstanceRepo.ClearBounty(updated.ID, time.Now().UTC()); err != nil {
+			log.Printf("Error clearing bounty for chore %d: %v", updated.ID, err)
+		}
+	}
+
 	writeJSON(w, http.StatusOK, map[string]interface{}{
 		"chore": ChoreResponse{
-			ID:          updated.ID,
-			FamilyID:    updated.FamilyID,
-			Name:        updated.Name,
-			Description: updated.Description,
-			RewardCents: updated.RewardCents,
-			Recurrence:  string(updated.Recurrence),
-			DayOfWeek:   updated.DayOfWeek,
-			DayOfMonth:  updated.DayOfMonth,
-			IsActive:    updated.IsActive,
-			CreatedAt:   updated.CreatedAt,
-			UpdatedAt:   updated.UpdatedAt,
+			ID:                updated.ID,
+			FamilyID:          updated.FamilyID,
+			Name:              updated.Name,
+			Description:       updated.Description,
+			RewardCents:       updated.RewardCents,
+			Recurrence:        string(updated.Recurrence),
+			DayOfWeek:         updated.DayOfWeek,
+			DayOfMonth:        updated.DayOfMonth,
+			RRule:             updated.RRule,
+			IsActive:          updated.IsActive,
+			IsBounty:          updated.IsBounty,
+			ClaimHours:        updated.ClaimHours,
+			IsRotation:        updated.IsRotation,
+			PenaltyCents:      updated.PenaltyCents,
+			MaxRedos:          updated.MaxRedos,
+			DueTime:           updated.DueTime,
+			DueWeekday:        updated.DueWeekday,
+			LatePayoutPercent: updated.LatePayoutPercent,
+			CreatedAt:         updated.CreatedAt,
+			UpdatedAt:         updated.UpdatedAt,
 		},
 	})
 }
@@ -1038,6 +1401,16 @@ func (h *Handler) HandleDeleteChore(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	// Remove stored photo files; their records go with the instances
+	if h.photoStore != nil {
+		photos, err := h.choreInstanceRepo.ListPhotosByChore(choreID)
+		if err != nil {
+			writeJSON(w, http.StatusInternalServerError, ErrorResponse{Error: "internal_error", Message: "Failed to delete chore photos."})
+			return
+		}
+		removeStoredPhotos(r.Context(), h.photoStore, photos)
+	}
+
 	// Delete all instances for this chore before deleting the chore itself
 	if err := h.choreInstanceRepo.DeleteByChoreID(choreID); err != nil {
 		writeJSON(w, http.StatusInternalServerError, ErrorResponse{Error: "internal_error", Message: "Failed to delete chore instances."})
@@ -1070,15 +1443,159 @@ func (h *Handler) HandleChildEarnings(w http.ResponseWriter, r *http.Request) {
 	recentItems := make([]map[string]interface{}, len(recent))
 	for i, r := range recent {
 		recentItems[i] = map[string]interface{}{
-			"chore_name":  r.ChoreName,
+			"chore_name":   r.ChoreName,
 			"reward_cents": r.RewardCents,
-			"approved_at": r.ApprovedAt,
+			"paid_cents":   r.PaidCents,
+			"rating":       r.Rating,
+			"approved_at":  r.ApprovedAt,
 		}
 	}
 
+	averageRating, ratedCount, err := h.choreInstanceRepo.RatingSummary(childID)
+	if err != nil {
+		writeJSON(w, http.StatusInternalServerError, ErrorResponse{Error: "internal_error", Message: "Failed to get earnings."})
+		return
+	}
+
+	periods, err := h.choreInstanceRepo.ListPeriodsByChild(childID)
+	if err != nil {
+		writeJSON(w, http.StatusInternalServerError, ErrorResponse{Error: "internal_error", Message: "Failed to get earnings."})
+		return
+	}
+	streaks := streaksByChore(periods)
+
+	// One entry per recurring chore, in the order the chores first appear
+	streakItems := make([]StreakResponse, 0, len(streaks))
+	seen := make(map[int64]bool, len(streaks))
+	for _, p := range periods {
+		if seen[p.ChoreID] {
+			continue
+		}
+		seen[p.ChoreID] = true
+		streakItems = append(streakItems, StreakResponse{
+			ChoreID:       p.ChoreID,
+			ChoreName:     p.ChoreName,
+			CurrentStreak: streaks[p.ChoreID].Current,
+			BestStreak:    streaks[p.ChoreID].Best,
+		})
+	}
+
 	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"average_rating":     averageRating,
+		"rated_count":        ratedCount,
+		"streaks":            streakItems,
 		"total_earned_cents": totalCents,
-		"chores_completed":  completedCount,
-		"recent":            recentItems,
+		"chores_completed":   completedCount,
+		"recent":             recentItems,
 	})
 }
+
+// StreakBonusRequest represents one streak bonus in a set streak bonuses request.
+type StreakBonusRequest struct {
+	StreakLength int   `json:"streak_length"`
+	BonusCents   int64 `json:"bonus_cents"`
+}
+
+// SetStreakBonusesRequest represents the request body for replacing a chore's streak bonuses.
+type SetStreakBonusesRequest struct {
+	Bonuses []StreakBonusRequest `json:"bonuses"`
+}
+
+// familyRecurringChore loads the chore named by the {id} path value and checks that it belongs to
+// the caller's family and repeats; feature names what needs a recurring chore in the error message.
+// Writes an error response and returns nil otherwise.
+func (h *Handler) familyRecurringChore(w http.ResponseWriter, r *http.Request, feature string) *models.Chore {
+	choreID, err := strconv.ParseInt(r.PathValue("id"), 10, 64)
+	if err != nil {
+		writeJSON(w, http.StatusBadRequest, ErrorResponse{Error: "invalid_request", Message: "Invalid chore ID."})
+		return nil
+	}
+
+	existingChore, err := h.choreRepo.GetByID(choreID)
+	if err != nil {
+		writeJSON(w, http.StatusInternalServerError, ErrorResponse{Error: "internal_error"})
+		return nil
+	}
+	if existingChore == nil {
+		writeJSON(w, http.StatusNotFound, ErrorResponse{Error: "not_found", Message: "Chore not found."})
+		return nil
+	}
+	if existingChore.FamilyID != middleware.GetFamilyID(r) {
+		writeJSON(w, http.StatusForbidden, ErrorResponse{Error: "forbidden", Message: "Chore does not belong to your family."})
+		return nil
+	}
+	if existingChore.Recurrence == models.ChoreRecurrenceOneTime {
+		writeJSON(w, http.StatusBadRequest, ErrorResponse{Error: "invalid_recurrence", Message: feature + " are only available for recurring chores."})
+		return nil
+	}
+	return existingChore
+}
+
+// HandleListStreakBonuses handles GET /api/chores/{id}/streak-bonuses
+func (h *Handler) HandleListStreakBonuses(w http.ResponseWriter, r *http.Request) {
+	if middleware.GetUserType(r) != "parent" {
+		writeJSON(w, http.StatusForbidden, ErrorResponse{Error: "forbidden", Message: "Only parents can view streak bonuses."})
+		return
+	}
+
+	existingChore := h.familyRecurringChore(w, r, "Streak bonuses")
+	if existingChore == nil {
+		return
+	}
+
+	bonuses, err := h.streakRepo.ListBonuses(existingChore.ID)
+	if err != nil {
+		writeJSON(w, http.StatusInternalServerError, ErrorResponse{Error: "internal_error", Message: "Failed to list streak bonuses."})
+		return
+	}
+	if bonuses == nil {
+		bonuses = []models.ChoreStreakBonus{}
+	}
+
+	writeJSON(w, http.StatusOK, map[string]interface{}{"streak_bonuses": bonuses})
+}
+
+// HandleSetStreakBonuses handles PUT /api/chores/{id}/streak-bonuses
+// Replaces the chore's streak bonuses; an empty list removes them.
+func (h *Handler) HandleSetStreakBonuses(w http.ResponseWriter, r *http.Request) {
+	if middleware.GetUserType(r) != "parent" {
+		writeJSON(w, http.StatusForbidden, ErrorResponse{Error: "forbidden", Message: "Only parents can configure streak bonuses."})
+		return
+	}
+
+	existingChore := h.familyRecurringChore(w, r, "Streak bonuses")
+	if existingChore == nil {
+		return
+	}
+
+	var req SetStreakBonusesRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeJSON(w, http.StatusBadRequest, ErrorResponse{Error: "invalid_request", Message: "Invalid request body."})
+		return
+	}
+
+	bonuses := make([]models.ChoreStreakBonus, len(req.Bonuses))
+	for i, b := range req.Bonuses {
+		bonuses[i] = models.ChoreStreakBonus{StreakLength: b.StreakLength, BonusCents: b.BonusCents}
+	}
+	if errMsg := ValidateStreakBonuses(bonuses); errMsg != "" {
+		writeJSON(w, http.StatusBadRequest, ErrorResponse{Error: "validation_error", Message: errMsg})
+		return
+	}
+
+	if err := h.streakRepo.ReplaceBonuses(existingChore.ID, bonuses); err != nil {
+		writeJSON(w, http.StatusInternalServerError, ErrorResponse{Error: "internal_error", Message: "Failed to save streak bonuses."})
+		return
+	}
+
+	saved, err := h.streakRepo.ListBonuses(existingChore.ID)
+	if err != nil {
+		writeJSON(w, http.StatusInternalServerError, ErrorResponse{Error: "internal_error", Message: "Failed to list streak bonuses."})
+		return
+	}
+	if saved == nil {
+		saved = []models.ChoreStreakBonus{}
+	}
+
+	writeJSON(w, http.StatusOK, map[string]interface{}{"streak_bonuses": saved})
+}
@@ -2,14 +2,20 @@ package chore
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"io"
+	"mime/multipart"
 	"net/http"
 	"net/http/httptest"
 	"strconv"
 	"strings"
 	"testing"
+	"time"
 
+	"bank-of-dad/internal/storage"
 	"bank-of-dad/internal/testutil"
 	"bank-of-dad/models"
 	"bank-of-dad/repositories"
@@ -270,6 +276,68 @@ func TestHandleCreateChore_InvalidAmount_Negative(t *testing.T) {
 	assert.Equal(t, "invalid_amount", errResp.Error)
 }
 
+func TestHandleCreateChore_CustomRecurrence(t *testing.T) {
+	db := testutil.SetupTestDB(t)
+	family := testutil.CreateTestFamily(t, db)
+	parent := testutil.CreateTestParent(t, db, family.ID)
+	child := testutil.CreateTestChild(t, db, family.ID, "Alice")
+
+	handler := NewHandler(
+		repositories.NewChoreRepo(db),
+		repositories.NewChoreInstanceRepo(db),
+		repositories.NewTransactionRepo(db),
+		repositories.NewChildRepo(db),
+	)
+
+	body := fmt.Sprintf(`{"name":"Trash","reward_cents":100,"recurrence":"custom","rrule":"FREQ=WEEKLY;BYDAY=MO,WE,FR","child_ids":[%d]}`, child.ID)
+	req := httptest.NewRequest("POST", "/api/chores", bytes.NewBufferString(body))
+	req = testutil.SetRequestContext(req, "parent", parent.ID, family.ID)
+
+	rr := httptest.NewRecorder()
+	handler.HandleCreateChore(rr, req)
+
+	assert.Equal(t, http.StatusCreated, rr.Code)
+
+	var resp map[string]json.RawMessage
+	err := json.Unmarshal(rr.Body.Bytes(), &resp)
+	require.NoError(t, err)
+
+	var choreResp ChoreResponse
+	err = json.Unmarshal(resp["chore"], &choreResp)
+	require.NoError(t, err)
+
+	assert.Equal(t, "custom", choreResp.Recurrence)
+	require.NotNil(t, choreResp.RRule)
+	assert.Equal(t, "FREQ=WEEKLY;BYDAY=MO,WE,FR", *choreResp.RRule)
+}
+
+func TestHandleCreateChore_CustomRecurrenceMissingRRule(t *testing.T) {
+	db := testutil.SetupTestDB(t)
+	family := testutil.CreateTestFamily(t, db)
+	parent := testutil.CreateTestParent(t, db, family.ID)
+	child := testutil.CreateTestChild(t, db, family.ID, "Alice")
+
+	handler := NewHandler(
+		repositories.NewChoreRepo(db),
+		repositories.NewChoreInstanceRepo(db),
+		repositories.NewTransactionRepo(db),
+		repositories.NewChildRepo(db),
+	)
+
+	body := fmt.Sprintf(`{"name":"Trash","reward_cents":100,"recurrence":"custom","child_ids":[%d]}`, child.ID)
+	req := httptest.NewRequest("POST", "/api/chores", bytes.NewBufferString(body))
+	req = testutil.SetRequestContext(req, "parent", parent.ID, family.ID)
+
+	rr := httptest.NewRecorder()
+	handler.HandleCreateChore(rr, req)
+
+	assert.Equal(t, http.StatusBadRequest, rr.Code)
+
+	var errResp ErrorResponse
+	require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &errResp))
+	assert.Equal(t, "invalid_recurrence", errResp.Error)
+}
+
 func TestHandleCreateChore_InvalidRecurrence(t *testing.T) {
 	db := testutil.SetupTestDB(t)
 	family := testutil.CreateTestFamily(t, db)
@@ -1807,3 +1875,1321 @@ func TestChoreLifecycle_EndToEnd(t *testing.T) {
 	require.NotNil(t, txs[0].Note)
 	assert.Contains(t, *txs[0].Note, "Wash car")
 }
+
+// =====================================================
+// Tests for bounty chores
+// =====================================================
+
+func TestHandleCreateChore_Bounty(t *testing.T) {
+	db := testutil.SetupTestDB(t)
+	family := testutil.CreateTestFamily(t, db)
+	parent := testutil.CreateTestParent(t, db, family.ID)
+	alice := testutil.CreateTestChild(t, db, family.ID, "Alice")
+	bob := testutil.CreateTestChild(t, db, family.ID, "Bob")
+
+	handler := NewHandler(
+		repositories.NewChoreRepo(db),
+		repositories.NewChoreInstanceRepo(db),
+		repositories.NewTransactionRepo(db),
+		repositories.NewChildRepo(db),
+	)
+
+	body := fmt.Sprintf(`{"name":"Wash car","reward_cents":1000,"recurrence":"one_time","is_bounty":true,"claim_hours":4,"child_ids":[%d,%d]}`, alice.ID, bob.ID)
+	req := httptest.NewRequest("POST", "/api/chores", bytes.NewBufferString(body))
+	req = testutil.SetRequestContext(req, "parent", parent.ID, family.ID)
+
+	rr := httptest.NewRecorder()
+	handler.HandleCreateChore(rr, req)
+	require.Equal(t, http.StatusCreated, rr.Code)
+
+	var resp struct {
+		Chore ChoreResponse `json:"chore"`
+	}
+	require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &resp))
+	assert.True(t, resp.Chore.IsBounty)
+	require.NotNil(t, resp.Chore.ClaimHours)
+	assert.Equal(t, 4, *resp.Chore.ClaimHours)
+
+	// Out of range claim hours
+	body = fmt.Sprintf(`{"name":"Wash car","reward_cents":1000,"recurrence":"one_time","is_bounty":true,"claim_hours":500,"child_ids":[%d]}`, alice.ID)
+	req = httptest.NewRequest("POST", "/api/chores", bytes.NewBufferString(body))
+	req = testutil.SetRequestContext(req, "parent", parent.ID, family.ID)
+
+	rr = httptest.NewRecorder()
+	handler.HandleCreateChore(rr, req)
+	assert.Equal(t, http.StatusBadRequest, rr.Code)
+}
+
+func TestHandleClaimChore_FirstComeWins(t *testing.T) {
+	db := testutil.SetupTestDB(t)
+	family := testutil.CreateTestFamily(t, db)
+	parent := testutil.CreateTestParent(t, db, family.ID)
+	alice := testutil.CreateTestChild(t, db, family.ID, "Alice")
+	bob := testutil.CreateTestChild(t, db, family.ID, "Bob")
+
+	choreRepo := repositories.NewChoreRepo(db)
+	instanceRepo := repositories.NewChoreInstanceRepo(db)
+	handler := NewHandler(choreRepo, instanceRepo, repositories.NewTransactionRepo(db), repositories.NewChildRepo(db))
+
+	claimHours := 2
+	createdChore, err := choreRepo.Create(&models.Chore{
+		FamilyID:          family.ID,
+		CreatedByParentID: parent.ID,
+		Name:              "Wash car",
+		RewardCents:       1000,
+		Recurrence:        models.ChoreRecurrenceOneTime,
+		IsActive:          true,
+		IsBounty:          true,
+		ClaimHours:        &claimHours,
+	})
+	require.NoError(t, err)
+
+	instanceIDs := make(map[int64]int64)
+	for _, childID := range []int64{alice.ID, bob.ID} {
+		inst, err := instanceRepo.CreateInstance(&models.ChoreInstance{
+			ChoreID:     createdChore.ID,
+			ChildID:     childID,
+			RewardCents: 1000,
+			Status:      models.ChoreInstanceStatusAvailable,
+		})
+		require.NoError(t, err)
+		instanceIDs[childID] = inst.ID
+	}
+
+	claim := func(childID int64) *httptest.ResponseRecorder {
+		id := strconv.FormatInt(instanceIDs[childID], 10)
+		req := httptest.NewRequest("POST", "/api/child/chores/"+id+"/claim", nil)
+		req.SetPathValue("id", id)
+		req = testutil.SetRequestContext(req, "child", childID, family.ID)
+		rr := httptest.NewRecorder()
+		handler.HandleClaimChore(rr, req)
+		return rr
+	}
+
+	rr := claim(alice.ID)
+	require.Equal(t, http.StatusOK, rr.Code)
+	var resp struct {
+		Instance InstanceResponse `json:"instance"`
+	}
+	require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &resp))
+	assert.Equal(t, models.ChoreInstanceStatusClaimed, resp.Instance.Status)
+	require.NotNil(t, resp.Instance.ClaimExpiresAt)
+	assert.WithinDuration(t, time.Now().Add(2*time.Hour), *resp.Instance.ClaimExpiresAt, time.Minute)
+
+	// Bob is too late, for claiming and for completing
+	rr = claim(bob.ID)
+	assert.Equal(t, http.StatusConflict, rr.Code)
+
+	id := strconv.FormatInt(instanceIDs[bob.ID], 10)
+	req := httptest.NewRequest("POST", "/api/child/chores/"+id+"/complete", nil)
+	req.SetPathValue("id", id)
+	req = testutil.SetRequestContext(req, "child", bob.ID, family.ID)
+	rr = httptest.NewRecorder()
+	handler.HandleCompleteChore(rr, req)
+	assert.Equal(t, http.StatusConflict, rr.Code)
+
+	// Bob no longer sees the chore
+	req = httptest.NewRequest("GET", "/api/child/chores", nil)
+	req = testutil.SetRequestContext(req, "child", bob.ID, family.ID)
+	rr = httptest.NewRecorder()
+	handler.HandleChildListChores(rr, req)
+	require.Equal(t, http.StatusOK, rr.Code)
+	var list struct {
+		Available []InstanceResponse `json:"available"`
+	}
+	require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &list))
+	assert.Empty(t, list.Available)
+}
+
+func TestHandleClaimChore_NotBounty(t *testing.T) {
+	db := testutil.SetupTestDB(t)
+	family := testutil.CreateTestFamily(t, db)
+	parent := testutil.CreateTestParent(t, db, family.ID)
+	child := testutil.CreateTestChild(t, db, family.ID, "Alice")
+
+	choreRepo := repositories.NewChoreRepo(db)
+	instanceRepo := repositories.NewChoreInstanceRepo(db)
+	handler := NewHandler(choreRepo, instanceRepo, repositories.NewTransactionRepo(db), repositories.NewChildRepo(db))
+
+	createdChore, err := choreRepo.Create(&models.Chore{
+		FamilyID:          family.ID,
+		CreatedByParentID: parent.ID,
+		Name:              "Clean room",
+		RewardCents:       500,
+		Recurrence:        models.ChoreRecurrenceOneTime,
+		IsActive:          true,
+	})
+	require.NoError(t, err)
+	inst, err := instanceRepo.CreateInstance(&models.ChoreInstance{
+		ChoreID:     createdChore.ID,
+		ChildID:     child.ID,
+		RewardCents: 500,
+		Status:      models.ChoreInstanceStatusAvailable,
+	})
+	require.NoError(t, err)
+
+	id := strconv.FormatInt(inst.ID, 10)
+	req := httptest.NewRequest("POST", "/api/child/chores/"+id+"/claim", nil)
+	req.SetPathValue("id", id)
+	req = testutil.SetRequestContext(req, "child", child.ID, family.ID)
+	rr := httptest.NewRecorder()
+	handler.HandleClaimChore(rr, req)
+
+	assert.Equal(t, http.StatusBadRequest, rr.Code)
+	assert.Contains(t, rr.Body.String(), "not_bounty")
+}
+
+// =====================================================
+// Tests for streak bonuses
+// =====================================================
+
+func TestHandleApprove_PaysStreakBonus(t *testing.T) {
+	db := testutil.SetupTestDB(t)
+	family := testutil.CreateTestFamily(t, db)
+	parent := testutil.CreateTestParent(t, db, family.ID)
+	child := testutil.CreateTestChild(t, db, family.ID, "Alice")
+
+	choreRepo := repositories.NewChoreRepo(db)
+	instanceRepo := repositories.NewChoreInstanceRepo(db)
+	streakRepo := repositories.NewChoreStreakRepo(db)
+	txRepo := repositories.NewTransactionRepo(db)
+	handler := NewHandler(choreRepo, instanceRepo, txRepo, repositories.NewChildRepo(db))
+	handler.SetStreakRepo(streakRepo)
+
+	createdChore, err := choreRepo.Create(&models.Chore{
+		FamilyID:          family.ID,
+		CreatedByParentID: parent.ID,
+		Name:              "Make bed",
+		RewardCents:       50,
+		Recurrence:        models.ChoreRecurrenceDaily,
+		IsActive:          true,
+	})
+	require.NoError(t, err)
+
+	// Configure "+$1 at 3 days"
+	body := `{"bonuses":[{"streak_length":3,"bonus_cents":100}]}`
+	id := strconv.FormatInt(createdChore.ID, 10)
+	req := httptest.NewRequest("PUT", "/api/chores/"+id+"/streak-bonuses", bytes.NewBufferString(body))
+	req.SetPathValue("id", id)
+	req = testutil.SetRequestContext(req, "parent", parent.ID, family.ID)
+	rr := httptest.NewRecorder()
+	handler.HandleSetStreakBonuses(rr, req)
+	require.Equal(t, http.StatusOK, rr.Code)
+
+	// Two approved days, then today's completion waiting for approval
+	today := time.Now().UTC().Truncate(24 * time.Hour)
+	var pendingID int64
+	for i := 2; i >= 0; i-- {
+		start := today.AddDate(0, 0, -i)
+		status := models.ChoreInstanceStatusApproved
+		if i == 0 {
+			status = models.ChoreInstanceStatusPendingApproval
+		}
+		inst, err := instanceRepo.CreateInstance(&models.ChoreInstance{
+			ChoreID:     createdChore.ID,
+			ChildID:     child.ID,
+			RewardCents: 50,
+			Status:      status,
+			PeriodStart: &start,
+			PeriodEnd:   &start,
+		})
+		require.NoError(t, err)
+		pendingID = inst.ID
+	}
+
+	id = strconv.FormatInt(pendingID, 10)
+	req = httptest.NewRequest("POST", "/api/chore-instances/"+id+"/approve", nil)
+	req.SetPathValue("id", id)
+	req = testutil.SetRequestContext(req, "parent", parent.ID, family.ID)
+	rr = httptest.NewRecorder()
+	handler.HandleApprove(rr, req)
+	require.Equal(t, http.StatusOK, rr.Code)
+
+	var resp struct {
+		Instance         InstanceResponse `json:"instance"`
+		NewBalance       int64            `json:"new_balance"`
+		StreakBonusCents int64            `json:"streak_bonus_cents"`
+	}
+	require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &resp))
+	assert.Equal(t, 3, resp.Instance.CurrentStreak)
+	assert.Equal(t, int64(100), resp.StreakBonusCents)
+	assert.Equal(t, int64(150), resp.NewBalance)
+
+	txs, err := txRepo.ListByChild(child.ID)
+	require.NoError(t, err)
+	require.Len(t, txs, 2)
+	for _, tx := range txs {
+		assert.Equal(t, models.TransactionTypeChore, tx.TransactionType)
+	}
+
+	// The child sees the streak in their chore list and earnings
+	req = httptest.NewRequest("GET", "/api/child/chores/earnings", nil)
+	req = testutil.SetRequestContext(req, "child", child.ID, family.ID)
+	rr = httptest.NewRecorder()
+	handler.HandleChildEarnings(rr, req)
+	require.Equal(t, http.StatusOK, rr.Code)
+
+	var earnings struct {
+		Streaks []StreakResponse `json:"streaks"`
+	}
+	require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &earnings))
+	require.Len(t, earnings.Streaks, 1)
+	assert.Equal(t, "Make bed", earnings.Streaks[0].ChoreName)
+	assert.Equal(t, 3, earnings.Streaks[0].CurrentStreak)
+	assert.Equal(t, 3, earnings.Streaks[0].BestStreak)
+}
+
+func TestHandleSetStreakBonuses_OneTimeChore(t *testing.T) {
+	db := testutil.SetupTestDB(t)
+	family := testutil.CreateTestFamily(t, db)
+	parent := testutil.CreateTestParent(t, db, family.ID)
+
+	choreRepo := repositories.NewChoreRepo(db)
+	handler := NewHandler(choreRepo, repositories.NewChoreInstanceRepo(db), repositories.NewTransactionRepo(db), repositories.NewChildRepo(db))
+	handler.SetStreakRepo(repositories.NewChoreStreakRepo(db))
+
+	createdChore, err := choreRepo.Create(&models.Chore{
+		FamilyID:          family.ID,
+		CreatedByParentID: parent.ID,
+		Name:              "Clean garage",
+		RewardCents:       500,
+		Recurrence:        models.ChoreRecurrenceOneTime,
+		IsActive:          true,
+	})
+	require.NoError(t, err)
+
+	id := strconv.FormatInt(createdChore.ID, 10)
+	req := httptest.NewRequest("PUT", "/api/chores/"+id+"/streak-bonuses", bytes.NewBufferString(`{"bonuses":[{"streak_length":3,"bonus_cents":100}]}`))
+	req.SetPathValue("id", id)
+	req = testutil.SetRequestContext(req, "parent", parent.ID, family.ID)
+	rr := httptest.NewRecorder()
+	handler.HandleSetStreakBonuses(rr, req)
+
+	assert.Equal(t, http.StatusBadRequest, rr.Code)
+}
+
+func TestHandleCompleteChore_WithPhotos(t *testing.T) {
+	db := testutil.SetupTestDB(t)
+	family := testutil.CreateTestFamily(t, db)
+	parent := testutil.CreateTestParent(t, db, family.ID)
+	child := testutil.CreateTestChild(t, db, family.ID, "Alice")
+
+	choreRepo := repositories.NewChoreRepo(db)
+	instanceRepo := repositories.NewChoreInstanceRepo(db)
+	handler := NewHandler(choreRepo, instanceRepo, repositories.NewTransactionRepo(db), repositories.NewChildRepo(db))
+	store, err := storage.NewLocalStore(t.TempDir())
+	require.NoError(t, err)
+	handler.SetPhotoStore(store)
+
+	createdChore, err := choreRepo.Create(&models.Chore{
+		FamilyID:          family.ID,
+		CreatedByParentID: parent.ID,
+		Name:              "Clean room",
+		RewardCents:       200,
+		Recurrence:        models.ChoreRecurrenceOneTime,
+		IsActive:          true,
+	})
+	require.NoError(t, err)
+	inst, err := instanceRepo.CreateInstance(&models.ChoreInstance{
+		ChoreID:     createdChore.ID,
+		ChildID:     child.ID,
+		RewardCents: 200,
+		Status:      models.ChoreInstanceStatusAvailable,
+	})
+	require.NoError(t, err)
+
+	var body bytes.Buffer
+	mw := multipart.NewWriter(&body)
+	part, err := mw.CreateFormFile("photos", "room.png")
+	require.NoError(t, err)
+	_, err = part.Write(testPNG(t, 64, 48))
+	require.NoError(t, err)
+	require.NoError(t, mw.Close())
+
+	id := strconv.FormatInt(inst.ID, 10)
+	req := httptest.NewRequest("POST", "/api/child/chores/"+id+"/complete", &body)
+	req.Header.Set("Content-Type", mw.FormDataContentType())
+	req.SetPathValue("id", id)
+	req = testutil.SetRequestContext(req, "child", child.ID, family.ID)
+	rr := httptest.NewRecorder()
+	handler.HandleCompleteChore(rr, req)
+	require.Equal(t, http.StatusOK, rr.Code, rr.Body.String())
+
+	var completeResp struct {
+		Instance InstanceResponse `json:"instance"`
+	}
+	require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &completeResp))
+	require.Len(t, completeResp.Instance.Photos, 1)
+	assert.Equal(t, "image/png", completeResp.Instance.Photos[0].ContentType)
+
+	// The parent sees the photo on the pending list and can download it
+	req = httptest.NewRequest("GET", "/api/chores/pending", nil)
+	req = testutil.SetRequestContext(req, "parent", parent.ID, family.ID)
+	rr = httptest.NewRecorder()
+	handler.HandleListPending(rr, req)
+	require.Equal(t, http.StatusOK, rr.Code)
+
+	var pendingResp struct {
+		Instances []InstanceResponse `json:"instances"`
+	}
+	require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &pendingResp))
+	require.Len(t, pendingResp.Instances, 1)
+	require.Len(t, pendingResp.Instances[0].Photos, 1)
+	photoID := strconv.FormatInt(pendingResp.Instances[0].Photos[0].ID, 10)
+
+	req = httptest.NewRequest("GET", "/api/chore-photos/"+photoID+"/thumbnail", nil)
+	req.SetPathValue("id", photoID)
+	req = testutil.SetRequestContext(req, "parent", parent.ID, family.ID)
+	rr = httptest.NewRecorder()
+	handler.HandleGetPhotoThumbnail(rr, req)
+	require.Equal(t, http.StatusOK, rr.Code)
+	assert.Equal(t, "image/jpeg", rr.Header().Get("Content-Type"))
+
+	// Another family's parent cannot
+	otherFamily := testutil.CreateTestFamily(t, db)
+	otherParent := testutil.CreateTestParent(t, db, otherFamily.ID)
+	req = httptest.NewRequest("GET", "/api/chore-photos/"+photoID, nil)
+	req.SetPathValue("id", photoID)
+	req = testutil.SetRequestContext(req, "parent", otherParent.ID, otherFamily.ID)
+	rr = httptest.NewRecorder()
+	handler.HandleGetPhoto(rr, req)
+	assert.Equal(t, http.StatusNotFound, rr.Code)
+
+	// Approval cleans up the photos
+	req = httptest.NewRequest("POST", "/api/chore-instances/"+id+"/approve", nil)
+	req.SetPathValue("id", id)
+	req = testutil.SetRequestContext(req, "parent", parent.ID, family.ID)
+	rr = httptest.NewRecorder()
+	handler.HandleApprove(rr, req)
+	require.Equal(t, http.StatusOK, rr.Code)
+
+	photos, err := instanceRepo.ListPhotos([]int64{inst.ID})
+	require.NoError(t, err)
+	assert.Empty(t, photos[inst.ID])
+}
+
+// failingStore is a storage.Store whose uploads fail.
+type failingStore struct {
+	storage.Store
+}
+
+func (failingStore) Put(ctx context.Context, key string, body io.Reader, size int64, contentType string) error {
+	return errors.New("storage unavailable")
+}
+
+func TestHandleCompleteChore_PhotoUploadFailsLeavesInstanceAvailable(t *testing.T) {
+	db := testutil.SetupTestDB(t)
+	family := testutil.CreateTestFamily(t, db)
+	parent := testutil.CreateTestParent(t, db, family.ID)
+	child := testutil.CreateTestChild(t, db, family.ID, "Alice")
+
+	choreRepo := repositories.NewChoreRepo(db)
+	instanceRepo := repositories.NewChoreInstanceRepo(db)
+	handler := NewHandler(choreRepo, instanceRepo, repositories.NewTransactionRepo(db), repositories.NewChildRepo(db))
+	store, err := storage.NewLocalStore(t.TempDir())
+	require.NoError(t, err)
+
+	createdChore, err := choreRepo.Create(&models.Chore{
+		FamilyID:          family.ID,
+		CreatedByParentID: parent.ID,
+		Name:              "Clean room",
+		RewardCents:       200,
+		Recurrence:        models.ChoreRecurrenceOneTime,
+		IsActive:          true,
+	})
+	require.NoError(t, err)
+	inst, err := instanceRepo.CreateInstance(&models.ChoreInstance{
+		ChoreID:     createdChore.ID,
+		ChildID:     child.ID,
+		RewardCents: 200,
+		Status:      models.ChoreInstanceStatusAvailable,
+	})
+	require.NoError(t, err)
+
+	// A photo left from an earlier, rejected completion
+	old := models.ChorePhoto{ChoreInstanceID: inst.ID, StorageKey: "chore-photos/old", ThumbnailKey: "chore-photos/old_thumb.jpg", ContentType: "image/png", SizeBytes: 4}
+	for _, key := range []string{old.StorageKey, old.ThumbnailKey} {
+		require.NoError(t, store.Put(context.Background(), key, strings.NewReader("data"), 4, "image/png"))
+	}
+	require.NoError(t, instanceRepo.CreatePhotos([]models.ChorePhoto{old}))
+
+	complete := func() *httptest.ResponseRecorder {
+		var body bytes.Buffer
+		mw := multipart.NewWriter(&body)
+		part, err := mw.CreateFormFile("photos", "room.png")
+		require.NoError(t, err)
+		_, err = part.Write(testPNG(t, 64, 48))
+		require.NoError(t, err)
+		require.NoError(t, mw.Close())
+
+		id := strconv.FormatInt(inst.ID, 10)
+		req := httptest.NewRequest("POST", "/api/child/chores/"+id+"/complete", &body)
+		req.Header.Set("Content-Type", mw.FormDataContentType())
+		req.SetPathValue("id", id)
+		req = testutil.SetRequestContext(req, "child", child.ID, family.ID)
+		rr := httptest.NewRecorder()
+		handler.HandleCompleteChore(rr, req)
+		return rr
+	}
+
+	handler.SetPhotoStore(failingStore{store})
+	rr := complete()
+	assert.Equal(t, http.StatusInternalServerError, rr.Code)
+	assert.Contains(t, rr.Body.String(), "photo_upload_failed")
+
+	got, err := instanceRepo.GetByID(inst.ID)
+	require.NoError(t, err)
+	assert.Equal(t, models.ChoreInstanceStatusAvailable, got.Status)
+	photos, err := instanceRepo.ListPhotos([]int64{inst.ID})
+	require.NoError(t, err)
+	require.Len(t, photos[inst.ID], 1)
+	assert.Equal(t, old.StorageKey, photos[inst.ID][0].StorageKey)
+
+	// Once storage works again the child can retry, and the old photo is replaced
+	handler.SetPhotoStore(store)
+	rr = complete()
+	require.Equal(t, http.StatusOK, rr.Code, rr.Body.String())
+
+	photos, err = instanceRepo.ListPhotos([]int64{inst.ID})
+	require.NoError(t, err)
+	require.Len(t, photos[inst.ID], 1)
+	assert.NotEqual(t, old.StorageKey, photos[inst.ID][0].StorageKey)
+	_, err = store.Get(context.Background(), old.StorageKey)
+	assert.ErrorIs(t, err, storage.ErrNotFound)
+}
+
+func TestHandleCompleteChore_InvalidPhoto(t *testing.T) {
+	db := testutil.SetupTestDB(t)
+	family := testutil.CreateTestFamily(t, db)
+	child := testutil.CreateTestChild(t, db, family.ID, "Alice")
+
+	handler := NewHandler(
+		repositories.NewChoreRepo(db),
+		repositories.NewChoreInstanceRepo(db),
+		repositories.NewTransactionRepo(db),
+		repositories.NewChildRepo(db),
+	)
+	store, err := storage.NewLocalStore(t.TempDir())
+	require.NoError(t, err)
+	handler.SetPhotoStore(store)
+
+	var body bytes.Buffer
+	mw := multipart.NewWriter(&body)
+	part, err := mw.CreateFormFile("photos", "notes.txt")
+	require.NoError(t, err)
+	_, err = part.Write([]byte("I cleaned it, promise"))
+	require.NoError(t, err)
+	require.NoError(t, mw.Close())
+
+	req := httptest.NewRequest("POST", "/api/child/chores/1/complete", &body)
+	req.Header.Set("Content-Type", mw.FormDataContentType())
+	req.SetPathValue("id", "1")
+	req = testutil.SetRequestContext(req, "child", child.ID, family.ID)
+	rr := httptest.NewRecorder()
+	handler.HandleCompleteChore(rr, req)
+	assert.Equal(t, http.StatusBadRequest, rr.Code)
+	assert.Contains(t, rr.Body.String(), "invalid_photo")
+}
+
+func TestHandleSetRotation_RemovingCurrentChildPassesTurn(t *testing.T) {
+	db := testutil.SetupTestDB(t)
+	family := testutil.CreateTestFamily(t, db)
+	parent := testutil.CreateTestParent(t, db, family.ID)
+	alice := testutil.CreateTestChild(t, db, family.ID, "Alice")
+	bob := testutil.CreateTestChild(t, db, family.ID, "Bob")
+	carol := testutil.CreateTestChild(t, db, family.ID, "Carol")
+
+	choreRepo := repositories.NewChoreRepo(db)
+	handler := NewHandler(choreRepo, repositories.NewChoreInstanceRepo(db), repositories.NewTransactionRepo(db), repositories.NewChildRepo(db))
+
+	body := fmt.Sprintf(`{"name":"Dishes","reward_cents":100,"recurrence":"weekly","day_of_week":1,"is_rotation":true,"child_ids":[%d,%d,%d]}`, alice.ID, bob.ID, carol.ID)
+	req := httptest.NewRequest("POST", "/api/chores", bytes.NewBufferString(body))
+	req = testutil.SetRequestContext(req, "parent", parent.ID, family.ID)
+	rr := httptest.NewRecorder()
+	handler.HandleCreateChore(rr, req)
+	require.Equal(t, http.StatusCreated, rr.Code)
+
+	var created struct {
+		Chore ChoreResponse `json:"chore"`
+	}
+	require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &created))
+	assert.True(t, created.Chore.IsRotation)
+
+	// Bob has this week's turn
+	require.NoError(t, choreRepo.SetRotationChild(created.Chore.ID, bob.ID))
+
+	id := strconv.FormatInt(created.Chore.ID, 10)
+	body = fmt.Sprintf(`{"child_ids":[%d,%d]}`, carol.ID, alice.ID)
+	req = httptest.NewRequest("PUT", "/api/chores/"+id+"/rotation", bytes.NewBufferString(body))
+	req.SetPathValue("id", id)
+	req = testutil.SetRequestContext(req, "parent", parent.ID, family.ID)
+	rr = httptest.NewRecorder()
+	handler.HandleSetRotation(rr, req)
+	require.Equal(t, http.StatusOK, rr.Code, rr.Body.String())
+
+	var resp struct {
+		Rotation RotationResponse `json:"rotation"`
+	}
+	require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &resp))
+	require.Len(t, resp.Rotation.Children, 2)
+	assert.Equal(t, carol.ID, resp.Rotation.Children[0].ChildID)
+	assert.Equal(t, alice.ID, resp.Rotation.Children[1].ChildID)
+	// Carol followed Bob, so it's still her turn next
+	require.NotNil(t, resp.Rotation.NextChildID)
+	assert.Equal(t, carol.ID, *resp.Rotation.NextChildID)
+
+	// A rotation cannot also be a bounty
+	body = `{"is_bounty":true}`
+	req = httptest.NewRequest("PUT", "/api/chores/"+id, bytes.NewBufferString(body))
+	req.SetPathValue("id", id)
+	req = testutil.SetRequestContext(req, "parent", parent.ID, family.ID)
+	rr = httptest.NewRecorder()
+	handler.HandleUpdateChore(rr, req)
+	assert.Equal(t, http.StatusBadRequest, rr.Code)
+}
+
+func TestHandleCreateChore_RotationRequiresRecurring(t *testing.T) {
+	db := testutil.SetupTestDB(t)
+	family := testutil.CreateTestFamily(t, db)
+	parent := testutil.CreateTestParent(t, db, family.ID)
+	child := testutil.CreateTestChild(t, db, family.ID, "Alice")
+
+	handler := NewHandler(
+		repositories.NewChoreRepo(db),
+		repositories.NewChoreInstanceRepo(db),
+		repositories.NewTransactionRepo(db),
+		repositories.NewChildRepo(db),
+	)
+
+	body := fmt.Sprintf(`{"name":"Dishes","reward_cents":100,"recurrence":"one_time","is_rotation":true,"child_ids":[%d]}`, child.ID)
+	req := httptest.NewRequest("POST", "/api/chores", bytes.NewBufferString(body))
+	req = testutil.SetRequestContext(req, "parent", parent.ID, family.ID)
+	rr := httptest.NewRecorder()
+	handler.HandleCreateChore(rr, req)
+	assert.Equal(t, http.StatusBadRequest, rr.Code)
+}
+
+func TestHandleChildListChores_ShowsPenalty(t *testing.T) {
+	db := testutil.SetupTestDB(t)
+	family := testutil.CreateTestFamily(t, db)
+	parent := testutil.CreateTestParent(t, db, family.ID)
+	child := testutil.CreateTestChild(t, db, family.ID, "Alice")
+
+	handler := NewHandler(
+		repositories.NewChoreRepo(db),
+		repositories.NewChoreInstanceRepo(db),
+		repositories.NewTransactionRepo(db),
+		repositories.NewChildRepo(db),
+	)
+
+	body := fmt.Sprintf(`{"name":"Make bed","reward_cents":50,"recurrence":"one_time","penalty_cents":25,"child_ids":[%d]}`, child.ID)
+	req := httptest.NewRequest("POST", "/api/chores", bytes.NewBufferString(body))
+	req = testutil.SetRequestContext(req, "parent", parent.ID, family.ID)
+	rr := httptest.NewRecorder()
+	handler.HandleCreateChore(rr, req)
+	require.Equal(t, http.StatusCreated, rr.Code)
+
+	req = httptest.NewRequest("GET", "/api/child/chores", nil)
+	req = testutil.SetRequestContext(req, "child", child.ID, family.ID)
+	rr = httptest.NewRecorder()
+	handler.HandleChildListChores(rr, req)
+	require.Equal(t, http.StatusOK, rr.Code)
+
+	var resp struct {
+		Available []InstanceResponse `json:"available"`
+	}
+	require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &resp))
+	require.Len(t, resp.Available, 1)
+	assert.Equal(t, 25, resp.Available[0].PenaltyCents)
+
+	// Bounties are optional, so they cannot carry a penalty
+	body = fmt.Sprintf(`{"name":"Wash car","reward_cents":500,"recurrence":"one_time","is_bounty":true,"penalty_cents":25,"child_ids":[%d]}`, child.ID)
+	req = httptest.NewRequest("POST", "/api/chores", bytes.NewBufferString(body))
+	req = testutil.SetRequestContext(req, "parent", parent.ID, family.ID)
+	rr = httptest.NewRecorder()
+	handler.HandleCreateChore(rr, req)
+	assert.Equal(t, http.StatusBadRequest, rr.Code)
+}
+
+func TestHandleApprove_RatingAndPartialCredit(t *testing.T) {
+	db := testutil.SetupTestDB(t)
+	family := testutil.CreateTestFamily(t, db)
+	parent := testutil.CreateTestParent(t, db, family.ID)
+	child := testutil.CreateTestChild(t, db, family.ID, "Alice")
+
+	choreRepo := repositories.NewChoreRepo(db)
+	instanceRepo := repositories.NewChoreInstanceRepo(db)
+	handler := NewHandler(choreRepo, instanceRepo, repositories.NewTransactionRepo(db), repositories.NewChildRepo(db))
+
+	createdChore, err := choreRepo.Create(&models.Chore{
+		FamilyID:          family.ID,
+		CreatedByParentID: parent.ID,
+		Name:              "Clean room",
+		RewardCents:       400,
+		Recurrence:        models.ChoreRecurrenceOneTime,
+		IsActive:          true,
+	})
+	require.NoError(t, err)
+
+	approve := func(body string) *httptest.ResponseRecorder {
+		inst, err := instanceRepo.CreateInstance(&models.ChoreInstance{
+			ChoreID:     createdChore.ID,
+			ChildID:     child.ID,
+			RewardCents: 400,
+			Status:      models.ChoreInstanceStatusPendingApproval,
+		})
+		require.NoError(t, err)
+
+		id := strconv.FormatInt(inst.ID, 10)
+		req := httptest.NewRequest("POST", "/api/chore-instances/"+id+"/approve", bytes.NewBufferString(body))
+		req.SetPathValue("id", id)
+		req = testutil.SetRequestContext(req, "parent", parent.ID, family.ID)
+		rr := httptest.NewRecorder()
+		handler.HandleApprove(rr, req)
+		return rr
+	}
+
+	// Half done: 2 stars, half the reward
+	rr := approve(`{"rating":2,"payout_percent":50}`)
+	require.Equal(t, http.StatusOK, rr.Code, rr.Body.String())
+	var resp struct {
+		Instance   InstanceResponse `json:"instance"`
+		NewBalance int64            `json:"new_balance"`
+	}
+	require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &resp))
+	require.NotNil(t, resp.Instance.Rating)
+	assert.Equal(t, 2, *resp.Instance.Rating)
+	require.NotNil(t, resp.Instance.PaidCents)
+	assert.Equal(t, 200, *resp.Instance.PaidCents)
+	assert.Equal(t, int64(200), resp.NewBalance)
+
+	// Done well: 5 stars and a bonus
+	rr = approve(`{"rating":5,"bonus_cents":100}`)
+	require.Equal(t, http.StatusOK, rr.Code)
+	require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &resp))
+	assert.Equal(t, int64(700), resp.NewBalance)
+
+	// No body approves at the full reward, unrated
+	rr = approve("")
+	require.Equal(t, http.StatusOK, rr.Code)
+
+	rr = approve(`{"rating":9}`)
+	assert.Equal(t, http.StatusBadRequest, rr.Code)
+
+	req := httptest.NewRequest("GET", "/api/child/chores/earnings", nil)
+	req = testutil.SetRequestContext(req, "child", child.ID, family.ID)
+	rr = httptest.NewRecorder()
+	handler.HandleChildEarnings(rr, req)
+	require.Equal(t, http.StatusOK, rr.Code)
+
+	var earnings struct {
+		TotalEarnedCents int64    `json:"total_earned_cents"`
+		AverageRating    *float64 `json:"average_rating"`
+		RatedCount       int64    `json:"rated_count"`
+	}
+	require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &earnings))
+	assert.Equal(t, int64(1100), earnings.TotalEarnedCents)
+	require.NotNil(t, earnings.AverageRating)
+	assert.InDelta(t, 3.5, *earnings.AverageRating, 0.001)
+	assert.Equal(t, int64(2), earnings.RatedCount)
+}
+
+func TestHandleRedo_LimitAndHistory(t *testing.T) {
+	db := testutil.SetupTestDB(t)
+	family := testutil.CreateTestFamily(t, db)
+	parent := testutil.CreateTestParent(t, db, family.ID)
+	child := testutil.CreateTestChild(t, db, family.ID, "Alice")
+
+	choreRepo := repositories.NewChoreRepo(db)
+	instanceRepo := repositories.NewChoreInstanceRepo(db)
+	handler := NewHandler(choreRepo, instanceRepo, repositories.NewTransactionRepo(db), repositories.NewChildRepo(db))
+
+	maxRedos := 1
+	createdChore, err := choreRepo.Create(&models.Chore{
+		FamilyID:          family.ID,
+		CreatedByParentID: parent.ID,
+		Name:              "Clean room",
+		RewardCents:       400,
+		Recurrence:        models.ChoreRecurrenceOneTime,
+		IsActive:          true,
+		MaxRedos:          &maxRedos,
+	})
+	require.NoError(t, err)
+	inst, err := instanceRepo.CreateInstance(&models.ChoreInstance{
+		ChoreID:     createdChore.ID,
+		ChildID:     child.ID,
+		RewardCents: 400,
+		Status:      models.ChoreInstanceStatusAvailable,
+	})
+	require.NoError(t, err)
+	id := strconv.FormatInt(inst.ID, 10)
+
+	redo := func(body string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest("POST", "/api/chore-instances/"+id+"/redo", bytes.NewBufferString(body))
+		req.SetPathValue("id", id)
+		req = testutil.SetRequestContext(req, "parent", parent.ID, family.ID)
+		rr := httptest.NewRecorder()
+		handler.HandleRedo(rr, req)
+		return rr
+	}
+
+	require.NoError(t, instanceRepo.MarkComplete(inst.ID, child.ID))
+
+	// Feedback is required
+	rr := redo(`{"feedback":"  "}`)
+	assert.Equal(t, http.StatusBadRequest, rr.Code)
+
+	rr = redo(`{"feedback":"Clothes are still on the floor"}`)
+	require.Equal(t, http.StatusOK, rr.Code, rr.Body.String())
+	var resp struct {
+		Instance InstanceResponse `json:"instance"`
+	}
+	require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &resp))
+	assert.Equal(t, models.ChoreInstanceStatusAvailable, resp.Instance.Status)
+	assert.Equal(t, 1, resp.Instance.RedoCount)
+
+	// The child sees the feedback on the available chore
+	req := httptest.NewRequest("GET", "/api/child/chores", nil)
+	req = testutil.SetRequestContext(req, "child", child.ID, family.ID)
+	rr = httptest.NewRecorder()
+	handler.HandleChildListChores(rr, req)
+	require.Equal(t, http.StatusOK, rr.Code)
+	assert.Contains(t, rr.Body.String(), "Clothes are still on the floor")
+
+	// Only one redo allowed
+	require.NoError(t, instanceRepo.MarkComplete(inst.ID, child.ID))
+	rr = redo(`{"feedback":"Bed isn't made"}`)
+	assert.Equal(t, http.StatusConflict, rr.Code)
+
+	req = httptest.NewRequest("GET", "/api/chore-instances/"+id+"/history", nil)
+	req.SetPathValue("id", id)
+	req = testutil.SetRequestContext(req, "child", child.ID, family.ID)
+	rr = httptest.NewRecorder()
+	handler.HandleListReviewRounds(rr, req)
+	require.Equal(t, http.StatusOK, rr.Code)
+
+	var history struct {
+		Rounds    []ReviewRoundResponse `json:"rounds"`
+		RedoCount int                   `json:"redo_count"`
+	}
+	require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &history))
+	require.Len(t, history.Rounds, 1)
+	assert.Equal(t, models.ChoreReviewOutcomeRedo, history.Rounds[0].Outcome)
+	assert.Equal(t, "Clothes are still on the floor", *history.Rounds[0].Feedback)
+	assert.Equal(t, 1, history.RedoCount)
+}
+
+func TestHandleUpdateChore_ClearsMaxRedos(t *testing.T) {
+	db := testutil.SetupTestDB(t)
+	family := testutil.CreateTestFamily(t, db)
+	parent := testutil.CreateTestParent(t, db, family.ID)
+
+	choreRepo := repositories.NewChoreRepo(db)
+	handler := NewHandler(choreRepo, repositories.NewChoreInstanceRepo(db), repositories.NewTransactionRepo(db), repositories.NewChildRepo(db))
+
+	maxRedos := 2
+	created, err := choreRepo.Create(&models.Chore{
+		FamilyID:          family.ID,
+		CreatedByParentID: parent.ID,
+		Name:              "Clean room",
+		RewardCents:       400,
+		Recurrence:        models.ChoreRecurrenceOneTime,
+		IsActive:          true,
+		MaxRedos:          &maxRedos,
+	})
+	require.NoError(t, err)
+	id := strconv.FormatInt(created.ID, 10)
+
+	update := func(body string) {
+		t.Helper()
+		req := httptest.NewRequest("PUT", "/api/chores/"+id, bytes.NewBufferString(body))
+		req.SetPathValue("id", id)
+		req = testutil.SetRequestContext(req, "parent", parent.ID, family.ID)
+		rr := httptest.NewRecorder()
+		handler.HandleUpdateChore(rr, req)
+		require.Equal(t, http.StatusOK, rr.Code, rr.Body.String())
+	}
+
+	// Leaving max_redos out keeps the limit
+	update(`{"name":"Tidy room"}`)
+	fetched, err := choreRepo.GetByID(created.ID)
+	require.NoError(t, err)
+	require.NotNil(t, fetched.MaxRedos)
+	assert.Equal(t, 2, *fetched.MaxRedos)
+
+	// An explicit null clears it
+	update(`{"max_redos":null}`)
+	fetched, err = choreRepo.GetByID(created.ID)
+	require.NoError(t, err)
+	assert.Nil(t, fetched.MaxRedos)
+}
+
+func TestHandleRedo_BountyNotAllowed(t *testing.T) {
+	db := testutil.SetupTestDB(t)
+	family := testutil.CreateTestFamily(t, db)
+	parent := testutil.CreateTestParent(t, db, family.ID)
+	child := testutil.CreateTestChild(t, db, family.ID, "Alice")
+
+	choreRepo := repositories.NewChoreRepo(db)
+	instanceRepo := repositories.NewChoreInstanceRepo(db)
+	handler := NewHandler(choreRepo, instanceRepo, repositories.NewTransactionRepo(db), repositories.NewChildRepo(db))
+
+	createdChore, err := choreRepo.Create(&models.Chore{
+		FamilyID:          family.ID,
+		CreatedByParentID: parent.ID,
+		Name:              "Wash car",
+		RewardCents:       500,
+		Recurrence:        models.ChoreRecurrenceOneTime,
+		IsActive:          true,
+		IsBounty:          true,
+	})
+	require.NoError(t, err)
+	inst, err := instanceRepo.CreateInstance(&models.ChoreInstance{
+		ChoreID:     createdChore.ID,
+		ChildID:     child.ID,
+		RewardCents: 500,
+		Status:      models.ChoreInstanceStatusPendingApproval,
+	})
+	require.NoError(t, err)
+
+	id := strconv.FormatInt(inst.ID, 10)
+	req := httptest.NewRequest("POST", "/api/chore-instances/"+id+"/redo", bytes.NewBufferString(`{"feedback":"Missed a spot"}`))
+	req.SetPathValue("id", id)
+	req = testutil.SetRequestContext(req, "parent", parent.ID, family.ID)
+	rr := httptest.NewRecorder()
+	handler.HandleRedo(rr, req)
+	assert.Equal(t, http.StatusBadRequest, rr.Code)
+}
+
+func TestHandleBatchReview_PerItemOutcomes(t *testing.T) {
+	db := testutil.SetupTestDB(t)
+	family := testutil.CreateTestFamily(t, db)
+	parent := testutil.CreateTestParent(t, db, family.ID)
+	alice := testutil.CreateTestChild(t, db, family.ID, "Alice")
+	bob := testutil.CreateTestChild(t, db, family.ID, "Bob")
+
+	choreRepo := repositories.NewChoreRepo(db)
+	instanceRepo := repositories.NewChoreInstanceRepo(db)
+	handler := NewHandler(choreRepo, instanceRepo, repositories.NewTransactionRepo(db), repositories.NewChildRepo(db))
+
+	createdChore, err := choreRepo.Create(&models.Chore{
+		FamilyID:          family.ID,
+		CreatedByParentID: parent.ID,
+		Name:              "Feed the cat",
+		RewardCents:       300,
+		Recurrence:        models.ChoreRecurrenceOneTime,
+		IsActive:          true,
+	})
+	require.NoError(t, err)
+
+	newInstance := func(childID int64, status models.ChoreInstanceStatus) int64 {
+		inst, err := instanceRepo.CreateInstance(&models.ChoreInstance{
+			ChoreID:     createdChore.ID,
+			ChildID:     childID,
+			RewardCents: 300,
+			Status:      status,
+		})
+		require.NoError(t, err)
+		return inst.ID
+	}
+	approveID := newInstance(alice.ID, models.ChoreInstanceStatusPendingApproval)
+	rejectID := newInstance(bob.ID, models.ChoreInstanceStatusPendingApproval)
+	notPendingID := newInstance(alice.ID, models.ChoreInstanceStatusAvailable)
+
+	body := fmt.Sprintf(`{"items":[
+		{"id":%d,"action":"approve","rating":4},
+		{"id":%d,"action":"reject","reason":"Bowl is empty"},
+		{"id":%d,"action":"approve"},
+		{"id":999999,"action":"approve"}
+	]}`, approveID, rejectID, notPendingID)
+	req := httptest.NewRequest("POST", "/api/chore-instances/batch", bytes.NewBufferString(body))
+	req = testutil.SetRequestContext(req, "parent", parent.ID, family.ID)
+	rr := httptest.NewRecorder()
+	handler.HandleBatchReview(rr, req)
+	require.Equal(t, http.StatusOK, rr.Code, rr.Body.String())
+
+	var resp struct {
+		Results   []BatchReviewResult `json:"results"`
+		Succeeded int                 `json:"succeeded"`
+		Failed    int                 `json:"failed"`
+	}
+	require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &resp))
+	require.Len(t, resp.Results, 4)
+	assert.Equal(t, 2, resp.Succeeded)
+	assert.Equal(t, 2, resp.Failed)
+
+	assert.True(t, resp.Results[0].Success)
+	require.NotNil(t, resp.Results[0].NewBalance)
+	assert.Equal(t, int64(300), *resp.Results[0].NewBalance)
+	assert.Equal(t, models.ChoreInstanceStatusApproved, resp.Results[0].Instance.Status)
+
+	assert.True(t, resp.Results[1].Success)
+	assert.Equal(t, models.ChoreInstanceStatusAvailable, resp.Results[1].Instance.Status)
+
+	assert.False(t, resp.Results[2].Success)
+	assert.Equal(t, "invalid_status", resp.Results[2].Error)
+	assert.False(t, resp.Results[3].Success)
+	assert.Equal(t, "not_found", resp.Results[3].Error)
+
+	// Approving the same instance again does not pay twice
+	rr = httptest.NewRecorder()
+	req = httptest.NewRequest("POST", "/api/chore-instances/batch", bytes.NewBufferString(fmt.Sprintf(`{"items":[{"id":%d,"action":"approve"}]}`, approveID)))
+	req = testutil.SetRequestContext(req, "parent", parent.ID, family.ID)
+	handler.HandleBatchReview(rr, req)
+	require.Equal(t, http.StatusOK, rr.Code)
+	require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &resp))
+	assert.Equal(t, 0, resp.Succeeded)
+
+	updatedAlice, err := repositories.NewChildRepo(db).GetByID(alice.ID)
+	require.NoError(t, err)
+	assert.Equal(t, int64(300), updatedAlice.BalanceCents)
+}
+
+func TestHandleApprove_LateCompletionReduced(t *testing.T) {
+	db := testutil.SetupTestDB(t)
+	family := testutil.CreateTestFamily(t, db)
+	parent := testutil.CreateTestParent(t, db, family.ID)
+	child := testutil.CreateTestChild(t, db, family.ID, "Alice")
+
+	choreRepo := repositories.NewChoreRepo(db)
+	instanceRepo := repositories.NewChoreInstanceRepo(db)
+	handler := NewHandler(choreRepo, instanceRepo, repositories.NewTransactionRepo(db), repositories.NewChildRepo(db))
+
+	dueTime := "19:00"
+	latePercent := 50
+	createdChore, err := choreRepo.Create(&models.Chore{
+		FamilyID:          family.ID,
+		CreatedByParentID: parent.ID,
+		Name:              "Take out trash",
+		RewardCents:       200,
+		Recurrence:        models.ChoreRecurrenceWeekly,
+		IsActive:          true,
+		DueTime:           &dueTime,
+		LatePayoutPercent: &latePercent,
+	})
+	require.NoError(t, err)
+
+	dueAt := time.Now().Add(-time.Hour)
+	periodStart := time.Now().UTC().Truncate(24 * time.Hour)
+	inst, err := instanceRepo.CreateInstance(&models.ChoreInstance{
+		ChoreID:     createdChore.ID,
+		ChildID:     child.ID,
+		RewardCents: 200,
+		Status:      models.ChoreInstanceStatusAvailable,
+		PeriodStart: &periodStart,
+		DueAt:       &dueAt,
+	})
+	require.NoError(t, err)
+
+	// The child sees the chore as overdue
+	req := httptest.NewRequest("GET", "/api/child/chores", nil)
+	req = testutil.SetRequestContext(req, "child", child.ID, family.ID)
+	rr := httptest.NewRecorder()
+	handler.HandleChildListChores(rr, req)
+	require.Equal(t, http.StatusOK, rr.Code)
+	var list struct {
+		Available []InstanceResponse `json:"available"`
+	}
+	require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &list))
+	require.Len(t, list.Available, 1)
+	assert.True(t, list.Available[0].IsOverdue)
+
+	require.NoError(t, instanceRepo.MarkComplete(inst.ID, child.ID))
+
+	id := strconv.FormatInt(inst.ID, 10)
+	req = httptest.NewRequest("POST", "/api/chore-instances/"+id+"/approve", nil)
+	req.SetPathValue("id", id)
+	req = testutil.SetRequestContext(req, "parent", parent.ID, family.ID)
+	rr = httptest.NewRecorder()
+	handler.HandleApprove(rr, req)
+	require.Equal(t, http.StatusOK, rr.Code, rr.Body.String())
+
+	var resp struct {
+		Instance   InstanceResponse `json:"instance"`
+		NewBalance int64            `json:"new_balance"`
+	}
+	require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &resp))
+	assert.True(t, resp.Instance.CompletedLate)
+	require.NotNil(t, resp.Instance.PaidCents)
+	assert.Equal(t, 100, *resp.Instance.PaidCents)
+	assert.Equal(t, int64(100), resp.NewBalance)
+}
+
+// =====================================================
+// Tests for chore templates and import/export
+// =====================================================
+
+func TestHandleInstantiateTemplates(t *testing.T) {
+	db := testutil.SetupTestDB(t)
+	family := testutil.CreateTestFamily(t, db)
+	parent := testutil.CreateTestParent(t, db, family.ID)
+	alice := testutil.CreateTestChild(t, db, family.ID, "Alice")
+	bob := testutil.CreateTestChild(t, db, family.ID, "Bob")
+
+	choreRepo := repositories.NewChoreRepo(db)
+	handler := NewHandler(choreRepo, repositories.NewChoreInstanceRepo(db), repositories.NewTransactionRepo(db), repositories.NewChildRepo(db))
+
+	post := func(body string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest("POST", "/api/chore-templates/instantiate", strings.NewReader(body))
+		req = testutil.SetRequestContext(req, "parent", parent.ID, family.ID)
+		rr := httptest.NewRecorder()
+		handler.HandleInstantiateTemplates(rr, req)
+		return rr
+	}
+
+	body := fmt.Sprintf(`{"items":[
+		{"template_key":"make_bed","child_ids":[%d,%d]},
+		{"template_key":"mow_lawn","child_ids":[%d],"reward_cents":1500}
+	]}`, alice.ID, bob.ID, bob.ID)
+	rr := post(body)
+	require.Equal(t, http.StatusCreated, rr.Code, rr.Body.String())
+
+	var resp struct {
+		Chores []ChoreResponse `json:"chores"`
+	}
+	require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &resp))
+	require.Len(t, resp.Chores, 2)
+	assert.Equal(t, "Make your bed", resp.Chores[0].Name)
+	assert.Equal(t, "daily", resp.Chores[0].Recurrence)
+	assert.Len(t, resp.Chores[0].Assignments, 2)
+	assert.Equal(t, "Mow the lawn", resp.Chores[1].Name)
+	assert.Equal(t, 1500, resp.Chores[1].RewardCents)
+	require.NotNil(t, resp.Chores[1].DayOfWeek)
+	assert.Equal(t, "Bob", resp.Chores[1].Assignments[0].ChildName)
+
+	// An unknown template or a child from another family creates nothing
+	otherFamily := testutil.CreateTestFamily(t, db)
+	stranger := testutil.CreateTestChild(t, db, otherFamily.ID, "Stranger")
+	rr = post(fmt.Sprintf(`{"items":[{"template_key":"vacuum","child_ids":[%d]},{"template_key":"nope","child_ids":[%d]}]}`, alice.ID, alice.ID))
+	assert.Equal(t, http.StatusBadRequest, rr.Code)
+	rr = post(fmt.Sprintf(`{"items":[{"template_key":"vacuum","child_ids":[%d]}]}`, stranger.ID))
+	assert.Equal(t, http.StatusNotFound, rr.Code)
+
+	chores, err := choreRepo.ListByFamily(family.ID)
+	require.NoError(t, err)
+	assert.Len(t, chores, 2)
+}
+
+func TestHandleListTemplates_ByChildAge(t *testing.T) {
+	db := testutil.SetupTestDB(t)
+	family := testutil.CreateTestFamily(t, db)
+	parent := testutil.CreateTestParent(t, db, family.ID)
+	child := testutil.CreateTestChild(t, db, family.ID, "Alice")
+
+	childRepo := repositories.NewChildRepo(db)
+	birthdate := time.Now().AddDate(-4, 0, -1)
+	require.NoError(t, childRepo.UpdateBirthdate(child.ID, &birthdate))
+	handler := NewHandler(repositories.NewChoreRepo(db), repositories.NewChoreInstanceRepo(db), repositories.NewTransactionRepo(db), childRepo)
+
+	req := httptest.NewRequest("GET", "/api/chore-templates?child_id="+strconv.FormatInt(child.ID, 10), nil)
+	req = testutil.SetRequestContext(req, "parent", parent.ID, family.ID)
+	rr := httptest.NewRecorder()
+	handler.HandleListTemplates(rr, req)
+	require.Equal(t, http.StatusOK, rr.Code)
+
+	var resp struct {
+		Templates []ChoreTemplate `json:"templates"`
+	}
+	require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &resp))
+	assert.Equal(t, templatesForAge(4), resp.Templates)
+}
+
+func TestHandleExportImportChores(t *testing.T) {
+	db := testutil.SetupTestDB(t)
+	family := testutil.CreateTestFamily(t, db)
+	parent := testutil.CreateTestParent(t, db, family.ID)
+	alice := testutil.CreateTestChild(t, db, family.ID, "Alice")
+	bob := testutil.CreateTestChild(t, db, family.ID, "Bob")
+
+	choreRepo := repositories.NewChoreRepo(db)
+	handler := NewHandler(choreRepo, repositories.NewChoreInstanceRepo(db), repositories.NewTransactionRepo(db), repositories.NewChildRepo(db))
+
+	dueTime := "18:00"
+	require.NoError(t, choreRepo.CreateMany([]repositories.NewChore{
+		{Chore: &models.Chore{FamilyID: family.ID, CreatedByParentID: parent.ID, Name: "Dishes", RewardCents: 100, Recurrence: models.ChoreRecurrenceDaily, IsActive: true, IsRotation: true, DueTime: &dueTime}, ChildIDs: []int64{bob.ID, alice.ID}},
+		{Chore: &models.Chore{FamilyID: family.ID, CreatedByParentID: parent.ID, Name: "Walk dog", RewardCents: 200, Recurrence: models.ChoreRecurrenceWeekly, DayOfWeek: intPtr(2), IsActive: true}, ChildIDs: []int64{bob.ID}},
+	}))
+
+	req := httptest.NewRequest("GET", "/api/chores/export", nil)
+	req = testutil.SetRequestContext(req, "parent", parent.ID, family.ID)
+	rr := httptest.NewRecorder()
+	handler.HandleExportChores(rr, req)
+	require.Equal(t, http.StatusOK, rr.Code)
+
+	var export ChoreExport
+	require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &export))
+	assert.Equal(t, ExportVersion, export.Version)
+	require.Len(t, export.Chores, 2)
+	assert.Equal(t, "Dishes", export.Chores[0].Name)
+	assert.Equal(t, []string{"Bob", "Alice"}, export.Chores[0].Children)
+	assert.True(t, export.Chores[0].IsRotation)
+	assert.Equal(t, "18:00", *export.Chores[0].DueTime)
+
+	// Import into a family that has an "alice" but no Bob
+	otherFamily := testutil.CreateTestFamily(t, db)
+	otherParent := testutil.CreateTestParent(t, db, otherFamily.ID)
+	otherAlice := testutil.CreateTestChild(t, db, otherFamily.ID, "alice")
+
+	req = httptest.NewRequest("POST", "/api/chores/import", bytes.NewReader(rr.Body.Bytes()))
+	req = testutil.SetRequestContext(req, "parent", otherParent.ID, otherFamily.ID)
+	rr = httptest.NewRecorder()
+	handler.HandleImportChores(rr, req)
+	require.Equal(t, http.StatusOK, rr.Code, rr.Body.String())
+
+	var resp struct {
+		Chores            []ChoreResponse `json:"chores"`
+		Skipped           []SkippedChore  `json:"skipped"`
+		UnmatchedChildren []string        `json:"unmatched_children"`
+	}
+	require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &resp))
+	require.Len(t, resp.Chores, 1)
+	assert.Equal(t, "Dishes", resp.Chores[0].Name)
+	assert.Equal(t, otherFamily.ID, resp.Chores[0].FamilyID)
+	require.Len(t, resp.Chores[0].Assignments, 1)
+	assert.Equal(t, otherAlice.ID, resp.Chores[0].Assignments[0].ChildID)
+	require.Len(t, resp.Skipped, 1)
+	assert.Equal(t, "Walk dog", resp.Skipped[0].Name)
+	assert.Equal(t, []string{"Bob"}, resp.UnmatchedChildren)
+
+	chores, err := choreRepo.ListByFamily(otherFamily.ID)
+	require.NoError(t, err)
+	require.Len(t, chores, 1)
+	assert.Equal(t, "18:00", *chores[0].DueTime)
+}
+
+func TestHandleImportChores_InvalidChoreImportsNothing(t *testing.T) {
+	db := testutil.SetupTestDB(t)
+	family := testutil.CreateTestFamily(t, db)
+	parent := testutil.CreateTestParent(t, db, family.ID)
+	testutil.CreateTestChild(t, db, family.ID, "Alice")
+
+	choreRepo := repositories.NewChoreRepo(db)
+	handler := NewHandler(choreRepo, repositories.NewChoreInstanceRepo(db), repositories.NewTransactionRepo(db), repositories.NewChildRepo(db))
+
+	body := `{"version":1,"chores":[
+		{"name":"Dishes","reward_cents":100,"recurrence":"daily","is_active":true,"children":["Alice"]},
+		{"name":"Walk dog","reward_cents":200,"recurrence":"weekly","is_active":true,"children":["Alice"]}
+	]}`
+	req := httptest.NewRequest("POST", "/api/chores/import", strings.NewReader(body))
+	req = testutil.SetRequestContext(req, "parent", parent.ID, family.ID)
+	rr := httptest.NewRecorder()
+	handler.HandleImportChores(rr, req)
+	assert.Equal(t, http.StatusBadRequest, rr.Code)
+	assert.Contains(t, rr.Body.String(), "Walk dog")
+
+	// Import rejects the chore with the same code as creating it would
+	var importErr ErrorResponse
+	require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &importErr))
+	req = httptest.NewRequest("POST", "/api/chores", strings.NewReader(`{"name":"Walk dog","reward_cents":200,"recurrence":"weekly","child_ids":[1]}`))
+	req = testutil.SetRequestContext(req, "parent", parent.ID, family.ID)
+	rr = httptest.NewRecorder()
+	handler.HandleCreateChore(rr, req)
+	assert.Equal(t, http.StatusBadRequest, rr.Code)
+	var createErr ErrorResponse
+	require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &createErr))
+	assert.Equal(t, "invalid_recurrence", createErr.Error)
+	assert.Equal(t, createErr.Error, importErr.Error)
+
+	chores, err := choreRepo.ListByFamily(family.ID)
+	require.NoError(t, err)
+	assert.Empty(t, chores)
+}
+
+func TestHandleImportChores_AmbiguousChildName(t *testing.T) {
+	db := testutil.SetupTestDB(t)
+	family := testutil.CreateTestFamily(t, db)
+	parent := testutil.CreateTestParent(t, db, family.ID)
+	testutil.CreateTestChild(t, db, family.ID, "Sam")
+	testutil.CreateTestChild(t, db, family.ID, "sam")
+	testutil.CreateTestChild(t, db, family.ID, "Alice")
+
+	choreRepo := repositories.NewChoreRepo(db)
+	handler := NewHandler(choreRepo, repositories.NewChoreInstanceRepo(db), repositories.NewTransactionRepo(db), repositories.NewChildRepo(db))
+
+	body := `{"version":1,"chores":[
+		{"name":"Dishes","reward_cents":100,"recurrence":"daily","is_active":true,"children":["Alice"]},
+		{"name":"Walk dog","reward_cents":200,"recurrence":"daily","is_active":true,"children":["Sam"]}
+	]}`
+	req := httptest.NewRequest("POST", "/api/chores/import", strings.NewReader(body))
+	req = testutil.SetRequestContext(req, "parent", parent.ID, family.ID)
+	rr := httptest.NewRecorder()
+	handler.HandleImportChores(rr, req)
+	assert.Equal(t, http.StatusBadRequest, rr.Code)
+
+	var errResp ErrorResponse
+	require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &errResp))
+	assert.Equal(t, "invalid_children", errResp.Error)
+	assert.Contains(t, errResp.Message, "Sam")
+
+	chores, err := choreRepo.ListByFamily(family.ID)
+	require.NoError(t, err)
+	assert.Empty(t, chores)
+}
+
+func TestHandleChoreAnalytics(t *testing.T) {
+	db := testutil.SetupTestDB(t)
+	family := testutil.CreateTestFamily(t, db)
+	parent := testutil.CreateTestParent(t, db, family.ID)
+	child := testutil.CreateTestChild(t, db, family.ID, "Alice")
+
+	choreRepo := repositories.NewChoreRepo(db)
+	instanceRepo := repositories.NewChoreInstanceRepo(db)
+	handler := NewHandler(choreRepo, instanceRepo, repositories.NewTransactionRepo(db), repositories.NewChildRepo(db))
+	handler.SetFamilyRepo(repositories.NewFamilyRepo(db))
+
+	createdChore, err := choreRepo.Create(&models.Chore{
+		FamilyID:          family.ID,
+		CreatedByParentID: parent.ID,
+		Name:              "Feed cat",
+		RewardCents:       100,
+		Recurrence:        models.ChoreRecurrenceDaily,
+		IsActive:          true,
+	})
+	require.NoError(t, err)
+	for _, status := range []models.ChoreInstanceStatus{models.ChoreInstanceStatusApproved, models.ChoreInstanceStatusExpired} {
+		start := time.Now().UTC().Truncate(24 * time.Hour)
+		if status == models.ChoreInstanceStatusExpired {
+			start = start.AddDate(0, 0, -1)
+		}
+		_, err := instanceRepo.CreateInstance(&models.ChoreInstance{
+			ChoreID:     createdChore.ID,
+			ChildID:     child.ID,
+			RewardCents: 100,
+			Status:      status,
+			PeriodStart: &start,
+		})
+		require.NoError(t, err)
+	}
+
+	req := httptest.NewRequest("GET", "/api/chores/analytics", nil)
+	req = testutil.SetRequestContext(req, "parent", parent.ID, family.ID)
+	rr := httptest.NewRecorder()
+	handler.HandleChoreAnalytics(rr, req)
+	require.Equal(t, http.StatusOK, rr.Code, rr.Body.String())
+
+	var resp struct {
+		ByChild []ChoreAnalytics `json:"by_child"`
+		ByChore []ChoreAnalytics `json:"by_chore"`
+	}
+	require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &resp))
+	require.Len(t, resp.ByChild, 1)
+	assert.Equal(t, child.ID, resp.ByChild[0].ChildID)
+	assert.Equal(t, int64(100), resp.ByChild[0].EarnedCents)
+	require.NotNil(t, resp.ByChild[0].CompletionRate)
+	assert.InDelta(t, 0.5, *resp.ByChild[0].CompletionRate, 1e-9)
+	require.Len(t, resp.ByChore, 1)
+	assert.Equal(t, "Feed cat", resp.ByChore[0].ChoreName)
+
+	// Children cannot view analytics, and bad ranges are rejected
+	req = httptest.NewRequest("GET", "/api/chores/analytics", nil)
+	req = testutil.SetRequestContext(req, "child", child.ID, family.ID)
+	rr = httptest.NewRecorder()
+	handler.HandleChoreAnalytics(rr, req)
+	assert.Equal(t, http.StatusForbidden, rr.Code)
+
+	req = httptest.NewRequest("GET", "/api/chores/analytics?from=2026-05-01&to=2026-04-01", nil)
+	req = testutil.SetRequestContext(req, "parent", parent.ID, family.ID)
+	rr = httptest.NewRecorder()
+	handler.HandleChoreAnalytics(rr, req)
+	assert.Equal(t, http.StatusBadRequest, rr.Code)
+}
@@ -0,0 +1,356 @@
+package chore
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"image"
+	"image/color"
+	_ "image/gif" // Register GIF decoding for uploads
+	"image/jpeg"
+	_ "image/png" // Register PNG decoding for uploads
+	"io"
+	"log"
+	"mime/multipart"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"bank-of-dad/internal/middleware"
+	"bank-of-dad/internal/storage"
+	"bank-of-dad/models"
+	"bank-of-dad/repositories"
+)
+
+const (
+	// MaxPhotosPerCompletion limits how many photos a child can attach when completing a chore.
+	MaxPhotosPerCompletion = 3
+	// MaxPhotoBytes limits the size of each uploaded photo.
+	MaxPhotoBytes = 5 << 20
+	// MaxPhotoPixels guards against images that are small on disk but huge once decoded.
+	MaxPhotoPixels = 40_000_000
+	// ThumbnailSize is the longest side of a photo thumbnail, in pixels.
+	ThumbnailSize = 320
+)
+
+// allowedPhotoTypes are the content types accepted for photo uploads, as sniffed from their bytes.
+var allowedPhotoTypes = map[string]bool{
+	"image/jpeg": true,
+	"image/png":  true,
+	"image/gif":  true,
+}
+
+// PhotoResponse represents an attached photo in API responses.
+type PhotoResponse struct {
+	ID           int64  `json:"id"`
+	ContentType  string `json:"content_type"`
+	SizeBytes    int64  `json:"size_bytes"`
+	URL          string `json:"url"`
+	ThumbnailURL string `json:"thumbnail_url"`
+}
+
+// photoUpload is a validated photo and its thumbnail, ready to store.
+type photoUpload struct {
+	contentType string
+	data        []byte
+	thumbnail   []byte
+}
+
+// SetPhotoStore sets the storage used for chore photo attachments.
+// Without one, completing a chore with photos is rejected.
+func (h *Handler) SetPhotoStore(store storage.Store) {
+	h.photoStore = store
+}
+
+// processPhoto validates an uploaded photo and renders its thumbnail.
+// Returns an error message if the photo is not acceptable.
+func processPhoto(data []byte) (*photoUpload, string) {
+	if len(data) == 0 {
+		return nil, "Photo is empty."
+	}
+	if len(data) > MaxPhotoBytes {
+		return nil, "Each photo must be 5 MB or smaller."
+	}
+	contentType := http.DetectContentType(data)
+	if !allowedPhotoTypes[contentType] {
+		return nil, "Photos must be JPEG, PNG or GIF images."
+	}
+
+	cfg, _, err := image.DecodeConfig(bytes.NewReader(data))
+	if err != nil {
+		return nil, "Photo could not be read."
+	}
+	if cfg.Width*cfg.Height > MaxPhotoPixels {
+		return nil, "Photo dimensions are too large."
+	}
+	img, _, err := image.Decode(bytes.NewReader(data))
+	if err != nil {
+		return nil, "Photo could not be read."
+	}
+
+	var thumb bytes.Buffer
+	if err := jpeg.Encode(&thumb, thumbnail(img, ThumbnailSize), &jpeg.Options{Quality: 80}); err != nil {
+		return nil, "Photo could not be read."
+	}
+	return &photoUpload{contentType: contentType, data: data, thumbnail: thumb.Bytes()}, ""
+}
+
+// thumbnail scales img down so its longest side is at most size pixels, averaging the source
+// pixels that fall into each thumbnail pixel. Smaller images are copied at their own size.
+func thumbnail(img image.Image, size int) image.Image {
+	b := img.Bounds()
+	w, h := b.Dx(), b.Dy()
+	tw, th := w, h
+	if w > size || h > size {
+		if w >= h {
+			tw, th = size, max(1, h*size/w)
+		} else {
+			tw, th = max(1, w*size/h), size
+		}
+	}
+
+	dst := image.NewRGBA(image.Rect(0, 0, tw, th))
+	for y := 0; y < th; y++ {
+		y0, y1 := b.Min.Y+y*h/th, b.Min.Y+max((y+1)*h/th, y*h/th+1)
+		for x := 0; x < tw; x++ {
+			x0, x1 := b.Min.X+x*w/tw, b.Min.X+max((x+1)*w/tw, x*w/tw+1)
+			var r, g, bl, a, n uint64
+			for sy := y0; sy < y1; sy++ {
+				for sx := x0; sx < x1; sx++ {
+					cr, cg, cb, ca := img.At(sx, sy).RGBA()
+					r, g, bl, a = r+uint64(cr), g+uint64(cg), bl+uint64(cb), a+uint64(ca)
+					n++
+				}
+			}
+			dst.Set(x, y, color.RGBA64{
+				R: uint16(r / n),
+				G: uint16(g / n),
+				B: uint16(bl / n),
+				A: uint16(a / n),
+			})
+		}
+	}
+	return dst
+}
+
+// readPhotos reads the "photos" files of a multipart completion request. Requests that are not
+// multipart carry no photos. Returns an error message if the upload is not acceptable.
+func readPhotos(w http.ResponseWriter, r *http.Request) ([]*photoUpload, string) {
+	if !strings.HasPrefix(r.Header.Get("Content-Type"), "multipart/form-data") {
+		return nil, ""
+	}
+
+	r.Body = http.MaxBytesReader(w, r.Body, MaxPhotosPerCompletion*MaxPhotoBytes+(1<<20))
+	if err := r.ParseMultipartForm(MaxPhotosPerCompletion * MaxPhotoBytes); err != nil {
+		return nil, "Photos are too large or the upload is malformed."
+	}
+	defer r.MultipartForm.RemoveAll() //nolint:errcheck // best-effort temp file cleanup
+
+	files := r.MultipartForm.File["photos"]
+	if len(files) > MaxPhotosPerCompletion {
+		return nil, fmt.Sprintf("At most %d photos can be attached.", MaxPhotosPerCompletion)
+	}
+
+	uploads := make([]*photoUpload, 0, len(files))
+	for _, fh := range files {
+		if fh.Size > MaxPhotoBytes {
+			return nil, "Each photo must be 5 MB or smaller."
+		}
+		data, err := readPart(fh)
+		if err != nil {
+			return nil, "Photo could not be read."
+		}
+		upload, errMsg := processPhoto(data)
+		if errMsg != "" {
+			return nil, errMsg
+		}
+		uploads = append(uploads, upload)
+	}
+	return uploads, ""
+}
+
+func readPart(fh *multipart.FileHeader) ([]byte, error) {
+	f, err := fh.Open()
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close() //nolint:errcheck // read-only
+	return io.ReadAll(io.LimitReader(f, MaxPhotoBytes+1))
+}
+
+// photoKey returns a new random storage key for one of an instance's photos.
+func photoKey(instanceID int64) (string, error) {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return "", fmt.Errorf("generate photo key: %w", err)
+	}
+	return fmt.Sprintf("chore-photos/%d/%s", instanceID, hex.EncodeToString(b)), nil
+}
+
+// storePhotos stores the uploads and returns their records, ready to be inserted for the
+// instance. If anything fails, the files stored so far are removed again.
+func (h *Handler) storePhotos(ctx context.Context, instanceID int64, uploads []*photoUpload) ([]models.ChorePhoto, error) {
+	photos := make([]models.ChorePhoto, 0, len(uploads))
+	for _, u := range uploads {
+		key, err := photoKey(instanceID)
+		if err != nil {
+			removeStoredPhotos(ctx, h.photoStore, photos)
+			return nil, err
+		}
+		photo := models.ChorePhoto{
+			ChoreInstanceID: instanceID,
+			StorageKey:      key,
+			ThumbnailKey:    key + "_thumb.jpg",
+			ContentType:     u.contentType,
+			SizeBytes:       int64(len(u.data)),
+		}
+		if err := h.photoStore.Put(ctx, photo.StorageKey, bytes.NewReader(u.data), int64(len(u.data)), u.contentType); err != nil {
+			removeStoredPhotos(ctx, h.photoStore, photos)
+			return nil, fmt.Errorf("store photo: %w", err)
+		}
+		photos = append(photos, photo)
+		if err := h.photoStore.Put(ctx, photo.ThumbnailKey, bytes.NewReader(u.thumbnail), int64(len(u.thumbnail)), "image/jpeg"); err != nil {
+			removeStoredPhotos(ctx, h.photoStore, photos)
+			return nil, fmt.Errorf("store thumbnail: %w", err)
+		}
+	}
+	return photos, nil
+}
+
+// removeStoredPhotos deletes photo files from storage, logging failures.
+func removeStoredPhotos(ctx context.Context, store storage.Store, photos []models.ChorePhoto) {
+	for _, p := range photos {
+		for _, key := range []string{p.StorageKey, p.ThumbnailKey} {
+			if err := store.Delete(ctx, key); err != nil {
+				log.Printf("Error deleting chore photo %s: %v", key, err)
+			}
+		}
+	}
+}
+
+// deletePhotos removes photos from storage and then their records.
+func deletePhotos(ctx context.Context, store storage.Store, repo *repositories.ChoreInstanceRepo, photos []models.ChorePhoto) error {
+	if store == nil || len(photos) == 0 {
+		return nil
+	}
+	removeStoredPhotos(ctx, store, photos)
+	ids := make([]int64, len(photos))
+	for i, p := range photos {
+		ids[i] = p.ID
+	}
+	return repo.DeletePhotos(ids)
+}
+
+// deleteInstancePhotos removes all photos attached to an instance, logging failures.
+func (h *Handler) deleteInstancePhotos(ctx context.Context, instanceID int64) {
+	if h.photoStore == nil {
+		return
+	}
+	byInstance, err := h.choreInstanceRepo.ListPhotos([]int64{instanceID})
+	if err == nil {
+		err = deletePhotos(ctx, h.photoStore, h.choreInstanceRepo, byInstance[instanceID])
+	}
+	if err != nil {
+		log.Printf("Error deleting photos for chore instance %d: %v", instanceID, err)
+	}
+}
+
+// photoResponses converts photo records into API responses with their download URLs.
+func photoResponses(photos []models.ChorePhoto) []PhotoResponse {
+	result := make([]PhotoResponse, len(photos))
+	for i, p := range photos {
+		url := "/api/chore-photos/" + strconv.FormatInt(p.ID, 10)
+		result[i] = PhotoResponse{
+			ID:           p.ID,
+			ContentType:  p.ContentType,
+			SizeBytes:    p.SizeBytes,
+			URL:          url,
+			ThumbnailURL: url + "/thumbnail",
+		}
+	}
+	return result
+}
+
+// HandleGetPhoto handles GET /api/chore-photos/{id}
+func (h *Handler) HandleGetPhoto(w http.ResponseWriter, r *http.Request) {
+	h.servePhoto(w, r, false)
+}
+
+// HandleGetPhotoThumbnail handles GET /api/chore-photos/{id}/thumbnail
+func (h *Handler) HandleGetPhotoThumbnail(w http.ResponseWriter, r *http.Request) {
+	h.servePhoto(w, r, true)
+}
+
+// servePhoto streams a photo or its thumbnail to a parent in the child's family or to the child
+// who attached it.
+func (h *Handler) servePhoto(w http.ResponseWriter, r *http.Request, thumb bool) {
+	if h.photoStore == nil {
+		writeJSON(w, http.StatusNotFound, ErrorResponse{Error: "not_found", Message: "Photo not found."})
+		return
+	}
+
+	photoID, err := strconv.ParseInt(r.PathValue("id"), 10, 64)
+	if err != nil {
+		writeJSON(w, http.StatusBadRequest, ErrorResponse{Error: "invalid_request", Message: "Invalid photo ID."})
+		return
+	}
+
+	photo, err := h.choreInstanceRepo.GetPhoto(photoID)
+	if err != nil {
+		writeJSON(w, http.StatusInternalServerError, ErrorResponse{Error: "internal_error", Message: "Failed to retrieve photo."})
+		return
+	}
+	if photo == nil {
+		writeJSON(w, http.StatusNotFound, ErrorResponse{Error: "not_found", Message: "Photo not found."})
+		return
+	}
+
+	instance, err := h.choreInstanceRepo.GetByID(photo.ChoreInstanceID)
+	if err != nil || instance == nil {
+		writeJSON(w, http.StatusInternalServerError, ErrorResponse{Error: "internal_error", Message: "Failed to retrieve instance."})
+		return
+	}
+	child, err := h.childRepo.GetByID(instance.ChildID)
+	if err != nil || child == nil {
+		writeJSON(w, http.StatusInternalServerError, ErrorResponse{Error: "internal_error", Message: "Failed to verify family ownership."})
+		return
+	}
+
+	allowed := false
+	switch middleware.GetUserType(r) {
+	case "parent":
+		allowed = child.FamilyID == middleware.GetFamilyID(r)
+	case "child":
+		allowed = child.ID == middleware.GetUserID(r)
+	}
+	if !allowed {
+		writeJSON(w, http.StatusNotFound, ErrorResponse{Error: "not_found", Message: "Photo not found."})
+		return
+	}
+
+	key, contentType := photo.StorageKey, photo.ContentType
+	if thumb {
+		key, contentType = photo.ThumbnailKey, "image/jpeg"
+	}
+	rc, err := h.photoStore.Get(r.Context(), key)
+	if errors.Is(err, storage.ErrNotFound) {
+		writeJSON(w, http.StatusNotFound, ErrorResponse{Error: "not_found", Message: "Photo not found."})
+		return
+	}
+	if err != nil {
+		writeJSON(w, http.StatusInternalServerError, ErrorResponse{Error: "internal_error", Message: "Failed to load photo."})
+		return
+	}
+	defer rc.Close() //nolint:errcheck // read-only
+
+	w.Header().Set("Content-Type", contentType)
+	w.Header().Set("Cache-Control", "private, max-age=3600")
+	w.Header().Set("X-Content-Type-Options", "nosniff")
+	w.WriteHeader(http.StatusOK)
+	if _, err := io.Copy(w, rc); err != nil {
+		log.Printf("Error streaming chore photo %d: %v", photo.ID, err)
+	}
+}
@@ -0,0 +1,83 @@
+package chore
+
+import (
+	"bytes"
+	"image"
+	"image/color"
+	"image/png"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// testPNG encodes a solid w x h PNG image.
+func testPNG(t *testing.T, w, h int) []byte {
+	t.Helper()
+	img := image.NewRGBA(image.Rect(0, 0, w, h))
+	for y := 0; y < h; y++ {
+		for x := 0; x < w; x++ {
+			img.Set(x, y, color.RGBA{R: 200, G: 100, B: 50, A: 255})
+		}
+	}
+	var buf bytes.Buffer
+	require.NoError(t, png.Encode(&buf, img))
+	return buf.Bytes()
+}
+
+func TestProcessPhoto_Valid(t *testing.T) {
+	upload, errMsg := processPhoto(testPNG(t, 800, 400))
+	require.Empty(t, errMsg)
+	assert.Equal(t, "image/png", upload.contentType)
+
+	thumb, format, err := image.Decode(bytes.NewReader(upload.thumbnail))
+	require.NoError(t, err)
+	assert.Equal(t, "jpeg", format)
+	assert.Equal(t, ThumbnailSize, thumb.Bounds().Dx())
+	assert.Equal(t, ThumbnailSize/2, thumb.Bounds().Dy())
+}
+
+func TestProcessPhoto_Rejected(t *testing.T) {
+	_, errMsg := processPhoto(nil)
+	assert.NotEmpty(t, errMsg)
+
+	_, errMsg = processPhoto([]byte("definitely not an image"))
+	assert.Equal(t, "Photos must be JPEG, PNG or GIF images.", errMsg)
+
+	// Sniffs as PNG but doesn't decode
+	_, errMsg = processPhoto(testPNG(t, 10, 10)[:40])
+	assert.Equal(t, "Photo could not be read.", errMsg)
+
+	_, errMsg = processPhoto(make([]byte, MaxPhotoBytes+1))
+	assert.Equal(t, "Each photo must be 5 MB or smaller.", errMsg)
+}
+
+func TestThumbnail_Dimensions(t *testing.T) {
+	tests := []struct {
+		w, h, wantW, wantH int
+	}{
+		{w: 640, h: 480, wantW: 320, wantH: 240},
+		{w: 480, h: 640, wantW: 240, wantH: 320},
+		{w: 1000, h: 1, wantW: 320, wantH: 1},
+		// Small images keep their size
+		{w: 100, h: 50, wantW: 100, wantH: 50},
+	}
+	for _, tt := range tests {
+		img := image.NewRGBA(image.Rect(0, 0, tt.w, tt.h))
+		b := thumbnail(img, ThumbnailSize).Bounds()
+		assert.Equal(t, tt.wantW, b.Dx(), "%dx%d", tt.w, tt.h)
+		assert.Equal(t, tt.wantH, b.Dy(), "%dx%d", tt.w, tt.h)
+	}
+}
+
+func TestThumbnail_AveragesPixels(t *testing.T) {
+	// Alternating black and white columns average to grey
+	img := image.NewGray(image.Rect(0, 0, 640, 640))
+	for y := 0; y < 640; y++ {
+		for x := 0; x < 640; x += 2 {
+			img.SetGray(x, y, color.Gray{Y: 255})
+		}
+	}
+	r, _, _, _ := thumbnail(img, ThumbnailSize).At(10, 10).RGBA()
+	assert.InDelta(t, 0x7fff, r, 0x100)
+}
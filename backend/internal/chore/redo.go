@@ -0,0 +1,177 @@
+package chore
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"bank-of-dad/internal/middleware"
+	"bank-of-dad/models"
+	"bank-of-dad/repositories"
+)
+
+// MaxRedoLimit is the highest max_redos a chore can be configured with.
+const MaxRedoLimit = 10
+
+// RedoRequest represents the request body for sending a chore instance back for redo.
+type RedoRequest struct {
+	Feedback string `json:"feedback"`
+}
+
+// ReviewRoundResponse represents one submit/review round of a chore instance in API responses.
+type ReviewRoundResponse struct {
+	Round       int                       `json:"round"`
+	SubmittedAt *time.Time                `json:"submitted_at,omitempty"`
+	Outcome     models.ChoreReviewOutcome `json:"outcome"`
+	Feedback    *string                   `json:"feedback,omitempty"`
+	ReviewedAt  time.Time                 `json:"reviewed_at"`
+}
+
+// validateMaxRedos checks a chore's redo limit. Returns an error message or empty string.
+func validateMaxRedos(c *models.Chore) string {
+	if c.MaxRedos != nil && (*c.MaxRedos < 0 || *c.MaxRedos > MaxRedoLimit) {
+		return "max_redos must be between 0 and 10."
+	}
+	return ""
+}
+
+// HandleRedo handles POST /api/chore-instances/{id}/redo
+// Unlike rejecting, this tells the child the chore isn't done yet: the instance goes back to
+// available with the parent's feedback, up to the chore's max_redos times.
+func (h *Handler) HandleRedo(w http.ResponseWriter, r *http.Request) {
+	if middleware.GetUserType(r) != "parent" {
+		writeJSON(w, http.StatusForbidden, ErrorResponse{Error: "forbidden", Message: "Only parents can send chores back for redo."})
+		return
+	}
+
+	instanceID, err := strconv.ParseInt(r.PathValue("id"), 10, 64)
+	if err != nil {
+		writeJSON(w, http.StatusBadRequest, ErrorResponse{Error: "invalid_request", Message: "Invalid instance ID."})
+		return
+	}
+
+	var req RedoRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeJSON(w, http.StatusBadRequest, ErrorResponse{Error: "invalid_request", Message: "Invalid request body."})
+		return
+	}
+
+	resp, fail := h.redoInstance(middleware.GetFamilyID(r), middleware.GetUserID(r), instanceID, req.Feedback)
+	if fail != nil {
+		writeJSON(w, fail.status, fail.resp)
+		return
+	}
+	writeJSON(w, http.StatusOK, map[string]interface{}{"instance": resp})
+}
+
+// redoInstance sends one of the family's pending chore instances back to the child with feedback.
+func (h *Handler) redoInstance(familyID, parentID, instanceID int64, feedback string) (*InstanceResponse, *reviewFailure) {
+	feedback = strings.TrimSpace(feedback)
+	if feedback == "" || len(feedback) > MaxDescriptionLength {
+		return nil, failure(http.StatusBadRequest, "invalid_request", "Feedback is required and must be 500 characters or less.")
+	}
+
+	instance, _, fail := h.familyInstance(familyID, instanceID)
+	if fail != nil {
+		return nil, fail
+	}
+	if instance.Status != models.ChoreInstanceStatusPendingApproval {
+		return nil, failure(http.StatusBadRequest, "invalid_status", "Instance is not pending approval.")
+	}
+
+	chore, err := h.choreRepo.GetByID(instance.ChoreID)
+	if err != nil || chore == nil {
+		return nil, failure(http.StatusInternalServerError, "internal_error", "Failed to retrieve chore.")
+	}
+	if chore.IsBounty {
+		return nil, failure(http.StatusBadRequest, "invalid_request", "Bounty chores cannot be sent back for redo. Reject it to reopen the bounty.")
+	}
+
+	err = h.choreInstanceRepo.RequestRedo(instanceID, parentID, feedback, chore.MaxRedos)
+	if errors.Is(err, repositories.ErrRedoLimitReached) {
+		return nil, failure(http.StatusConflict, "redo_limit_reached", "This chore has already been sent back the maximum number of times.")
+	}
+	if errors.Is(err, repositories.ErrInvalidStatusTransition) {
+		return nil, failure(http.StatusBadRequest, "invalid_status", "Instance is not pending approval.")
+	}
+	if err != nil {
+		return nil, failure(http.StatusInternalServerError, "internal_error", "Failed to send chore back for redo.")
+	}
+
+	updated, err := h.choreInstanceRepo.GetByID(instanceID)
+	if err != nil || updated == nil {
+		return nil, failure(http.StatusInternalServerError, "internal_error", "Failed to retrieve updated instance.")
+	}
+
+	return &InstanceResponse{
+		ID:              updated.ID,
+		ChoreID:         updated.ChoreID,
+		ChildID:         updated.ChildID,
+		RewardCents:     updated.RewardCents,
+		Status:          updated.Status,
+		PeriodStart:     updated.PeriodStart,
+		PeriodEnd:       updated.PeriodEnd,
+		ReviewedAt:      updated.ReviewedAt,
+		RejectionReason: updated.RejectionReason,
+		RedoCount:       updated.RedoCount,
+		CreatedAt:       updated.CreatedAt,
+	}, nil
+}
+
+// HandleListReviewRounds handles GET /api/chore-instances/{id}/history
+// Parents can view any instance in their family; children only their own.
+func (h *Handler) HandleListReviewRounds(w http.ResponseWriter, r *http.Request) {
+	instanceID, err := strconv.ParseInt(r.PathValue("id"), 10, 64)
+	if err != nil {
+		writeJSON(w, http.StatusBadRequest, ErrorResponse{Error: "invalid_request", Message: "Invalid instance ID."})
+		return
+	}
+
+	var instance *models.ChoreInstance
+	switch middleware.GetUserType(r) {
+	case "parent":
+		var fail *reviewFailure
+		instance, _, fail = h.familyInstance(middleware.GetFamilyID(r), instanceID)
+		if fail != nil {
+			writeJSON(w, fail.status, fail.resp)
+			return
+		}
+	case "child":
+		instance, err = h.choreInstanceRepo.GetByID(instanceID)
+		if err != nil {
+			writeJSON(w, http.StatusInternalServerError, ErrorResponse{Error: "internal_error", Message: "Failed to retrieve instance."})
+			return
+		}
+		if instance == nil || instance.ChildID != middleware.GetUserID(r) {
+			writeJSON(w, http.StatusNotFound, ErrorResponse{Error: "not_found", Message: "Chore instance not found."})
+			return
+		}
+	default:
+		writeJSON(w, http.StatusForbidden, ErrorResponse{Error: "forbidden"})
+		return
+	}
+
+	rounds, err := h.choreInstanceRepo.ListReviewRounds(instance.ID)
+	if err != nil {
+		writeJSON(w, http.StatusInternalServerError, ErrorResponse{Error: "internal_error", Message: "Failed to load review history."})
+		return
+	}
+
+	resp := make([]ReviewRoundResponse, len(rounds))
+	for i, round := range rounds {
+		resp[i] = ReviewRoundResponse{
+			Round:       round.Round,
+			SubmittedAt: round.SubmittedAt,
+			Outcome:     round.Outcome,
+			Feedback:    round.Feedback,
+			ReviewedAt:  round.ReviewedAt,
+		}
+	}
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"rounds":     resp,
+		"redo_count": instance.RedoCount,
+	})
+}
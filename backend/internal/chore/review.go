@@ -0,0 +1,35 @@
+package chore
+
+const (
+	MinRating = 1
+	MaxRating = 5
+)
+
+// ApproveRequest represents the optional request body for approving a chore instance.
+// PayoutPercent pays only part of the reward; BonusCents is paid on top.
+type ApproveRequest struct {
+	Rating        *int `json:"rating,omitempty"`
+	PayoutPercent *int `json:"payout_percent,omitempty"`
+	BonusCents    int  `json:"bonus_cents,omitempty"`
+}
+
+// reviewPayout validates an approval review and returns what the child is paid for a chore
+// with the given reward. Returns an error message if the review is invalid.
+func reviewPayout(rewardCents int, req ApproveRequest) (int, string) {
+	if req.Rating != nil && (*req.Rating < MinRating || *req.Rating > MaxRating) {
+		return 0, "rating must be between 1 and 5."
+	}
+	if req.PayoutPercent != nil && (*req.PayoutPercent < 0 || *req.PayoutPercent > 100) {
+		return 0, "payout_percent must be between 0 and 100."
+	}
+	if req.BonusCents < 0 || req.BonusCents > MaxRewardCents {
+		return 0, "Bonus must be between 0 and $999,999.99."
+	}
+
+	paid := rewardCents
+	if req.PayoutPercent != nil {
+		// Round half up to the nearest cent
+		paid = (rewardCents*(*req.PayoutPercent) + 50) / 100
+	}
+	return paid + req.BonusCents, ""
+}
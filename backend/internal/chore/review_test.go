@@ -0,0 +1,42 @@
+package chore
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func intPtr(v int) *int { return &v }
+
+func TestReviewPayout(t *testing.T) {
+	tests := []struct {
+		name     string
+		reward   int
+		req      ApproveRequest
+		wantPaid int
+		wantErr  bool
+	}{
+		{name: "full reward by default", reward: 500, req: ApproveRequest{}, wantPaid: 500},
+		{name: "rating alone keeps the full reward", reward: 500, req: ApproveRequest{Rating: intPtr(2)}, wantPaid: 500},
+		{name: "partial payout", reward: 500, req: ApproveRequest{PayoutPercent: intPtr(50)}, wantPaid: 250},
+		{name: "partial payout rounds half up", reward: 125, req: ApproveRequest{PayoutPercent: intPtr(50)}, wantPaid: 63},
+		{name: "nothing paid", reward: 500, req: ApproveRequest{PayoutPercent: intPtr(0)}, wantPaid: 0},
+		{name: "bonus on top", reward: 500, req: ApproveRequest{Rating: intPtr(5), BonusCents: 100}, wantPaid: 600},
+		{name: "bonus with partial payout", reward: 500, req: ApproveRequest{PayoutPercent: intPtr(80), BonusCents: 25}, wantPaid: 425},
+		{name: "rating too low", reward: 500, req: ApproveRequest{Rating: intPtr(0)}, wantErr: true},
+		{name: "rating too high", reward: 500, req: ApproveRequest{Rating: intPtr(6)}, wantErr: true},
+		{name: "percent over 100", reward: 500, req: ApproveRequest{PayoutPercent: intPtr(150)}, wantErr: true},
+		{name: "negative bonus", reward: 500, req: ApproveRequest{BonusCents: -1}, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			paid, errMsg := reviewPayout(tt.reward, tt.req)
+			if tt.wantErr {
+				assert.NotEmpty(t, errMsg)
+				return
+			}
+			assert.Empty(t, errMsg)
+			assert.Equal(t, tt.wantPaid, paid)
+		})
+	}
+}
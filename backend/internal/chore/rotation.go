@@ -0,0 +1,235 @@
+package chore
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"bank-of-dad/internal/middleware"
+	"bank-of-dad/models"
+)
+
+// RotationChildResponse represents one child's place in a chore rotation.
+type RotationChildResponse struct {
+	ChildID    int64  `json:"child_id"`
+	ChildName  string `json:"child_name"`
+	IsDisabled bool   `json:"is_disabled,omitempty"`
+}
+
+// RotationResponse represents a chore rotation in API responses.
+// CurrentChildID is the child given the most recent period and NextChildID the child whose turn is next.
+type RotationResponse struct {
+	Children       []RotationChildResponse `json:"children"`
+	CurrentChildID *int64                  `json:"current_child_id,omitempty"`
+	NextChildID    *int64                  `json:"next_child_id,omitempty"`
+}
+
+// SetRotationRequest represents the request body for replacing a chore's rotation.
+type SetRotationRequest struct {
+	ChildIDs []int64 `json:"child_ids"`
+}
+
+// validateRotation checks that rotation mode fits the chore. Returns an error message or empty string.
+func validateRotation(c *models.Chore) string {
+	if !c.IsRotation {
+		return ""
+	}
+	if c.Recurrence == models.ChoreRecurrenceOneTime {
+		return "Rotation is only available for recurring chores."
+	}
+	if c.IsBounty {
+		return "A chore cannot be both a bounty and a rotation."
+	}
+	return ""
+}
+
+// nextInRotation returns the first eligible child after last in order, wrapping around.
+// If last is nil or no longer in the rotation, the rotation starts from the beginning.
+// Returns false if no child in the rotation is eligible.
+func nextInRotation(order []int64, last *int64, eligible func(childID int64) bool) (int64, bool) {
+	start := 0
+	if last != nil {
+		for i, id := range order {
+			if id == *last {
+				start = i + 1
+				break
+			}
+		}
+	}
+	for i := 0; i < len(order); i++ {
+		childID := order[(start+i)%len(order)]
+		if eligible(childID) {
+			return childID, true
+		}
+	}
+	return 0, false
+}
+
+// rotationAnchor returns the child the rotation should continue after once oldOrder is replaced
+// by newOrder. If the last child was removed, the turn passes to whoever followed them: the
+// anchor becomes the nearest child before them in oldOrder that is still in the rotation.
+func rotationAnchor(oldOrder, newOrder []int64, last *int64) *int64 {
+	if last == nil {
+		return nil
+	}
+	kept := make(map[int64]bool, len(newOrder))
+	for _, id := range newOrder {
+		kept[id] = true
+	}
+	if kept[*last] {
+		return last
+	}
+
+	idx := -1
+	for i, id := range oldOrder {
+		if id == *last {
+			idx = i
+			break
+		}
+	}
+	if idx < 0 {
+		return nil
+	}
+	for i := 1; i < len(oldOrder); i++ {
+		id := oldOrder[(idx-i+len(oldOrder))%len(oldOrder)]
+		if kept[id] {
+			return &id
+		}
+	}
+	return nil
+}
+
+// rotationResponse describes a rotation chore's children in order and whose turn it is.
+func (h *Handler) rotationResponse(c *models.Chore) (*RotationResponse, error) {
+	childIDs, err := h.choreRepo.ListAssignedChildIDs(c.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &RotationResponse{
+		Children:       make([]RotationChildResponse, 0, len(childIDs)),
+		CurrentChildID: c.RotationChildID,
+	}
+	disabled := make(map[int64]bool, len(childIDs))
+	for _, childID := range childIDs {
+		child, err := h.childRepo.GetByID(childID)
+		if err != nil {
+			return nil, err
+		}
+		if child == nil {
+			continue
+		}
+		disabled[childID] = child.IsDisabled
+		resp.Children = append(resp.Children, RotationChildResponse{
+			ChildID:    child.ID,
+			ChildName:  child.FirstName,
+			IsDisabled: child.IsDisabled,
+		})
+	}
+
+	if next, ok := nextInRotation(childIDs, c.RotationChildID, func(id int64) bool { return !disabled[id] }); ok {
+		resp.NextChildID = &next
+	}
+	return resp, nil
+}
+
+// familyRotationChore loads the rotation chore named by the {id} path value. Writes an error
+// response and returns nil if it is not a rotation chore in the caller's family.
+func (h *Handler) familyRotationChore(w http.ResponseWriter, r *http.Request) *models.Chore {
+	existingChore := h.familyRecurringChore(w, r, "Rotations")
+	if existingChore == nil {
+		return nil
+	}
+	if !existingChore.IsRotation {
+		writeJSON(w, http.StatusBadRequest, ErrorResponse{Error: "not_rotation", Message: "Chore is not in rotation mode."})
+		return nil
+	}
+	return existingChore
+}
+
+// HandleGetRotation handles GET /api/chores/{id}/rotation
+func (h *Handler) HandleGetRotation(w http.ResponseWriter, r *http.Request) {
+	if middleware.GetUserType(r) != "parent" {
+		writeJSON(w, http.StatusForbidden, ErrorResponse{Error: "forbidden", Message: "Only parents can view chore rotations."})
+		return
+	}
+
+	existingChore := h.familyRotationChore(w, r)
+	if existingChore == nil {
+		return
+	}
+
+	resp, err := h.rotationResponse(existingChore)
+	if err != nil {
+		writeJSON(w, http.StatusInternalServerError, ErrorResponse{Error: "internal_error", Message: "Failed to load rotation."})
+		return
+	}
+	writeJSON(w, http.StatusOK, map[string]interface{}{"rotation": resp})
+}
+
+// HandleSetRotation handles PUT /api/chores/{id}/rotation
+// Replaces the children in the rotation and their order. Removing the child whose turn it was
+// passes the turn on to the next child; instances already generated are left alone.
+func (h *Handler) HandleSetRotation(w http.ResponseWriter, r *http.Request) {
+	if middleware.GetUserType(r) != "parent" {
+		writeJSON(w, http.StatusForbidden, ErrorResponse{Error: "forbidden", Message: "Only parents can manage chore rotations."})
+		return
+	}
+
+	existingChore := h.familyRotationChore(w, r)
+	if existingChore == nil {
+		return
+	}
+
+	var req SetRotationRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeJSON(w, http.StatusBadRequest, ErrorResponse{Error: "invalid_request", Message: "Invalid request body."})
+		return
+	}
+	if len(req.ChildIDs) == 0 {
+		writeJSON(w, http.StatusBadRequest, ErrorResponse{Error: "invalid_children", Message: "At least one child must be assigned."})
+		return
+	}
+
+	familyID := middleware.GetFamilyID(r)
+	seen := make(map[int64]bool, len(req.ChildIDs))
+	for _, childID := range req.ChildIDs {
+		if seen[childID] {
+			writeJSON(w, http.StatusBadRequest, ErrorResponse{Error: "invalid_children", Message: "Each child can only appear once in a rotation."})
+			return
+		}
+		seen[childID] = true
+
+		child, err := h.childRepo.GetByID(childID)
+		if err != nil {
+			writeJSON(w, http.StatusInternalServerError, ErrorResponse{Error: "internal_error", Message: "Failed to lookup child."})
+			return
+		}
+		if child == nil {
+			writeJSON(w, http.StatusNotFound, ErrorResponse{Error: "child_not_found", Message: "Child not found."})
+			return
+		}
+		if child.FamilyID != familyID {
+			writeJSON(w, http.StatusForbidden, ErrorResponse{Error: "forbidden", Message: "Child does not belong to your family."})
+			return
+		}
+	}
+
+	oldOrder, err := h.choreRepo.ListAssignedChildIDs(existingChore.ID)
+	if err != nil {
+		writeJSON(w, http.StatusInternalServerError, ErrorResponse{Error: "internal_error", Message: "Failed to load rotation."})
+		return
+	}
+	anchor := rotationAnchor(oldOrder, req.ChildIDs, existingChore.RotationChildID)
+	if err := h.choreRepo.ReplaceAssignments(existingChore.ID, req.ChildIDs, anchor); err != nil {
+		writeJSON(w, http.StatusInternalServerError, ErrorResponse{Error: "internal_error", Message: "Failed to save rotation."})
+		return
+	}
+	existingChore.RotationChildID = anchor
+
+	resp, err := h.rotationResponse(existingChore)
+	if err != nil {
+		writeJSON(w, http.StatusInternalServerError, ErrorResponse{Error: "internal_error", Message: "Failed to load rotation."})
+		return
+	}
+	writeJSON(w, http.StatusOK, map[string]interface{}{"rotation": resp})
+}
@@ -0,0 +1,74 @@
+package chore
+
+import (
+	"testing"
+
+	"bank-of-dad/models"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func ptr(id int64) *int64 { return &id }
+
+func TestNextInRotation(t *testing.T) {
+	order := []int64{1, 2, 3}
+	all := func(int64) bool { return true }
+
+	tests := []struct {
+		name     string
+		last     *int64
+		eligible func(int64) bool
+		want     int64
+		wantOK   bool
+	}{
+		{name: "starts at the beginning", last: nil, eligible: all, want: 1, wantOK: true},
+		{name: "moves to the next child", last: ptr(1), eligible: all, want: 2, wantOK: true},
+		{name: "wraps around", last: ptr(3), eligible: all, want: 1, wantOK: true},
+		{name: "restarts when the last child left", last: ptr(9), eligible: all, want: 1, wantOK: true},
+		{name: "skips disabled children", last: ptr(1), eligible: func(id int64) bool { return id != 2 }, want: 3, wantOK: true},
+		{name: "gives the only enabled child every turn", last: ptr(2), eligible: func(id int64) bool { return id == 2 }, want: 2, wantOK: true},
+		{name: "nobody eligible", last: ptr(1), eligible: func(int64) bool { return false }, wantOK: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := nextInRotation(order, tt.last, tt.eligible)
+			assert.Equal(t, tt.wantOK, ok)
+			if tt.wantOK {
+				assert.Equal(t, tt.want, got)
+			}
+		})
+	}
+
+	_, ok := nextInRotation(nil, nil, all)
+	assert.False(t, ok)
+}
+
+func TestRotationAnchor(t *testing.T) {
+	old := []int64{1, 2, 3, 4}
+
+	// Nothing rotated yet
+	assert.Nil(t, rotationAnchor(old, []int64{1, 2}, nil))
+
+	// Last child kept, even if reordered
+	assert.Equal(t, int64(2), *rotationAnchor(old, []int64{4, 2, 1}, ptr(2)))
+
+	// Last child removed: the rotation continues after the child before them, so the child
+	// after them is next
+	anchor := rotationAnchor(old, []int64{1, 3, 4}, ptr(2))
+	assert.Equal(t, int64(1), *anchor)
+	next, _ := nextInRotation([]int64{1, 3, 4}, anchor, func(int64) bool { return true })
+	assert.Equal(t, int64(3), next)
+
+	// Wraps back around when the children before them were removed too
+	assert.Equal(t, int64(4), *rotationAnchor(old, []int64{3, 4}, ptr(1)))
+
+	// Everyone replaced
+	assert.Nil(t, rotationAnchor(old, []int64{5, 6}, ptr(2)))
+}
+
+func TestValidateRotation(t *testing.T) {
+	assert.Empty(t, validateRotation(&models.Chore{Recurrence: models.ChoreRecurrenceOneTime}))
+	assert.Empty(t, validateRotation(&models.Chore{Recurrence: models.ChoreRecurrenceWeekly, IsRotation: true}))
+	assert.NotEmpty(t, validateRotation(&models.Chore{Recurrence: models.ChoreRecurrenceOneTime, IsRotation: true}))
+	assert.NotEmpty(t, validateRotation(&models.Chore{Recurrence: models.ChoreRecurrenceDaily, IsRotation: true, IsBounty: true}))
+}
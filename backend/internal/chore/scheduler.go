@@ -1,9 +1,13 @@
 package chore
 
 import (
+	"context"
+	"fmt"
 	"log"
 	"time"
 
+	"bank-of-dad/internal/recurrence"
+	"bank-of-dad/internal/storage"
 	"bank-of-dad/models"
 	"bank-of-dad/repositories"
 )
@@ -14,6 +18,7 @@ type ChoreScheduler struct {
 	choreInstanceRepo *repositories.ChoreInstanceRepo
 	childRepo         *repositories.ChildRepo
 	familyRepo        *repositories.FamilyRepo
+	photoStore        storage.Store
 }
 
 // NewScheduler creates a new ChoreScheduler.
@@ -26,6 +31,12 @@ func NewScheduler(choreRepo *repositories.ChoreRepo, choreInstanceRepo *reposito
 	}
 }
 
+// SetPhotoStore sets the storage holding chore photos, so the scheduler can clean up photos of
+// approved and expired instances.
+func (s *ChoreScheduler) SetPhotoStore(store storage.Store) {
+	s.photoStore = store
+}
+
 // Start begins the background chore scheduling goroutine.
 func (s *ChoreScheduler) Start(interval time.Duration, stop <-chan struct{}) {
 	go func() {
@@ -35,12 +46,14 @@ func (s *ChoreScheduler) Start(interval time.Duration, stop <-chan struct{}) {
 		// Process immediately on start
 		s.GenerateInstances()
 		s.ExpireInstances()
+		s.CleanupPhotos()
 
 		for {
 			select {
 			case <-ticker.C:
 				s.GenerateInstances()
 				s.ExpireInstances()
+				s.CleanupPhotos()
 			case <-stop:
 				return
 			}
@@ -48,35 +61,33 @@ func (s *ChoreScheduler) Start(interval time.Duration, stop <-chan struct{}) {
 	}()
 }
 
-// CalculatePeriodBounds returns the start and end of the current period for a recurring chore.
-// The period is timezone-aware using the provided location.
-func CalculatePeriodBounds(recurrence models.ChoreRecurrence, dayOfWeek *int, dayOfMonth *int, now time.Time, loc *time.Location) (periodStart, periodEnd time.Time) {
-	localNow := now.In(loc)
-
-	switch recurrence {
+// RecurrenceRule returns the recurrence rule for a recurring chore.
+// Daily, weekly and monthly chores map onto equivalent rules (monthly periods always
+// start on the 1st); custom chores use their stored RRULE, with intervals counted from creation.
+func RecurrenceRule(c *models.Chore) (recurrence.Rule, error) {
+	switch c.Recurrence {
 	case models.ChoreRecurrenceDaily:
-		start := time.Date(localNow.Year(), localNow.Month(), localNow.Day(), 0, 0, 0, 0, loc)
-		end := start.AddDate(0, 0, 1).Add(-time.Second)
-		return start, end
-
+		return recurrence.DailyRule(), nil
 	case models.ChoreRecurrenceWeekly:
 		dow := 0
-		if dayOfWeek != nil {
-			dow = *dayOfWeek
+		if c.DayOfWeek != nil {
+			dow = *c.DayOfWeek
 		}
-		currentDow := int(localNow.Weekday())
-		daysBack := (currentDow - dow + 7) % 7
-		start := time.Date(localNow.Year(), localNow.Month(), localNow.Day()-daysBack, 0, 0, 0, 0, loc)
-		end := start.AddDate(0, 0, 7).Add(-time.Second)
-		return start, end
-
+		return recurrence.WeeklyOn(time.Weekday(dow), 1), nil
 	case models.ChoreRecurrenceMonthly:
-		start := time.Date(localNow.Year(), localNow.Month(), 1, 0, 0, 0, 0, loc)
-		end := start.AddDate(0, 1, 0).Add(-time.Second)
-		return start, end
+		return recurrence.MonthlyOn(1), nil
+	case models.ChoreRecurrenceCustom:
+		if c.RRule == nil {
+			return recurrence.Rule{}, fmt.Errorf("custom chore has no rrule")
+		}
+		rule, err := recurrence.Parse(*c.RRule)
+		if err != nil {
+			return recurrence.Rule{}, err
+		}
+		rule.DTStart = c.CreatedAt
+		return rule, nil
 	}
-
-	return now, now
+	return recurrence.Rule{}, fmt.Errorf("chore recurrence %q does not repeat", c.Recurrence)
 }
 
 // GenerateInstances creates chore instances for all active recurring chores in the current period.
@@ -98,7 +109,34 @@ func (s *ChoreScheduler) GenerateInstances() {
 		}
 		loc := loadTimezone(tz)
 
-		periodStart, periodEnd := CalculatePeriodBounds(cwa.Recurrence, cwa.DayOfWeek, cwa.DayOfMonth, now, loc)
+		rule, err := RecurrenceRule(&cwa.Chore)
+		if err != nil {
+			log.Printf("Chore scheduler: invalid recurrence for chore %d: %v", cwa.ID, err)
+			continue
+		}
+		periodStart, periodEnd := rule.Bounds(now, loc)
+
+		dueAt := DueAt(&cwa.Chore, periodStart, periodEnd, loc)
+
+		if cwa.IsRotation {
+			if s.generateRotation(&cwa, periodStart, periodEnd, dueAt) {
+				generated++
+			}
+			continue
+		}
+
+		// A child assigned to a bounty after a sibling took it this period joins as taken
+		status := models.ChoreInstanceStatusAvailable
+		if cwa.IsBounty {
+			held, err := s.choreInstanceRepo.BountyHeld(cwa.ID, &periodStart, now)
+			if err != nil {
+				log.Printf("Chore scheduler: error checking bounty for chore %d: %v", cwa.ID, err)
+				continue
+			}
+			if held {
+				status = models.ChoreInstanceStatusTaken
+			}
+		}
 
 		for _, childID := range cwa.ChildIDs {
 			// Skip disabled children
@@ -120,9 +158,10 @@ func (s *ChoreScheduler) GenerateInstances() {
 				ChoreID:     cwa.ID,
 				ChildID:     childID,
 				RewardCents: cwa.RewardCents,
-				Status:      models.ChoreInstanceStatusAvailable,
+				Status:      status,
 				PeriodStart: &periodStart,
 				PeriodEnd:   &periodEnd,
+				DueAt:       dueAt,
 			}
 			if _, err := s.choreInstanceRepo.CreateInstance(instance); err != nil {
 				log.Printf("Chore scheduler: error creating instance for chore %d child %d: %v", cwa.ID, childID, err)
@@ -137,18 +176,99 @@ func (s *ChoreScheduler) GenerateInstances() {
 	}
 }
 
-// ExpireInstances marks all available instances past their period_end as expired.
+// generateRotation creates a rotation chore's instance for the period, for the next enabled child
+// in the rotation. Reports whether an instance was created.
+func (s *ChoreScheduler) generateRotation(cwa *repositories.ChoreWithAssignmentIDs, periodStart, periodEnd time.Time, dueAt *time.Time) bool {
+	exists, err := s.choreInstanceRepo.ExistsForChorePeriod(cwa.ID, periodStart)
+	if err != nil {
+		log.Printf("Chore scheduler: error checking period for chore %d: %v", cwa.ID, err)
+		return false
+	}
+	if exists {
+		return false
+	}
+
+	childID, ok := nextInRotation(cwa.ChildIDs, cwa.RotationChildID, func(id int64) bool {
+		child, err := s.childRepo.GetByID(id)
+		return err == nil && child != nil && !child.IsDisabled
+	})
+	if !ok {
+		return false
+	}
+
+	instance := &models.ChoreInstance{
+		ChoreID:     cwa.ID,
+		ChildID:     childID,
+		RewardCents: cwa.RewardCents,
+		Status:      models.ChoreInstanceStatusAvailable,
+		PeriodStart: &periodStart,
+		PeriodEnd:   &periodEnd,
+		DueAt:       dueAt,
+	}
+	if _, err := s.choreInstanceRepo.CreateInstance(instance); err != nil {
+		log.Printf("Chore scheduler: error creating instance for chore %d child %d: %v", cwa.ID, childID, err)
+		return false
+	}
+	if err := s.choreRepo.SetRotationChild(cwa.ID, childID); err != nil {
+		log.Printf("Chore scheduler: error advancing rotation for chore %d: %v", cwa.ID, err)
+	}
+	return true
+}
+
+// ExpireInstances releases lapsed bounty claims and marks all open instances past their period_end as expired.
 func (s *ChoreScheduler) ExpireInstances() {
-	count, err := s.choreInstanceRepo.ExpireByPeriod(time.Now().UTC())
+	released, err := s.choreInstanceRepo.ReleaseExpiredClaims(time.Now().UTC())
 	if err != nil {
-		log.Printf("Chore scheduler: error expiring instances: %v", err)
+		log.Printf("Chore scheduler: error releasing expired claims: %v", err)
+	} else if released > 0 {
+		log.Printf("Chore scheduler: released %d expired bounty claims", released)
+	}
+
+	// Each instance is expired on its own, so one that fails doesn't hold back the rest
+	now := time.Now().UTC()
+	ids, err := s.choreInstanceRepo.ListExpirable(now)
+	if err != nil {
+		log.Printf("Chore scheduler: error listing instances to expire: %v", err)
 		return
 	}
+	count := 0
+	for _, id := range ids {
+		expired, err := s.choreInstanceRepo.Expire(id, now)
+		if err != nil {
+			log.Printf("Chore scheduler: error expiring instance %d: %v", id, err)
+			continue
+		}
+		if expired {
+			count++
+		}
+	}
 	if count > 0 {
 		log.Printf("Chore scheduler: expired %d instances", count)
 	}
 }
 
+// photoCleanupBatch is how many photos CleanupPhotos removes per pass.
+const photoCleanupBatch = 100
+
+// CleanupPhotos removes the photos of instances that were approved or expired.
+func (s *ChoreScheduler) CleanupPhotos() {
+	if s.photoStore == nil {
+		return
+	}
+	photos, err := s.choreInstanceRepo.ListClosedPhotos(photoCleanupBatch)
+	if err != nil {
+		log.Printf("Chore scheduler: error listing photos to clean up: %v", err)
+		return
+	}
+	if err := deletePhotos(context.Background(), s.photoStore, s.choreInstanceRepo, photos); err != nil {
+		log.Printf("Chore scheduler: error cleaning up photos: %v", err)
+		return
+	}
+	if len(photos) > 0 {
+		log.Printf("Chore scheduler: cleaned up %d photos", len(photos))
+	}
+}
+
 func loadTimezone(tz string) *time.Location {
 	if tz != "" {
 		if loc, err := time.LoadLocation(tz); err == nil {
@@ -12,61 +12,116 @@ import (
 	"github.com/stretchr/testify/require"
 )
 
-func TestCalculatePeriodBounds_Daily(t *testing.T) {
+func periodBounds(t *testing.T, c *models.Chore, now time.Time, loc *time.Location) (time.Time, time.Time) {
+	t.Helper()
+	rule, err := RecurrenceRule(c)
+	require.NoError(t, err)
+	return rule.Bounds(now, loc)
+}
+
+func TestRecurrenceRuleBounds_Daily(t *testing.T) {
 	loc := time.UTC
 	now := time.Date(2026, 3, 15, 14, 30, 0, 0, loc)
 
-	start, end := CalculatePeriodBounds(models.ChoreRecurrenceDaily, nil, nil, now, loc)
+	start, end := periodBounds(t, &models.Chore{Recurrence: models.ChoreRecurrenceDaily}, now, loc)
 
 	assert.Equal(t, time.Date(2026, 3, 15, 0, 0, 0, 0, loc), start)
 	assert.Equal(t, time.Date(2026, 3, 15, 23, 59, 59, 0, loc), end)
 }
 
-func TestCalculatePeriodBounds_Weekly(t *testing.T) {
+func TestRecurrenceRuleBounds_Weekly(t *testing.T) {
 	loc := time.UTC
 	// 2026-03-15 is a Sunday (weekday 0)
 	now := time.Date(2026, 3, 18, 10, 0, 0, 0, loc) // Wednesday
 
 	dow := 1 // Monday
-	start, end := CalculatePeriodBounds(models.ChoreRecurrenceWeekly, &dow, nil, now, loc)
+	start, end := periodBounds(t, &models.Chore{Recurrence: models.ChoreRecurrenceWeekly, DayOfWeek: &dow}, now, loc)
 
 	// Week starting Monday March 16
 	assert.Equal(t, time.Date(2026, 3, 16, 0, 0, 0, 0, loc), start)
 	assert.Equal(t, time.Date(2026, 3, 22, 23, 59, 59, 0, loc), end)
 }
 
-func TestCalculatePeriodBounds_Weekly_SameDayAsStart(t *testing.T) {
+func TestRecurrenceRuleBounds_Weekly_SameDayAsStart(t *testing.T) {
 	loc := time.UTC
 	now := time.Date(2026, 3, 16, 10, 0, 0, 0, loc) // Monday
 
 	dow := 1 // Monday
-	start, end := CalculatePeriodBounds(models.ChoreRecurrenceWeekly, &dow, nil, now, loc)
+	start, end := periodBounds(t, &models.Chore{Recurrence: models.ChoreRecurrenceWeekly, DayOfWeek: &dow}, now, loc)
 
 	assert.Equal(t, time.Date(2026, 3, 16, 0, 0, 0, 0, loc), start)
 	assert.Equal(t, time.Date(2026, 3, 22, 23, 59, 59, 0, loc), end)
 }
 
-func TestCalculatePeriodBounds_Monthly(t *testing.T) {
+func TestRecurrenceRuleBounds_Monthly(t *testing.T) {
 	loc := time.UTC
 	now := time.Date(2026, 3, 15, 14, 30, 0, 0, loc)
 
-	start, end := CalculatePeriodBounds(models.ChoreRecurrenceMonthly, nil, nil, now, loc)
+	start, end := periodBounds(t, &models.Chore{Recurrence: models.ChoreRecurrenceMonthly}, now, loc)
 
 	assert.Equal(t, time.Date(2026, 3, 1, 0, 0, 0, 0, loc), start)
 	assert.Equal(t, time.Date(2026, 3, 31, 23, 59, 59, 0, loc), end)
 }
 
-func TestCalculatePeriodBounds_Timezone(t *testing.T) {
+func TestRecurrenceRuleBounds_Timezone(t *testing.T) {
 	loc, _ := time.LoadLocation("America/New_York")
 	// UTC time that's still "yesterday" in ET
 	now := time.Date(2026, 3, 16, 3, 0, 0, 0, time.UTC) // 11pm ET on March 15
 
-	start, end := CalculatePeriodBounds(models.ChoreRecurrenceDaily, nil, nil, now, loc)
+	start, end := periodBounds(t, &models.Chore{Recurrence: models.ChoreRecurrenceDaily}, now, loc)
 
 	assert.Equal(t, time.Date(2026, 3, 15, 0, 0, 0, 0, loc), start)
 	assert.Equal(t, time.Date(2026, 3, 15, 23, 59, 59, 0, loc), end)
 }
 
+func TestRecurrenceRuleBounds_MonthlyIgnoresDayOfMonth(t *testing.T) {
+	loc := time.UTC
+	now := time.Date(2026, 2, 20, 9, 0, 0, 0, loc)
+	dom := 15
+
+	start, end := periodBounds(t, &models.Chore{Recurrence: models.ChoreRecurrenceMonthly, DayOfMonth: &dom}, now, loc)
+
+	assert.Equal(t, time.Date(2026, 2, 1, 0, 0, 0, 0, loc), start)
+	assert.Equal(t, time.Date(2026, 2, 28, 23, 59, 59, 0, loc), end)
+}
+
+func TestRecurrenceRuleBounds_CustomWeekdays(t *testing.T) {
+	loc := time.UTC
+	rrule := "FREQ=WEEKLY;BYDAY=MO,WE,FR"
+	c := &models.Chore{
+		Recurrence: models.ChoreRecurrenceCustom,
+		RRule:      &rrule,
+		CreatedAt:  time.Date(2026, 3, 1, 12, 0, 0, 0, loc),
+	}
+	now := time.Date(2026, 3, 19, 10, 0, 0, 0, loc) // Thursday
+
+	start, end := periodBounds(t, c, now, loc)
+
+	assert.Equal(t, time.Date(2026, 3, 18, 0, 0, 0, 0, loc), start)
+	assert.Equal(t, time.Date(2026, 3, 19, 23, 59, 59, 0, loc), end)
+}
+
+func TestRecurrenceRuleBounds_CustomEveryThreeDaysFromCreation(t *testing.T) {
+	loc := time.UTC
+	rrule := "FREQ=DAILY;INTERVAL=3"
+	c := &models.Chore{
+		Recurrence: models.ChoreRecurrenceCustom,
+		RRule:      &rrule,
+		CreatedAt:  time.Date(2026, 3, 1, 12, 0, 0, 0, loc),
+	}
+	now := time.Date(2026, 3, 8, 10, 0, 0, 0, loc)
+
+	start, end := periodBounds(t, c, now, loc)
+
+	assert.Equal(t, time.Date(2026, 3, 7, 0, 0, 0, 0, loc), start)
+	assert.Equal(t, time.Date(2026, 3, 9, 23, 59, 59, 0, loc), end)
+}
+
+func TestRecurrenceRule_OneTimeErrors(t *testing.T) {
+	_, err := RecurrenceRule(&models.Chore{Recurrence: models.ChoreRecurrenceOneTime})
+	assert.Error(t, err)
+}
+
 func TestGenerateInstances_CreatesForRecurringChore(t *testing.T) {
 	db := testutil.SetupTestDB(t)
 	family := testutil.CreateTestFamily(t, db)
@@ -258,3 +313,84 @@ func TestExpireInstances(t *testing.T) {
 	require.NotNil(t, instance)
 	assert.Equal(t, models.ChoreInstanceStatusExpired, instance.Status)
 }
+
+func TestGenerateInstances_Rotation(t *testing.T) {
+	db := testutil.SetupTestDB(t)
+	family := testutil.CreateTestFamily(t, db)
+	parent := testutil.CreateTestParent(t, db, family.ID)
+	alice := testutil.CreateTestChild(t, db, family.ID, "Alice")
+	bob := testutil.CreateTestChild(t, db, family.ID, "Bob")
+	carol := testutil.CreateTestChild(t, db, family.ID, "Carol")
+
+	choreRepo := repositories.NewChoreRepo(db)
+	instanceRepo := repositories.NewChoreInstanceRepo(db)
+
+	chore, err := choreRepo.Create(&models.Chore{
+		FamilyID:          family.ID,
+		CreatedByParentID: parent.ID,
+		Name:              "Dishes",
+		RewardCents:       100,
+		Recurrence:        models.ChoreRecurrenceDaily,
+		IsActive:          true,
+		IsRotation:        true,
+	})
+	require.NoError(t, err)
+	// Alice took the last turn; Bob is next but disabled, so it's Carol's turn
+	require.NoError(t, choreRepo.ReplaceAssignments(chore.ID, []int64{alice.ID, bob.ID, carol.ID}, &alice.ID))
+	db.Model(&models.Child{}).Where("id = ?", bob.ID).Update("is_disabled", true)
+
+	scheduler := NewScheduler(choreRepo, instanceRepo, repositories.NewChildRepo(db), repositories.NewFamilyRepo(db))
+	scheduler.GenerateInstances()
+	// The period already has its instance
+	scheduler.GenerateInstances()
+
+	for _, child := range []*models.Child{alice, bob} {
+		available, _, _, err := instanceRepo.ListByChild(child.ID)
+		require.NoError(t, err)
+		assert.Empty(t, available, child.FirstName)
+	}
+	available, _, _, err := instanceRepo.ListByChild(carol.ID)
+	require.NoError(t, err)
+	assert.Len(t, available, 1)
+
+	updated, err := choreRepo.GetByID(chore.ID)
+	require.NoError(t, err)
+	require.NotNil(t, updated.RotationChildID)
+	assert.Equal(t, carol.ID, *updated.RotationChildID)
+}
+
+func TestGenerateInstances_RotationNonUTCTimezone(t *testing.T) {
+	db := testutil.SetupTestDB(t)
+	family := testutil.CreateTestFamily(t, db)
+	parent := testutil.CreateTestParent(t, db, family.ID)
+	alice := testutil.CreateTestChild(t, db, family.ID, "Alice")
+	bob := testutil.CreateTestChild(t, db, family.ID, "Bob")
+
+	familyRepo := repositories.NewFamilyRepo(db)
+	require.NoError(t, familyRepo.UpdateTimezone(family.ID, "America/Los_Angeles"))
+
+	choreRepo := repositories.NewChoreRepo(db)
+	instanceRepo := repositories.NewChoreInstanceRepo(db)
+
+	chore, err := choreRepo.Create(&models.Chore{
+		FamilyID:          family.ID,
+		CreatedByParentID: parent.ID,
+		Name:              "Dishes",
+		RewardCents:       100,
+		Recurrence:        models.ChoreRecurrenceDaily,
+		IsActive:          true,
+		IsRotation:        true,
+	})
+	require.NoError(t, err)
+	require.NoError(t, choreRepo.ReplaceAssignments(chore.ID, []int64{alice.ID, bob.ID}, nil))
+
+	// Local midnight is not UTC midnight, but the period is still recognized on later ticks
+	scheduler := NewScheduler(choreRepo, instanceRepo, repositories.NewChildRepo(db), familyRepo)
+	scheduler.GenerateInstances()
+	scheduler.GenerateInstances()
+	scheduler.GenerateInstances()
+
+	var count int64
+	require.NoError(t, db.Model(&models.ChoreInstance{}).Where("chore_id = ?", chore.ID).Count(&count).Error)
+	assert.Equal(t, int64(1), count)
+}
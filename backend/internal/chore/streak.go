@@ -0,0 +1,164 @@
+package chore
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	"bank-of-dad/models"
+	"bank-of-dad/repositories"
+)
+
+const (
+	MinStreakLength  = 2
+	MaxStreakLength  = 365
+	MaxStreakBonuses = 10
+)
+
+// Streak summarizes a child's run of consecutive approved periods on one recurring chore.
+type Streak struct {
+	Current int
+	Best    int
+	// StartedOn is the period_start of the first period in the current streak, or nil if Current is 0.
+	StartedOn *time.Time
+}
+
+// StreakResponse represents a child's streak on one recurring chore in API responses.
+type StreakResponse struct {
+	ChoreID       int64  `json:"chore_id"`
+	ChoreName     string `json:"chore_name"`
+	CurrentStreak int    `json:"current_streak"`
+	BestStreak    int    `json:"best_streak"`
+}
+
+// ComputeStreak walks one child's instances of one chore, oldest period first.
+// Approved periods extend the streak and expired periods break it. Periods that are still open
+// (available, claimed or pending approval) and bounty periods a sibling took do neither.
+// A period with no instance at all, because it was skipped or never generated, is a miss and also
+// breaks the streak; on a rotation chore the periods between the child's turns are not theirs,
+// so only consecutive turns are compared.
+func ComputeStreak(instances []models.ChoreInstance, rotation bool) Streak {
+	var s Streak
+	for i := range instances {
+		if !rotation && i > 0 && missedPeriodBetween(&instances[i-1], &instances[i]) {
+			s.Current = 0
+			s.StartedOn = nil
+		}
+		switch instances[i].Status {
+		case models.ChoreInstanceStatusApproved:
+			if s.Current == 0 {
+				s.StartedOn = instances[i].PeriodStart
+			}
+			s.Current++
+			if s.Current > s.Best {
+				s.Best = s.Current
+			}
+		case models.ChoreInstanceStatusExpired:
+			s.Current = 0
+			s.StartedOn = nil
+		}
+	}
+	return s
+}
+
+// missedPeriodBetween reports whether a period is missing between two consecutive instances of a chore.
+func missedPeriodBetween(prev, next *models.ChoreInstance) bool {
+	if prev.PeriodEnd == nil || next.PeriodStart == nil {
+		return false
+	}
+	return next.PeriodStart.After(prev.PeriodEnd.AddDate(0, 0, 1))
+}
+
+// streaksByChore computes a child's streak on each recurring chore from ListPeriodsByChild results.
+func streaksByChore(periods []repositories.ChoreInstanceWithDetails) map[int64]Streak {
+	byChore := make(map[int64][]models.ChoreInstance)
+	rotation := make(map[int64]bool)
+	for _, p := range periods {
+		byChore[p.ChoreID] = append(byChore[p.ChoreID], p.ChoreInstance)
+		rotation[p.ChoreID] = p.IsRotation
+	}
+	streaks := make(map[int64]Streak, len(byChore))
+	for choreID, instances := range byChore {
+		streaks[choreID] = ComputeStreak(instances, rotation[choreID])
+	}
+	return streaks
+}
+
+// ValidateStreakBonuses returns an error message if a set of streak bonuses is invalid, or empty string if valid.
+func ValidateStreakBonuses(bonuses []models.ChoreStreakBonus) string {
+	if len(bonuses) > MaxStreakBonuses {
+		return fmt.Sprintf("A chore can have at most %d streak bonuses.", MaxStreakBonuses)
+	}
+	seen := make(map[int]bool, len(bonuses))
+	for _, b := range bonuses {
+		if b.StreakLength < MinStreakLength || b.StreakLength > MaxStreakLength {
+			return fmt.Sprintf("streak_length must be between %d and %d.", MinStreakLength, MaxStreakLength)
+		}
+		if b.BonusCents <= 0 || b.BonusCents > MaxRewardCents {
+			return "Bonus must be between $0.01 and $999,999.99."
+		}
+		if seen[b.StreakLength] {
+			return "Each streak_length can only have one bonus."
+		}
+		seen[b.StreakLength] = true
+	}
+	return ""
+}
+
+// bonusForStreak returns the bonus configured for a streak of exactly length periods, if any.
+func bonusForStreak(bonuses []models.ChoreStreakBonus, length int) *models.ChoreStreakBonus {
+	for i := range bonuses {
+		if bonuses[i].StreakLength == length {
+			return &bonuses[i]
+		}
+	}
+	return nil
+}
+
+// payStreakBonus computes the child's streak on a chore after one of its instances was approved and
+// pays the bonus for the milestone the streak just reached, if one is configured.
+// Returns the streak, the bonus paid (0 if none) and the child's balance after the bonus.
+// Failures are logged rather than returned: the approval itself has already succeeded.
+func (h *Handler) payStreakBonus(instance *models.ChoreInstance, chore *models.Chore, parentID int64) (Streak, int64, int64) {
+	if chore.Recurrence == models.ChoreRecurrenceOneTime {
+		return Streak{}, 0, 0
+	}
+
+	periods, err := h.choreInstanceRepo.ListPeriodsByChild(instance.ChildID)
+	if err != nil {
+		log.Printf("Error computing streak for chore %d child %d: %v", chore.ID, instance.ChildID, err)
+		return Streak{}, 0, 0
+	}
+	streak := streaksByChore(periods)[chore.ID]
+	if h.streakRepo == nil || streak.StartedOn == nil {
+		return streak, 0, 0
+	}
+
+	bonuses, err := h.streakRepo.ListBonuses(chore.ID)
+	if err != nil {
+		log.Printf("Error listing streak bonuses for chore %d: %v", chore.ID, err)
+		return streak, 0, 0
+	}
+	bonus := bonusForStreak(bonuses, streak.Current)
+	if bonus == nil {
+		return streak, 0, 0
+	}
+
+	award := &models.ChoreStreakAward{
+		ChoreID:         chore.ID,
+		ChildID:         instance.ChildID,
+		StreakLength:    bonus.StreakLength,
+		StreakStartedOn: *streak.StartedOn,
+		BonusCents:      bonus.BonusCents,
+	}
+	note := fmt.Sprintf("Streak bonus: %d in a row on %s", bonus.StreakLength, chore.Name)
+	_, balance, err := h.streakRepo.Award(award, parentID, note)
+	if err != nil {
+		if !errors.Is(err, repositories.ErrStreakBonusAwarded) {
+			log.Printf("Error paying streak bonus for chore %d child %d: %v", chore.ID, instance.ChildID, err)
+		}
+		return streak, 0, 0
+	}
+	return streak, bonus.BonusCents, balance
+}
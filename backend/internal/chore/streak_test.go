@@ -0,0 +1,98 @@
+package chore
+
+import (
+	"testing"
+	"time"
+
+	"bank-of-dad/models"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// periods builds daily instances starting Jan 1 2026, one per status.
+func periods(statuses ...models.ChoreInstanceStatus) []models.ChoreInstance {
+	instances := make([]models.ChoreInstance, len(statuses))
+	for i, status := range statuses {
+		start := time.Date(2026, time.January, 1+i, 0, 0, 0, 0, time.UTC)
+		instances[i] = models.ChoreInstance{Status: status, PeriodStart: &start, PeriodEnd: &start}
+	}
+	return instances
+}
+
+const (
+	approved = models.ChoreInstanceStatusApproved
+	expired  = models.ChoreInstanceStatusExpired
+	pending  = models.ChoreInstanceStatusPendingApproval
+	open     = models.ChoreInstanceStatusAvailable
+	taken    = models.ChoreInstanceStatusTaken
+)
+
+func TestComputeStreak_Empty(t *testing.T) {
+	s := ComputeStreak(nil, false)
+	assert.Equal(t, 0, s.Current)
+	assert.Equal(t, 0, s.Best)
+	assert.Nil(t, s.StartedOn)
+}
+
+func TestComputeStreak_ExpiredBreaksStreak(t *testing.T) {
+	s := ComputeStreak(periods(approved, approved, approved, expired, approved, approved), false)
+	assert.Equal(t, 2, s.Current)
+	assert.Equal(t, 3, s.Best)
+	require.NotNil(t, s.StartedOn)
+	assert.Equal(t, time.Date(2026, time.January, 5, 0, 0, 0, 0, time.UTC), *s.StartedOn)
+}
+
+func TestComputeStreak_OpenPeriodsDoNotBreakStreak(t *testing.T) {
+	s := ComputeStreak(periods(approved, pending, approved, taken, approved, open), false)
+	assert.Equal(t, 3, s.Current)
+	assert.Equal(t, 3, s.Best)
+	assert.Equal(t, time.Date(2026, time.January, 1, 0, 0, 0, 0, time.UTC), *s.StartedOn)
+}
+
+func TestComputeStreak_EndsExpired(t *testing.T) {
+	s := ComputeStreak(periods(approved, approved, expired), false)
+	assert.Equal(t, 0, s.Current)
+	assert.Equal(t, 2, s.Best)
+	assert.Nil(t, s.StartedOn)
+}
+
+func TestComputeStreak_MissingPeriodBreaksStreak(t *testing.T) {
+	instances := periods(approved, approved, approved, approved, approved)
+	// No instance for Jan 3: the period was never generated
+	instances = append(instances[:2], instances[3:]...)
+
+	s := ComputeStreak(instances, false)
+	assert.Equal(t, 2, s.Current)
+	assert.Equal(t, 2, s.Best)
+	require.NotNil(t, s.StartedOn)
+	assert.Equal(t, time.Date(2026, time.January, 4, 0, 0, 0, 0, time.UTC), *s.StartedOn)
+
+	// A rotation child's turns are not consecutive periods
+	s = ComputeStreak(instances, true)
+	assert.Equal(t, 4, s.Current)
+}
+
+func TestValidateStreakBonuses(t *testing.T) {
+	valid := []models.ChoreStreakBonus{{StreakLength: 7, BonusCents: 100}, {StreakLength: 30, BonusCents: 500}}
+	assert.Empty(t, ValidateStreakBonuses(valid))
+	assert.Empty(t, ValidateStreakBonuses(nil))
+
+	assert.NotEmpty(t, ValidateStreakBonuses([]models.ChoreStreakBonus{{StreakLength: 1, BonusCents: 100}}))
+	assert.NotEmpty(t, ValidateStreakBonuses([]models.ChoreStreakBonus{{StreakLength: 400, BonusCents: 100}}))
+	assert.NotEmpty(t, ValidateStreakBonuses([]models.ChoreStreakBonus{{StreakLength: 7, BonusCents: 0}}))
+	assert.NotEmpty(t, ValidateStreakBonuses([]models.ChoreStreakBonus{{StreakLength: 7, BonusCents: 100}, {StreakLength: 7, BonusCents: 200}}))
+
+	tooMany := make([]models.ChoreStreakBonus, MaxStreakBonuses+1)
+	for i := range tooMany {
+		tooMany[i] = models.ChoreStreakBonus{StreakLength: i + 2, BonusCents: 100}
+	}
+	assert.NotEmpty(t, ValidateStreakBonuses(tooMany))
+}
+
+func TestBonusForStreak(t *testing.T) {
+	bonuses := []models.ChoreStreakBonus{{StreakLength: 7, BonusCents: 100}, {StreakLength: 30, BonusCents: 500}}
+	require.NotNil(t, bonusForStreak(bonuses, 7))
+	assert.Equal(t, int64(100), bonusForStreak(bonuses, 7).BonusCents)
+	assert.Nil(t, bonusForStreak(bonuses, 8))
+}
@@ -0,0 +1,305 @@
+package chore
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"bank-of-dad/internal/middleware"
+	"bank-of-dad/models"
+	"bank-of-dad/repositories"
+)
+
+// ChoreTemplate is a built-in chore suggestion with an age range, reward and recurrence.
+type ChoreTemplate struct {
+	Key         string                 `json:"key"`
+	Name        string                 `json:"name"`
+	Description string                 `json:"description"`
+	MinAge      int                    `json:"min_age"`
+	MaxAge      int                    `json:"max_age"`
+	RewardCents int                    `json:"reward_cents"`
+	Recurrence  models.ChoreRecurrence `json:"recurrence"`
+	DayOfWeek   *int                   `json:"day_of_week,omitempty"`
+	DayOfMonth  *int                   `json:"day_of_month,omitempty"`
+}
+
+func dayPtr(d int) *int { return &d }
+
+// templateCatalog is the built-in chore template library, roughly ordered by age.
+var templateCatalog = []ChoreTemplate{
+	{Key: "put_away_toys", Name: "Put away toys", Description: "Put toys back where they belong before bed.", MinAge: 3, MaxAge: 7, RewardCents: 25, Recurrence: models.ChoreRecurrenceDaily},
+	{Key: "feed_pet", Name: "Feed the pet", Description: "Fill the food and water bowls.", MinAge: 4, MaxAge: 17, RewardCents: 25, Recurrence: models.ChoreRecurrenceDaily},
+	{Key: "make_bed", Name: "Make your bed", Description: "Straighten the sheets and pillows each morning.", MinAge: 4, MaxAge: 17, RewardCents: 25, Recurrence: models.ChoreRecurrenceDaily},
+	{Key: "set_table", Name: "Set the table", Description: "Set out plates, cups and cutlery for dinner.", MinAge: 5, MaxAge: 12, RewardCents: 25, Recurrence: models.ChoreRecurrenceDaily},
+	{Key: "water_plants", Name: "Water the plants", Description: "Water the house plants.", MinAge: 5, MaxAge: 12, RewardCents: 50, Recurrence: models.ChoreRecurrenceWeekly, DayOfWeek: dayPtr(3)},
+	{Key: "sort_laundry", Name: "Sort the laundry", Description: "Sort dirty clothes into lights and darks.", MinAge: 6, MaxAge: 10, RewardCents: 50, Recurrence: models.ChoreRecurrenceWeekly, DayOfWeek: dayPtr(6)},
+	{Key: "tidy_bedroom", Name: "Tidy your bedroom", Description: "Clear the floor, put clothes away and make the room neat.", MinAge: 5, MaxAge: 17, RewardCents: 100, Recurrence: models.ChoreRecurrenceWeekly, DayOfWeek: dayPtr(6)},
+	{Key: "empty_dishwasher", Name: "Empty the dishwasher", Description: "Put clean dishes away.", MinAge: 7, MaxAge: 17, RewardCents: 50, Recurrence: models.ChoreRecurrenceDaily},
+	{Key: "take_out_trash", Name: "Take out the trash", Description: "Empty the bins and take the bags out for collection.", MinAge: 8, MaxAge: 17, RewardCents: 100, Recurrence: models.ChoreRecurrenceWeekly, DayOfWeek: dayPtr(1)},
+	{Key: "fold_laundry", Name: "Fold laundry", Description: "Fold clean clothes and put them away.", MinAge: 8, MaxAge: 17, RewardCents: 100, Recurrence: models.ChoreRecurrenceWeekly, DayOfWeek: dayPtr(0)},
+	{Key: "vacuum", Name: "Vacuum", Description: "Vacuum the living room and hallway.", MinAge: 9, MaxAge: 17, RewardCents: 200, Recurrence: models.ChoreRecurrenceWeekly, DayOfWeek: dayPtr(6)},
+	{Key: "clean_bathroom", Name: "Clean the bathroom", Description: "Wipe the sink, mirror and counters and scrub the toilet.", MinAge: 10, MaxAge: 17, RewardCents: 300, Recurrence: models.ChoreRecurrenceWeekly, DayOfWeek: dayPtr(0)},
+	{Key: "wash_dishes", Name: "Wash the dishes", Description: "Wash, dry and put away the dinner dishes.", MinAge: 10, MaxAge: 17, RewardCents: 100, Recurrence: models.ChoreRecurrenceDaily},
+	{Key: "mow_lawn", Name: "Mow the lawn", Description: "Mow the front and back lawn.", MinAge: 12, MaxAge: 17, RewardCents: 1000, Recurrence: models.ChoreRecurrenceWeekly, DayOfWeek: dayPtr(6)},
+	{Key: "cook_dinner", Name: "Cook dinner", Description: "Plan and cook a family dinner.", MinAge: 13, MaxAge: 17, RewardCents: 500, Recurrence: models.ChoreRecurrenceWeekly, DayOfWeek: dayPtr(5)},
+	{Key: "wash_car", Name: "Wash the car", Description: "Wash and dry the outside of the car.", MinAge: 10, MaxAge: 17, RewardCents: 800, Recurrence: models.ChoreRecurrenceMonthly, DayOfMonth: dayPtr(1)},
+	{Key: "clean_fridge", Name: "Clean out the fridge", Description: "Throw out old food and wipe down the shelves.", MinAge: 11, MaxAge: 17, RewardCents: 500, Recurrence: models.ChoreRecurrenceMonthly, DayOfMonth: dayPtr(15)},
+}
+
+// templateByKey returns the catalog template with the given key, or nil.
+func templateByKey(key string) *ChoreTemplate {
+	for i := range templateCatalog {
+		if templateCatalog[i].Key == key {
+			return &templateCatalog[i]
+		}
+	}
+	return nil
+}
+
+// templatesForAge returns the catalog templates suited to a child of the given age.
+func templatesForAge(age int) []ChoreTemplate {
+	templates := []ChoreTemplate{}
+	for _, t := range templateCatalog {
+		if age >= t.MinAge && age <= t.MaxAge {
+			templates = append(templates, t)
+		}
+	}
+	return templates
+}
+
+// childAge returns a child's age in whole years on the given day.
+func childAge(birthdate, now time.Time) int {
+	age := now.Year() - birthdate.Year()
+	if now.Month() < birthdate.Month() || (now.Month() == birthdate.Month() && now.Day() < birthdate.Day()) {
+		age--
+	}
+	return age
+}
+
+// InstantiateTemplateItem asks for one template to be created as a chore for the given children.
+// RewardCents, if set, replaces the template's suggested reward.
+type InstantiateTemplateItem struct {
+	TemplateKey string  `json:"template_key"`
+	ChildIDs    []int64 `json:"child_ids"`
+	RewardCents *int    `json:"reward_cents,omitempty"`
+}
+
+// InstantiateTemplatesRequest represents the request body for creating chores from templates.
+type InstantiateTemplatesRequest struct {
+	Items []InstantiateTemplateItem `json:"items"`
+}
+
+// HandleListTemplates handles GET /api/chore-templates
+// The catalog can be narrowed to templates suited to an age, or to one of the family's children
+// by their birthdate, with the age or child_id query parameter.
+func (h *Handler) HandleListTemplates(w http.ResponseWriter, r *http.Request) {
+	if middleware.GetUserType(r) != "parent" {
+		writeJSON(w, http.StatusForbidden, ErrorResponse{Error: "forbidden", Message: "Only parents can browse chore templates."})
+		return
+	}
+
+	age := -1
+	if s := r.URL.Query().Get("age"); s != "" {
+		n, err := strconv.Atoi(s)
+		if err != nil || n < 0 {
+			writeJSON(w, http.StatusBadRequest, ErrorResponse{Error: "invalid_request", Message: "Age must be a non-negative whole number."})
+			return
+		}
+		age = n
+	} else if s := r.URL.Query().Get("child_id"); s != "" {
+		childID, err := strconv.ParseInt(s, 10, 64)
+		if err != nil {
+			writeJSON(w, http.StatusBadRequest, ErrorResponse{Error: "invalid_request", Message: "Invalid child ID."})
+			return
+		}
+		child, err := h.childRepo.GetByID(childID)
+		if err != nil {
+			writeJSON(w, http.StatusInternalServerError, ErrorResponse{Error: "internal_error", Message: "Failed to lookup child."})
+			return
+		}
+		if child == nil || child.FamilyID != middleware.GetFamilyID(r) {
+			writeJSON(w, http.StatusNotFound, ErrorResponse{Error: "child_not_found", Message: "Child not found."})
+			return
+		}
+		if child.Birthdate != nil {
+			age = childAge(*child.Birthdate, time.Now())
+		}
+	}
+
+	templates := templateCatalog
+	if age >= 0 {
+		templates = templatesForAge(age)
+	}
+	writeJSON(w, http.StatusOK, map[string]interface{}{"templates": templates})
+}
+
+// HandleInstantiateTemplates handles POST /api/chore-templates/instantiate
+// All the requested chores are created together, or none are if any item is invalid.
+func (h *Handler) HandleInstantiateTemplates(w http.ResponseWriter, r *http.Request) {
+	if middleware.GetUserType(r) != "parent" {
+		writeJSON(w, http.StatusForbidden, ErrorResponse{Error: "forbidden", Message: "Only parents can create chores."})
+		return
+	}
+
+	var req InstantiateTemplatesRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeJSON(w, http.StatusBadRequest, ErrorResponse{Error: "invalid_request", Message: "Invalid request body."})
+		return
+	}
+	if len(req.Items) == 0 || len(req.Items) > MaxBatchSize {
+		writeJSON(w, http.StatusBadRequest, ErrorResponse{Error: "invalid_request", Message: "Choose between 1 and 50 templates."})
+		return
+	}
+
+	familyID := middleware.GetFamilyID(r)
+	parentID := middleware.GetUserID(r)
+
+	children, err := h.familyChildren(familyID)
+	if err != nil {
+		writeJSON(w, http.StatusInternalServerError, ErrorResponse{Error: "internal_error", Message: "Failed to list children."})
+		return
+	}
+
+	newChores := make([]repositories.NewChore, len(req.Items))
+	for i, item := range req.Items {
+		tmpl := templateByKey(item.TemplateKey)
+		if tmpl == nil {
+			writeJSON(w, http.StatusBadRequest, ErrorResponse{Error: "template_not_found", Message: "Unknown chore template: " + item.TemplateKey + "."})
+			return
+		}
+		if len(item.ChildIDs) == 0 {
+			writeJSON(w, http.StatusBadRequest, ErrorResponse{Error: "invalid_children", Message: "At least one child must be assigned."})
+			return
+		}
+		for _, childID := range item.ChildIDs {
+			if _, ok := children[childID]; !ok {
+				writeJSON(w, http.StatusNotFound, ErrorResponse{Error: "child_not_found", Message: "Child not found."})
+				return
+			}
+		}
+
+		description := tmpl.Description
+		chore := &models.Chore{
+			FamilyID:          familyID,
+			CreatedByParentID: parentID,
+			Name:              tmpl.Name,
+			Description:       &description,
+			RewardCents:       tmpl.RewardCents,
+			Recurrence:        tmpl.Recurrence,
+			DayOfWeek:         tmpl.DayOfWeek,
+			DayOfMonth:        tmpl.DayOfMonth,
+			IsActive:          true,
+		}
+		if item.RewardCents != nil {
+			chore.RewardCents = *item.RewardCents
+		}
+		if code, errMsg := validateChore(chore); code != "" {
+			writeJSON(w, http.StatusBadRequest, ErrorResponse{Error: code, Message: errMsg})
+			return
+		}
+		newChores[i] = repositories.NewChore{Chore: chore, ChildIDs: item.ChildIDs}
+	}
+
+	if err := h.choreRepo.CreateMany(newChores); err != nil {
+		writeJSON(w, http.StatusInternalServerError, ErrorResponse{Error: "internal_error", Message: "Failed to create chores."})
+		return
+	}
+
+	writeJSON(w, http.StatusCreated, map[string]interface{}{"chores": newChoreResponses(newChores, children)})
+}
+
+// familyChildren returns the family's children by ID.
+func (h *Handler) familyChildren(familyID int64) (map[int64]models.Child, error) {
+	children, err := h.childRepo.ListByFamily(familyID)
+	if err != nil {
+		return nil, err
+	}
+	byID := make(map[int64]models.Child, len(children))
+	for _, c := range children {
+		byID[c.ID] = c
+	}
+	return byID, nil
+}
+
+// newChoreResponses converts freshly created chores into API responses.
+func newChoreResponses(newChores []repositories.NewChore, children map[int64]models.Child) []ChoreResponse {
+	resp := make([]ChoreResponse, len(newChores))
+	for i, nc := range newChores {
+		c := nc.Chore
+		assignments := make([]AssignmentResponse, len(nc.ChildIDs))
+		for j, childID := range nc.ChildIDs {
+			assignments[j] = AssignmentResponse{ChildID: childID, ChildName: children[childID].FirstName}
+		}
+		resp[i] = ChoreResponse{
+			ID:                c.ID,
+			FamilyID:          c.FamilyID,
+			Name:              c.Name,
+			Description:       c.Description,
+			RewardCents:       c.RewardCents,
+			Recurrence:        string(c.Recurrence),
+			DayOfWeek:         c.DayOfWeek,
+			DayOfMonth:        c.DayOfMonth,
+			RRule:             c.RRule,
+			IsActive:          c.IsActive,
+			IsBounty:          c.IsBounty,
+			ClaimHours:        c.ClaimHours,
+			IsRotation:        c.IsRotation,
+			PenaltyCents:      c.PenaltyCents,
+			MaxRedos:          c.MaxRedos,
+			DueTime:           c.DueTime,
+			DueWeekday:        c.DueWeekday,
+			LatePayoutPercent: c.LatePayoutPercent,
+			CreatedAt:         c.CreatedAt,
+			UpdatedAt:         c.UpdatedAt,
+			Assignments:       assignments,
+		}
+	}
+	return resp
+}
+
+// validateChore checks all of a chore's settings. Creating a chore, instantiating templates and
+// importing all use it, so they reject the same input the same way. Returns an error code and
+// message, or empty strings if the chore is valid.
+func validateChore(c *models.Chore) (string, string) {
+	if name := strings.TrimSpace(c.Name); len(name) == 0 || len(name) > MaxNameLength {
+		return "invalid_name", "Name must be between 1 and 100 characters."
+	}
+	if c.Description != nil && len(*c.Description) > MaxDescriptionLength {
+		return "invalid_request", "Description must be 500 characters or less."
+	}
+	if c.RewardCents < 0 || c.RewardCents > MaxRewardCents {
+		return "invalid_amount", "Reward must be between 0 and $999,999.99."
+	}
+
+	switch c.Recurrence {
+	case models.ChoreRecurrenceOneTime, models.ChoreRecurrenceDaily:
+	case models.ChoreRecurrenceWeekly:
+		if c.DayOfWeek == nil || *c.DayOfWeek < 0 || *c.DayOfWeek > 6 {
+			return "invalid_recurrence", "Weekly chores require day_of_week (0-6)."
+		}
+	case models.ChoreRecurrenceMonthly:
+		if c.DayOfMonth == nil || *c.DayOfMonth < 1 || *c.DayOfMonth > 31 {
+			return "invalid_recurrence", "Monthly chores require day_of_month (1-31)."
+		}
+	case models.ChoreRecurrenceCustom:
+		if errMsg := validateChoreRRule(c.RRule); errMsg != "" {
+			return "invalid_recurrence", errMsg
+		}
+	default:
+		return "invalid_recurrence", "Recurrence must be one of: one_time, daily, weekly, monthly, custom."
+	}
+
+	if c.IsBounty {
+		if errMsg := validateClaimHours(c.ClaimHours); errMsg != "" {
+			return "invalid_request", errMsg
+		}
+	}
+	for _, errMsg := range []string{validateRotation(c), validatePenalty(c), validateMaxRedos(c), validateDue(c)} {
+		if errMsg != "" {
+			return "invalid_request", errMsg
+		}
+	}
+	return "", ""
+}
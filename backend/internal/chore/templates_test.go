@@ -0,0 +1,86 @@
+package chore
+
+import (
+	"testing"
+	"time"
+
+	"bank-of-dad/models"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTemplateCatalog_Valid(t *testing.T) {
+	seen := make(map[string]bool)
+	for _, tmpl := range templateCatalog {
+		assert.False(t, seen[tmpl.Key], "duplicate template key %s", tmpl.Key)
+		seen[tmpl.Key] = true
+		assert.LessOrEqual(t, tmpl.MinAge, tmpl.MaxAge, tmpl.Key)
+
+		desc := tmpl.Description
+		chore := &models.Chore{
+			Name:        tmpl.Name,
+			Description: &desc,
+			RewardCents: tmpl.RewardCents,
+			Recurrence:  tmpl.Recurrence,
+			DayOfWeek:   tmpl.DayOfWeek,
+			DayOfMonth:  tmpl.DayOfMonth,
+		}
+		code, errMsg := validateChore(chore)
+		assert.Empty(t, code, tmpl.Key)
+		assert.Empty(t, errMsg, tmpl.Key)
+	}
+
+	assert.NotNil(t, templateByKey("make_bed"))
+	assert.Nil(t, templateByKey("juggle_chainsaws"))
+}
+
+func TestTemplatesForAge(t *testing.T) {
+	for _, tmpl := range templatesForAge(4) {
+		assert.LessOrEqual(t, tmpl.MinAge, 4)
+		assert.GreaterOrEqual(t, tmpl.MaxAge, 4)
+	}
+	keys := func(templates []ChoreTemplate) map[string]bool {
+		m := make(map[string]bool)
+		for _, tmpl := range templates {
+			m[tmpl.Key] = true
+		}
+		return m
+	}
+	assert.True(t, keys(templatesForAge(4))["put_away_toys"])
+	assert.False(t, keys(templatesForAge(4))["mow_lawn"])
+	assert.True(t, keys(templatesForAge(14))["mow_lawn"])
+	assert.Empty(t, templatesForAge(1))
+}
+
+func TestChildAge(t *testing.T) {
+	birthdate := time.Date(2016, time.June, 15, 0, 0, 0, 0, time.UTC)
+	assert.Equal(t, 9, childAge(birthdate, time.Date(2026, time.June, 14, 12, 0, 0, 0, time.UTC)))
+	assert.Equal(t, 10, childAge(birthdate, time.Date(2026, time.June, 15, 12, 0, 0, 0, time.UTC)))
+	assert.Equal(t, 10, childAge(birthdate, time.Date(2027, time.January, 1, 0, 0, 0, 0, time.UTC)))
+}
+
+func TestValidateChore(t *testing.T) {
+	tests := []struct {
+		name     string
+		chore    models.Chore
+		wantCode string
+		want     string
+	}{
+		{"valid daily", models.Chore{Name: "Dishes", Recurrence: models.ChoreRecurrenceDaily}, "", ""},
+		{"blank name", models.Chore{Name: "  ", Recurrence: models.ChoreRecurrenceDaily}, "invalid_name", "Name must be between 1 and 100 characters."},
+		{"negative reward", models.Chore{Name: "Dishes", RewardCents: -1, Recurrence: models.ChoreRecurrenceDaily}, "invalid_amount", "Reward must be between 0 and $999,999.99."},
+		{"unknown recurrence", models.Chore{Name: "Dishes", Recurrence: "hourly"}, "invalid_recurrence", "Recurrence must be one of: one_time, daily, weekly, monthly, custom."},
+		{"weekly without day", models.Chore{Name: "Dishes", Recurrence: models.ChoreRecurrenceWeekly}, "invalid_recurrence", "Weekly chores require day_of_week (0-6)."},
+		{"monthly without day", models.Chore{Name: "Dishes", Recurrence: models.ChoreRecurrenceMonthly}, "invalid_recurrence", "Monthly chores require day_of_month (1-31)."},
+		{"custom without rrule", models.Chore{Name: "Dishes", Recurrence: models.ChoreRecurrenceCustom}, "invalid_recurrence", "Custom chores require an rrule."},
+		{"one-time rotation", models.Chore{Name: "Dishes", Recurrence: models.ChoreRecurrenceOneTime, IsRotation: true}, "invalid_request", "Rotation is only available for recurring chores."},
+		{"bounty claim hours", models.Chore{Name: "Dishes", Recurrence: models.ChoreRecurrenceDaily, IsBounty: true, ClaimHours: intPtr(500)}, "invalid_request", "claim_hours must be between 1 and 168."},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			code, errMsg := validateChore(&tt.chore)
+			assert.Equal(t, tt.wantCode, code)
+			assert.Equal(t, tt.want, errMsg)
+		})
+	}
+}
@@ -293,6 +293,7 @@ func (h *Handlers) HandleListChildren(w http.ResponseWriter, r *http.Request) {
 		BalanceCents int64   `json:"balance_cents"`
 		CreatedAt    string  `json:"created_at"`
 		Avatar       *string `json:"avatar"`
+		Birthdate    *string `json:"birthdate"`
 	}
 
 	result := make([]childResponse, len(children))
@@ -306,6 +307,10 @@ func (h *Handlers) HandleListChildren(w http.ResponseWriter, r *http.Request) {
 			CreatedAt:    c.CreatedAt.Format("2006-01-02T15:04:05Z"),
 			Avatar:       c.Avatar,
 		}
+		if c.Birthdate != nil {
+			bd := c.Birthdate.Format("2006-01-02")
+			result[i].Birthdate = &bd
+		}
 	}
 
 	writeJSON(w, http.StatusOK, map[string]interface{}{"children": result})
@@ -453,6 +458,65 @@ func (h *Handlers) HandleUpdateName(w http.ResponseWriter, r *http.Request) {
 	})
 }
 
+// HandleUpdateBirthdate sets or clears a child's birthdate ("YYYY-MM-DD", or null to clear).
+// Age-based allowances pick up the change on the next scheduler run.
+func (h *Handlers) HandleUpdateBirthdate(w http.ResponseWriter, r *http.Request) {
+	familyID := auth.GetFamilyID(r)
+	childID, err := strconv.ParseInt(r.PathValue("id"), 10, 64)
+	if err != nil {
+		writeJSON(w, http.StatusBadRequest, map[string]string{"error": "Invalid child ID"})
+		return
+	}
+
+	child, err := h.childRepo.GetByID(childID)
+	if err != nil || child == nil {
+		writeJSON(w, http.StatusNotFound, map[string]string{"error": "Child not found"})
+		return
+	}
+
+	if child.FamilyID != familyID {
+		writeJSON(w, http.StatusForbidden, map[string]string{"error": "Forbidden"})
+		return
+	}
+
+	var req struct {
+		Birthdate *string `json:"birthdate"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeJSON(w, http.StatusBadRequest, map[string]string{"error": "Invalid request body"})
+		return
+	}
+
+	var birthdate *time.Time
+	if req.Birthdate != nil && *req.Birthdate != "" {
+		bd, err := ValidateBirthdate(*req.Birthdate, time.Now().UTC())
+		if err != nil {
+			writeJSON(w, http.StatusBadRequest, map[string]interface{}{
+				"error":   "Invalid birthdate",
+				"message": err.Error(),
+			})
+			return
+		}
+		birthdate = &bd
+	}
+
+	if err := h.childRepo.UpdateBirthdate(childID, birthdate); err != nil {
+		writeJSON(w, http.StatusInternalServerError, map[string]string{"error": "Failed to update birthdate"})
+		return
+	}
+
+	var birthdateVal *string
+	if birthdate != nil {
+		formatted := birthdate.Format("2006-01-02")
+		birthdateVal = &formatted
+	}
+
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"message":   "Birthdate updated",
+		"birthdate": birthdateVal,
+	})
+}
+
 func (h *Handlers) HandleDeleteChild(w http.ResponseWriter, r *http.Request) {
 	familyID := auth.GetFamilyID(r)
 	childID, err := strconv.ParseInt(r.PathValue("id"), 10, 64)
@@ -412,3 +412,58 @@ func TestHandleCreateChild_MaxChildrenLimit(t *testing.T) {
 	require.NoError(t, err)
 	assert.Equal(t, "Limit reached", resp["error"])
 }
+
+func TestHandleUpdateBirthdate_SetAndClear(t *testing.T) {
+	h, familyRepo, childRepo := newTestHandlers(t)
+
+	fam, err := familyRepo.Create("test-family")
+	require.NoError(t, err)
+
+	child, err := childRepo.Create(fam.ID, "Alice", "password123", nil)
+	require.NoError(t, err)
+
+	req := httptest.NewRequest("PUT", "/api/children/1/birthdate", strings.NewReader(`{"birthdate":"2016-05-10"}`))
+	req.SetPathValue("id", fmt.Sprintf("%d", child.ID))
+	req = testutil.SetRequestContext(req, "parent", 1, fam.ID)
+	rr := httptest.NewRecorder()
+
+	h.HandleUpdateBirthdate(rr, req)
+
+	assert.Equal(t, http.StatusOK, rr.Code)
+	updated, err := childRepo.GetByID(child.ID)
+	require.NoError(t, err)
+	require.NotNil(t, updated.Birthdate)
+	assert.Equal(t, "2016-05-10", updated.Birthdate.Format("2006-01-02"))
+
+	req = httptest.NewRequest("PUT", "/api/children/1/birthdate", strings.NewReader(`{"birthdate":null}`))
+	req.SetPathValue("id", fmt.Sprintf("%d", child.ID))
+	req = testutil.SetRequestContext(req, "parent", 1, fam.ID)
+	rr = httptest.NewRecorder()
+
+	h.HandleUpdateBirthdate(rr, req)
+
+	assert.Equal(t, http.StatusOK, rr.Code)
+	updated, err = childRepo.GetByID(child.ID)
+	require.NoError(t, err)
+	assert.Nil(t, updated.Birthdate)
+}
+
+func TestHandleUpdateBirthdate_RejectsFutureDate(t *testing.T) {
+	h, familyRepo, childRepo := newTestHandlers(t)
+
+	fam, err := familyRepo.Create("test-family")
+	require.NoError(t, err)
+
+	child, err := childRepo.Create(fam.ID, "Alice", "password123", nil)
+	require.NoError(t, err)
+
+	future := time.Now().UTC().AddDate(1, 0, 0).Format("2006-01-02")
+	req := httptest.NewRequest("PUT", "/api/children/1/birthdate", strings.NewReader(`{"birthdate":"`+future+`"}`))
+	req.SetPathValue("id", fmt.Sprintf("%d", child.ID))
+	req = testutil.SetRequestContext(req, "parent", 1, fam.ID)
+	rr := httptest.NewRecorder()
+
+	h.HandleUpdateBirthdate(rr, req)
+
+	assert.Equal(t, http.StatusBadRequest, rr.Code)
+}
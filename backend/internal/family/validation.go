@@ -4,6 +4,7 @@ import (
 	"bank-of-dad/models"
 	"fmt"
 	"strings"
+	"time"
 )
 
 func ValidateSlug(slug string) error {
@@ -33,3 +34,19 @@ func ValidateChildName(name string) error {
 	}
 	return nil
 }
+
+// ValidateBirthdate parses a "YYYY-MM-DD" birthdate and checks it is not in the future
+// and at most 30 years before today.
+func ValidateBirthdate(value string, today time.Time) (time.Time, error) {
+	bd, err := time.Parse("2006-01-02", value)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("birthdate must be a date in YYYY-MM-DD format")
+	}
+	if bd.After(today) {
+		return time.Time{}, fmt.Errorf("birthdate cannot be in the future")
+	}
+	if bd.Before(today.AddDate(-30, 0, 0)) {
+		return time.Time{}, fmt.Errorf("birthdate must be within the last 30 years")
+	}
+	return bd, nil
+}
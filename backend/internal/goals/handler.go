@@ -2,9 +2,11 @@ package goals
 
 import (
 	"encoding/json"
+	"log"
 	"net/http"
 	"strconv"
 	"strings"
+	"time"
 
 	"bank-of-dad/internal/middleware"
 	"bank-of-dad/models"
@@ -22,6 +24,13 @@ type Handler struct {
 	goalRepo           *repositories.SavingsGoalRepo
 	childRepo          *repositories.ChildRepo
 	goalAllocationRepo *repositories.GoalAllocationRepo
+	allocationRuleRepo *repositories.GoalAllocationRuleRepo
+	sharedGoalRepo     *repositories.SharedGoalRepo
+
+	// Pacing guidance for goals with a target date forecasts allowance and interest
+	scheduleRepo         *repositories.ScheduleRepo
+	interestScheduleRepo *repositories.InterestScheduleRepo
+	familyRepo           *repositories.FamilyRepo
 }
 
 // NewHandler creates a new goals handler.
@@ -33,6 +42,24 @@ func NewHandler(goalRepo *repositories.SavingsGoalRepo, childRepo *repositories.
 	}
 }
 
+// SetAllocationRuleRepo sets the repo used to manage a child's standing goal allocation rules.
+func (h *Handler) SetAllocationRuleRepo(repo *repositories.GoalAllocationRuleRepo) {
+	h.allocationRuleRepo = repo
+}
+
+// SetSharedGoalRepo sets the repo used to manage the family's shared goals.
+func (h *Handler) SetSharedGoalRepo(repo *repositories.SharedGoalRepo) {
+	h.sharedGoalRepo = repo
+}
+
+// SetForecastRepos sets the repos used to forecast a child's allowance and interest when pacing
+// goals with a target date, and the family repo used for the family's timezone.
+func (h *Handler) SetForecastRepos(scheduleRepo *repositories.ScheduleRepo, interestScheduleRepo *repositories.InterestScheduleRepo, familyRepo *repositories.FamilyRepo) {
+	h.scheduleRepo = scheduleRepo
+	h.interestScheduleRepo = interestScheduleRepo
+	h.familyRepo = familyRepo
+}
+
 // ErrorResponse represents an error response.
 type ErrorResponse struct {
 	Error   string `json:"error"`
@@ -107,17 +134,19 @@ func (h *Handler) verifyChildOwner(r *http.Request, childID int64) (int, *ErrorR
 }
 
 // CreateRequest represents a create savings goal request body.
+// TargetDate (YYYY-MM-DD) optionally sets the date the child aims to reach the target by.
 type CreateRequest struct {
 	Name        string  `json:"name"`
 	TargetCents int64   `json:"target_cents"`
 	Emoji       *string `json:"emoji,omitempty"`
+	TargetDate  string  `json:"target_date,omitempty"`
 }
 
 // SavingsGoalsResponse represents the response for listing goals.
 type SavingsGoalsResponse struct {
-	Goals                 []*models.SavingsGoal `json:"goals"`
-	AvailableBalanceCents int64                 `json:"available_balance_cents"`
-	TotalSavedCents       int64                 `json:"total_saved_cents"`
+	Goals                 []GoalWithPace `json:"goals"`
+	AvailableBalanceCents int64          `json:"available_balance_cents"`
+	TotalSavedCents       int64          `json:"total_saved_cents"`
 }
 
 // HandleCreate handles POST /api/children/{id}/savings-goals
@@ -160,6 +189,12 @@ func (h *Handler) HandleCreate(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	targetDate, errMsg := parseTargetDate(req.TargetDate, time.Now(), h.familyLocation(middleware.GetFamilyID(r)))
+	if errMsg != "" {
+		writeJSON(w, http.StatusBadRequest, ErrorResponse{Error: "invalid_target_date", Message: errMsg})
+		return
+	}
+
 	// Check max active goals
 	count, err := h.goalRepo.CountActiveByChild(childID)
 	if err != nil {
@@ -171,7 +206,7 @@ func (h *Handler) HandleCreate(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	goal, err := h.goalRepo.Create(childID, name, req.TargetCents, req.Emoji)
+	goal, err := h.goalRepo.CreateWithTargetDate(childID, name, req.TargetCents, req.Emoji, targetDate)
 	if err != nil {
 		writeJSON(w, http.StatusInternalServerError, ErrorResponse{Error: "internal_error", Message: "Failed to create goal."})
 		return
@@ -203,12 +238,11 @@ func (h *Handler) HandleList(w http.ResponseWriter, r *http.Request) {
 		goals = []*models.SavingsGoal{}
 	}
 
-	// Compute totals from active goals
-	var totalSavedCents int64
-	for _, g := range goals {
-		if g.Status == "active" {
-			totalSavedCents += g.SavedCents
-		}
+	// Total set aside across active goals, including contributions to shared goals
+	totalSavedCents, err := h.goalRepo.GetTotalSavedByChild(childID)
+	if err != nil {
+		writeJSON(w, http.StatusInternalServerError, ErrorResponse{Error: "internal_error", Message: "Failed to get total saved."})
+		return
 	}
 
 	// Get child's total balance to compute available balance
@@ -220,18 +254,43 @@ func (h *Handler) HandleList(w http.ResponseWriter, r *http.Request) {
 
 	availableBalanceCents := child.BalanceCents - totalSavedCents
 
+	// Pace active goals with a target date; pacing is guidance, so a failed forecast only omits it
+	result := make([]GoalWithPace, len(goals))
+	var forecast *savingsForecast
+	now := time.Now()
+	for i, g := range goals {
+		result[i].SavingsGoal = g
+		if g.Status != "active" || g.TargetDate == nil {
+			continue
+		}
+		if forecast == nil {
+			if forecast, err = h.loadForecast(child); err != nil {
+				log.Printf("Error loading savings forecast for child %d: %v", childID, err)
+				break
+			}
+		}
+		scheduled, err := forecast.contributions(g.ID, now, targetDeadline(*g.TargetDate, forecast.loc))
+		if err != nil {
+			log.Printf("Error forecasting contributions to goal %d: %v", g.ID, err)
+			continue
+		}
+		result[i].Pace = goalPace(g, scheduled, now, forecast.loc)
+	}
+
 	writeJSON(w, http.StatusOK, SavingsGoalsResponse{
-		Goals:                 goals,
+		Goals:                 result,
 		AvailableBalanceCents: availableBalanceCents,
 		TotalSavedCents:       totalSavedCents,
 	})
 }
 
 // UpdateRequest represents a partial update request for a savings goal.
+// An empty TargetDate removes the goal's target date.
 type UpdateRequest struct {
 	Name        *string `json:"name,omitempty"`
 	TargetCents *int64  `json:"target_cents,omitempty"`
 	Emoji       *string `json:"emoji"`
+	TargetDate  *string `json:"target_date,omitempty"`
 }
 
 // HandleUpdate handles PUT /api/children/{id}/savings-goals/{goalId}
@@ -290,6 +349,16 @@ func (h *Handler) HandleUpdate(w http.ResponseWriter, r *http.Request) {
 		TargetCents: req.TargetCents,
 	}
 
+	if req.TargetDate != nil {
+		targetDate, errMsg := parseTargetDate(*req.TargetDate, time.Now(), h.familyLocation(middleware.GetFamilyID(r)))
+		if errMsg != "" {
+			writeJSON(w, http.StatusBadRequest, ErrorResponse{Error: "invalid_target_date", Message: errMsg})
+			return
+		}
+		params.TargetDate = targetDate
+		params.TargetDateSet = true
+	}
+
 	// Handle emoji: the JSON decoder will set Emoji to non-nil even for explicit null,
 	// but we use EmojiSet to indicate the field was present in the JSON.
 	// Since UpdateRequest uses *string for emoji, if it was present in JSON it will be set.
@@ -438,6 +507,60 @@ func (h *Handler) HandleAllocate(w http.ResponseWriter, r *http.Request) {
 	})
 }
 
+// RedeemResponse represents the response after redeeming a completed goal.
+type RedeemResponse struct {
+	Goal            *models.SavingsGoal `json:"goal"`
+	Transaction     *models.Transaction `json:"transaction"`
+	NewBalanceCents int64               `json:"new_balance_cents"`
+}
+
+// HandleRedeem handles POST /api/children/{id}/savings-goals/{goalId}/redeem
+// A parent spends a completed goal's saved funds, withdrawing them and marking the goal purchased.
+// Children redeem a goal by submitting a withdrawal request for it, for a parent to approve.
+func (h *Handler) HandleRedeem(w http.ResponseWriter, r *http.Request) {
+	childID, err := parseChildID(r)
+	if err != nil {
+		writeJSON(w, http.StatusBadRequest, ErrorResponse{Error: "invalid_child_id", Message: "Invalid child ID."})
+		return
+	}
+
+	goalID, err := parseGoalID(r)
+	if err != nil {
+		writeJSON(w, http.StatusBadRequest, ErrorResponse{Error: "invalid_goal_id", Message: "Invalid goal ID."})
+		return
+	}
+
+	if middleware.GetUserType(r) != "parent" {
+		writeJSON(w, http.StatusForbidden, ErrorResponse{Error: "forbidden", Message: "Only parents can redeem goals. Submit a withdrawal request for the goal instead."})
+		return
+	}
+	if status, errResp := h.verifyChildAccess(r, childID); errResp != nil {
+		writeJSON(w, status, errResp)
+		return
+	}
+
+	goal, transaction, newBalance, err := h.goalRepo.Redeem(goalID, childID, middleware.GetUserID(r))
+	if err != nil {
+		switch err {
+		case repositories.ErrGoalNotFound:
+			writeJSON(w, http.StatusNotFound, ErrorResponse{Error: "not_found", Message: "Goal not found."})
+		case repositories.ErrGoalNotRedeemable:
+			writeJSON(w, http.StatusConflict, ErrorResponse{Error: "not_redeemable", Message: "Only completed goals that have not been purchased can be redeemed."})
+		case models.ErrInsufficientFunds:
+			writeJSON(w, http.StatusUnprocessableEntity, ErrorResponse{Error: "insufficient_funds", Message: "The balance no longer covers this goal."})
+		default:
+			writeJSON(w, http.StatusInternalServerError, ErrorResponse{Error: "internal_error", Message: "Failed to redeem goal."})
+		}
+		return
+	}
+
+	writeJSON(w, http.StatusOK, RedeemResponse{
+		Goal:            goal,
+		Transaction:     transaction,
+		NewBalanceCents: newBalance,
+	})
+}
+
 // HandleListAllocations handles GET /api/children/{id}/savings-goals/{goalId}/allocations
 func (h *Handler) HandleListAllocations(w http.ResponseWriter, r *http.Request) {
 	childID, err := parseChildID(r)
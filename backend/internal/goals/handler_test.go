@@ -31,6 +31,8 @@ func setupHandler(t *testing.T) (*Handler, *repositories.SavingsGoalRepo, *repos
 	childRepo := repositories.NewChildRepo(db)
 	goalAllocationRepo := repositories.NewGoalAllocationRepo(db)
 	handler := NewHandler(goalRepo, childRepo, goalAllocationRepo)
+	handler.SetAllocationRuleRepo(repositories.NewGoalAllocationRuleRepo(db))
+	handler.SetSharedGoalRepo(repositories.NewSharedGoalRepo(db))
 
 	return handler, goalRepo, childRepo, family, parent, child
 }
@@ -348,6 +350,8 @@ func setupHandlerWithBalance(t *testing.T) (*Handler, *repositories.SavingsGoalR
 	childRepo := repositories.NewChildRepo(db)
 	goalAllocationRepo := repositories.NewGoalAllocationRepo(db)
 	handler := NewHandler(goalRepo, childRepo, goalAllocationRepo)
+	handler.SetAllocationRuleRepo(repositories.NewGoalAllocationRuleRepo(db))
+	handler.SetSharedGoalRepo(repositories.NewSharedGoalRepo(db))
 
 	return handler, goalRepo, childRepo, family, parent, child, db
 }
@@ -887,3 +891,67 @@ func TestHandleDelete_404_NotFoundOrCompleted(t *testing.T) {
 
 	assert.Equal(t, http.StatusNotFound, rr.Code)
 }
+
+// =====================================================
+// Tests for POST /api/children/{id}/savings-goals/{goalId}/redeem (HandleRedeem)
+// =====================================================
+
+func redeemRequest(child *models.Child, goalID int64, userType string, userID int64) *http.Request {
+	req := httptest.NewRequest("POST", "/api/children/1/savings-goals/"+strconv.FormatInt(goalID, 10)+"/redeem", nil)
+	req.SetPathValue("id", strconv.FormatInt(child.ID, 10))
+	req.SetPathValue("goalId", strconv.FormatInt(goalID, 10))
+	return testutil.SetRequestContext(req, userType, userID, child.FamilyID)
+}
+
+func TestHandleRedeem_200_ParentRedeemsCompletedGoal(t *testing.T) {
+	handler, goalRepo, childRepo, _, parent, child, _ := setupHandlerWithBalance(t)
+
+	goal, err := goalRepo.Create(child.ID, "Skateboard", 3000, nil)
+	require.NoError(t, err)
+	_, err = goalRepo.Allocate(goal.ID, child.ID, 3000)
+	require.NoError(t, err)
+
+	rr := httptest.NewRecorder()
+	handler.HandleRedeem(rr, redeemRequest(child, goal.ID, "parent", parent.ID))
+	require.Equal(t, http.StatusOK, rr.Code, rr.Body.String())
+
+	var resp RedeemResponse
+	require.NoError(t, json.NewDecoder(rr.Body).Decode(&resp))
+	assert.Equal(t, "purchased", resp.Goal.Status)
+	assert.NotNil(t, resp.Goal.PurchasedAt)
+	require.NotNil(t, resp.Goal.PurchaseTransactionID)
+	assert.Equal(t, resp.Transaction.ID, *resp.Goal.PurchaseTransactionID)
+	assert.Equal(t, int64(3000), resp.Transaction.AmountCents)
+	assert.Equal(t, models.TransactionTypeWithdrawal, resp.Transaction.TransactionType)
+	assert.Equal(t, int64(7000), resp.NewBalanceCents)
+
+	balance, err := childRepo.GetBalance(child.ID)
+	require.NoError(t, err)
+	assert.Equal(t, int64(7000), balance)
+
+	// A purchased goal can't be redeemed again
+	rr = httptest.NewRecorder()
+	handler.HandleRedeem(rr, redeemRequest(child, goal.ID, "parent", parent.ID))
+	assert.Equal(t, http.StatusConflict, rr.Code)
+}
+
+func TestHandleRedeem_RejectsActiveGoalAndChildCaller(t *testing.T) {
+	handler, goalRepo, childRepo, _, parent, child, _ := setupHandlerWithBalance(t)
+
+	goal, err := goalRepo.Create(child.ID, "Skateboard", 3000, nil)
+	require.NoError(t, err)
+	_, err = goalRepo.Allocate(goal.ID, child.ID, 1000)
+	require.NoError(t, err)
+
+	rr := httptest.NewRecorder()
+	handler.HandleRedeem(rr, redeemRequest(child, goal.ID, "parent", parent.ID))
+	assert.Equal(t, http.StatusConflict, rr.Code)
+
+	rr = httptest.NewRecorder()
+	handler.HandleRedeem(rr, redeemRequest(child, goal.ID, "child", child.ID))
+	assert.Equal(t, http.StatusForbidden, rr.Code)
+
+	balance, err := childRepo.GetBalance(child.ID)
+	require.NoError(t, err)
+	assert.Equal(t, int64(10000), balance)
+}
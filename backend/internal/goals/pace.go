@@ -0,0 +1,249 @@
+package goals
+
+import (
+	"fmt"
+	"math"
+	"time"
+
+	"bank-of-dad/internal/allowance"
+	"bank-of-dad/models"
+	"bank-of-dad/repositories"
+)
+
+// PaceStatus says how a goal with a target date is doing against it.
+type PaceStatus string
+
+const (
+	PaceOnTrack PaceStatus = "on_track"
+	PaceBehind  PaceStatus = "behind"
+	PaceAhead   PaceStatus = "ahead"
+)
+
+const week = 7 * 24 * time.Hour
+
+// GoalPace is the savings guidance for an active goal with a target date.
+// ScheduledCents is what allowance splits and allocation rules are expected to put into the goal
+// by its target date; RequiredWeeklyCents is what the child needs to save on top of that each week.
+type GoalPace struct {
+	Status              PaceStatus `json:"status"`
+	ScheduledCents      int64      `json:"scheduled_cents"`
+	RequiredWeeklyCents int64      `json:"required_weekly_cents"`
+	Message             string     `json:"message"`
+}
+
+// GoalWithPace is a savings goal with its pacing guidance, if it is active and has a target date.
+type GoalWithPace struct {
+	*models.SavingsGoal
+	Pace *GoalPace `json:"pace,omitempty"`
+}
+
+// savingsForecast holds what is needed to estimate the money a child's allowance schedules and
+// interest will put into their goals automatically.
+type savingsForecast struct {
+	schedules        []models.AllowanceSchedule
+	interestSchedule *models.InterestSchedule
+	rules            []models.GoalAllocationRule
+	balanceCents     int64
+	interestRateBps  int
+	loc              *time.Location
+}
+
+// loadForecast gathers a child's allowance schedules, interest schedule and allocation rules.
+// Sources whose repos are not set are left out of the forecast.
+func (h *Handler) loadForecast(child *models.Child) (*savingsForecast, error) {
+	f := &savingsForecast{
+		balanceCents:    child.BalanceCents,
+		interestRateBps: child.InterestRateBps,
+		loc:             h.familyLocation(child.FamilyID),
+	}
+
+	var err error
+	if h.scheduleRepo != nil {
+		if f.schedules, err = h.scheduleRepo.ListActiveByChild(child.ID); err != nil {
+			return nil, err
+		}
+	}
+	if h.interestScheduleRepo != nil {
+		if f.interestSchedule, err = h.interestScheduleRepo.GetByChildID(child.ID); err != nil {
+			return nil, err
+		}
+	}
+	if h.allocationRuleRepo != nil {
+		if f.rules, err = h.allocationRuleRepo.ListByChild(child.ID); err != nil {
+			return nil, err
+		}
+	}
+	return f, nil
+}
+
+// ruleShare returns how much a payout of amountCents from source puts into goalID through allocation rules.
+func (f *savingsForecast) ruleShare(goalID int64, source models.AllocationSource, amountCents int64) int64 {
+	var share int64
+	for i := range f.rules {
+		if f.rules[i].GoalID == goalID && f.rules[i].Source == source {
+			share += f.rules[i].Share(amountCents)
+		}
+	}
+	return share
+}
+
+// contributions estimates what the child's allowance runs and interest payments between now and
+// until will put into goalID, at current allowance amounts, balance and interest rate.
+func (f *savingsForecast) contributions(goalID int64, now, until time.Time) (int64, error) {
+	var total int64
+
+	for i := range f.schedules {
+		sched := &f.schedules[i]
+		perRun := f.ruleShare(goalID, models.AllocationSourceAllowance, sched.AmountCents)
+		for _, split := range sched.Splits {
+			if split.GoalID == goalID {
+				perRun += sched.AmountCents * int64(split.Percent) / 100
+			}
+		}
+		if perRun <= 0 {
+			continue
+		}
+		runs, err := allowance.UpcomingRuns(sched, now, until, f.loc)
+		if err != nil {
+			return 0, fmt.Errorf("forecast schedule %d: %w", sched.ID, err)
+		}
+		total += perRun * int64(len(runs))
+	}
+
+	is := f.interestSchedule
+	if is != nil && is.Status == models.ScheduleStatusActive && f.interestRateBps > 0 && f.balanceCents > 0 {
+		interest := repositories.InterestForPeriod(f.balanceCents, f.interestRateBps, is.Frequency)
+		if perRun := f.ruleShare(goalID, models.AllocationSourceInterest, interest); perRun > 0 {
+			// Interest schedules share the allowance schedule's recurrence fields
+			runs, err := allowance.UpcomingRuns(&models.AllowanceSchedule{
+				Frequency:  is.Frequency,
+				DayOfWeek:  is.DayOfWeek,
+				DayOfMonth: is.DayOfMonth,
+				RRule:      is.RRule,
+				NextRunAt:  is.NextRunAt,
+			}, now, until, f.loc)
+			if err != nil {
+				return 0, fmt.Errorf("forecast interest: %w", err)
+			}
+			total += perRun * int64(len(runs))
+		}
+	}
+
+	return total, nil
+}
+
+// familyLocation loads the family's timezone, falling back to UTC.
+func (h *Handler) familyLocation(familyID int64) *time.Location {
+	if h.familyRepo == nil {
+		return time.UTC
+	}
+	tz, err := h.familyRepo.GetTimezone(familyID)
+	if err != nil || tz == "" {
+		return time.UTC
+	}
+	loc, err := time.LoadLocation(tz)
+	if err != nil {
+		return time.UTC
+	}
+	return loc
+}
+
+// parseTargetDate parses a target_date request value, which must be after today in loc.
+// An empty string clears the target date. Returns an error message or empty string.
+func parseTargetDate(value string, now time.Time, loc *time.Location) (*time.Time, string) {
+	if value == "" {
+		return nil, ""
+	}
+	targetDate, err := time.Parse(time.DateOnly, value)
+	if err != nil {
+		return nil, "Target date must be in YYYY-MM-DD format."
+	}
+	if targetDate.Format(time.DateOnly) <= now.In(loc).Format(time.DateOnly) {
+		return nil, "Target date must be in the future."
+	}
+	return &targetDate, ""
+}
+
+// targetDeadline returns the end of a goal's target date in loc.
+func targetDeadline(targetDate time.Time, loc *time.Location) time.Time {
+	return time.Date(targetDate.Year(), targetDate.Month(), targetDate.Day()+1, 0, 0, 0, 0, loc)
+}
+
+// goalPace works out the guidance for an active goal with a target date, given what its automatic
+// contributions are expected to add by then.
+//
+// The child needs to save whatever those contributions leave short, spread evenly over the weeks
+// left (at least one). They are ahead if the contributions alone will reach the target or they have
+// saved a week more than an even pace from the goal's creation to its target date would have, and
+// behind if they have saved a week less or the date has passed.
+func goalPace(goal *models.SavingsGoal, scheduledCents int64, now time.Time, loc *time.Location) *GoalPace {
+	if goal.Status != "active" || goal.TargetDate == nil {
+		return nil
+	}
+
+	deadline := targetDeadline(*goal.TargetDate, loc)
+	remaining := goal.TargetCents - goal.SavedCents
+	if scheduledCents > remaining {
+		scheduledCents = remaining
+	}
+
+	if !now.Before(deadline) {
+		return &GoalPace{
+			Status:              PaceBehind,
+			RequiredWeeklyCents: remaining,
+			Message:             fmt.Sprintf("Your target date has passed. You still need %s.", formatCents(remaining)),
+		}
+	}
+
+	byDate := formatTargetDate(*goal.TargetDate, now.In(loc))
+	extra := remaining - scheduledCents
+	if extra <= 0 {
+		return &GoalPace{
+			Status:         PaceAhead,
+			ScheduledCents: scheduledCents,
+			Message:        fmt.Sprintf("You're ahead! Your automatic savings will get you there by %s.", byDate),
+		}
+	}
+
+	weeksLeft := math.Max(float64(deadline.Sub(now))/float64(week), 1)
+	pace := &GoalPace{
+		Status:              PaceOnTrack,
+		ScheduledCents:      scheduledCents,
+		RequiredWeeklyCents: int64(math.Ceil(float64(extra) / weeksLeft)),
+	}
+
+	// Compare what has been saved with an even pace from creation to the target date
+	span := float64(deadline.Sub(goal.CreatedAt))
+	if span > 0 {
+		elapsed := math.Max(float64(now.Sub(goal.CreatedAt)), 0)
+		expected := float64(goal.TargetCents) * elapsed / span
+		slack := float64(goal.TargetCents) * float64(week) / span
+		switch saved := float64(goal.SavedCents); {
+		case saved >= expected+slack:
+			pace.Status = PaceAhead
+		case saved < expected-slack:
+			pace.Status = PaceBehind
+		}
+	}
+
+	if pace.Status == PaceBehind {
+		pace.Message = fmt.Sprintf("Save %s/week to catch up by %s.", formatCents(pace.RequiredWeeklyCents), byDate)
+	} else {
+		pace.Message = fmt.Sprintf("Save %s/week to get there by %s.", formatCents(pace.RequiredWeeklyCents), byDate)
+	}
+	return pace
+}
+
+// formatTargetDate renders a target date for a pacing message, e.g. "June 5",
+// with the year when it is not the current one.
+func formatTargetDate(targetDate, now time.Time) string {
+	if targetDate.Year() != now.Year() {
+		return targetDate.Format("January 2, 2006")
+	}
+	return targetDate.Format("January 2")
+}
+
+// formatCents renders an amount in cents as dollars, e.g. 320 as "$3.20".
+func formatCents(cents int64) string {
+	return fmt.Sprintf("$%d.%02d", cents/100, cents%100)
+}
@@ -0,0 +1,239 @@
+package goals
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+	"time"
+
+	"bank-of-dad/internal/testutil"
+	"bank-of-dad/models"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func paceGoal(targetCents, savedCents int64, createdAt, targetDate time.Time) *models.SavingsGoal {
+	return &models.SavingsGoal{
+		ID:          1,
+		TargetCents: targetCents,
+		SavedCents:  savedCents,
+		Status:      "active",
+		TargetDate:  &targetDate,
+		CreatedAt:   createdAt,
+	}
+}
+
+func TestGoalPace_OnTrack(t *testing.T) {
+	created := time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC)
+	now := created.Add(5 * week)
+	// 10 weeks from creation to the end of the target date, halfway there after 5
+	goal := paceGoal(10000, 5000, created, time.Date(2026, 5, 9, 0, 0, 0, 0, time.UTC))
+
+	pace := goalPace(goal, 0, now, time.UTC)
+	require.NotNil(t, pace)
+	assert.Equal(t, PaceOnTrack, pace.Status)
+	assert.Equal(t, int64(1000), pace.RequiredWeeklyCents)
+	assert.Equal(t, "Save $10.00/week to get there by May 9.", pace.Message)
+}
+
+func TestGoalPace_ScheduledContributionsReduceRequired(t *testing.T) {
+	created := time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC)
+	now := created.Add(5 * week)
+	goal := paceGoal(10000, 5000, created, time.Date(2026, 5, 9, 0, 0, 0, 0, time.UTC))
+
+	pace := goalPace(goal, 3400, now, time.UTC)
+	require.NotNil(t, pace)
+	assert.Equal(t, int64(3400), pace.ScheduledCents)
+	assert.Equal(t, int64(320), pace.RequiredWeeklyCents)
+	assert.Equal(t, "Save $3.20/week to get there by May 9.", pace.Message)
+}
+
+func TestGoalPace_Behind(t *testing.T) {
+	created := time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC)
+	now := created.Add(5 * week)
+	goal := paceGoal(10000, 2000, created, time.Date(2026, 5, 9, 0, 0, 0, 0, time.UTC))
+
+	pace := goalPace(goal, 0, now, time.UTC)
+	require.NotNil(t, pace)
+	assert.Equal(t, PaceBehind, pace.Status)
+	assert.Equal(t, int64(1600), pace.RequiredWeeklyCents)
+	assert.Equal(t, "Save $16.00/week to catch up by May 9.", pace.Message)
+}
+
+func TestGoalPace_AheadOfEvenPace(t *testing.T) {
+	created := time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC)
+	now := created.Add(5 * week)
+	goal := paceGoal(10000, 7000, created, time.Date(2026, 5, 9, 0, 0, 0, 0, time.UTC))
+
+	pace := goalPace(goal, 0, now, time.UTC)
+	require.NotNil(t, pace)
+	assert.Equal(t, PaceAhead, pace.Status)
+	assert.Equal(t, int64(600), pace.RequiredWeeklyCents)
+}
+
+func TestGoalPace_ScheduledCoversRemaining(t *testing.T) {
+	created := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	now := created.Add(week)
+	goal := paceGoal(10000, 0, created, time.Date(2027, 1, 15, 0, 0, 0, 0, time.UTC))
+
+	pace := goalPace(goal, 12000, now, time.UTC)
+	require.NotNil(t, pace)
+	assert.Equal(t, PaceAhead, pace.Status)
+	assert.Equal(t, int64(10000), pace.ScheduledCents)
+	assert.Equal(t, int64(0), pace.RequiredWeeklyCents)
+	assert.Equal(t, "You're ahead! Your automatic savings will get you there by January 15, 2027.", pace.Message)
+}
+
+func TestGoalPace_TargetDatePassed(t *testing.T) {
+	created := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	goal := paceGoal(10000, 4000, created, time.Date(2026, 4, 1, 0, 0, 0, 0, time.UTC))
+
+	pace := goalPace(goal, 0, time.Date(2026, 4, 2, 9, 0, 0, 0, time.UTC), time.UTC)
+	require.NotNil(t, pace)
+	assert.Equal(t, PaceBehind, pace.Status)
+	assert.Equal(t, int64(6000), pace.RequiredWeeklyCents)
+	assert.Equal(t, "Your target date has passed. You still need $60.00.", pace.Message)
+}
+
+func TestGoalPace_LastWeekNeedsEverything(t *testing.T) {
+	created := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	goal := paceGoal(10000, 9000, created, time.Date(2026, 4, 1, 0, 0, 0, 0, time.UTC))
+
+	pace := goalPace(goal, 0, time.Date(2026, 3, 30, 9, 0, 0, 0, time.UTC), time.UTC)
+	require.NotNil(t, pace)
+	assert.Equal(t, int64(1000), pace.RequiredWeeklyCents)
+}
+
+func TestGoalPace_NoTargetDateOrInactive(t *testing.T) {
+	now := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	assert.Nil(t, goalPace(&models.SavingsGoal{Status: "active", TargetCents: 100}, 0, now, time.UTC))
+
+	goal := paceGoal(10000, 10000, now, now.AddDate(0, 1, 0))
+	goal.Status = "completed"
+	assert.Nil(t, goalPace(goal, 0, now, time.UTC))
+}
+
+func TestParseTargetDate(t *testing.T) {
+	ny, err := time.LoadLocation("America/New_York")
+	require.NoError(t, err)
+	// Still March 1 in New York
+	now := time.Date(2026, 3, 2, 3, 0, 0, 0, time.UTC)
+
+	date, errMsg := parseTargetDate("2026-03-02", now, ny)
+	assert.Empty(t, errMsg)
+	require.NotNil(t, date)
+	assert.Equal(t, "2026-03-02", date.Format(time.DateOnly))
+
+	_, errMsg = parseTargetDate("2026-03-01", now, ny)
+	assert.Equal(t, "Target date must be in the future.", errMsg)
+
+	_, errMsg = parseTargetDate("03/05/2026", now, ny)
+	assert.Equal(t, "Target date must be in YYYY-MM-DD format.", errMsg)
+
+	date, errMsg = parseTargetDate("", now, ny)
+	assert.Empty(t, errMsg)
+	assert.Nil(t, date)
+}
+
+func TestSavingsForecast_Contributions(t *testing.T) {
+	now := time.Date(2026, 3, 2, 12, 0, 0, 0, time.UTC) // a Monday
+	monday := 1
+	nextRun := time.Date(2026, 3, 9, 0, 0, 0, 0, time.UTC)
+	percent := 30
+	f := &savingsForecast{
+		schedules: []models.AllowanceSchedule{{
+			ID:          1,
+			AmountCents: 1000,
+			Frequency:   models.FrequencyWeekly,
+			DayOfWeek:   &monday,
+			NextRunAt:   &nextRun,
+			Splits:      []models.AllowanceSplit{{GoalID: 1, Percent: 10}, {GoalID: 2, Percent: 50}},
+		}},
+		rules: []models.GoalAllocationRule{
+			{GoalID: 1, Source: models.AllocationSourceAllowance, Kind: models.AllocationRulePercent, Percent: &percent},
+			{GoalID: 1, Source: models.AllocationSourceDeposit, Kind: models.AllocationRulePercent, Percent: &percent},
+		},
+		loc: time.UTC,
+	}
+
+	// Runs on March 9, 16 and 23 fall before March 28; each puts 10% + 30% of $10 into goal 1
+	total, err := f.contributions(1, now, time.Date(2026, 3, 28, 0, 0, 0, 0, time.UTC))
+	require.NoError(t, err)
+	assert.Equal(t, int64(1200), total)
+}
+
+func TestFormatCents(t *testing.T) {
+	assert.Equal(t, "$3.20", formatCents(320))
+	assert.Equal(t, "$0.05", formatCents(5))
+	assert.Equal(t, "$1000.00", formatCents(100000))
+}
+
+func TestHandleList_IncludesPace(t *testing.T) {
+	handler, goalRepo, _, family, _, child, _ := setupHandlerWithBalance(t)
+
+	targetDate := time.Now().UTC().AddDate(0, 0, 70)
+	targetDate = time.Date(targetDate.Year(), targetDate.Month(), targetDate.Day(), 0, 0, 0, 0, time.UTC)
+	_, err := goalRepo.CreateWithTargetDate(child.ID, "Bike", 5000, nil, &targetDate)
+	require.NoError(t, err)
+	_, err = goalRepo.Create(child.ID, "Someday", 5000, nil)
+	require.NoError(t, err)
+
+	req := httptest.NewRequest("GET", "/api/children/1/savings-goals", nil)
+	req.SetPathValue("id", strconv.FormatInt(child.ID, 10))
+	req = testutil.SetRequestContext(req, "child", child.ID, family.ID)
+	rr := httptest.NewRecorder()
+	handler.HandleList(rr, req)
+	require.Equal(t, http.StatusOK, rr.Code)
+
+	var resp SavingsGoalsResponse
+	require.NoError(t, json.NewDecoder(rr.Body).Decode(&resp))
+	require.Len(t, resp.Goals, 2)
+	for _, g := range resp.Goals {
+		if g.Name == "Someday" {
+			assert.Nil(t, g.Pace)
+			continue
+		}
+		require.NotNil(t, g.Pace)
+		assert.Equal(t, PaceOnTrack, g.Pace.Status)
+		assert.Greater(t, g.Pace.RequiredWeeklyCents, int64(0))
+		assert.Contains(t, g.Pace.Message, fmt.Sprintf("/week to get there by %s", targetDate.Format("January 2")))
+	}
+}
+
+func TestHandleCreate_400_TargetDateInPast(t *testing.T) {
+	handler, _, _, family, _, child := setupHandler(t)
+
+	req := httptest.NewRequest("POST", "/api/children/1/savings-goals",
+		bytes.NewBufferString(`{"name": "Bike", "target_cents": 5000, "target_date": "2020-01-01"}`))
+	req.SetPathValue("id", strconv.FormatInt(child.ID, 10))
+	req = testutil.SetRequestContext(req, "child", child.ID, family.ID)
+	rr := httptest.NewRecorder()
+	handler.HandleCreate(rr, req)
+
+	assert.Equal(t, http.StatusBadRequest, rr.Code)
+	assert.Contains(t, rr.Body.String(), "invalid_target_date")
+}
+
+func TestHandleUpdate_ClearsTargetDate(t *testing.T) {
+	handler, goalRepo, _, family, _, child := setupHandler(t)
+	targetDate := time.Now().UTC().AddDate(0, 2, 0)
+	goal, err := goalRepo.CreateWithTargetDate(child.ID, "Bike", 5000, nil, &targetDate)
+	require.NoError(t, err)
+
+	req := httptest.NewRequest("PUT", "/api/children/1/savings-goals/1", bytes.NewBufferString(`{"target_date": ""}`))
+	req.SetPathValue("id", strconv.FormatInt(child.ID, 10))
+	req.SetPathValue("goalId", strconv.FormatInt(goal.ID, 10))
+	req = testutil.SetRequestContext(req, "child", child.ID, family.ID)
+	rr := httptest.NewRecorder()
+	handler.HandleUpdate(rr, req)
+	require.Equal(t, http.StatusOK, rr.Code)
+
+	updated, err := goalRepo.GetByID(goal.ID)
+	require.NoError(t, err)
+	assert.Nil(t, updated.TargetDate)
+}
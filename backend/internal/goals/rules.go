@@ -0,0 +1,192 @@
+package goals
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+
+	"bank-of-dad/models"
+	"bank-of-dad/repositories"
+)
+
+const (
+	MaxAllocationRules  = 10
+	DefaultRoundToCents = 100
+	MaxRoundToCents     = 10000
+)
+
+// CreateRuleRequest represents the request body for creating a goal allocation rule.
+// Percent applies to percent rules and RoundToCents to round_up rules, defaulting to a dollar.
+type CreateRuleRequest struct {
+	GoalID       int64                     `json:"goal_id"`
+	Source       models.AllocationSource   `json:"source"`
+	Kind         models.AllocationRuleKind `json:"kind"`
+	Percent      *int                      `json:"percent,omitempty"`
+	RoundToCents *int                      `json:"round_to_cents,omitempty"`
+}
+
+// AllocationRulesResponse represents the response for listing goal allocation rules.
+type AllocationRulesResponse struct {
+	Rules []models.GoalAllocationRule `json:"rules"`
+}
+
+// validateRule checks a create rule request. Returns an error message or empty string.
+func validateRule(req *CreateRuleRequest) string {
+	switch req.Source {
+	case models.AllocationSourceAllowance, models.AllocationSourceChore, models.AllocationSourceInterest, models.AllocationSourceDeposit:
+	default:
+		return "Source must be one of: allowance, chore, interest, deposit."
+	}
+	switch req.Kind {
+	case models.AllocationRulePercent:
+		if req.Percent == nil || *req.Percent < 1 || *req.Percent > 100 {
+			return "Percent must be between 1 and 100."
+		}
+		if req.RoundToCents != nil {
+			return "Round to amount only applies to round_up rules."
+		}
+	case models.AllocationRuleRoundUp:
+		if req.Percent != nil {
+			return "Percent only applies to percent rules."
+		}
+		if req.RoundToCents != nil && (*req.RoundToCents < 2 || *req.RoundToCents > MaxRoundToCents) {
+			return "Round to amount must be between $0.02 and $100.00."
+		}
+	default:
+		return "Kind must be one of: percent, round_up."
+	}
+	return ""
+}
+
+// parseRuleID extracts and validates the rule ID from the URL path.
+func parseRuleID(r *http.Request) (int64, error) {
+	return strconv.ParseInt(r.PathValue("ruleId"), 10, 64)
+}
+
+// HandleListRules handles GET /api/children/{id}/goal-allocation-rules
+func (h *Handler) HandleListRules(w http.ResponseWriter, r *http.Request) {
+	childID, err := parseChildID(r)
+	if err != nil {
+		writeJSON(w, http.StatusBadRequest, ErrorResponse{Error: "invalid_child_id", Message: "Invalid child ID."})
+		return
+	}
+
+	if status, errResp := h.verifyChildAccess(r, childID); errResp != nil {
+		writeJSON(w, status, errResp)
+		return
+	}
+
+	rules, err := h.allocationRuleRepo.ListByChild(childID)
+	if err != nil {
+		writeJSON(w, http.StatusInternalServerError, ErrorResponse{Error: "internal_error", Message: "Failed to list allocation rules."})
+		return
+	}
+
+	if rules == nil {
+		rules = []models.GoalAllocationRule{}
+	}
+
+	writeJSON(w, http.StatusOK, AllocationRulesResponse{Rules: rules})
+}
+
+// HandleCreateRule handles POST /api/children/{id}/goal-allocation-rules
+// Rules are applied as allowance, chore, interest and deposit money is posted, oldest rule first.
+// Both the child and their parents can set up rules, since parents often set standing
+// instructions such as "all interest goes to the Lego goal".
+func (h *Handler) HandleCreateRule(w http.ResponseWriter, r *http.Request) {
+	childID, err := parseChildID(r)
+	if err != nil {
+		writeJSON(w, http.StatusBadRequest, ErrorResponse{Error: "invalid_child_id", Message: "Invalid child ID."})
+		return
+	}
+
+	if status, errResp := h.verifyChildAccess(r, childID); errResp != nil {
+		writeJSON(w, status, errResp)
+		return
+	}
+
+	var req CreateRuleRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeJSON(w, http.StatusBadRequest, ErrorResponse{Error: "invalid_request", Message: "Invalid request body."})
+		return
+	}
+	if errMsg := validateRule(&req); errMsg != "" {
+		writeJSON(w, http.StatusBadRequest, ErrorResponse{Error: "invalid_rule", Message: errMsg})
+		return
+	}
+	if req.Kind == models.AllocationRuleRoundUp && req.RoundToCents == nil {
+		roundTo := DefaultRoundToCents
+		req.RoundToCents = &roundTo
+	}
+
+	goal, err := h.goalRepo.GetByID(req.GoalID)
+	if err != nil {
+		writeJSON(w, http.StatusInternalServerError, ErrorResponse{Error: "internal_error", Message: "Failed to lookup goal."})
+		return
+	}
+	if goal == nil || goal.ChildID != childID || goal.Status != "active" {
+		writeJSON(w, http.StatusNotFound, ErrorResponse{Error: "not_found", Message: "Goal not found or not active."})
+		return
+	}
+
+	count, err := h.allocationRuleRepo.CountByChild(childID)
+	if err != nil {
+		writeJSON(w, http.StatusInternalServerError, ErrorResponse{Error: "internal_error", Message: "Failed to check allocation rules."})
+		return
+	}
+	if count >= MaxAllocationRules {
+		writeJSON(w, http.StatusConflict, ErrorResponse{Error: "max_rules_reached", Message: "Maximum of 10 allocation rules reached."})
+		return
+	}
+
+	rule, err := h.allocationRuleRepo.Create(&models.GoalAllocationRule{
+		ChildID:      childID,
+		GoalID:       goal.ID,
+		Source:       req.Source,
+		Kind:         req.Kind,
+		Percent:      req.Percent,
+		RoundToCents: req.RoundToCents,
+	})
+	if err != nil {
+		if err == repositories.ErrAllocationRuleExists {
+			writeJSON(w, http.StatusConflict, ErrorResponse{Error: "rule_exists", Message: "This goal already has a rule for that kind of money."})
+			return
+		}
+		writeJSON(w, http.StatusInternalServerError, ErrorResponse{Error: "internal_error", Message: "Failed to create allocation rule."})
+		return
+	}
+
+	writeJSON(w, http.StatusCreated, rule)
+}
+
+// HandleDeleteRule handles DELETE /api/children/{id}/goal-allocation-rules/{ruleId}
+// The child or their parents can delete a rule. Money the rule already allocated stays in its goal.
+func (h *Handler) HandleDeleteRule(w http.ResponseWriter, r *http.Request) {
+	childID, err := parseChildID(r)
+	if err != nil {
+		writeJSON(w, http.StatusBadRequest, ErrorResponse{Error: "invalid_child_id", Message: "Invalid child ID."})
+		return
+	}
+
+	ruleID, err := parseRuleID(r)
+	if err != nil {
+		writeJSON(w, http.StatusBadRequest, ErrorResponse{Error: "invalid_rule_id", Message: "Invalid rule ID."})
+		return
+	}
+
+	if status, errResp := h.verifyChildAccess(r, childID); errResp != nil {
+		writeJSON(w, status, errResp)
+		return
+	}
+
+	if err := h.allocationRuleRepo.Delete(ruleID, childID); err != nil {
+		if err == repositories.ErrAllocationRuleNotFound {
+			writeJSON(w, http.StatusNotFound, ErrorResponse{Error: "not_found", Message: "Allocation rule not found."})
+			return
+		}
+		writeJSON(w, http.StatusInternalServerError, ErrorResponse{Error: "internal_error", Message: "Failed to delete allocation rule."})
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
@@ -0,0 +1,151 @@
+package goals
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+
+	"bank-of-dad/internal/testutil"
+	"bank-of-dad/models"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func createRuleRequest(handler *Handler, child *models.Child, familyID int64, userType string, userID int64, body string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest("POST", "/api/children/1/goal-allocation-rules", bytes.NewBufferString(body))
+	req.SetPathValue("id", strconv.FormatInt(child.ID, 10))
+	req = testutil.SetRequestContext(req, userType, userID, familyID)
+	rr := httptest.NewRecorder()
+	handler.HandleCreateRule(rr, req)
+	return rr
+}
+
+func TestValidateRule(t *testing.T) {
+	pct := func(v int) *int { return &v }
+	tests := []struct {
+		name  string
+		req   CreateRuleRequest
+		valid bool
+	}{
+		{"percent", CreateRuleRequest{Source: "allowance", Kind: "percent", Percent: pct(30)}, true},
+		{"round up default", CreateRuleRequest{Source: "deposit", Kind: "round_up"}, true},
+		{"round up to five", CreateRuleRequest{Source: "chore", Kind: "round_up", RoundToCents: pct(500)}, true},
+		{"unknown source", CreateRuleRequest{Source: "withdrawal", Kind: "percent", Percent: pct(30)}, false},
+		{"unknown kind", CreateRuleRequest{Source: "interest", Kind: "fixed"}, false},
+		{"percent missing", CreateRuleRequest{Source: "interest", Kind: "percent"}, false},
+		{"percent too high", CreateRuleRequest{Source: "interest", Kind: "percent", Percent: pct(101)}, false},
+		{"percent with round to", CreateRuleRequest{Source: "interest", Kind: "percent", Percent: pct(10), RoundToCents: pct(100)}, false},
+		{"round to too small", CreateRuleRequest{Source: "deposit", Kind: "round_up", RoundToCents: pct(1)}, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.valid, validateRule(&tt.req) == "")
+		})
+	}
+}
+
+func TestHandleCreateRule_201_DefaultsRoundTo(t *testing.T) {
+	handler, goalRepo, _, family, _, child := setupHandler(t)
+	goal, err := goalRepo.Create(child.ID, "Lego", 5000, nil)
+	require.NoError(t, err)
+
+	rr := createRuleRequest(handler, child, family.ID, "child", child.ID,
+		fmt.Sprintf(`{"goal_id": %d, "source": "deposit", "kind": "round_up"}`, goal.ID))
+	require.Equal(t, http.StatusCreated, rr.Code)
+
+	var rule models.GoalAllocationRule
+	require.NoError(t, json.NewDecoder(rr.Body).Decode(&rule))
+	assert.Equal(t, goal.ID, rule.GoalID)
+	assert.Equal(t, models.AllocationSourceDeposit, rule.Source)
+	require.NotNil(t, rule.RoundToCents)
+	assert.Equal(t, DefaultRoundToCents, *rule.RoundToCents)
+}
+
+func TestHandleCreateRule_409_DuplicateSource(t *testing.T) {
+	handler, goalRepo, _, family, _, child := setupHandler(t)
+	goal, err := goalRepo.Create(child.ID, "Bike", 5000, nil)
+	require.NoError(t, err)
+
+	body := fmt.Sprintf(`{"goal_id": %d, "source": "allowance", "kind": "percent", "percent": 30}`, goal.ID)
+	require.Equal(t, http.StatusCreated, createRuleRequest(handler, child, family.ID, "child", child.ID, body).Code)
+
+	rr := createRuleRequest(handler, child, family.ID, "child", child.ID, body)
+	assert.Equal(t, http.StatusConflict, rr.Code)
+	assert.Contains(t, rr.Body.String(), "rule_exists")
+}
+
+func TestHandleCreateRule_201_Parent(t *testing.T) {
+	handler, goalRepo, _, family, parent, child := setupHandler(t)
+	goal, err := goalRepo.Create(child.ID, "Lego", 5000, nil)
+	require.NoError(t, err)
+
+	rr := createRuleRequest(handler, child, family.ID, "parent", parent.ID,
+		fmt.Sprintf(`{"goal_id": %d, "source": "interest", "kind": "percent", "percent": 100}`, goal.ID))
+	require.Equal(t, http.StatusCreated, rr.Code)
+
+	var rule models.GoalAllocationRule
+	require.NoError(t, json.NewDecoder(rr.Body).Decode(&rule))
+	assert.Equal(t, child.ID, rule.ChildID)
+}
+
+func TestHandleCreateRule_403_OtherFamilyParent(t *testing.T) {
+	handler, goalRepo, _, _, _, child := setupHandler(t)
+	goal, err := goalRepo.Create(child.ID, "Bike", 5000, nil)
+	require.NoError(t, err)
+
+	rr := createRuleRequest(handler, child, child.FamilyID+1000, "parent", 1,
+		fmt.Sprintf(`{"goal_id": %d, "source": "allowance", "kind": "percent", "percent": 30}`, goal.ID))
+	assert.Equal(t, http.StatusForbidden, rr.Code)
+}
+
+func TestHandleCreateRule_404_SiblingGoal(t *testing.T) {
+	handler, goalRepo, _, family, _, child, db := setupHandlerWithBalance(t)
+	sibling := testutil.CreateTestChild(t, db, family.ID, "Jack")
+	goal, err := goalRepo.Create(sibling.ID, "Skateboard", 5000, nil)
+	require.NoError(t, err)
+
+	rr := createRuleRequest(handler, child, family.ID, "child", child.ID,
+		fmt.Sprintf(`{"goal_id": %d, "source": "allowance", "kind": "percent", "percent": 30}`, goal.ID))
+	assert.Equal(t, http.StatusNotFound, rr.Code)
+}
+
+func TestHandleListAndDeleteRules(t *testing.T) {
+	handler, goalRepo, _, family, parent, child := setupHandler(t)
+	goal, err := goalRepo.Create(child.ID, "Bike", 5000, nil)
+	require.NoError(t, err)
+
+	rr := createRuleRequest(handler, child, family.ID, "child", child.ID,
+		fmt.Sprintf(`{"goal_id": %d, "source": "interest", "kind": "percent", "percent": 100}`, goal.ID))
+	require.Equal(t, http.StatusCreated, rr.Code)
+	var rule models.GoalAllocationRule
+	require.NoError(t, json.NewDecoder(rr.Body).Decode(&rule))
+
+	// Parents can see their children's rules
+	req := httptest.NewRequest("GET", "/api/children/1/goal-allocation-rules", nil)
+	req.SetPathValue("id", strconv.FormatInt(child.ID, 10))
+	req = testutil.SetRequestContext(req, "parent", parent.ID, family.ID)
+	rr = httptest.NewRecorder()
+	handler.HandleListRules(rr, req)
+	require.Equal(t, http.StatusOK, rr.Code)
+	var resp AllocationRulesResponse
+	require.NoError(t, json.NewDecoder(rr.Body).Decode(&resp))
+	require.Len(t, resp.Rules, 1)
+	assert.Equal(t, rule.ID, resp.Rules[0].ID)
+
+	del := func() int {
+		req := httptest.NewRequest("DELETE", "/api/children/1/goal-allocation-rules/1", nil)
+		req.SetPathValue("id", strconv.FormatInt(child.ID, 10))
+		req.SetPathValue("ruleId", strconv.FormatInt(rule.ID, 10))
+		req = testutil.SetRequestContext(req, "child", child.ID, family.ID)
+		rr := httptest.NewRecorder()
+		handler.HandleDeleteRule(rr, req)
+		return rr.Code
+	}
+	assert.Equal(t, http.StatusNoContent, del())
+	assert.Equal(t, http.StatusNotFound, del())
+}
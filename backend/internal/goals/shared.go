@@ -0,0 +1,242 @@
+package goals
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+
+	"bank-of-dad/internal/middleware"
+	"bank-of-dad/models"
+	"bank-of-dad/repositories"
+)
+
+// CreateSharedRequest represents a create shared goal request body.
+type CreateSharedRequest struct {
+	Name        string  `json:"name"`
+	TargetCents int64   `json:"target_cents"`
+	Emoji       *string `json:"emoji,omitempty"`
+}
+
+// SharedGoalsResponse represents the response for listing a family's shared goals.
+type SharedGoalsResponse struct {
+	Goals []models.SharedGoal `json:"goals"`
+}
+
+// SharedAllocateResponse represents the response after allocating to a shared goal.
+type SharedAllocateResponse struct {
+	Goal                  *models.SharedGoal `json:"goal"`
+	AvailableBalanceCents int64              `json:"available_balance_cents"`
+	Completed             bool               `json:"completed"`
+}
+
+// SharedRedeemResponse represents the response after redeeming a completed shared goal,
+// with one withdrawal per contributor.
+type SharedRedeemResponse struct {
+	Goal         *models.SharedGoal   `json:"goal"`
+	Transactions []models.Transaction `json:"transactions"`
+}
+
+// HandleListShared handles GET /api/shared-goals
+func (h *Handler) HandleListShared(w http.ResponseWriter, r *http.Request) {
+	goals, err := h.sharedGoalRepo.ListByFamily(middleware.GetFamilyID(r))
+	if err != nil {
+		writeJSON(w, http.StatusInternalServerError, ErrorResponse{Error: "internal_error", Message: "Failed to list shared goals."})
+		return
+	}
+
+	if goals == nil {
+		goals = []models.SharedGoal{}
+	}
+
+	writeJSON(w, http.StatusOK, SharedGoalsResponse{Goals: goals})
+}
+
+// HandleCreateShared handles POST /api/shared-goals
+// Children and parents can create shared goals for the family.
+func (h *Handler) HandleCreateShared(w http.ResponseWriter, r *http.Request) {
+	familyID := middleware.GetFamilyID(r)
+
+	var req CreateSharedRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeJSON(w, http.StatusBadRequest, ErrorResponse{Error: "invalid_request", Message: "Invalid request body."})
+		return
+	}
+
+	name := strings.TrimSpace(req.Name)
+	if name == "" {
+		writeJSON(w, http.StatusBadRequest, ErrorResponse{Error: "invalid_name", Message: "Name is required."})
+		return
+	}
+	if len(name) > MaxNameLength {
+		writeJSON(w, http.StatusBadRequest, ErrorResponse{Error: "invalid_name", Message: "Name must be 50 characters or less."})
+		return
+	}
+	if req.TargetCents <= 0 {
+		writeJSON(w, http.StatusBadRequest, ErrorResponse{Error: "invalid_target", Message: "Target amount must be greater than zero."})
+		return
+	}
+	if req.TargetCents > MaxTargetCents {
+		writeJSON(w, http.StatusBadRequest, ErrorResponse{Error: "invalid_target", Message: "Target amount must be $999,999.99 or less."})
+		return
+	}
+
+	count, err := h.sharedGoalRepo.CountActiveByFamily(familyID)
+	if err != nil {
+		writeJSON(w, http.StatusInternalServerError, ErrorResponse{Error: "internal_error", Message: "Failed to check active shared goals."})
+		return
+	}
+	if count >= MaxActiveGoals {
+		writeJSON(w, http.StatusConflict, ErrorResponse{Error: "max_goals_reached", Message: "Maximum of 5 active shared goals reached."})
+		return
+	}
+
+	var createdBy *int64
+	if middleware.GetUserType(r) == "child" {
+		childID := middleware.GetUserID(r)
+		createdBy = &childID
+	}
+
+	goal, err := h.sharedGoalRepo.Create(familyID, name, req.TargetCents, req.Emoji, createdBy)
+	if err != nil {
+		writeJSON(w, http.StatusInternalServerError, ErrorResponse{Error: "internal_error", Message: "Failed to create shared goal."})
+		return
+	}
+
+	writeJSON(w, http.StatusCreated, goal)
+}
+
+// HandleAllocateShared handles POST /api/shared-goals/{goalId}/allocate
+// Children move their own money into or out of their contribution to a shared goal.
+func (h *Handler) HandleAllocateShared(w http.ResponseWriter, r *http.Request) {
+	goalID, err := parseGoalID(r)
+	if err != nil {
+		writeJSON(w, http.StatusBadRequest, ErrorResponse{Error: "invalid_goal_id", Message: "Invalid goal ID."})
+		return
+	}
+
+	if middleware.GetUserType(r) != "child" {
+		writeJSON(w, http.StatusForbidden, ErrorResponse{Error: "forbidden", Message: "Only children can allocate to shared goals."})
+		return
+	}
+	childID := middleware.GetUserID(r)
+
+	var req AllocateRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeJSON(w, http.StatusBadRequest, ErrorResponse{Error: "invalid_request", Message: "Invalid request body."})
+		return
+	}
+	if req.AmountCents == 0 {
+		writeJSON(w, http.StatusBadRequest, ErrorResponse{Error: "invalid_amount", Message: "Amount must be non-zero."})
+		return
+	}
+
+	goal, err := h.sharedGoalRepo.Allocate(goalID, middleware.GetFamilyID(r), childID, req.AmountCents)
+	if err != nil {
+		switch err {
+		case repositories.ErrGoalNotFound:
+			writeJSON(w, http.StatusNotFound, ErrorResponse{Error: "not_found", Message: "Shared goal not found or not active."})
+		case repositories.ErrInsufficientAvailable:
+			writeJSON(w, http.StatusBadRequest, ErrorResponse{Error: "insufficient_balance", Message: "Amount exceeds available balance."})
+		case repositories.ErrDeallocationExceedsSaved:
+			writeJSON(w, http.StatusBadRequest, ErrorResponse{Error: "exceeds_saved", Message: "De-allocation amount exceeds your contribution."})
+		case repositories.ErrZeroAllocation:
+			writeJSON(w, http.StatusBadRequest, ErrorResponse{Error: "invalid_amount", Message: "Amount must be non-zero."})
+		default:
+			writeJSON(w, http.StatusInternalServerError, ErrorResponse{Error: "internal_error", Message: "Failed to allocate funds."})
+		}
+		return
+	}
+
+	availableBalance, err := h.goalRepo.GetAvailableBalance(childID)
+	if err != nil {
+		writeJSON(w, http.StatusInternalServerError, ErrorResponse{Error: "internal_error", Message: "Failed to get available balance."})
+		return
+	}
+
+	writeJSON(w, http.StatusOK, SharedAllocateResponse{
+		Goal:                  goal,
+		AvailableBalanceCents: availableBalance,
+		Completed:             goal.Status == "completed",
+	})
+}
+
+// HandleDeleteShared handles DELETE /api/shared-goals/{goalId}
+// Parents can delete any shared goal. A child can delete one they created while nobody else has
+// contributed to it. Every contribution is released back to its contributor.
+func (h *Handler) HandleDeleteShared(w http.ResponseWriter, r *http.Request) {
+	goalID, err := parseGoalID(r)
+	if err != nil {
+		writeJSON(w, http.StatusBadRequest, ErrorResponse{Error: "invalid_goal_id", Message: "Invalid goal ID."})
+		return
+	}
+	familyID := middleware.GetFamilyID(r)
+
+	goal, err := h.sharedGoalRepo.GetByID(goalID, familyID)
+	if err != nil {
+		writeJSON(w, http.StatusInternalServerError, ErrorResponse{Error: "internal_error", Message: "Failed to lookup shared goal."})
+		return
+	}
+	if goal == nil {
+		writeJSON(w, http.StatusNotFound, ErrorResponse{Error: "not_found", Message: "Shared goal not found."})
+		return
+	}
+
+	var byChildID *int64
+	if middleware.GetUserType(r) == "child" {
+		childID := middleware.GetUserID(r)
+		if goal.CreatedByChildID == nil || *goal.CreatedByChildID != childID {
+			writeJSON(w, http.StatusForbidden, ErrorResponse{Error: "forbidden", Message: "Only a parent or the child who created this goal can delete it."})
+			return
+		}
+		byChildID = &childID
+	}
+
+	if err := h.sharedGoalRepo.Delete(goalID, familyID, byChildID); err != nil {
+		switch err {
+		case repositories.ErrGoalNotFound:
+			writeJSON(w, http.StatusNotFound, ErrorResponse{Error: "not_found", Message: "Shared goal not found."})
+		case repositories.ErrSharedGoalHasContributors:
+			writeJSON(w, http.StatusConflict, ErrorResponse{Error: "has_contributors", Message: "Others have contributed to this goal. Ask a parent to delete it."})
+		default:
+			writeJSON(w, http.StatusInternalServerError, ErrorResponse{Error: "internal_error", Message: "Failed to delete shared goal."})
+		}
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// HandleRedeemShared handles POST /api/shared-goals/{goalId}/redeem
+// A parent spends a completed shared goal, withdrawing each contributor's share from their balance.
+func (h *Handler) HandleRedeemShared(w http.ResponseWriter, r *http.Request) {
+	goalID, err := parseGoalID(r)
+	if err != nil {
+		writeJSON(w, http.StatusBadRequest, ErrorResponse{Error: "invalid_goal_id", Message: "Invalid goal ID."})
+		return
+	}
+
+	if middleware.GetUserType(r) != "parent" {
+		writeJSON(w, http.StatusForbidden, ErrorResponse{Error: "forbidden", Message: "Only parents can redeem shared goals."})
+		return
+	}
+
+	goal, transactions, err := h.sharedGoalRepo.Redeem(goalID, middleware.GetFamilyID(r), middleware.GetUserID(r))
+	if err != nil {
+		switch err {
+		case repositories.ErrGoalNotFound:
+			writeJSON(w, http.StatusNotFound, ErrorResponse{Error: "not_found", Message: "Shared goal not found."})
+		case repositories.ErrGoalNotRedeemable:
+			writeJSON(w, http.StatusConflict, ErrorResponse{Error: "not_redeemable", Message: "Only completed shared goals that have not been purchased can be redeemed."})
+		case models.ErrInsufficientFunds:
+			writeJSON(w, http.StatusUnprocessableEntity, ErrorResponse{Error: "insufficient_funds", Message: "A contributor's balance no longer covers their share."})
+		default:
+			writeJSON(w, http.StatusInternalServerError, ErrorResponse{Error: "internal_error", Message: "Failed to redeem shared goal."})
+		}
+		return
+	}
+
+	writeJSON(w, http.StatusOK, SharedRedeemResponse{
+		Goal:         goal,
+		Transactions: transactions,
+	})
+}
@@ -0,0 +1,204 @@
+package goals
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+
+	"bank-of-dad/internal/testutil"
+	"bank-of-dad/models"
+	"bank-of-dad/repositories"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func createSharedGoal(t *testing.T, handler *Handler, userType string, userID, familyID int64, body string) *models.SharedGoal {
+	t.Helper()
+	req := httptest.NewRequest("POST", "/api/shared-goals", bytes.NewBufferString(body))
+	req = testutil.SetRequestContext(req, userType, userID, familyID)
+	rr := httptest.NewRecorder()
+	handler.HandleCreateShared(rr, req)
+	require.Equal(t, http.StatusCreated, rr.Code, rr.Body.String())
+
+	var goal models.SharedGoal
+	require.NoError(t, json.NewDecoder(rr.Body).Decode(&goal))
+	return &goal
+}
+
+func allocateShared(handler *Handler, childID, familyID, goalID int64, body string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest("POST", "/api/shared-goals/1/allocate", bytes.NewBufferString(body))
+	req.SetPathValue("goalId", strconv.FormatInt(goalID, 10))
+	req = testutil.SetRequestContext(req, "child", childID, familyID)
+	rr := httptest.NewRecorder()
+	handler.HandleAllocateShared(rr, req)
+	return rr
+}
+
+func deleteShared(handler *Handler, userType string, userID, familyID, goalID int64) *httptest.ResponseRecorder {
+	req := httptest.NewRequest("DELETE", "/api/shared-goals/1", nil)
+	req.SetPathValue("goalId", strconv.FormatInt(goalID, 10))
+	req = testutil.SetRequestContext(req, userType, userID, familyID)
+	rr := httptest.NewRecorder()
+	handler.HandleDeleteShared(rr, req)
+	return rr
+}
+
+func TestHandleCreateShared_ChildAndParent(t *testing.T) {
+	handler, _, _, family, parent, child := setupHandler(t)
+
+	goal := createSharedGoal(t, handler, "child", child.ID, family.ID, `{"name": "Trampoline", "target_cents": 30000}`)
+	assert.Equal(t, "active", goal.Status)
+	require.NotNil(t, goal.CreatedByChildID)
+	assert.Equal(t, child.ID, *goal.CreatedByChildID)
+
+	goal = createSharedGoal(t, handler, "parent", parent.ID, family.ID, `{"name": "Zoo trip", "target_cents": 8000}`)
+	assert.Nil(t, goal.CreatedByChildID)
+
+	req := httptest.NewRequest("GET", "/api/shared-goals", nil)
+	req = testutil.SetRequestContext(req, "child", child.ID, family.ID)
+	rr := httptest.NewRecorder()
+	handler.HandleListShared(rr, req)
+	require.Equal(t, http.StatusOK, rr.Code)
+
+	var resp SharedGoalsResponse
+	require.NoError(t, json.NewDecoder(rr.Body).Decode(&resp))
+	assert.Len(t, resp.Goals, 2)
+}
+
+func TestHandleCreateShared_400_InvalidTarget(t *testing.T) {
+	handler, _, _, family, _, child := setupHandler(t)
+
+	req := httptest.NewRequest("POST", "/api/shared-goals", bytes.NewBufferString(`{"name": "Trampoline", "target_cents": 0}`))
+	req = testutil.SetRequestContext(req, "child", child.ID, family.ID)
+	rr := httptest.NewRecorder()
+	handler.HandleCreateShared(rr, req)
+
+	assert.Equal(t, http.StatusBadRequest, rr.Code)
+	assert.Contains(t, rr.Body.String(), "invalid_target")
+}
+
+func TestHandleAllocateShared_Success(t *testing.T) {
+	handler, _, _, family, parent, child, db := setupHandlerWithBalance(t)
+	goal := createSharedGoal(t, handler, "parent", parent.ID, family.ID, `{"name": "Trampoline", "target_cents": 30000}`)
+
+	rr := allocateShared(handler, child.ID, family.ID, goal.ID, `{"amount_cents": 4000}`)
+	require.Equal(t, http.StatusOK, rr.Code, rr.Body.String())
+
+	var resp SharedAllocateResponse
+	require.NoError(t, json.NewDecoder(rr.Body).Decode(&resp))
+	assert.Equal(t, int64(6000), resp.AvailableBalanceCents)
+	assert.Equal(t, int64(4000), resp.Goal.SavedCents)
+	assert.False(t, resp.Completed)
+	require.Len(t, resp.Goal.Contributions, 1)
+	assert.Equal(t, "Emma", resp.Goal.Contributions[0].ChildName)
+
+	var allocs []models.SharedGoalAllocation
+	require.NoError(t, db.Where("shared_goal_id = ?", goal.ID).Find(&allocs).Error)
+	require.Len(t, allocs, 1)
+	assert.Equal(t, int64(4000), allocs[0].AmountCents)
+}
+
+func TestHandleAllocateShared_403_Parent(t *testing.T) {
+	handler, _, _, family, parent, _ := setupHandler(t)
+	goal := createSharedGoal(t, handler, "parent", parent.ID, family.ID, `{"name": "Trampoline", "target_cents": 30000}`)
+
+	req := httptest.NewRequest("POST", "/api/shared-goals/1/allocate", bytes.NewBufferString(`{"amount_cents": 100}`))
+	req.SetPathValue("goalId", strconv.FormatInt(goal.ID, 10))
+	req = testutil.SetRequestContext(req, "parent", parent.ID, family.ID)
+	rr := httptest.NewRecorder()
+	handler.HandleAllocateShared(rr, req)
+
+	assert.Equal(t, http.StatusForbidden, rr.Code)
+}
+
+func TestHandleAllocateShared_400_InsufficientBalance(t *testing.T) {
+	handler, _, _, family, parent, child, _ := setupHandlerWithBalance(t)
+	goal := createSharedGoal(t, handler, "parent", parent.ID, family.ID, `{"name": "Trampoline", "target_cents": 30000}`)
+
+	rr := allocateShared(handler, child.ID, family.ID, goal.ID, `{"amount_cents": 20000}`)
+
+	assert.Equal(t, http.StatusBadRequest, rr.Code)
+	assert.Contains(t, rr.Body.String(), "insufficient_balance")
+}
+
+func TestHandleAllocateShared_404_OtherFamily(t *testing.T) {
+	handler, _, _, family, parent, child, _ := setupHandlerWithBalance(t)
+	goal := createSharedGoal(t, handler, "parent", parent.ID, family.ID, `{"name": "Trampoline", "target_cents": 30000}`)
+
+	rr := allocateShared(handler, child.ID, family.ID+1, goal.ID, `{"amount_cents": 100}`)
+
+	assert.Equal(t, http.StatusNotFound, rr.Code)
+}
+
+func TestHandleDeleteShared_ChildCreatorWithoutOtherContributors(t *testing.T) {
+	handler, _, _, family, _, child, _ := setupHandlerWithBalance(t)
+	goal := createSharedGoal(t, handler, "child", child.ID, family.ID, `{"name": "Trampoline", "target_cents": 30000}`)
+	require.Equal(t, http.StatusOK, allocateShared(handler, child.ID, family.ID, goal.ID, `{"amount_cents": 1000}`).Code)
+
+	rr := deleteShared(handler, "child", child.ID, family.ID, goal.ID)
+	assert.Equal(t, http.StatusNoContent, rr.Code)
+}
+
+func TestHandleDeleteShared_409_OtherContributors(t *testing.T) {
+	handler, _, _, family, parent, child, db := setupHandlerWithBalance(t)
+	sibling := testutil.CreateTestChild(t, db, family.ID, "Liam")
+	_, _, err := repositories.NewTransactionRepo(db).Deposit(sibling.ID, parent.ID, 5000, "initial balance")
+	require.NoError(t, err)
+
+	goal := createSharedGoal(t, handler, "child", child.ID, family.ID, `{"name": "Trampoline", "target_cents": 30000}`)
+	require.Equal(t, http.StatusOK, allocateShared(handler, sibling.ID, family.ID, goal.ID, `{"amount_cents": 1000}`).Code)
+
+	rr := deleteShared(handler, "child", child.ID, family.ID, goal.ID)
+	assert.Equal(t, http.StatusConflict, rr.Code)
+	assert.Contains(t, rr.Body.String(), "has_contributors")
+
+	// The sibling didn't create it
+	rr = deleteShared(handler, "child", sibling.ID, family.ID, goal.ID)
+	assert.Equal(t, http.StatusForbidden, rr.Code)
+
+	// A parent can always delete it
+	rr = deleteShared(handler, "parent", parent.ID, family.ID, goal.ID)
+	assert.Equal(t, http.StatusNoContent, rr.Code)
+}
+
+func TestHandleRedeemShared_Success(t *testing.T) {
+	handler, _, _, family, parent, child, _ := setupHandlerWithBalance(t)
+	goal := createSharedGoal(t, handler, "child", child.ID, family.ID, `{"name": "Trampoline", "target_cents": 3000}`)
+
+	rr := allocateShared(handler, child.ID, family.ID, goal.ID, `{"amount_cents": 3000}`)
+	require.Equal(t, http.StatusOK, rr.Code)
+	var allocResp SharedAllocateResponse
+	require.NoError(t, json.NewDecoder(rr.Body).Decode(&allocResp))
+	assert.True(t, allocResp.Completed)
+
+	req := httptest.NewRequest("POST", "/api/shared-goals/1/redeem", nil)
+	req.SetPathValue("goalId", strconv.FormatInt(goal.ID, 10))
+	req = testutil.SetRequestContext(req, "parent", parent.ID, family.ID)
+	rr = httptest.NewRecorder()
+	handler.HandleRedeemShared(rr, req)
+	require.Equal(t, http.StatusOK, rr.Code, rr.Body.String())
+
+	var resp SharedRedeemResponse
+	require.NoError(t, json.NewDecoder(rr.Body).Decode(&resp))
+	assert.Equal(t, "purchased", resp.Goal.Status)
+	require.Len(t, resp.Transactions, 1)
+	assert.Equal(t, int64(3000), resp.Transactions[0].AmountCents)
+}
+
+func TestHandleRedeemShared_409_Active(t *testing.T) {
+	handler, _, _, family, parent, _ := setupHandler(t)
+	goal := createSharedGoal(t, handler, "parent", parent.ID, family.ID, `{"name": "Trampoline", "target_cents": 3000}`)
+
+	req := httptest.NewRequest("POST", "/api/shared-goals/1/redeem", nil)
+	req.SetPathValue("goalId", strconv.FormatInt(goal.ID, 10))
+	req = testutil.SetRequestContext(req, "parent", parent.ID, family.ID)
+	rr := httptest.NewRecorder()
+	handler.HandleRedeemShared(rr, req)
+
+	assert.Equal(t, http.StatusConflict, rr.Code)
+	assert.Contains(t, rr.Body.String(), "not_redeemable")
+}
@@ -61,6 +61,7 @@ type SetInterestRequest struct {
 	Frequency       models.Frequency `json:"frequency,omitempty"`
 	DayOfWeek       *int             `json:"day_of_week,omitempty"`
 	DayOfMonth      *int             `json:"day_of_month,omitempty"`
+	RRule           *string          `json:"rrule,omitempty"`
 }
 
 // SetInterestResponse represents the combined response after setting interest rate and schedule.
@@ -120,6 +121,10 @@ func (h *Handler) HandleSetInterest(w http.ResponseWriter, r *http.Request) {
 			writeJSON(w, http.StatusBadRequest, ErrorResponse{Error: "invalid_schedule", Message: errMsg})
 			return
 		}
+		if errMsg := allowance.ValidateRRule(req.Frequency, req.RRule); errMsg != "" {
+			writeJSON(w, http.StatusBadRequest, ErrorResponse{Error: "invalid_schedule", Message: errMsg})
+			return
+		}
 	}
 
 	// Set the interest rate
@@ -150,7 +155,12 @@ func (h *Handler) HandleSetInterest(w http.ResponseWriter, r *http.Request) {
 			existing.Frequency = req.Frequency
 			existing.DayOfWeek = req.DayOfWeek
 			existing.DayOfMonth = req.DayOfMonth
-			nextRun := calculateInterestNextRun(existing, time.Now().UTC(), loc)
+			existing.RRule = req.RRule
+			nextRun, err := calculateInterestNextRun(existing, time.Now().UTC(), loc)
+			if err != nil {
+				writeJSON(w, http.StatusInternalServerError, ErrorResponse{Error: "internal_error", Message: "Failed to calculate next run."})
+				return
+			}
 			existing.NextRunAt = &nextRun
 
 			schedule, err = h.interestScheduleRepo.Update(existing)
@@ -165,9 +175,14 @@ func (h *Handler) HandleSetInterest(w http.ResponseWriter, r *http.Request) {
 				Frequency:  req.Frequency,
 				DayOfWeek:  req.DayOfWeek,
 				DayOfMonth: req.DayOfMonth,
+				RRule:      req.RRule,
 				Status:     models.ScheduleStatusActive,
 			}
-			nextRun := calculateInterestNextRun(sched, time.Now().UTC(), loc)
+			nextRun, err := calculateInterestNextRun(sched, time.Now().UTC(), loc)
+			if err != nil {
+				writeJSON(w, http.StatusInternalServerError, ErrorResponse{Error: "internal_error", Message: "Failed to calculate next run."})
+				return
+			}
 			sched.NextRunAt = &nextRun
 
 			schedule, err = h.interestScheduleRepo.Create(sched)
@@ -226,14 +241,15 @@ func (h *Handler) HandleGetInterestSchedule(w http.ResponseWriter, r *http.Reque
 }
 
 // calculateInterestNextRun reuses the allowance schedule calculation logic for interest schedules.
-func calculateInterestNextRun(sched *models.InterestSchedule, now time.Time, loc *time.Location) time.Time {
-	// Create a temporary AllowanceSchedule to reuse CalculateNextRun
+func calculateInterestNextRun(sched *models.InterestSchedule, now time.Time, loc *time.Location) (time.Time, error) {
+	// Create a temporary AllowanceSchedule to reuse NextRunAfter
 	tmpSched := &models.AllowanceSchedule{
 		Frequency:  sched.Frequency,
 		DayOfWeek:  sched.DayOfWeek,
 		DayOfMonth: sched.DayOfMonth,
+		RRule:      sched.RRule,
 	}
-	return allowance.CalculateNextRun(tmpSched, now, loc)
+	return allowance.NextRunAfter(tmpSched, now, loc)
 }
 
 func writeJSON(w http.ResponseWriter, status int, v interface{}) {
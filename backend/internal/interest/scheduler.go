@@ -45,8 +45,13 @@ func (s *Scheduler) RecalculateAllNextRuns() {
 			Frequency:  ds.Frequency,
 			DayOfWeek:  ds.DayOfWeek,
 			DayOfMonth: ds.DayOfMonth,
+			RRule:      ds.RRule,
+		}
+		nextRun, err := allowance.NextRunAfter(tmpSched, now, loc)
+		if err != nil {
+			log.Printf("Error calculating next_run_at for interest schedule %d: %v", ds.ID, err)
+			continue
 		}
-		nextRun := allowance.CalculateNextRun(tmpSched, now, loc)
 		if err := s.interestScheduleRepo.UpdateNextRunAt(ds.ID, nextRun); err != nil {
 			log.Printf("Error recalculating next_run_at for interest schedule %d: %v", ds.ID, err)
 		}
@@ -149,8 +154,13 @@ func (s *Scheduler) advanceNextRun(sched *repositories.DueInterestSchedule) {
 		Frequency:  sched.Frequency,
 		DayOfWeek:  sched.DayOfWeek,
 		DayOfMonth: sched.DayOfMonth,
+		RRule:      sched.RRule,
+	}
+	nextRun, err := allowance.NextRunAfter(tmpSched, *sched.NextRunAt, loc)
+	if err != nil {
+		log.Printf("Error calculating next_run_at for interest schedule %d: %v", sched.ID, err)
+		return
 	}
-	nextRun := allowance.CalculateNextRunAfterExecution(tmpSched, *sched.NextRunAt, loc)
 	if err := s.interestScheduleRepo.UpdateNextRunAt(sched.ID, nextRun); err != nil {
 		log.Printf("Error updating next_run_at for interest schedule %d: %v", sched.ID, err)
 	}
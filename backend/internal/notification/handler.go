@@ -0,0 +1,91 @@
+package notification
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+
+	"bank-of-dad/internal/middleware"
+	"bank-of-dad/models"
+	"bank-of-dad/repositories"
+)
+
+// MaxNotifications is how many of the most recent notifications are listed.
+const MaxNotifications = 50
+
+// Handler handles notification HTTP requests for parents and children.
+type Handler struct {
+	notificationRepo *repositories.NotificationRepo
+}
+
+// NewHandler creates a new notification handler.
+func NewHandler(notificationRepo *repositories.NotificationRepo) *Handler {
+	return &Handler{notificationRepo: notificationRepo}
+}
+
+// ErrorResponse represents an error response.
+type ErrorResponse struct {
+	Error   string `json:"error"`
+	Message string `json:"message,omitempty"`
+}
+
+// HandleList handles GET /api/notifications
+// Returns the caller's most recent notifications, newest first.
+func (h *Handler) HandleList(w http.ResponseWriter, r *http.Request) {
+	userID := middleware.GetUserID(r)
+
+	var notifications []models.Notification
+	var err error
+	if middleware.GetUserType(r) == "child" {
+		notifications, err = h.notificationRepo.ListForChild(userID, MaxNotifications)
+	} else {
+		notifications, err = h.notificationRepo.ListForParent(userID, MaxNotifications)
+	}
+	if err != nil {
+		writeJSON(w, http.StatusInternalServerError, ErrorResponse{Error: "internal_error", Message: "Failed to list notifications."})
+		return
+	}
+
+	unread := 0
+	for _, n := range notifications {
+		if n.ReadAt == nil {
+			unread++
+		}
+	}
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"notifications": notifications,
+		"unread_count":  unread,
+	})
+}
+
+// HandleMarkRead handles POST /api/notifications/{id}/read
+func (h *Handler) HandleMarkRead(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.ParseInt(r.PathValue("id"), 10, 64)
+	if err != nil {
+		writeJSON(w, http.StatusBadRequest, ErrorResponse{Error: "invalid_id", Message: "Invalid notification ID."})
+		return
+	}
+
+	userID := middleware.GetUserID(r)
+	if middleware.GetUserType(r) == "child" {
+		err = h.notificationRepo.MarkReadForChild(id, userID)
+	} else {
+		err = h.notificationRepo.MarkReadForParent(id, userID)
+	}
+	if errors.Is(err, repositories.ErrNotificationNotFound) {
+		writeJSON(w, http.StatusNotFound, ErrorResponse{Error: "not_found", Message: "Notification not found."})
+		return
+	}
+	if err != nil {
+		writeJSON(w, http.StatusInternalServerError, ErrorResponse{Error: "internal_error", Message: "Failed to update notification."})
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func writeJSON(w http.ResponseWriter, status int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(v)
+}
@@ -0,0 +1,69 @@
+package notification
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+
+	"bank-of-dad/internal/testutil"
+	"bank-of-dad/models"
+	"bank-of-dad/repositories"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHandleListAndMarkRead(t *testing.T) {
+	db := testutil.SetupTestDB(t)
+	family := testutil.CreateTestFamily(t, db)
+	parent := testutil.CreateTestParent(t, db, family.ID)
+	child := testutil.CreateTestChild(t, db, family.ID, "Emma")
+
+	require.NoError(t, db.Create(&[]models.Notification{
+		{FamilyID: family.ID, ParentID: &parent.ID, Kind: models.NotificationKindWithdrawalReminder, Message: "For the parent"},
+		{FamilyID: family.ID, ChildID: &child.ID, Kind: models.NotificationKindWithdrawalExpired, Message: "For the child"},
+	}).Error)
+
+	handler := NewHandler(repositories.NewNotificationRepo(db))
+
+	req := httptest.NewRequest(http.MethodGet, "/api/notifications", nil)
+	req = testutil.SetRequestContext(req, "child", child.ID, family.ID)
+	rr := httptest.NewRecorder()
+	handler.HandleList(rr, req)
+	require.Equal(t, http.StatusOK, rr.Code)
+
+	var resp struct {
+		Notifications []models.Notification `json:"notifications"`
+		UnreadCount   int                   `json:"unread_count"`
+	}
+	require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &resp))
+	require.Len(t, resp.Notifications, 1)
+	assert.Equal(t, "For the child", resp.Notifications[0].Message)
+	assert.Equal(t, 1, resp.UnreadCount)
+	childNotificationID := resp.Notifications[0].ID
+
+	// A parent cannot mark a child's notification read
+	req = httptest.NewRequest(http.MethodPost, "/api/notifications/1/read", nil)
+	req = testutil.SetRequestContext(req, "parent", parent.ID, family.ID)
+	req.SetPathValue("id", strconv.FormatInt(childNotificationID, 10))
+	rr = httptest.NewRecorder()
+	handler.HandleMarkRead(rr, req)
+	assert.Equal(t, http.StatusNotFound, rr.Code)
+
+	req = httptest.NewRequest(http.MethodPost, "/api/notifications/1/read", nil)
+	req = testutil.SetRequestContext(req, "child", child.ID, family.ID)
+	req.SetPathValue("id", strconv.FormatInt(childNotificationID, 10))
+	rr = httptest.NewRecorder()
+	handler.HandleMarkRead(rr, req)
+	assert.Equal(t, http.StatusNoContent, rr.Code)
+
+	req = httptest.NewRequest(http.MethodGet, "/api/notifications", nil)
+	req = testutil.SetRequestContext(req, "child", child.ID, family.ID)
+	rr = httptest.NewRecorder()
+	handler.HandleList(rr, req)
+	require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &resp))
+	assert.Equal(t, 0, resp.UnreadCount)
+	assert.NotNil(t, resp.Notifications[0].ReadAt)
+}
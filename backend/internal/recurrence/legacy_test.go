@@ -0,0 +1,255 @@
+package recurrence
+
+import (
+	"testing"
+	"time"
+)
+
+// The legacy* functions are verbatim copies of the calculators this package replaced
+// (allowance.CalculateNextRun, allowance.CalculateNextRunAfterExecution and
+// chore.CalculatePeriodBounds). They pin the engine to the previous behavior.
+
+type legacyFrequency string
+
+const (
+	legacyWeekly   legacyFrequency = "weekly"
+	legacyBiweekly legacyFrequency = "biweekly"
+	legacyMonthly  legacyFrequency = "monthly"
+)
+
+func legacyCalculateNextRun(freq legacyFrequency, dayOfWeek, dayOfMonth int, after time.Time, loc *time.Location) time.Time {
+	switch freq {
+	case legacyWeekly:
+		return legacyNextWeeklyDate(dayOfWeek, after, loc)
+	case legacyBiweekly:
+		return legacyNextBiweeklyDate(dayOfWeek, after, loc)
+	case legacyMonthly:
+		return legacyNextMonthlyDate(dayOfMonth, after, loc)
+	}
+	return after
+}
+
+func legacyCalculateNextRunAfterExecution(freq legacyFrequency, dayOfMonth int, executedAt time.Time, loc *time.Location) time.Time {
+	localExecuted := executedAt.In(loc)
+	switch freq {
+	case legacyWeekly:
+		next := localExecuted.AddDate(0, 0, 7)
+		return time.Date(next.Year(), next.Month(), next.Day(), 0, 0, 0, 0, loc)
+	case legacyBiweekly:
+		next := localExecuted.AddDate(0, 0, 14)
+		return time.Date(next.Year(), next.Month(), next.Day(), 0, 0, 0, 0, loc)
+	case legacyMonthly:
+		return legacyNextMonthlyDate(dayOfMonth, executedAt, loc)
+	}
+	return executedAt
+}
+
+func legacyNextWeeklyDate(dayOfWeek int, after time.Time, loc *time.Location) time.Time {
+	after = after.In(loc)
+	daysUntil := (dayOfWeek - int(after.Weekday()) + 7) % 7
+	if daysUntil == 0 {
+		daysUntil = 7
+	}
+	return time.Date(after.Year(), after.Month(), after.Day()+daysUntil, 0, 0, 0, 0, loc)
+}
+
+func legacyNextBiweeklyDate(dayOfWeek int, after time.Time, loc *time.Location) time.Time {
+	after = after.In(loc)
+	daysUntil := (dayOfWeek - int(after.Weekday()) + 7) % 7
+	if daysUntil == 0 {
+		daysUntil = 14
+	}
+	return time.Date(after.Year(), after.Month(), after.Day()+daysUntil, 0, 0, 0, 0, loc)
+}
+
+func legacyNextMonthlyDate(dayOfMonth int, after time.Time, loc *time.Location) time.Time {
+	after = after.In(loc)
+	year, month, day := after.Date()
+
+	clampedDay := min(dayOfMonth, daysInMonth(year, month))
+	target := time.Date(year, month, clampedDay, 0, 0, 0, 0, loc)
+	if target.After(after) || (clampedDay > day) {
+		return target
+	}
+
+	month++
+	if month > 12 {
+		month = 1
+		year++
+	}
+	clampedDay = min(dayOfMonth, daysInMonth(year, month))
+	return time.Date(year, month, clampedDay, 0, 0, 0, 0, loc)
+}
+
+type legacyChoreRecurrence string
+
+const (
+	legacyChoreDaily   legacyChoreRecurrence = "daily"
+	legacyChoreWeekly  legacyChoreRecurrence = "weekly"
+	legacyChoreMonthly legacyChoreRecurrence = "monthly"
+)
+
+func legacyCalculatePeriodBounds(recurrence legacyChoreRecurrence, dayOfWeek int, now time.Time, loc *time.Location) (periodStart, periodEnd time.Time) {
+	localNow := now.In(loc)
+
+	switch recurrence {
+	case legacyChoreDaily:
+		start := time.Date(localNow.Year(), localNow.Month(), localNow.Day(), 0, 0, 0, 0, loc)
+		end := start.AddDate(0, 0, 1).Add(-time.Second)
+		return start, end
+
+	case legacyChoreWeekly:
+		currentDow := int(localNow.Weekday())
+		daysBack := (currentDow - dayOfWeek + 7) % 7
+		start := time.Date(localNow.Year(), localNow.Month(), localNow.Day()-daysBack, 0, 0, 0, 0, loc)
+		end := start.AddDate(0, 0, 7).Add(-time.Second)
+		return start, end
+
+	case legacyChoreMonthly:
+		start := time.Date(localNow.Year(), localNow.Month(), 1, 0, 0, 0, 0, loc)
+		end := start.AddDate(0, 1, 0).Add(-time.Second)
+		return start, end
+	}
+
+	return now, now
+}
+
+// Timezones chosen for DST in both hemispheres, a 30-minute DST shift, a
+// +13:45 offset, and a zone that skipped a whole calendar day (Samoa, 2011-12-30).
+var legacyZones = []string{
+	"UTC",
+	"America/New_York",
+	"America/Sao_Paulo",
+	"Europe/London",
+	"Australia/Lord_Howe",
+	"Pacific/Chatham",
+	"Asia/Kolkata",
+}
+
+// forEachInstant calls fn for several times of day on every date in [from, to) in loc.
+func forEachInstant(from, to time.Time, loc *time.Location, fn func(time.Time)) {
+	for d := from; d.Before(to); d = d.AddDate(0, 0, 1) {
+		for _, hm := range [][2]int{{0, 0}, {1, 30}, {12, 0}, {23, 59}} {
+			fn(time.Date(d.Year(), d.Month(), d.Day(), hm[0], hm[1], 0, 0, loc))
+		}
+	}
+}
+
+func TestLegacy_CalculateNextRun_WeeklyAndBiweekly(t *testing.T) {
+	for _, zone := range legacyZones {
+		loc := mustLoad(t, zone)
+		for dow := 0; dow <= 6; dow++ {
+			weekly := WeeklyOn(time.Weekday(dow), 1)
+			biweekly := WeeklyOn(time.Weekday(dow), 2)
+			forEachInstant(date(2026, time.January, 1), date(2027, time.January, 1), loc, func(after time.Time) {
+				if want, got := legacyCalculateNextRun(legacyWeekly, dow, 0, after, loc), weekly.Next(after, loc); !want.Equal(got) {
+					t.Fatalf("%s weekly dow=%d after=%s: want %s, got %s", zone, dow, after, want, got)
+				}
+				if want, got := legacyCalculateNextRun(legacyBiweekly, dow, 0, after, loc), biweekly.Next(after, loc); !want.Equal(got) {
+					t.Fatalf("%s biweekly dow=%d after=%s: want %s, got %s", zone, dow, after, want, got)
+				}
+			})
+		}
+	}
+}
+
+func TestLegacy_CalculateNextRun_Monthly(t *testing.T) {
+	for _, zone := range legacyZones {
+		loc := mustLoad(t, zone)
+		for dom := 1; dom <= 31; dom++ {
+			rule := MonthlyOn(dom)
+			// Covers a leap year and the Dec -> Jan rollover
+			forEachInstant(date(2027, time.December, 1), date(2028, time.December, 31), loc, func(after time.Time) {
+				if want, got := legacyCalculateNextRun(legacyMonthly, 0, dom, after, loc), rule.Next(after, loc); !want.Equal(got) {
+					t.Fatalf("%s monthly dom=%d after=%s: want %s, got %s", zone, dom, after, want, got)
+				}
+			})
+		}
+	}
+}
+
+func TestLegacy_CalculateNextRunAfterExecution(t *testing.T) {
+	for _, zone := range legacyZones {
+		loc := mustLoad(t, zone)
+
+		// Executions happen at the stored next_run_at, i.e. local midnight of an occurrence
+		for dow := 0; dow <= 6; dow++ {
+			for _, tc := range []struct {
+				freq legacyFrequency
+				rule Rule
+			}{
+				{legacyWeekly, WeeklyOn(time.Weekday(dow), 1)},
+				{legacyBiweekly, WeeklyOn(time.Weekday(dow), 2)},
+			} {
+				executedAt := legacyNextWeeklyDate(dow, date(2025, time.December, 31), loc).UTC()
+				for i := 0; i < 60; i++ {
+					want := legacyCalculateNextRunAfterExecution(tc.freq, 0, executedAt, loc)
+					got := tc.rule.Next(executedAt, loc)
+					if !want.Equal(got) {
+						t.Fatalf("%s %s dow=%d executedAt=%s: want %s, got %s", zone, tc.freq, dow, executedAt, want, got)
+					}
+					executedAt = got.UTC()
+				}
+			}
+		}
+
+		for dom := 1; dom <= 31; dom++ {
+			rule := MonthlyOn(dom)
+			executedAt := legacyNextMonthlyDate(dom, date(2025, time.December, 31), loc).UTC()
+			for i := 0; i < 36; i++ {
+				want := legacyCalculateNextRunAfterExecution(legacyMonthly, dom, executedAt, loc)
+				got := rule.Next(executedAt, loc)
+				if !want.Equal(got) {
+					t.Fatalf("%s monthly dom=%d executedAt=%s: want %s, got %s", zone, dom, executedAt, want, got)
+				}
+				executedAt = got.UTC()
+			}
+		}
+	}
+}
+
+func TestLegacy_CalculatePeriodBounds(t *testing.T) {
+	for _, zone := range legacyZones {
+		loc := mustLoad(t, zone)
+		cases := []struct {
+			name       string
+			recurrence legacyChoreRecurrence
+			dow        int
+			rule       Rule
+		}{
+			{"daily", legacyChoreDaily, 0, DailyRule()},
+			{"monthly", legacyChoreMonthly, 0, MonthlyOn(1)},
+		}
+		for dow := 0; dow <= 6; dow++ {
+			cases = append(cases, struct {
+				name       string
+				recurrence legacyChoreRecurrence
+				dow        int
+				rule       Rule
+			}{"weekly", legacyChoreWeekly, dow, WeeklyOn(time.Weekday(dow), 1)})
+		}
+
+		for _, tc := range cases {
+			forEachInstant(date(2026, time.January, 1), date(2027, time.January, 1), loc, func(now time.Time) {
+				wantStart, wantEnd := legacyCalculatePeriodBounds(tc.recurrence, tc.dow, now, loc)
+				gotStart, gotEnd := tc.rule.Bounds(now, loc)
+				if !wantStart.Equal(gotStart) || !wantEnd.Equal(gotEnd) {
+					t.Fatalf("%s %s dow=%d now=%s: want [%s, %s], got [%s, %s]",
+						zone, tc.name, tc.dow, now, wantStart, wantEnd, gotStart, gotEnd)
+				}
+			})
+		}
+	}
+}
+
+func TestLegacy_SamoaSkippedDay(t *testing.T) {
+	// Samoa skipped 2011-12-30 entirely; both implementations normalize the same way
+	loc := mustLoad(t, "Pacific/Apia")
+	forEachInstant(date(2011, time.December, 20), date(2012, time.January, 10), loc, func(after time.Time) {
+		for dow := 0; dow <= 6; dow++ {
+			if want, got := legacyNextWeeklyDate(dow, after, loc), WeeklyOn(time.Weekday(dow), 1).Next(after, loc); !want.Equal(got) {
+				t.Fatalf("dow=%d after=%s: want %s, got %s", dow, after, want, got)
+			}
+		}
+	})
+}
@@ -0,0 +1,27 @@
+package recurrence
+
+import "time"
+
+// DailyRule returns a rule that occurs every day.
+func DailyRule() Rule {
+	return Rule{Freq: Daily, Interval: 1}
+}
+
+// WeeklyOn returns a rule that occurs on day every interval weeks.
+func WeeklyOn(day time.Weekday, interval int) Rule {
+	return Rule{Freq: Weekly, Interval: interval, ByDay: []time.Weekday{day}}
+}
+
+// MonthlyOn returns a rule that occurs on the given day of each month.
+// Months shorter than day fall back to their last day, so 31 means the last day of every month.
+func MonthlyOn(day int) Rule {
+	if day <= 28 {
+		return Rule{Freq: Monthly, Interval: 1, ByMonthDay: []int{day}}
+	}
+	// Pick the latest of 28..day that exists in the month
+	days := make([]int, 0, day-27)
+	for d := 28; d <= day; d++ {
+		days = append(days, d)
+	}
+	return Rule{Freq: Monthly, Interval: 1, ByMonthDay: days, BySetPos: []int{-1}}
+}
@@ -0,0 +1,549 @@
+// Package recurrence implements a subset of RFC 5545 RRULE recurrence rules.
+//
+// Occurrences are calendar dates. Each occurrence starts at midnight in the
+// caller's timezone, so results stay on the right day across DST changes.
+package recurrence
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Frequency is the RRULE FREQ value.
+type Frequency string
+
+const (
+	Daily   Frequency = "DAILY"
+	Weekly  Frequency = "WEEKLY"
+	Monthly Frequency = "MONTHLY"
+	Yearly  Frequency = "YEARLY"
+)
+
+// MaxInterval is the largest INTERVAL accepted by Validate.
+const MaxInterval = 366
+
+// maxPeriods bounds how many periods are scanned when searching for an occurrence.
+const maxPeriods = 10000
+
+var ErrNoOccurrence = errors.New("recurrence rule has no occurrences")
+
+// Rule is a parsed recurrence rule.
+//
+// BY* parts that are empty default to the matching part of DTStart, or of the
+// reference date passed to Next, Prev or Bounds when DTStart is zero.
+type Rule struct {
+	Freq       Frequency
+	Interval   int
+	ByDay      []time.Weekday
+	ByMonthDay []int // 1..31, or -1..-31 counting back from the end of the month
+	ByMonth    []time.Month
+	BySetPos   []int // picks occurrences within each period, e.g. -1 for the last
+
+	// DTStart anchors INTERVAL counting. Only its date is used.
+	// When zero, intervals are counted from the reference date.
+	DTStart time.Time
+}
+
+var weekdayCodes = map[string]time.Weekday{
+	"SU": time.Sunday,
+	"MO": time.Monday,
+	"TU": time.Tuesday,
+	"WE": time.Wednesday,
+	"TH": time.Thursday,
+	"FR": time.Friday,
+	"SA": time.Saturday,
+}
+
+var weekdayNames = [...]string{"SU", "MO", "TU", "WE", "TH", "FR", "SA"}
+
+// Parse parses an RRULE value such as "FREQ=WEEKLY;BYDAY=MO,WE,FR".
+// An optional "RRULE:" prefix is accepted.
+func Parse(s string) (Rule, error) {
+	s = strings.TrimPrefix(strings.TrimSpace(s), "RRULE:")
+	if s == "" {
+		return Rule{}, errors.New("empty recurrence rule")
+	}
+
+	var r Rule
+	seen := make(map[string]bool)
+	for _, part := range strings.Split(s, ";") {
+		key, value, ok := strings.Cut(part, "=")
+		if !ok || value == "" {
+			return Rule{}, fmt.Errorf("invalid rule part %q", part)
+		}
+		key = strings.ToUpper(key)
+		if seen[key] {
+			return Rule{}, fmt.Errorf("duplicate rule part %s", key)
+		}
+		seen[key] = true
+
+		var err error
+		switch key {
+		case "FREQ":
+			r.Freq = Frequency(strings.ToUpper(value))
+		case "INTERVAL":
+			r.Interval, err = strconv.Atoi(value)
+			if err == nil && r.Interval < 1 {
+				err = errors.New("must be positive")
+			}
+		case "BYDAY":
+			r.ByDay, err = parseWeekdays(value)
+		case "BYMONTHDAY":
+			r.ByMonthDay, err = parseInts(value)
+		case "BYMONTH":
+			var months []int
+			months, err = parseInts(value)
+			for _, m := range months {
+				r.ByMonth = append(r.ByMonth, time.Month(m))
+			}
+		case "BYSETPOS":
+			r.BySetPos, err = parseInts(value)
+		case "WKST":
+			if strings.ToUpper(value) != "MO" {
+				err = errors.New("only WKST=MO is supported")
+			}
+		default:
+			err = errors.New("unsupported rule part")
+		}
+		if err != nil {
+			return Rule{}, fmt.Errorf("parse %s: %w", key, err)
+		}
+	}
+
+	if err := r.Validate(); err != nil {
+		return Rule{}, err
+	}
+	return r, nil
+}
+
+func parseWeekdays(value string) ([]time.Weekday, error) {
+	var days []time.Weekday
+	for _, code := range strings.Split(value, ",") {
+		day, ok := weekdayCodes[strings.ToUpper(code)]
+		if !ok {
+			return nil, fmt.Errorf("invalid weekday %q", code)
+		}
+		days = append(days, day)
+	}
+	return days, nil
+}
+
+func parseInts(value string) ([]int, error) {
+	var nums []int
+	for _, field := range strings.Split(value, ",") {
+		n, err := strconv.Atoi(field)
+		if err != nil {
+			return nil, fmt.Errorf("invalid number %q", field)
+		}
+		nums = append(nums, n)
+	}
+	return nums, nil
+}
+
+// String returns the rule in canonical RRULE form, without DTStart.
+func (r Rule) String() string {
+	parts := []string{"FREQ=" + string(r.Freq)}
+	if r.Interval > 1 {
+		parts = append(parts, "INTERVAL="+strconv.Itoa(r.Interval))
+	}
+	if len(r.ByMonth) > 0 {
+		months := make([]int, len(r.ByMonth))
+		for i, m := range r.ByMonth {
+			months[i] = int(m)
+		}
+		parts = append(parts, "BYMONTH="+joinInts(months))
+	}
+	if len(r.ByMonthDay) > 0 {
+		parts = append(parts, "BYMONTHDAY="+joinInts(r.ByMonthDay))
+	}
+	if len(r.ByDay) > 0 {
+		codes := make([]string, len(r.ByDay))
+		for i, d := range r.ByDay {
+			codes[i] = weekdayNames[d]
+		}
+		parts = append(parts, "BYDAY="+strings.Join(codes, ","))
+	}
+	if len(r.BySetPos) > 0 {
+		parts = append(parts, "BYSETPOS="+joinInts(r.BySetPos))
+	}
+	return strings.Join(parts, ";")
+}
+
+func joinInts(nums []int) string {
+	strs := make([]string, len(nums))
+	for i, n := range nums {
+		strs[i] = strconv.Itoa(n)
+	}
+	return strings.Join(strs, ",")
+}
+
+// Validate checks that the rule is well-formed and produces at least one occurrence.
+func (r Rule) Validate() error {
+	switch r.Freq {
+	case Daily, Weekly, Monthly, Yearly:
+	case "":
+		return errors.New("FREQ is required")
+	default:
+		return fmt.Errorf("unsupported FREQ %q", r.Freq)
+	}
+	if r.Interval < 0 || r.Interval > MaxInterval {
+		return fmt.Errorf("INTERVAL must be between 1 and %d", MaxInterval)
+	}
+	for _, d := range r.ByMonthDay {
+		if d == 0 || d < -31 || d > 31 {
+			return errors.New("BYMONTHDAY values must be between 1 and 31 or -31 and -1")
+		}
+	}
+	if r.Freq == Weekly && len(r.ByMonthDay) > 0 {
+		return errors.New("BYMONTHDAY is not allowed with FREQ=WEEKLY")
+	}
+	for _, m := range r.ByMonth {
+		if m < time.January || m > time.December {
+			return errors.New("BYMONTH values must be between 1 and 12")
+		}
+	}
+	for _, p := range r.BySetPos {
+		if p == 0 || p < -366 || p > 366 {
+			return errors.New("BYSETPOS values must be between 1 and 366 or -366 and -1")
+		}
+	}
+
+	// Rules like Feb 30 parse but never occur.
+	ref := time.Date(2000, time.January, 1, 0, 0, 0, 0, time.UTC)
+	if !r.DTStart.IsZero() {
+		ref = r.DTStart
+	}
+	if r.Next(ref, time.UTC).IsZero() {
+		return ErrNoOccurrence
+	}
+	return nil
+}
+
+func (r Rule) interval() int {
+	if r.Interval < 1 {
+		return 1
+	}
+	return r.Interval
+}
+
+// Next returns the first occurrence on a date after the date of after, at midnight in loc.
+// Returns the zero time if the rule has no such occurrence.
+func (r Rule) Next(after time.Time, loc *time.Location) time.Time {
+	ref := dayOf(after, loc)
+	anchor, defaults := ref, ref
+	if !r.DTStart.IsZero() {
+		anchor = dayOf(r.DTStart, loc)
+		defaults = anchor
+	}
+	d, ok := r.nextAfter(ref, anchor, defaults)
+	if !ok {
+		return time.Time{}
+	}
+	return midnight(d, loc)
+}
+
+// Prev returns the last occurrence on a date before the date of before, at midnight in loc.
+// Returns the zero time if the rule has no such occurrence.
+//
+// Without DTStart, the date of before is treated as lying on the interval grid,
+// so a biweekly rule steps back exactly two weeks from a run date.
+func (r Rule) Prev(before time.Time, loc *time.Location) time.Time {
+	ref := dayOf(before, loc)
+	d, ok := r.onOrBefore(ref-1, ref, loc)
+	if !ok {
+		return time.Time{}
+	}
+	return midnight(d, loc)
+}
+
+// Bounds returns the period containing at: from the last occurrence on or before the
+// date of at, up to one second before the following occurrence.
+// If at precedes the first occurrence, the period starts at DTStart.
+func (r Rule) Bounds(at time.Time, loc *time.Location) (start, end time.Time) {
+	ref := dayOf(at, loc)
+	startDay, ok := r.onOrBefore(ref, ref, loc)
+	if !ok {
+		if r.DTStart.IsZero() {
+			return at, at
+		}
+		startDay = dayOf(r.DTStart, loc)
+	}
+
+	anchor, defaults := startDay, ref
+	if !r.DTStart.IsZero() {
+		anchor = dayOf(r.DTStart, loc)
+		defaults = anchor
+	}
+	endDay, ok := r.nextAfter(startDay, anchor, defaults)
+	if !ok {
+		return midnight(startDay, loc), at
+	}
+	return midnight(startDay, loc), midnight(endDay, loc).Add(-time.Second)
+}
+
+// nextAfter returns the first occurrence after day ref and on or after day anchor.
+// Intervals are counted from the first period with an occurrence on or after anchor.
+func (r Rule) nextAfter(ref, anchor, defaults int) (int, bool) {
+	base, ok := r.basePeriod(anchor, defaults)
+	if !ok {
+		return 0, false
+	}
+	iv := r.interval()
+	p := r.period(max(ref, anchor))
+	if p < base {
+		p = base
+	} else {
+		p = base + floorDiv(p-base, iv)*iv
+	}
+	for i := 0; i < maxPeriods; i++ {
+		for _, c := range r.candidates(p, defaults) {
+			if c > ref && c >= anchor {
+				return c, true
+			}
+		}
+		p += iv
+	}
+	return 0, false
+}
+
+// onOrBefore returns the last occurrence on or before day ref.
+// With DTStart, occurrences before DTStart are excluded. Without it, the period
+// containing gridDay is assumed to be on the interval grid.
+func (r Rule) onOrBefore(ref, gridDay int, loc *time.Location) (int, bool) {
+	iv := r.interval()
+	defaults := gridDay
+	lowest := r.period(ref) - maxPeriods*iv
+	anchor := math.MinInt
+
+	var p int
+	if r.DTStart.IsZero() {
+		grid := r.period(gridDay)
+		p = grid - ceilDiv(grid-r.period(ref), iv)*iv
+	} else {
+		anchor = dayOf(r.DTStart, loc)
+		defaults = anchor
+		base, ok := r.basePeriod(anchor, defaults)
+		if !ok || r.period(ref) < base {
+			return 0, false
+		}
+		p = base + floorDiv(r.period(ref)-base, iv)*iv
+		lowest = base
+	}
+
+	for ; p >= lowest; p -= iv {
+		cands := r.candidates(p, defaults)
+		for i := len(cands) - 1; i >= 0; i-- {
+			if c := cands[i]; c <= ref && c >= anchor {
+				return c, true
+			}
+		}
+	}
+	return 0, false
+}
+
+// basePeriod returns the first period at or after anchor's period with an occurrence on or after anchor.
+func (r Rule) basePeriod(anchor, defaults int) (int, bool) {
+	p := r.period(anchor)
+	for i := 0; i < maxPeriods; i++ {
+		for _, c := range r.candidates(p, defaults) {
+			if c >= anchor {
+				return p, true
+			}
+		}
+		p++
+	}
+	return 0, false
+}
+
+// period returns the index of the DAILY, WEEKLY (Monday-based), MONTHLY or YEARLY period containing day d.
+func (r Rule) period(d int) int {
+	switch r.Freq {
+	case Weekly:
+		return floorDiv(d+3, 7) // day 0 (1970-01-01) is a Thursday
+	case Monthly:
+		y, m, _ := civil(d)
+		return y*12 + int(m) - 1
+	case Yearly:
+		y, _, _ := civil(d)
+		return y
+	}
+	return d
+}
+
+// candidates returns the sorted occurrence days within period p.
+func (r Rule) candidates(p int, defaults int) []int {
+	var days []int
+	switch r.Freq {
+	case Daily:
+		if r.matchesMonth(p) && r.matchesMonthDay(p) && r.matchesWeekday(p, nil) {
+			days = append(days, p)
+		}
+	case Weekly:
+		byDay := r.ByDay
+		if len(byDay) == 0 {
+			byDay = []time.Weekday{weekday(defaults)}
+		}
+		start := p*7 - 3
+		for d := start; d < start+7; d++ {
+			if r.matchesMonth(d) && r.matchesWeekday(d, byDay) {
+				days = append(days, d)
+			}
+		}
+	case Monthly:
+		y, m := floorDiv(p, 12), time.Month(floorMod(p, 12)+1)
+		if len(r.ByMonth) == 0 || slices.Contains(r.ByMonth, m) {
+			days = r.monthDays(y, m, defaults)
+		}
+	case Yearly:
+		months := r.ByMonth
+		if len(months) == 0 {
+			if len(r.ByMonthDay) > 0 || len(r.ByDay) > 0 {
+				months = []time.Month{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12}
+			} else {
+				_, m, _ := civil(defaults)
+				months = []time.Month{m}
+			}
+		}
+		for _, m := range months {
+			days = append(days, r.monthDays(p, m, defaults)...)
+		}
+	}
+
+	slices.Sort(days)
+	days = slices.Compact(days)
+	return r.applySetPos(days)
+}
+
+// monthDays returns the occurrence days within a month for MONTHLY and YEARLY rules.
+func (r Rule) monthDays(y int, m time.Month, defaults int) []int {
+	n := daysInMonth(y, m)
+	var days []int
+	switch {
+	case len(r.ByMonthDay) > 0:
+		for _, md := range r.ByMonthDay {
+			if md < 0 {
+				md = n + md + 1
+			}
+			if md >= 1 && md <= n {
+				days = append(days, dayNumber(y, m, md))
+			}
+		}
+	case len(r.ByDay) > 0:
+		for md := 1; md <= n; md++ {
+			days = append(days, dayNumber(y, m, md))
+		}
+	default:
+		_, _, md := civil(defaults)
+		if md <= n {
+			days = append(days, dayNumber(y, m, md))
+		}
+	}
+
+	filtered := days[:0]
+	for _, d := range days {
+		if r.matchesWeekday(d, nil) {
+			filtered = append(filtered, d)
+		}
+	}
+	return filtered
+}
+
+func (r Rule) applySetPos(days []int) []int {
+	if len(r.BySetPos) == 0 || len(days) == 0 {
+		return days
+	}
+	var picked []int
+	for _, pos := range r.BySetPos {
+		i := pos - 1
+		if pos < 0 {
+			i = len(days) + pos
+		}
+		if i >= 0 && i < len(days) {
+			picked = append(picked, days[i])
+		}
+	}
+	slices.Sort(picked)
+	return slices.Compact(picked)
+}
+
+func (r Rule) matchesMonth(d int) bool {
+	if len(r.ByMonth) == 0 {
+		return true
+	}
+	_, m, _ := civil(d)
+	return slices.Contains(r.ByMonth, m)
+}
+
+func (r Rule) matchesMonthDay(d int) bool {
+	if len(r.ByMonthDay) == 0 {
+		return true
+	}
+	y, m, md := civil(d)
+	n := daysInMonth(y, m)
+	for _, want := range r.ByMonthDay {
+		if want < 0 {
+			want = n + want + 1
+		}
+		if want == md {
+			return true
+		}
+	}
+	return false
+}
+
+// matchesWeekday reports whether day d falls on one of byDay, defaulting to the rule's ByDay.
+func (r Rule) matchesWeekday(d int, byDay []time.Weekday) bool {
+	if byDay == nil {
+		byDay = r.ByDay
+	}
+	return len(byDay) == 0 || slices.Contains(byDay, weekday(d))
+}
+
+// Day numbers count calendar days since 1970-01-01, independent of timezone.
+
+func dayOf(t time.Time, loc *time.Location) int {
+	y, m, d := t.In(loc).Date()
+	return dayNumber(y, m, d)
+}
+
+func dayNumber(y int, m time.Month, d int) int {
+	return int(time.Date(y, m, d, 0, 0, 0, 0, time.UTC).Unix() / 86400)
+}
+
+func civil(d int) (int, time.Month, int) {
+	return time.Unix(int64(d)*86400, 0).UTC().Date()
+}
+
+func midnight(d int, loc *time.Location) time.Time {
+	y, m, day := civil(d)
+	return time.Date(y, m, day, 0, 0, 0, 0, loc)
+}
+
+func weekday(d int) time.Weekday {
+	return time.Weekday(floorMod(d+4, 7))
+}
+
+func daysInMonth(y int, m time.Month) int {
+	return time.Date(y, m+1, 0, 0, 0, 0, 0, time.UTC).Day()
+}
+
+func floorDiv(a, b int) int {
+	q := a / b
+	if (a%b != 0) && ((a < 0) != (b < 0)) {
+		q--
+	}
+	return q
+}
+
+func floorMod(a, b int) int {
+	return a - floorDiv(a, b)*b
+}
+
+func ceilDiv(a, b int) int {
+	return -floorDiv(-a, b)
+}
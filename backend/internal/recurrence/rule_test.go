@@ -0,0 +1,244 @@
+package recurrence
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func date(year int, month time.Month, day int) time.Time {
+	return time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+}
+
+func mustLoad(t *testing.T, name string) *time.Location {
+	t.Helper()
+	loc, err := time.LoadLocation(name)
+	require.NoError(t, err)
+	return loc
+}
+
+func mustParse(t *testing.T, s string) Rule {
+	t.Helper()
+	r, err := Parse(s)
+	require.NoError(t, err)
+	return r
+}
+
+// =====================================================
+// Parse / String
+// =====================================================
+
+func TestParse_RoundTrip(t *testing.T) {
+	for _, s := range []string{
+		"FREQ=DAILY",
+		"FREQ=DAILY;INTERVAL=3",
+		"FREQ=WEEKLY;BYDAY=MO,WE,FR",
+		"FREQ=WEEKLY;INTERVAL=2;BYDAY=FR",
+		"FREQ=MONTHLY;BYMONTHDAY=1,15",
+		"FREQ=MONTHLY;BYMONTHDAY=-1",
+		"FREQ=MONTHLY;INTERVAL=3;BYMONTHDAY=1",
+		"FREQ=MONTHLY;BYDAY=FR;BYSETPOS=-1",
+		"FREQ=YEARLY;BYMONTH=6;BYMONTHDAY=15",
+	} {
+		r := mustParse(t, s)
+		assert.Equal(t, s, r.String())
+	}
+}
+
+func TestParse_AcceptsPrefixAndLowercase(t *testing.T) {
+	r := mustParse(t, "RRULE:freq=weekly;byday=mo,fr;wkst=MO")
+	assert.Equal(t, "FREQ=WEEKLY;BYDAY=MO,FR", r.String())
+}
+
+func TestParse_Invalid(t *testing.T) {
+	for _, s := range []string{
+		"",
+		"BYDAY=MO",
+		"FREQ=HOURLY",
+		"FREQ=DAILY;INTERVAL=0",
+		"FREQ=DAILY;INTERVAL=abc",
+		"FREQ=DAILY;INTERVAL=1000",
+		"FREQ=WEEKLY;BYDAY=XX",
+		"FREQ=WEEKLY;BYDAY=1MO",
+		"FREQ=WEEKLY;BYMONTHDAY=1",
+		"FREQ=MONTHLY;BYMONTHDAY=0",
+		"FREQ=MONTHLY;BYMONTHDAY=32",
+		"FREQ=YEARLY;BYMONTH=13",
+		"FREQ=MONTHLY;BYSETPOS=0",
+		"FREQ=DAILY;COUNT=5",
+		"FREQ=DAILY;FREQ=WEEKLY",
+		"FREQ=DAILY;WKST=SU",
+		"FREQ=YEARLY;BYMONTH=2;BYMONTHDAY=30",
+		"FREQ",
+	} {
+		_, err := Parse(s)
+		assert.Error(t, err, s)
+	}
+}
+
+// =====================================================
+// New patterns
+// =====================================================
+
+func nextN(r Rule, after time.Time, loc *time.Location, n int) []time.Time {
+	var out []time.Time
+	for i := 0; i < n; i++ {
+		after = r.Next(after, loc)
+		out = append(out, after)
+	}
+	return out
+}
+
+func TestNext_SemiMonthly(t *testing.T) {
+	r := mustParse(t, "FREQ=MONTHLY;BYMONTHDAY=1,15")
+	got := nextN(r, date(2026, time.January, 10), time.UTC, 4)
+	assert.Equal(t, []time.Time{
+		date(2026, time.January, 15),
+		date(2026, time.February, 1),
+		date(2026, time.February, 15),
+		date(2026, time.March, 1),
+	}, got)
+}
+
+func TestNext_EveryNDays(t *testing.T) {
+	r := mustParse(t, "FREQ=DAILY;INTERVAL=10")
+	// Without DTStart the reference date anchors the interval, like biweekly schedules
+	got := nextN(r, date(2026, time.February, 25), time.UTC, 3)
+	assert.Equal(t, []time.Time{
+		date(2026, time.March, 7),
+		date(2026, time.March, 17),
+		date(2026, time.March, 27),
+	}, got)
+}
+
+func TestNext_EveryNDaysWithDTStart(t *testing.T) {
+	r := mustParse(t, "FREQ=DAILY;INTERVAL=3")
+	r.DTStart = date(2026, time.March, 1)
+	// Mar 1, 4, 7, 10 ...
+	assert.Equal(t, date(2026, time.March, 7), r.Next(date(2026, time.March, 5), time.UTC))
+	assert.Equal(t, date(2026, time.March, 1), r.Next(date(2026, time.February, 1), time.UTC))
+}
+
+func TestNext_SpecificWeekdays(t *testing.T) {
+	r := mustParse(t, "FREQ=WEEKLY;BYDAY=MO,WE,FR")
+	// 2026-02-04 is a Wednesday
+	got := nextN(r, date(2026, time.February, 4), time.UTC, 4)
+	assert.Equal(t, []time.Time{
+		date(2026, time.February, 6),
+		date(2026, time.February, 9),
+		date(2026, time.February, 11),
+		date(2026, time.February, 13),
+	}, got)
+}
+
+func TestNext_BiweeklyMultipleDays(t *testing.T) {
+	r := mustParse(t, "FREQ=WEEKLY;INTERVAL=2;BYDAY=TU,TH")
+	// 2026-02-02 is a Monday; first occurrence Tue Feb 3 sets the grid
+	got := nextN(r, date(2026, time.February, 2), time.UTC, 4)
+	assert.Equal(t, []time.Time{
+		date(2026, time.February, 3),
+		date(2026, time.February, 5),
+		date(2026, time.February, 17),
+		date(2026, time.February, 19),
+	}, got)
+}
+
+func TestNext_Quarterly(t *testing.T) {
+	r := mustParse(t, "FREQ=MONTHLY;INTERVAL=3;BYMONTHDAY=1")
+	got := nextN(r, date(2026, time.January, 15), time.UTC, 3)
+	assert.Equal(t, []time.Time{
+		date(2026, time.February, 1),
+		date(2026, time.May, 1),
+		date(2026, time.August, 1),
+	}, got)
+}
+
+func TestNext_Yearly(t *testing.T) {
+	r := mustParse(t, "FREQ=YEARLY;BYMONTH=2;BYMONTHDAY=29")
+	got := nextN(r, date(2026, time.January, 1), time.UTC, 2)
+	assert.Equal(t, []time.Time{
+		date(2028, time.February, 29),
+		date(2032, time.February, 29),
+	}, got)
+}
+
+func TestNext_YearlyDefaultsToDTStart(t *testing.T) {
+	r := mustParse(t, "FREQ=YEARLY")
+	r.DTStart = date(2020, time.July, 4)
+	assert.Equal(t, date(2026, time.July, 4), r.Next(date(2026, time.March, 1), time.UTC))
+	assert.Equal(t, date(2027, time.July, 4), r.Next(date(2026, time.July, 4), time.UTC))
+}
+
+func TestNext_LastDayOfMonth(t *testing.T) {
+	r := mustParse(t, "FREQ=MONTHLY;BYMONTHDAY=-1")
+	got := nextN(r, date(2028, time.January, 31), time.UTC, 3)
+	assert.Equal(t, []time.Time{
+		date(2028, time.February, 29),
+		date(2028, time.March, 31),
+		date(2028, time.April, 30),
+	}, got)
+}
+
+func TestNext_LastFridayOfMonth(t *testing.T) {
+	r := mustParse(t, "FREQ=MONTHLY;BYDAY=FR;BYSETPOS=-1")
+	got := nextN(r, date(2026, time.February, 1), time.UTC, 2)
+	assert.Equal(t, []time.Time{
+		date(2026, time.February, 27),
+		date(2026, time.March, 27),
+	}, got)
+}
+
+func TestNext_DSTSpringForward(t *testing.T) {
+	ny := mustLoad(t, "America/New_York")
+	r := mustParse(t, "FREQ=DAILY")
+	// DST starts 2026-03-08 at 2am in New York
+	next := r.Next(time.Date(2026, time.March, 7, 12, 0, 0, 0, ny), ny)
+	assert.Equal(t, time.Date(2026, time.March, 8, 0, 0, 0, 0, ny), next)
+	next = r.Next(next, ny)
+	assert.Equal(t, time.Date(2026, time.March, 9, 0, 0, 0, 0, ny), next)
+	assert.Equal(t, 23*time.Hour, next.Sub(time.Date(2026, time.March, 8, 0, 0, 0, 0, ny)))
+}
+
+func TestNext_UTCInputNonUTCLocation(t *testing.T) {
+	tokyo := mustLoad(t, "Asia/Tokyo")
+	r := mustParse(t, "FREQ=WEEKLY;BYDAY=FR")
+	// 2026-02-05 20:00 UTC is already Friday 05:00 in Tokyo
+	after := time.Date(2026, time.February, 5, 20, 0, 0, 0, time.UTC)
+	assert.Equal(t, time.Date(2026, time.February, 13, 0, 0, 0, 0, tokyo), r.Next(after, tokyo))
+}
+
+// =====================================================
+// Prev / Bounds
+// =====================================================
+
+func TestPrev(t *testing.T) {
+	assert.Equal(t, date(2026, time.February, 6), WeeklyOn(time.Friday, 1).Prev(date(2026, time.February, 13), time.UTC))
+	assert.Equal(t, date(2026, time.January, 30), WeeklyOn(time.Friday, 2).Prev(date(2026, time.February, 13), time.UTC))
+	assert.Equal(t, date(2026, time.February, 28), MonthlyOn(31).Prev(date(2026, time.March, 31), time.UTC))
+	assert.Equal(t, date(2026, time.February, 15), mustParse(t, "FREQ=MONTHLY;BYMONTHDAY=1,15").Prev(date(2026, time.March, 1), time.UTC))
+}
+
+func TestBounds_WithDTStart(t *testing.T) {
+	r := mustParse(t, "FREQ=DAILY;INTERVAL=3")
+	r.DTStart = date(2026, time.March, 1)
+
+	start, end := r.Bounds(time.Date(2026, time.March, 5, 15, 0, 0, 0, time.UTC), time.UTC)
+	assert.Equal(t, date(2026, time.March, 4), start)
+	assert.Equal(t, date(2026, time.March, 7).Add(-time.Second), end)
+
+	// Before the first occurrence, the period starts at DTStart
+	r = mustParse(t, "FREQ=WEEKLY;BYDAY=MO")
+	r.DTStart = date(2026, time.March, 4) // Wednesday
+	start, end = r.Bounds(date(2026, time.March, 5), time.UTC)
+	assert.Equal(t, date(2026, time.March, 4), start)
+	assert.Equal(t, date(2026, time.March, 9).Add(-time.Second), end)
+}
+
+func TestBounds_SemiMonthly(t *testing.T) {
+	r := mustParse(t, "FREQ=MONTHLY;BYMONTHDAY=1,15")
+	start, end := r.Bounds(date(2026, time.February, 20), time.UTC)
+	assert.Equal(t, date(2026, time.February, 15), start)
+	assert.Equal(t, date(2026, time.March, 1).Add(-time.Second), end)
+}
@@ -14,6 +14,15 @@ import (
 
 var validBankName = regexp.MustCompile(`^[\p{L}\p{N} '\-]+$`)
 
+const (
+	MinWithdrawalExpiryDays = 1
+	MaxWithdrawalExpiryDays = 90
+
+	MinWithdrawalQuorumApprovals = 2
+	MaxWithdrawalQuorumApprovals = 10
+	MaxWithdrawalQuorumThreshold = 99999999 // $999,999.99
+)
+
 type Handlers struct {
 	familyRepo *repositories.FamilyRepo
 }
@@ -41,9 +50,26 @@ func (h *Handlers) HandleGetSettings(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	expiryDays, err := h.familyRepo.GetWithdrawalExpiryDays(familyID)
+	if err != nil {
+		writeJSON(w, http.StatusInternalServerError, map[string]string{"error": "Internal server error"})
+		return
+	}
+
+	quorumThreshold, quorumApprovals, err := h.familyRepo.GetWithdrawalQuorum(familyID)
+	if err != nil {
+		writeJSON(w, http.StatusInternalServerError, map[string]string{"error": "Internal server error"})
+		return
+	}
+
 	writeJSON(w, http.StatusOK, map[string]interface{}{
-		"timezone":  tz,
-		"bank_name": bankName,
+		"timezone":               tz,
+		"bank_name":              bankName,
+		"withdrawal_expiry_days": expiryDays,
+		"withdrawal_quorum": map[string]interface{}{
+			"threshold_cents": quorumThreshold,
+			"approvals":       quorumApprovals,
+		},
 	})
 }
 
@@ -142,6 +168,107 @@ func (h *Handlers) HandleUpdateBankName(w http.ResponseWriter, r *http.Request)
 	})
 }
 
+// HandleUpdateWithdrawalExpiry sets how many days pending withdrawal requests wait for a parent
+// before they expire.
+func (h *Handlers) HandleUpdateWithdrawalExpiry(w http.ResponseWriter, r *http.Request) {
+	familyID := middleware.GetFamilyID(r)
+	if familyID == 0 {
+		writeJSON(w, http.StatusBadRequest, map[string]string{"error": "No family associated"})
+		return
+	}
+
+	var req struct {
+		Days int `json:"days"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeJSON(w, http.StatusBadRequest, map[string]string{"error": "Invalid request body"})
+		return
+	}
+
+	if req.Days < MinWithdrawalExpiryDays || req.Days > MaxWithdrawalExpiryDays {
+		writeJSON(w, http.StatusBadRequest, map[string]string{
+			"error":   "bad_request",
+			"message": "Withdrawal requests must expire after between 1 and 90 days",
+		})
+		return
+	}
+
+	if err := h.familyRepo.UpdateWithdrawalExpiryDays(familyID, req.Days); err != nil {
+		writeJSON(w, http.StatusInternalServerError, map[string]string{"error": "Internal server error"})
+		return
+	}
+
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"message":                "Withdrawal expiry updated",
+		"withdrawal_expiry_days": req.Days,
+	})
+}
+
+// HandleUpdateWithdrawalQuorum sets how many distinct parents must approve withdrawal requests
+// above a threshold amount. A null threshold_cents lets any one parent approve any request.
+func (h *Handlers) HandleUpdateWithdrawalQuorum(w http.ResponseWriter, r *http.Request) {
+	familyID := middleware.GetFamilyID(r)
+	if familyID == 0 {
+		writeJSON(w, http.StatusBadRequest, map[string]string{"error": "No family associated"})
+		return
+	}
+
+	var req struct {
+		ThresholdCents *int `json:"threshold_cents"`
+		Approvals      int  `json:"approvals"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeJSON(w, http.StatusBadRequest, map[string]string{"error": "Invalid request body"})
+		return
+	}
+	if req.Approvals == 0 {
+		req.Approvals = MinWithdrawalQuorumApprovals
+	}
+
+	if req.Approvals < MinWithdrawalQuorumApprovals || req.Approvals > MaxWithdrawalQuorumApprovals {
+		writeJSON(w, http.StatusBadRequest, map[string]string{
+			"error":   "bad_request",
+			"message": "Approvals must be between 2 and 10",
+		})
+		return
+	}
+	if req.ThresholdCents != nil && (*req.ThresholdCents < 0 || *req.ThresholdCents > MaxWithdrawalQuorumThreshold) {
+		writeJSON(w, http.StatusBadRequest, map[string]string{
+			"error":   "bad_request",
+			"message": "Threshold must be between $0.00 and $999,999.99",
+		})
+		return
+	}
+
+	if req.ThresholdCents != nil {
+		parents, err := h.familyRepo.CountParents(familyID)
+		if err != nil {
+			writeJSON(w, http.StatusInternalServerError, map[string]string{"error": "Internal server error"})
+			return
+		}
+		if int64(req.Approvals) > parents {
+			writeJSON(w, http.StatusBadRequest, map[string]string{
+				"error":   "bad_request",
+				"message": fmt.Sprintf("Your family has %d parent(s), so it cannot require %d approvals", parents, req.Approvals),
+			})
+			return
+		}
+	}
+
+	if err := h.familyRepo.UpdateWithdrawalQuorum(familyID, req.ThresholdCents, req.Approvals); err != nil {
+		writeJSON(w, http.StatusInternalServerError, map[string]string{"error": "Internal server error"})
+		return
+	}
+
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"message": "Withdrawal quorum updated",
+		"withdrawal_quorum": map[string]interface{}{
+			"threshold_cents": req.ThresholdCents,
+			"approvals":       req.Approvals,
+		},
+	})
+}
+
 func writeJSON(w http.ResponseWriter, status int, v interface{}) {
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(status)
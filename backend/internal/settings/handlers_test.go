@@ -38,10 +38,11 @@ func TestHandleGetSettings_ReturnsDefaultTimezone(t *testing.T) {
 
 	assert.Equal(t, http.StatusOK, rr.Code)
 
-	var resp map[string]string
+	var resp map[string]interface{}
 	err = json.Unmarshal(rr.Body.Bytes(), &resp)
 	require.NoError(t, err)
 	assert.Equal(t, "America/New_York", resp["timezone"])
+	assert.Equal(t, float64(7), resp["withdrawal_expiry_days"])
 }
 
 func TestHandleGetSettings_NoFamilyID(t *testing.T) {
@@ -148,3 +149,91 @@ func TestHandleUpdateTimezone_MalformedJSON(t *testing.T) {
 
 	assert.Equal(t, http.StatusBadRequest, rr.Code)
 }
+
+// --- PUT /api/settings/withdrawal-expiry ---
+
+func TestHandleUpdateWithdrawalExpiry_Valid(t *testing.T) {
+	h, fs := newTestHandlers(t)
+
+	fam, err := fs.Create("expiry-update-fam")
+	require.NoError(t, err)
+
+	body, _ := json.Marshal(map[string]int{"days": 3})
+	req := httptest.NewRequest("PUT", "/api/settings/withdrawal-expiry", bytes.NewReader(body))
+	req = testutil.SetRequestContext(req, "parent", 1, fam.ID)
+	rr := httptest.NewRecorder()
+
+	h.HandleUpdateWithdrawalExpiry(rr, req)
+
+	assert.Equal(t, http.StatusOK, rr.Code)
+
+	days, err := fs.GetWithdrawalExpiryDays(fam.ID)
+	require.NoError(t, err)
+	assert.Equal(t, 3, days)
+}
+
+func TestHandleUpdateWithdrawalExpiry_OutOfRange(t *testing.T) {
+	h, fs := newTestHandlers(t)
+
+	fam, err := fs.Create("expiry-range-fam")
+	require.NoError(t, err)
+
+	for _, days := range []int{0, 91} {
+		body, _ := json.Marshal(map[string]int{"days": days})
+		req := httptest.NewRequest("PUT", "/api/settings/withdrawal-expiry", bytes.NewReader(body))
+		req = testutil.SetRequestContext(req, "parent", 1, fam.ID)
+		rr := httptest.NewRecorder()
+
+		h.HandleUpdateWithdrawalExpiry(rr, req)
+
+		assert.Equal(t, http.StatusBadRequest, rr.Code)
+	}
+}
+
+// --- PUT /api/settings/withdrawal-quorum ---
+
+func TestHandleUpdateWithdrawalQuorum(t *testing.T) {
+	db := testutil.SetupTestDB(t)
+	fs := repositories.NewFamilyRepo(db)
+	h := NewHandlers(fs)
+
+	fam := testutil.CreateTestFamily(t, db)
+	parent := testutil.CreateTestParent(t, db, fam.ID)
+
+	put := func(body string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest("PUT", "/api/settings/withdrawal-quorum", bytes.NewBufferString(body))
+		req = testutil.SetRequestContext(req, "parent", parent.ID, fam.ID)
+		rr := httptest.NewRecorder()
+		h.HandleUpdateWithdrawalQuorum(rr, req)
+		return rr
+	}
+
+	// A one-parent family can't require two approvals
+	rr := put(`{"threshold_cents": 5000, "approvals": 2}`)
+	assert.Equal(t, http.StatusBadRequest, rr.Code)
+
+	ps := repositories.NewParentRepo(db)
+	second, err := ps.Create("google-id-456", "parent2@test.com", "Second Parent")
+	require.NoError(t, err)
+	require.NoError(t, ps.SetFamilyID(second.ID, fam.ID))
+
+	rr = put(`{"threshold_cents": 5000, "approvals": 2}`)
+	require.Equal(t, http.StatusOK, rr.Code, rr.Body.String())
+
+	threshold, approvals, err := fs.GetWithdrawalQuorum(fam.ID)
+	require.NoError(t, err)
+	require.NotNil(t, threshold)
+	assert.Equal(t, 5000, *threshold)
+	assert.Equal(t, 2, approvals)
+
+	for _, body := range []string{`{"threshold_cents": 5000, "approvals": 11}`, `{"threshold_cents": -1, "approvals": 2}`} {
+		assert.Equal(t, http.StatusBadRequest, put(body).Code, body)
+	}
+
+	// A null threshold turns the quorum off
+	rr = put(`{"threshold_cents": null}`)
+	require.Equal(t, http.StatusOK, rr.Code, rr.Body.String())
+	threshold, _, err = fs.GetWithdrawalQuorum(fam.ID)
+	require.NoError(t, err)
+	assert.Nil(t, threshold)
+}
@@ -0,0 +1,65 @@
+package storage
+
+import (
+	"context"
+	"io"
+	"path"
+)
+
+// S3Client is the subset of an S3-compatible object storage API that S3Store needs. It is small
+// enough to wrap the AWS SDK, MinIO or any other S3-compatible client. GetObject must return
+// ErrNotFound for missing keys, and DeleteObject must not fail for them.
+type S3Client interface {
+	PutObject(ctx context.Context, bucket, key string, body io.Reader, size int64, contentType string) error
+	GetObject(ctx context.Context, bucket, key string) (io.ReadCloser, error)
+	DeleteObject(ctx context.Context, bucket, key string) error
+}
+
+// S3Store keeps objects in a bucket of an S3-compatible service, optionally under a key prefix.
+type S3Store struct {
+	client S3Client
+	bucket string
+	prefix string
+}
+
+// NewS3Store creates an S3Store for bucket. prefix, if set, is prepended to every key.
+func NewS3Store(client S3Client, bucket, prefix string) *S3Store {
+	return &S3Store{client: client, bucket: bucket, prefix: prefix}
+}
+
+func (s *S3Store) objectKey(key string) (string, error) {
+	if !validKey(key) {
+		return "", ErrInvalidKey
+	}
+	if s.prefix == "" {
+		return key, nil
+	}
+	return path.Join(s.prefix, key), nil
+}
+
+// Put uploads body under key.
+func (s *S3Store) Put(ctx context.Context, key string, body io.Reader, size int64, contentType string) error {
+	objectKey, err := s.objectKey(key)
+	if err != nil {
+		return err
+	}
+	return s.client.PutObject(ctx, s.bucket, objectKey, body, size, contentType)
+}
+
+// Get downloads the object under key.
+func (s *S3Store) Get(ctx context.Context, key string) (io.ReadCloser, error) {
+	objectKey, err := s.objectKey(key)
+	if err != nil {
+		return nil, err
+	}
+	return s.client.GetObject(ctx, s.bucket, objectKey)
+}
+
+// Delete removes the object under key.
+func (s *S3Store) Delete(ctx context.Context, key string) error {
+	objectKey, err := s.objectKey(key)
+	if err != nil {
+		return err
+	}
+	return s.client.DeleteObject(ctx, s.bucket, objectKey)
+}
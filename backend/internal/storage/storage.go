@@ -0,0 +1,36 @@
+// Package storage stores uploaded files, such as chore photos, outside the database.
+package storage
+
+import (
+	"context"
+	"errors"
+	"io"
+	"strings"
+)
+
+// ErrNotFound is returned when no object is stored under a key.
+var ErrNotFound = errors.New("object not found")
+
+// ErrInvalidKey is returned for keys that are empty, absolute or climb out of the store.
+var ErrInvalidKey = errors.New("invalid object key")
+
+// Store saves and loads objects by key. Keys are slash-separated paths such as
+// "chore-photos/12/ab34.jpg".
+type Store interface {
+	Put(ctx context.Context, key string, body io.Reader, size int64, contentType string) error
+	Get(ctx context.Context, key string) (io.ReadCloser, error)
+	Delete(ctx context.Context, key string) error
+}
+
+// validKey reports whether key is a relative path without empty, "." or ".." segments.
+func validKey(key string) bool {
+	if key == "" || strings.HasPrefix(key, "/") || strings.Contains(key, `\`) {
+		return false
+	}
+	for _, part := range strings.Split(key, "/") {
+		if part == "" || part == "." || part == ".." {
+			return false
+		}
+	}
+	return true
+}
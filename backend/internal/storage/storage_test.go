@@ -0,0 +1,99 @@
+package storage
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidKey(t *testing.T) {
+	assert.True(t, validKey("chore-photos/12/ab34.jpg"))
+	assert.True(t, validKey("file.txt"))
+
+	assert.False(t, validKey(""))
+	assert.False(t, validKey("/etc/passwd"))
+	assert.False(t, validKey("chore-photos/../secret"))
+	assert.False(t, validKey("chore-photos//a.jpg"))
+	assert.False(t, validKey("./a.jpg"))
+	assert.False(t, validKey(`chore-photos\a.jpg`))
+}
+
+func TestLocalStore_RoundTrip(t *testing.T) {
+	store, err := NewLocalStore(t.TempDir())
+	require.NoError(t, err)
+	ctx := context.Background()
+
+	require.NoError(t, store.Put(ctx, "chore-photos/1/a.jpg", strings.NewReader("photo"), 5, "image/jpeg"))
+
+	rc, err := store.Get(ctx, "chore-photos/1/a.jpg")
+	require.NoError(t, err)
+	data, err := io.ReadAll(rc)
+	require.NoError(t, err)
+	require.NoError(t, rc.Close())
+	assert.Equal(t, "photo", string(data))
+
+	require.NoError(t, store.Delete(ctx, "chore-photos/1/a.jpg"))
+	_, err = store.Get(ctx, "chore-photos/1/a.jpg")
+	assert.ErrorIs(t, err, ErrNotFound)
+
+	// Deleting again is fine
+	assert.NoError(t, store.Delete(ctx, "chore-photos/1/a.jpg"))
+}
+
+func TestLocalStore_RejectsInvalidKeys(t *testing.T) {
+	store, err := NewLocalStore(t.TempDir())
+	require.NoError(t, err)
+
+	err = store.Put(context.Background(), "../escape.jpg", strings.NewReader("x"), 1, "image/jpeg")
+	assert.ErrorIs(t, err, ErrInvalidKey)
+}
+
+// fakeS3 is an in-memory S3Client.
+type fakeS3 struct {
+	objects map[string][]byte
+}
+
+func (f *fakeS3) PutObject(_ context.Context, bucket, key string, body io.Reader, _ int64, _ string) error {
+	data, err := io.ReadAll(body)
+	if err != nil {
+		return err
+	}
+	f.objects[bucket+":"+key] = data
+	return nil
+}
+
+func (f *fakeS3) GetObject(_ context.Context, bucket, key string) (io.ReadCloser, error) {
+	data, ok := f.objects[bucket+":"+key]
+	if !ok {
+		return nil, ErrNotFound
+	}
+	return io.NopCloser(bytes.NewReader(data)), nil
+}
+
+func (f *fakeS3) DeleteObject(_ context.Context, bucket, key string) error {
+	delete(f.objects, bucket+":"+key)
+	return nil
+}
+
+func TestS3Store_UsesBucketAndPrefix(t *testing.T) {
+	client := &fakeS3{objects: map[string][]byte{}}
+	store := NewS3Store(client, "family-uploads", "prod")
+	ctx := context.Background()
+
+	require.NoError(t, store.Put(ctx, "chore-photos/1/a.jpg", strings.NewReader("photo"), 5, "image/jpeg"))
+	assert.Contains(t, client.objects, "family-uploads:prod/chore-photos/1/a.jpg")
+
+	rc, err := store.Get(ctx, "chore-photos/1/a.jpg")
+	require.NoError(t, err)
+	rc.Close()
+
+	require.NoError(t, store.Delete(ctx, "chore-photos/1/a.jpg"))
+	assert.Empty(t, client.objects)
+
+	assert.ErrorIs(t, store.Put(ctx, "../a.jpg", strings.NewReader("x"), 1, "image/jpeg"), ErrInvalidKey)
+}
@@ -0,0 +1,120 @@
+package withdrawal
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"bank-of-dad/internal/middleware"
+	"bank-of-dad/models"
+	"bank-of-dad/repositories"
+)
+
+// MaxBatchSize is the most withdrawal requests that can be reviewed in one batch.
+const MaxBatchSize = 50
+
+// Batch review actions.
+const (
+	BatchActionApprove = "approve"
+	BatchActionDeny    = "deny"
+)
+
+// BatchReviewItem is one withdrawal request in a batch review, with its own outcome.
+// ConfirmGoalImpact applies to approvals and Reason to denials.
+type BatchReviewItem struct {
+	ID                int64  `json:"id"`
+	Action            string `json:"action"`
+	ConfirmGoalImpact bool   `json:"confirm_goal_impact,omitempty"`
+	Reason            string `json:"reason,omitempty"`
+}
+
+// BatchReviewRequest represents the request body for reviewing several withdrawal requests at once.
+type BatchReviewRequest struct {
+	Items []BatchReviewItem `json:"items"`
+}
+
+// BatchReviewResult reports how one item of a batch review went. Failed items carry the same
+// error, message and goal impact details as the single-request endpoints. An approval without
+// NewBalanceCents was recorded but the request still awaits other parents' approvals.
+type BatchReviewResult struct {
+	ID                 int64                           `json:"id"`
+	Action             string                          `json:"action"`
+	Success            bool                            `json:"success"`
+	WithdrawalRequest  *models.WithdrawalRequest       `json:"withdrawal_request,omitempty"`
+	NewBalanceCents    *int64                          `json:"new_balance_cents,omitempty"`
+	Error              string                          `json:"error,omitempty"`
+	Message            string                          `json:"message,omitempty"`
+	AffectedGoals      []repositories.AffectedGoalInfo `json:"affected_goals,omitempty"`
+	TotalReleasedCents int64                           `json:"total_released_cents,omitempty"`
+}
+
+// validateBatch checks the shape of a batch review. Returns an error message or empty string.
+func validateBatch(items []BatchReviewItem) string {
+	if len(items) == 0 || len(items) > MaxBatchSize {
+		return "A batch must contain between 1 and 50 requests."
+	}
+	seen := make(map[int64]bool, len(items))
+	for _, item := range items {
+		if item.Action != BatchActionApprove && item.Action != BatchActionDeny {
+			return "Each action must be one of: approve, deny."
+		}
+		if seen[item.ID] {
+			return "Each request can only appear once in a batch."
+		}
+		seen[item.ID] = true
+	}
+	return ""
+}
+
+// HandleBatchReview handles POST /api/withdrawal-requests/batch
+// Each request is approved or denied on its own, so one failure doesn't hold up the rest.
+func (h *Handler) HandleBatchReview(w http.ResponseWriter, r *http.Request) {
+	familyID := middleware.GetFamilyID(r)
+	parentID := middleware.GetUserID(r)
+
+	var req BatchReviewRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeJSON(w, http.StatusBadRequest, ErrorResponse{
+			Error:   "invalid_request",
+			Message: "Invalid request body.",
+		})
+		return
+	}
+	if errMsg := validateBatch(req.Items); errMsg != "" {
+		writeJSON(w, http.StatusBadRequest, ErrorResponse{
+			Error:   "invalid_request",
+			Message: errMsg,
+		})
+		return
+	}
+
+	results := make([]BatchReviewResult, len(req.Items))
+	succeeded := 0
+	for i, item := range req.Items {
+		result := BatchReviewResult{ID: item.ID, Action: item.Action}
+
+		var fail *reviewFailure
+		switch item.Action {
+		case BatchActionApprove:
+			result.WithdrawalRequest, result.NewBalanceCents, fail = h.approve(familyID, parentID, item.ID, item.ConfirmGoalImpact)
+		case BatchActionDeny:
+			result.WithdrawalRequest, fail = h.deny(familyID, parentID, item.ID, item.Reason)
+		}
+
+		if fail != nil {
+			result.Error = fail.Error
+			result.Message = fail.Message
+			result.AffectedGoals = fail.AffectedGoals
+			result.TotalReleasedCents = fail.TotalReleasedCents
+		} else {
+			result.Success = true
+			succeeded++
+		}
+		results[i] = result
+	}
+
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"results":   results,
+		"succeeded": succeeded,
+		"failed":    len(results) - succeeded,
+	})
+}
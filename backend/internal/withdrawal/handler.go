@@ -19,10 +19,11 @@ const (
 
 // Handler handles withdrawal request HTTP endpoints.
 type Handler struct {
-	wrRepo    *repositories.WithdrawalRequestRepo
-	txRepo    *repositories.TransactionRepo
-	childRepo *repositories.ChildRepo
-	goalRepo  *repositories.SavingsGoalRepo
+	wrRepo     *repositories.WithdrawalRequestRepo
+	txRepo     *repositories.TransactionRepo
+	childRepo  *repositories.ChildRepo
+	goalRepo   *repositories.SavingsGoalRepo
+	policyRepo *repositories.WithdrawalPolicyRepo
 }
 
 // NewHandler creates a new withdrawal request handler.
@@ -35,6 +36,11 @@ func NewHandler(wrRepo *repositories.WithdrawalRequestRepo, txRepo *repositories
 	}
 }
 
+// SetPolicyRepo sets the repo used to auto-approve requests covered by a child's withdrawal policy.
+func (h *Handler) SetPolicyRepo(repo *repositories.WithdrawalPolicyRepo) {
+	h.policyRepo = repo
+}
+
 // ErrorResponse represents an error response.
 type ErrorResponse struct {
 	Error   string `json:"error"`
@@ -48,12 +54,16 @@ func writeJSON(w http.ResponseWriter, status int, v interface{}) {
 }
 
 // SubmitRequest represents the request body for submitting a withdrawal request.
+// With SavingsGoalID set, the request redeems that completed goal: the amount is the goal's
+// saved funds and the reason defaults to the goal's name.
 type SubmitRequest struct {
-	AmountCents int    `json:"amount_cents"`
-	Reason      string `json:"reason"`
+	AmountCents   int    `json:"amount_cents"`
+	Reason        string `json:"reason"`
+	SavingsGoalID *int64 `json:"savings_goal_id,omitempty"`
 }
 
 // HandleSubmitRequest handles POST /api/child/withdrawal-requests
+// Requests covered by the child's withdrawal policy are approved immediately.
 func (h *Handler) HandleSubmitRequest(w http.ResponseWriter, r *http.Request) {
 	userType := middleware.GetUserType(r)
 	if userType != "child" {
@@ -94,6 +104,36 @@ func (h *Handler) HandleSubmitRequest(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	// A goal redemption is for the goal's saved funds
+	if req.SavingsGoalID != nil && h.goalRepo != nil {
+		goal, err := h.goalRepo.GetByID(*req.SavingsGoalID)
+		if err != nil {
+			writeJSON(w, http.StatusInternalServerError, ErrorResponse{
+				Error:   "internal_error",
+				Message: "Failed to lookup goal.",
+			})
+			return
+		}
+		if goal == nil || goal.ChildID != childID {
+			writeJSON(w, http.StatusNotFound, ErrorResponse{
+				Error:   "not_found",
+				Message: "Goal not found.",
+			})
+			return
+		}
+		if goal.Status != "completed" {
+			writeJSON(w, http.StatusConflict, ErrorResponse{
+				Error:   "not_redeemable",
+				Message: "Only completed goals that have not been purchased can be redeemed.",
+			})
+			return
+		}
+		req.AmountCents = int(goal.SavedCents)
+		if strings.TrimSpace(req.Reason) == "" {
+			req.Reason = "Purchase: " + goal.Name
+		}
+	}
+
 	// Validate amount
 	if req.AmountCents <= 0 || req.AmountCents > MaxAmountCents {
 		writeJSON(w, http.StatusBadRequest, ErrorResponse{
@@ -131,10 +171,11 @@ func (h *Handler) HandleSubmitRequest(w http.ResponseWriter, r *http.Request) {
 
 	// Create the withdrawal request
 	wr := &models.WithdrawalRequest{
-		ChildID:     childID,
-		FamilyID:    familyID,
-		AmountCents: req.AmountCents,
-		Reason:      reason,
+		ChildID:       childID,
+		FamilyID:      familyID,
+		AmountCents:   req.AmountCents,
+		Reason:        reason,
+		SavingsGoalID: req.SavingsGoalID,
 	}
 
 	created, err := h.wrRepo.Create(wr)
@@ -153,6 +194,15 @@ func (h *Handler) HandleSubmitRequest(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	// Approve it straight away if the child's withdrawal policy covers it
+	if approved, newBalance := h.autoApprove(created); approved != nil {
+		writeJSON(w, http.StatusCreated, map[string]interface{}{
+			"withdrawal_request": approved,
+			"new_balance_cents":  *newBalance,
+		})
+		return
+	}
+
 	writeJSON(w, http.StatusCreated, map[string]interface{}{
 		"withdrawal_request": created,
 	})
@@ -235,6 +285,20 @@ type ApproveRequest struct {
 	ConfirmGoalImpact bool `json:"confirm_goal_impact"`
 }
 
+// reviewFailure is why a withdrawal request could not be approved or denied.
+// AffectedGoals and TotalReleasedCents are set for goal impact warnings.
+type reviewFailure struct {
+	status             int
+	Error              string                          `json:"error"`
+	Message            string                          `json:"message,omitempty"`
+	AffectedGoals      []repositories.AffectedGoalInfo `json:"affected_goals,omitempty"`
+	TotalReleasedCents int64                           `json:"total_released_cents,omitempty"`
+}
+
+func failure(status int, code, message string) *reviewFailure {
+	return &reviewFailure{status: status, Error: code, Message: message}
+}
+
 // HandleApprove handles POST /api/withdrawal-requests/{id}/approve
 func (h *Handler) HandleApprove(w http.ResponseWriter, r *http.Request) {
 	parentID := middleware.GetUserID(r)
@@ -249,102 +313,115 @@ func (h *Handler) HandleApprove(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	// Parse request body for confirm_goal_impact
+	var approveReq ApproveRequest
+	_ = json.NewDecoder(r.Body).Decode(&approveReq)
+
+	updated, newBalance, fail := h.approve(familyID, parentID, reqID, approveReq.ConfirmGoalImpact)
+	if fail != nil {
+		writeJSON(w, fail.status, fail)
+		return
+	}
+
+	approvals, err := h.wrRepo.ListApprovals(reqID)
+	if err != nil {
+		log.Printf("Error listing approvals for withdrawal request %d: %v", reqID, err)
+	}
+
+	resp := map[string]interface{}{
+		"withdrawal_request": updated,
+		"approvals":          approvals,
+	}
+	if newBalance != nil {
+		resp["new_balance_cents"] = *newBalance
+	}
+	writeJSON(w, http.StatusOK, resp)
+}
+
+// approve records a parent's approval of one of the family's pending withdrawal requests, and
+// withdraws the money once the family's approval quorum is met. The new balance is nil while the
+// request still awaits other parents' approvals.
+// The balance is only checked for the approval that meets the quorum. Unless confirmGoalImpact is
+// set, that approval fails with a warning if the withdrawal would eat into savings goals.
+func (h *Handler) approve(familyID, parentID, reqID int64, confirmGoalImpact bool) (*models.WithdrawalRequest, *int64, *reviewFailure) {
 	// Get the withdrawal request
 	wr, err := h.wrRepo.GetByID(reqID)
 	if err != nil {
-		writeJSON(w, http.StatusInternalServerError, ErrorResponse{
-			Error:   "internal_error",
-			Message: "Failed to lookup request.",
-		})
-		return
+		return nil, nil, failure(http.StatusInternalServerError, "internal_error", "Failed to lookup request.")
 	}
 	if wr == nil || wr.FamilyID != familyID {
-		writeJSON(w, http.StatusNotFound, ErrorResponse{
-			Error:   "not_found",
-			Message: "Withdrawal request not found.",
-		})
-		return
+		return nil, nil, failure(http.StatusNotFound, "not_found", "Withdrawal request not found.")
 	}
 
 	if wr.Status != models.WithdrawalRequestStatusPending {
-		writeJSON(w, http.StatusConflict, ErrorResponse{
-			Error:   "invalid_status",
-			Message: "Request is not pending.",
-		})
-		return
+		return nil, nil, failure(http.StatusConflict, "invalid_status", "Request is not pending.")
 	}
 
 	// Check child account
 	child, err := h.childRepo.GetByID(wr.ChildID)
 	if err != nil || child == nil {
-		writeJSON(w, http.StatusInternalServerError, ErrorResponse{
-			Error:   "internal_error",
-			Message: "Failed to lookup child.",
-		})
-		return
+		return nil, nil, failure(http.StatusInternalServerError, "internal_error", "Failed to lookup child.")
 	}
 	if child.IsDisabled {
-		writeJSON(w, http.StatusUnprocessableEntity, ErrorResponse{
-			Error:   "account_disabled",
-			Message: "Child's account is disabled. Deny this request instead.",
-		})
-		return
+		return nil, nil, failure(http.StatusUnprocessableEntity, "account_disabled", "Child's account is disabled. Deny this request instead.")
 	}
 
-	// Check available balance
-	if child.BalanceCents < int64(wr.AmountCents) {
-		writeJSON(w, http.StatusUnprocessableEntity, ErrorResponse{
-			Error:   "insufficient_funds",
-			Message: "Child no longer has sufficient funds for this request.",
-		})
-		return
+	// Money only moves once the quorum is met, so earlier approvals are recorded regardless of
+	// the balance
+	completes, err := h.wrRepo.CompletesQuorum(reqID, parentID)
+	if err != nil {
+		return nil, nil, failure(http.StatusInternalServerError, "internal_error", "Failed to check approvals.")
 	}
 
-	// Parse request body for confirm_goal_impact
-	var approveReq ApproveRequest
-	_ = json.NewDecoder(r.Body).Decode(&approveReq)
+	// Check available balance
+	if completes && child.BalanceCents < int64(wr.AmountCents) {
+		return nil, nil, failure(http.StatusUnprocessableEntity, "insufficient_funds", "Child no longer has sufficient funds for this request.")
+	}
 
 	// Check for goal impact
-	if h.goalRepo != nil {
+	if completes && h.goalRepo != nil && !confirmGoalImpact {
 		totalSaved, err := h.goalRepo.GetTotalSavedByChild(wr.ChildID)
 		if err == nil && totalSaved > 0 {
 			newBalanceAfter := child.BalanceCents - int64(wr.AmountCents)
-			if newBalanceAfter < totalSaved && !approveReq.ConfirmGoalImpact {
+			if newBalanceAfter < totalSaved {
 				totalToRelease := totalSaved - newBalanceAfter
 				affectedGoals, err := h.goalRepo.GetAffectedGoals(wr.ChildID, totalToRelease)
 				if err == nil && len(affectedGoals) > 0 {
-					writeJSON(w, http.StatusConflict, map[string]interface{}{
-						"error":                "goal_impact_warning",
-						"message":              "This approval will reduce savings goals allocations.",
-						"affected_goals":       affectedGoals,
-						"total_released_cents": totalToRelease,
-					})
-					return
+					fail := failure(http.StatusConflict, "goal_impact_warning", "This approval will reduce savings goals allocations.")
+					fail.AffectedGoals = affectedGoals
+					fail.TotalReleasedCents = totalToRelease
+					return nil, nil, fail
 				}
 			}
 		}
 	}
 
-	// Create withdrawal transaction
+	// Withdraw and approve together
 	note := "Withdrawal request: " + wr.Reason
-	tx, newBalance, err := h.txRepo.WithdrawAsType(wr.ChildID, parentID, int64(wr.AmountCents), note, models.TransactionTypeWithdrawalRequest)
+	transaction, newBalance, err := h.wrRepo.ApproveWithWithdrawal(reqID, parentID, note)
 	if err != nil {
+		if err == repositories.ErrAlreadyApproved {
+			return nil, nil, failure(http.StatusConflict, "already_approved", "You have already approved this request. It is waiting for another parent.")
+		}
 		if err == models.ErrInsufficientFunds {
-			writeJSON(w, http.StatusUnprocessableEntity, ErrorResponse{
-				Error:   "insufficient_funds",
-				Message: "Child no longer has sufficient funds for this request.",
-			})
-			return
+			return nil, nil, failure(http.StatusUnprocessableEntity, "insufficient_funds", "Child no longer has sufficient funds for this request.")
 		}
-		writeJSON(w, http.StatusInternalServerError, ErrorResponse{
-			Error:   "internal_error",
-			Message: "Failed to process withdrawal.",
-		})
-		return
+		if err == repositories.ErrInvalidStatusTransition {
+			return nil, nil, failure(http.StatusConflict, "invalid_status", "Request is not pending.")
+		}
+		if err == repositories.ErrGoalNotRedeemable {
+			return nil, nil, failure(http.StatusConflict, "not_redeemable", "The goal this request redeems can no longer be purchased. Deny this request instead.")
+		}
+		return nil, nil, failure(http.StatusInternalServerError, "internal_error", "Failed to process withdrawal.")
+	}
+
+	if transaction == nil {
+		updated, _ := h.wrRepo.GetByID(reqID)
+		return updated, nil, nil
 	}
 
 	// Reduce goals proportionally if confirmed
-	if h.goalRepo != nil && approveReq.ConfirmGoalImpact {
+	if h.goalRepo != nil && confirmGoalImpact {
 		totalSaved, err := h.goalRepo.GetTotalSavedByChild(wr.ChildID)
 		if err == nil && totalSaved > newBalance {
 			totalToRelease := totalSaved - newBalance
@@ -354,17 +431,9 @@ func (h *Handler) HandleApprove(w http.ResponseWriter, r *http.Request) {
 		}
 	}
 
-	// Update request status
-	if err := h.wrRepo.Approve(reqID, parentID, tx.ID); err != nil {
-		log.Printf("ERROR: withdrawal succeeded but request status update failed for request %d: %v", reqID, err)
-	}
-
 	// Fetch updated request
 	updated, _ := h.wrRepo.GetByID(reqID)
-	writeJSON(w, http.StatusOK, map[string]interface{}{
-		"withdrawal_request": updated,
-		"new_balance_cents":  newBalance,
-	})
+	return updated, &newBalance, nil
 }
 
 // DenyRequest represents the request body for denying a withdrawal request.
@@ -386,55 +455,46 @@ func (h *Handler) HandleDeny(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	// Parse optional denial reason
+	var denyReq DenyRequest
+	_ = json.NewDecoder(r.Body).Decode(&denyReq)
+
+	updated, fail := h.deny(familyID, parentID, reqID, denyReq.Reason)
+	if fail != nil {
+		writeJSON(w, fail.status, fail)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"withdrawal_request": updated,
+	})
+}
+
+// deny denies one of the family's pending withdrawal requests with an optional reason.
+func (h *Handler) deny(familyID, parentID, reqID int64, reason string) (*models.WithdrawalRequest, *reviewFailure) {
 	// Get the withdrawal request
 	wr, err := h.wrRepo.GetByID(reqID)
 	if err != nil {
-		writeJSON(w, http.StatusInternalServerError, ErrorResponse{
-			Error:   "internal_error",
-			Message: "Failed to lookup request.",
-		})
-		return
+		return nil, failure(http.StatusInternalServerError, "internal_error", "Failed to lookup request.")
 	}
 	if wr == nil || wr.FamilyID != familyID {
-		writeJSON(w, http.StatusNotFound, ErrorResponse{
-			Error:   "not_found",
-			Message: "Withdrawal request not found.",
-		})
-		return
+		return nil, failure(http.StatusNotFound, "not_found", "Withdrawal request not found.")
 	}
 
-	// Parse optional denial reason
-	var denyReq DenyRequest
-	_ = json.NewDecoder(r.Body).Decode(&denyReq)
-
-	reason := strings.TrimSpace(denyReq.Reason)
+	reason = strings.TrimSpace(reason)
 	if len(reason) > MaxReasonLength {
-		writeJSON(w, http.StatusBadRequest, ErrorResponse{
-			Error:   "invalid_reason",
-			Message: "Denial reason must be 500 characters or less.",
-		})
-		return
+		return nil, failure(http.StatusBadRequest, "invalid_reason", "Denial reason must be 500 characters or less.")
 	}
 
 	if err := h.wrRepo.Deny(reqID, parentID, reason); err != nil {
 		if err == repositories.ErrInvalidStatusTransition {
-			writeJSON(w, http.StatusConflict, ErrorResponse{
-				Error:   "invalid_status",
-				Message: "Request is not pending.",
-			})
-			return
+			return nil, failure(http.StatusConflict, "invalid_status", "Request is not pending.")
 		}
-		writeJSON(w, http.StatusInternalServerError, ErrorResponse{
-			Error:   "internal_error",
-			Message: "Failed to deny request.",
-		})
-		return
+		return nil, failure(http.StatusInternalServerError, "internal_error", "Failed to deny request.")
 	}
 
 	updated, _ := h.wrRepo.GetByID(reqID)
-	writeJSON(w, http.StatusOK, map[string]interface{}{
-		"withdrawal_request": updated,
-	})
+	return updated, nil
 }
 
 // HandlePendingCount handles GET /api/withdrawal-requests/pending/count
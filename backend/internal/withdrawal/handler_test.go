@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"net/http"
 	"net/http/httptest"
+	"strconv"
 	"testing"
 
 	"bank-of-dad/internal/testutil"
@@ -14,10 +15,14 @@ import (
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
 )
 
 func setupHandler(t *testing.T) (*Handler, *repositories.WithdrawalRequestRepo, *repositories.TransactionRepo, *repositories.ChildRepo, *repositories.SavingsGoalRepo) {
-	db := testutil.SetupTestDB(t)
+	return setupHandlerForDB(testutil.SetupTestDB(t))
+}
+
+func setupHandlerForDB(db *gorm.DB) (*Handler, *repositories.WithdrawalRequestRepo, *repositories.TransactionRepo, *repositories.ChildRepo, *repositories.SavingsGoalRepo) {
 	wrRepo := repositories.NewWithdrawalRequestRepo(db)
 	txRepo := repositories.NewTransactionRepo(db)
 	childRepo := repositories.NewChildRepo(db)
@@ -781,3 +786,533 @@ func TestHandlePendingCount_Success(t *testing.T) {
 	require.NoError(t, err)
 	assert.Equal(t, int64(2), resp["count"])
 }
+
+// =====================================================
+// Tests for POST /api/withdrawal-requests/batch (HandleBatchReview)
+// =====================================================
+
+func TestHandleBatchReview_PerItemOutcomes(t *testing.T) {
+	db := testutil.SetupTestDB(t)
+	family := testutil.CreateTestFamily(t, db)
+	parent := testutil.CreateTestParent(t, db, family.ID)
+	alice := testutil.CreateTestChild(t, db, family.ID, "Alice")
+	bob := testutil.CreateTestChild(t, db, family.ID, "Bob")
+	carol := testutil.CreateTestChild(t, db, family.ID, "Carol")
+	dave := testutil.CreateTestChild(t, db, family.ID, "Dave")
+
+	txRepo := repositories.NewTransactionRepo(db)
+	for _, childID := range []int64{alice.ID, bob.ID, carol.ID, dave.ID} {
+		_, _, err := txRepo.Deposit(childID, parent.ID, 1000, "seed")
+		require.NoError(t, err)
+	}
+
+	// Carol has most of her money set aside for a goal
+	goalRepo := repositories.NewSavingsGoalRepo(db)
+	goal, err := goalRepo.Create(carol.ID, "Bike", 5000, nil)
+	require.NoError(t, err)
+	_, err = goalRepo.Allocate(goal.ID, carol.ID, 800)
+	require.NoError(t, err)
+
+	wrRepo := repositories.NewWithdrawalRequestRepo(db)
+	newRequest := func(childID int64, amount int) int64 {
+		wr, err := wrRepo.Create(&models.WithdrawalRequest{
+			ChildID:     childID,
+			FamilyID:    family.ID,
+			AmountCents: amount,
+			Reason:      "test",
+		})
+		require.NoError(t, err)
+		return wr.ID
+	}
+	approveID := newRequest(alice.ID, 400)
+	tooMuchID := newRequest(bob.ID, 2000)
+	goalID := newRequest(carol.ID, 500)
+	denyID := newRequest(dave.ID, 100)
+
+	handler := NewHandler(wrRepo, txRepo, repositories.NewChildRepo(db), goalRepo)
+
+	body := fmt.Sprintf(`{"items":[
+		{"id":%d,"action":"approve"},
+		{"id":%d,"action":"approve"},
+		{"id":%d,"action":"approve"},
+		{"id":%d,"action":"deny","reason":"Not this week"}
+	]}`, approveID, tooMuchID, goalID, denyID)
+	req := httptest.NewRequest("POST", "/api/withdrawal-requests/batch", bytes.NewBufferString(body))
+	req = testutil.SetRequestContext(req, "parent", parent.ID, family.ID)
+	rr := httptest.NewRecorder()
+	handler.HandleBatchReview(rr, req)
+	require.Equal(t, http.StatusOK, rr.Code, rr.Body.String())
+
+	var resp struct {
+		Results   []BatchReviewResult `json:"results"`
+		Succeeded int                 `json:"succeeded"`
+		Failed    int                 `json:"failed"`
+	}
+	require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &resp))
+	require.Len(t, resp.Results, 4)
+	assert.Equal(t, 2, resp.Succeeded)
+	assert.Equal(t, 2, resp.Failed)
+
+	assert.True(t, resp.Results[0].Success)
+	require.NotNil(t, resp.Results[0].NewBalanceCents)
+	assert.Equal(t, int64(600), *resp.Results[0].NewBalanceCents)
+
+	assert.False(t, resp.Results[1].Success)
+	assert.Equal(t, "insufficient_funds", resp.Results[1].Error)
+
+	assert.False(t, resp.Results[2].Success)
+	assert.Equal(t, "goal_impact_warning", resp.Results[2].Error)
+	require.Len(t, resp.Results[2].AffectedGoals, 1)
+	assert.Equal(t, int64(300), resp.Results[2].TotalReleasedCents)
+
+	assert.True(t, resp.Results[3].Success)
+	assert.Equal(t, models.WithdrawalRequestStatusDenied, resp.Results[3].WithdrawalRequest.Status)
+
+	// Confirming the goal impact lets Carol's request through
+	body = fmt.Sprintf(`{"items":[{"id":%d,"action":"approve","confirm_goal_impact":true}]}`, goalID)
+	req = httptest.NewRequest("POST", "/api/withdrawal-requests/batch", bytes.NewBufferString(body))
+	req = testutil.SetRequestContext(req, "parent", parent.ID, family.ID)
+	rr = httptest.NewRecorder()
+	handler.HandleBatchReview(rr, req)
+	require.Equal(t, http.StatusOK, rr.Code)
+	require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &resp))
+	assert.Equal(t, 1, resp.Succeeded)
+}
+
+func TestHandleBatchReview_InvalidBatch(t *testing.T) {
+	handler, _, _, _, _ := setupHandler(t)
+
+	for _, body := range []string{
+		`{"items":[]}`,
+		`{"items":[{"id":1,"action":"maybe"}]}`,
+		`{"items":[{"id":1,"action":"approve"},{"id":1,"action":"deny"}]}`,
+	} {
+		req := httptest.NewRequest("POST", "/api/withdrawal-requests/batch", bytes.NewBufferString(body))
+		req = testutil.SetRequestContext(req, "parent", 1, 1)
+		rr := httptest.NewRecorder()
+		handler.HandleBatchReview(rr, req)
+		assert.Equal(t, http.StatusBadRequest, rr.Code, body)
+	}
+}
+
+// =====================================================
+// Tests for withdrawal policies
+// =====================================================
+
+func TestValidatePolicy(t *testing.T) {
+	capCents := func(c int) *int { return &c }
+	assert.Empty(t, validatePolicy(SetPolicyRequest{MaxAmountCents: 500}))
+	assert.Empty(t, validatePolicy(SetPolicyRequest{MaxAmountCents: 500, WeeklyCapCents: capCents(500)}))
+	assert.NotEmpty(t, validatePolicy(SetPolicyRequest{MaxAmountCents: 0}))
+	assert.NotEmpty(t, validatePolicy(SetPolicyRequest{MaxAmountCents: MaxAmountCents + 1}))
+	assert.NotEmpty(t, validatePolicy(SetPolicyRequest{MaxAmountCents: 500, WeeklyCapCents: capCents(499)}))
+}
+
+func TestHandleSubmitRequest_AutoApprovedByPolicy(t *testing.T) {
+	db := testutil.SetupTestDB(t)
+	family := testutil.CreateTestFamily(t, db)
+	parent := testutil.CreateTestParent(t, db, family.ID)
+	child := testutil.CreateTestChild(t, db, family.ID, "Alice")
+
+	txRepo := repositories.NewTransactionRepo(db)
+	_, _, err := txRepo.Deposit(child.ID, parent.ID, 5000, "seed")
+	require.NoError(t, err)
+
+	wrRepo := repositories.NewWithdrawalRequestRepo(db)
+	policyRepo := repositories.NewWithdrawalPolicyRepo(db)
+	handler := NewHandler(wrRepo, txRepo, repositories.NewChildRepo(db), repositories.NewSavingsGoalRepo(db))
+	handler.SetPolicyRepo(policyRepo)
+
+	// Parent allows up to $2 at a time, $3 a week
+	req := httptest.NewRequest("PUT", "/api/children/"+strconv.FormatInt(child.ID, 10)+"/withdrawal-policy",
+		bytes.NewBufferString(`{"max_amount_cents":200,"weekly_cap_cents":300}`))
+	req.SetPathValue("id", strconv.FormatInt(child.ID, 10))
+	req = testutil.SetRequestContext(req, "parent", parent.ID, family.ID)
+	rr := httptest.NewRecorder()
+	handler.HandleSetPolicy(rr, req)
+	require.Equal(t, http.StatusOK, rr.Code, rr.Body.String())
+
+	submit := func(amount int) models.WithdrawalRequest {
+		body := fmt.Sprintf(`{"amount_cents":%d,"reason":"Candy"}`, amount)
+		req := httptest.NewRequest("POST", "/api/child/withdrawal-requests", bytes.NewBufferString(body))
+		req = testutil.SetRequestContext(req, "child", child.ID, family.ID)
+		rr := httptest.NewRecorder()
+		handler.HandleSubmitRequest(rr, req)
+		require.Equal(t, http.StatusCreated, rr.Code, rr.Body.String())
+		var resp struct {
+			WithdrawalRequest models.WithdrawalRequest `json:"withdrawal_request"`
+		}
+		require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &resp))
+		return resp.WithdrawalRequest
+	}
+
+	// Within the limit: approved by policy, with no reviewing parent
+	wr := submit(200)
+	assert.Equal(t, models.WithdrawalRequestStatusApproved, wr.Status)
+	assert.True(t, wr.AutoApproved)
+	assert.Nil(t, wr.ReviewedByParentID)
+	require.NotNil(t, wr.TransactionID)
+	balance, err := repositories.NewChildRepo(db).GetBalance(child.ID)
+	require.NoError(t, err)
+	assert.Equal(t, int64(4800), balance)
+
+	// Would exceed the weekly cap: stays pending for a parent
+	wr = submit(150)
+	assert.Equal(t, models.WithdrawalRequestStatusPending, wr.Status)
+	assert.False(t, wr.AutoApproved)
+	require.NoError(t, wrRepo.Cancel(wr.ID, child.ID))
+
+	// Over the per-request limit: stays pending
+	wr = submit(250)
+	assert.Equal(t, models.WithdrawalRequestStatusPending, wr.Status)
+
+	// The audit trail records the policy approval
+	req = httptest.NewRequest("GET", "/api/children/"+strconv.FormatInt(child.ID, 10)+"/withdrawal-policy", nil)
+	req.SetPathValue("id", strconv.FormatInt(child.ID, 10))
+	req = testutil.SetRequestContext(req, "parent", parent.ID, family.ID)
+	rr = httptest.NewRecorder()
+	handler.HandleGetPolicy(rr, req)
+	require.Equal(t, http.StatusOK, rr.Code)
+	var got struct {
+		Policy    *models.WithdrawalPolicy          `json:"policy"`
+		Approvals []models.WithdrawalPolicyApproval `json:"approvals"`
+	}
+	require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &got))
+	require.NotNil(t, got.Policy)
+	assert.Equal(t, 200, got.Policy.MaxAmountCents)
+	require.Len(t, got.Approvals, 1)
+	assert.Equal(t, 200, got.Approvals[0].AmountCents)
+	assert.Equal(t, int64(0), got.Approvals[0].WeekTotalCents)
+	assert.Equal(t, 300, *got.Approvals[0].WeeklyCapCents)
+}
+
+func TestWithdrawalPolicy_NeverTouchesGoals(t *testing.T) {
+	db := testutil.SetupTestDB(t)
+	family := testutil.CreateTestFamily(t, db)
+	parent := testutil.CreateTestParent(t, db, family.ID)
+	child := testutil.CreateTestChild(t, db, family.ID, "Alice")
+
+	txRepo := repositories.NewTransactionRepo(db)
+	_, _, err := txRepo.Deposit(child.ID, parent.ID, 1000, "seed")
+	require.NoError(t, err)
+
+	policyRepo := repositories.NewWithdrawalPolicyRepo(db)
+	_, err = policyRepo.Upsert(&models.WithdrawalPolicy{ChildID: child.ID, FamilyID: family.ID, MaxAmountCents: 500, UpdatedByParentID: parent.ID})
+	require.NoError(t, err)
+
+	wrRepo := repositories.NewWithdrawalRequestRepo(db)
+	wr, err := wrRepo.Create(&models.WithdrawalRequest{ChildID: child.ID, FamilyID: family.ID, AmountCents: 400, Reason: "Toy"})
+	require.NoError(t, err)
+
+	// Money set aside for a goal after the request was made is not touched
+	goalRepo := repositories.NewSavingsGoalRepo(db)
+	goal, err := goalRepo.Create(child.ID, "Bike", 5000, nil)
+	require.NoError(t, err)
+	_, err = goalRepo.Allocate(goal.ID, child.ID, 800)
+	require.NoError(t, err)
+
+	_, _, err = policyRepo.Apply(wr.ID, "Withdrawal request: Toy")
+	assert.ErrorIs(t, err, repositories.ErrPolicyNotSatisfied)
+
+	updated, err := wrRepo.GetByID(wr.ID)
+	require.NoError(t, err)
+	assert.Equal(t, models.WithdrawalRequestStatusPending, updated.Status)
+	balance, err := repositories.NewChildRepo(db).GetBalance(child.ID)
+	require.NoError(t, err)
+	assert.Equal(t, int64(1000), balance)
+}
+
+// =====================================================
+// Tests for the approval quorum
+// =====================================================
+
+// createSecondParent adds another parent to the family, besides testutil.CreateTestParent's.
+func createSecondParent(t *testing.T, parentRepo *repositories.ParentRepo, familyID int64) *models.Parent {
+	t.Helper()
+	p, err := parentRepo.Create("google-id-456", "parent2@test.com", "Second Parent")
+	require.NoError(t, err)
+	require.NoError(t, parentRepo.SetFamilyID(p.ID, familyID))
+	return p
+}
+
+func TestHandleApprove_QuorumNeedsDistinctParents(t *testing.T) {
+	db := testutil.SetupTestDB(t)
+	family := testutil.CreateTestFamily(t, db)
+	mom := testutil.CreateTestParent(t, db, family.ID)
+	dad := createSecondParent(t, repositories.NewParentRepo(db), family.ID)
+	child := testutil.CreateTestChild(t, db, family.ID, "Alice")
+
+	// Requests over $10 need both parents
+	threshold := 1000
+	require.NoError(t, repositories.NewFamilyRepo(db).UpdateWithdrawalQuorum(family.ID, &threshold, 2))
+
+	handler, wrRepo, txRepo, childRepo, _ := setupHandlerForDB(db)
+	_, _, err := txRepo.Deposit(child.ID, mom.ID, 5000, "seed")
+	require.NoError(t, err)
+	wr, err := wrRepo.Create(&models.WithdrawalRequest{ChildID: child.ID, FamilyID: family.ID, AmountCents: 2000, Reason: "Bike"})
+	require.NoError(t, err)
+
+	approve := func(parentID int64) *httptest.ResponseRecorder {
+		req := httptest.NewRequest("POST", fmt.Sprintf("/api/withdrawal-requests/%d/approve", wr.ID), bytes.NewBufferString(`{}`))
+		req.SetPathValue("id", strconv.FormatInt(wr.ID, 10))
+		req = testutil.SetRequestContext(req, "parent", parentID, family.ID)
+		rr := httptest.NewRecorder()
+		handler.HandleApprove(rr, req)
+		return rr
+	}
+
+	// First approval is recorded but nothing is paid out
+	rr := approve(mom.ID)
+	require.Equal(t, http.StatusOK, rr.Code, rr.Body.String())
+	var resp map[string]json.RawMessage
+	require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &resp))
+	_, hasBalance := resp["new_balance_cents"]
+	assert.False(t, hasBalance)
+	var pending models.WithdrawalRequest
+	require.NoError(t, json.Unmarshal(resp["withdrawal_request"], &pending))
+	assert.Equal(t, models.WithdrawalRequestStatusPending, pending.Status)
+	var approvals []models.WithdrawalRequestApproval
+	require.NoError(t, json.Unmarshal(resp["approvals"], &approvals))
+	require.Len(t, approvals, 1)
+	assert.Equal(t, mom.ID, approvals[0].ParentID)
+
+	balance, err := childRepo.GetBalance(child.ID)
+	require.NoError(t, err)
+	assert.Equal(t, int64(5000), balance)
+
+	// The same parent can't approve twice
+	rr = approve(mom.ID)
+	assert.Equal(t, http.StatusConflict, rr.Code)
+	assert.Contains(t, rr.Body.String(), "already_approved")
+
+	// The second parent's approval meets the quorum and executes the withdrawal
+	rr = approve(dad.ID)
+	require.Equal(t, http.StatusOK, rr.Code, rr.Body.String())
+	require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &resp))
+	var newBalance int64
+	require.NoError(t, json.Unmarshal(resp["new_balance_cents"], &newBalance))
+	assert.Equal(t, int64(3000), newBalance)
+	var approved models.WithdrawalRequest
+	require.NoError(t, json.Unmarshal(resp["withdrawal_request"], &approved))
+	assert.Equal(t, models.WithdrawalRequestStatusApproved, approved.Status)
+	assert.Equal(t, dad.ID, *approved.ReviewedByParentID)
+
+	approvals, err = wrRepo.ListApprovals(wr.ID)
+	require.NoError(t, err)
+	assert.Len(t, approvals, 2)
+
+	// Requests at or under the threshold need only one parent
+	small, err := wrRepo.Create(&models.WithdrawalRequest{ChildID: child.ID, FamilyID: family.ID, AmountCents: 1000, Reason: "Snacks"})
+	require.NoError(t, err)
+	_, newBal, err := wrRepo.ApproveWithWithdrawal(small.ID, mom.ID, "")
+	require.NoError(t, err)
+	assert.Equal(t, int64(2000), newBal)
+}
+
+func TestHandleApprove_QuorumRecordsApprovalWhileBalanceShort(t *testing.T) {
+	db := testutil.SetupTestDB(t)
+	family := testutil.CreateTestFamily(t, db)
+	mom := testutil.CreateTestParent(t, db, family.ID)
+	dad := createSecondParent(t, repositories.NewParentRepo(db), family.ID)
+	child := testutil.CreateTestChild(t, db, family.ID, "Alice")
+
+	threshold := 0
+	require.NoError(t, repositories.NewFamilyRepo(db).UpdateWithdrawalQuorum(family.ID, &threshold, 2))
+
+	handler, wrRepo, txRepo, childRepo, _ := setupHandlerForDB(db)
+	_, _, err := txRepo.Deposit(child.ID, mom.ID, 5000, "seed")
+	require.NoError(t, err)
+	wr, err := wrRepo.Create(&models.WithdrawalRequest{ChildID: child.ID, FamilyID: family.ID, AmountCents: 2000, Reason: "Bike"})
+	require.NoError(t, err)
+	_, _, err = txRepo.Withdraw(child.ID, mom.ID, 4000, "Spent elsewhere")
+	require.NoError(t, err)
+
+	approve := func(parentID int64) *httptest.ResponseRecorder {
+		req := httptest.NewRequest("POST", fmt.Sprintf("/api/withdrawal-requests/%d/approve", wr.ID), bytes.NewBufferString(`{}`))
+		req.SetPathValue("id", strconv.FormatInt(wr.ID, 10))
+		req = testutil.SetRequestContext(req, "parent", parentID, family.ID)
+		rr := httptest.NewRecorder()
+		handler.HandleApprove(rr, req)
+		return rr
+	}
+
+	// The first approval moves no money, so it is recorded even though the balance is short
+	rr := approve(mom.ID)
+	require.Equal(t, http.StatusOK, rr.Code, rr.Body.String())
+	approvals, err := wrRepo.ListApprovals(wr.ID)
+	require.NoError(t, err)
+	assert.Len(t, approvals, 1)
+
+	// The approval that would withdraw the money needs the funds
+	rr = approve(dad.ID)
+	assert.Equal(t, http.StatusUnprocessableEntity, rr.Code)
+	assert.Contains(t, rr.Body.String(), "insufficient_funds")
+
+	_, _, err = txRepo.Deposit(child.ID, mom.ID, 3000, "Topped up")
+	require.NoError(t, err)
+	rr = approve(dad.ID)
+	require.Equal(t, http.StatusOK, rr.Code, rr.Body.String())
+
+	balance, err := childRepo.GetBalance(child.ID)
+	require.NoError(t, err)
+	assert.Equal(t, int64(2000), balance)
+	got, err := wrRepo.GetByID(wr.ID)
+	require.NoError(t, err)
+	assert.Equal(t, models.WithdrawalRequestStatusApproved, got.Status)
+}
+
+func TestHandleDeny_ClosesPartiallyApprovedRequest(t *testing.T) {
+	db := testutil.SetupTestDB(t)
+	family := testutil.CreateTestFamily(t, db)
+	mom := testutil.CreateTestParent(t, db, family.ID)
+	dad := createSecondParent(t, repositories.NewParentRepo(db), family.ID)
+	child := testutil.CreateTestChild(t, db, family.ID, "Alice")
+
+	threshold := 0
+	require.NoError(t, repositories.NewFamilyRepo(db).UpdateWithdrawalQuorum(family.ID, &threshold, 2))
+
+	handler, wrRepo, txRepo, _, _ := setupHandlerForDB(db)
+	_, _, err := txRepo.Deposit(child.ID, mom.ID, 5000, "seed")
+	require.NoError(t, err)
+	wr, err := wrRepo.Create(&models.WithdrawalRequest{ChildID: child.ID, FamilyID: family.ID, AmountCents: 2000, Reason: "Bike"})
+	require.NoError(t, err)
+
+	tx, _, err := wrRepo.ApproveWithWithdrawal(wr.ID, mom.ID, "")
+	require.NoError(t, err)
+	assert.Nil(t, tx)
+
+	req := httptest.NewRequest("POST", fmt.Sprintf("/api/withdrawal-requests/%d/deny", wr.ID), bytes.NewBufferString(`{"reason":"Too expensive"}`))
+	req.SetPathValue("id", strconv.FormatInt(wr.ID, 10))
+	req = testutil.SetRequestContext(req, "parent", dad.ID, family.ID)
+	rr := httptest.NewRecorder()
+	handler.HandleDeny(rr, req)
+	require.Equal(t, http.StatusOK, rr.Code, rr.Body.String())
+
+	got, err := wrRepo.GetByID(wr.ID)
+	require.NoError(t, err)
+	assert.Equal(t, models.WithdrawalRequestStatusDenied, got.Status)
+
+	// Once denied, the remaining approval can't execute it
+	_, _, err = wrRepo.ApproveWithWithdrawal(wr.ID, dad.ID, "")
+	assert.Equal(t, repositories.ErrInvalidStatusTransition, err)
+}
+
+func TestWithdrawalPolicy_DoesNotBypassQuorum(t *testing.T) {
+	db := testutil.SetupTestDB(t)
+	family := testutil.CreateTestFamily(t, db)
+	parent := testutil.CreateTestParent(t, db, family.ID)
+	createSecondParent(t, repositories.NewParentRepo(db), family.ID)
+	child := testutil.CreateTestChild(t, db, family.ID, "Alice")
+
+	threshold := 500
+	require.NoError(t, repositories.NewFamilyRepo(db).UpdateWithdrawalQuorum(family.ID, &threshold, 2))
+
+	_, wrRepo, txRepo, _, _ := setupHandlerForDB(db)
+	_, _, err := txRepo.Deposit(child.ID, parent.ID, 5000, "seed")
+	require.NoError(t, err)
+
+	policyRepo := repositories.NewWithdrawalPolicyRepo(db)
+	_, err = policyRepo.Upsert(&models.WithdrawalPolicy{ChildID: child.ID, FamilyID: family.ID, MaxAmountCents: 1000, UpdatedByParentID: parent.ID})
+	require.NoError(t, err)
+
+	wr, err := wrRepo.Create(&models.WithdrawalRequest{ChildID: child.ID, FamilyID: family.ID, AmountCents: 800, Reason: "Lego"})
+	require.NoError(t, err)
+	_, _, err = policyRepo.Apply(wr.ID, "")
+	assert.Equal(t, repositories.ErrPolicyNotSatisfied, err)
+}
+
+// =====================================================
+// Tests for redeeming a savings goal through a withdrawal request
+// =====================================================
+
+func TestHandleSubmitRequest_GoalRedemptionApproved(t *testing.T) {
+	db := testutil.SetupTestDB(t)
+	family := testutil.CreateTestFamily(t, db)
+	parent := testutil.CreateTestParent(t, db, family.ID)
+	child := testutil.CreateTestChild(t, db, family.ID, "Alice")
+
+	handler, wrRepo, txRepo, childRepo, goalRepo := setupHandlerForDB(db)
+	_, _, err := txRepo.Deposit(child.ID, parent.ID, 5000, "seed")
+	require.NoError(t, err)
+	goal, err := goalRepo.Create(child.ID, "Headphones", 2500, nil)
+	require.NoError(t, err)
+
+	submit := func() *httptest.ResponseRecorder {
+		body := fmt.Sprintf(`{"savings_goal_id":%d}`, goal.ID)
+		req := httptest.NewRequest("POST", "/api/child/withdrawal-requests", bytes.NewBufferString(body))
+		req = testutil.SetRequestContext(req, "child", child.ID, family.ID)
+		rr := httptest.NewRecorder()
+		handler.HandleSubmitRequest(rr, req)
+		return rr
+	}
+
+	// An active goal can't be redeemed yet
+	assert.Equal(t, http.StatusConflict, submit().Code)
+
+	_, err = goalRepo.Allocate(goal.ID, child.ID, 2500)
+	require.NoError(t, err)
+
+	rr := submit()
+	require.Equal(t, http.StatusCreated, rr.Code, rr.Body.String())
+	var created struct {
+		WithdrawalRequest models.WithdrawalRequest `json:"withdrawal_request"`
+	}
+	require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &created))
+	wr := created.WithdrawalRequest
+	assert.Equal(t, 2500, wr.AmountCents)
+	assert.Equal(t, "Purchase: Headphones", wr.Reason)
+	require.NotNil(t, wr.SavingsGoalID)
+	assert.Equal(t, models.WithdrawalRequestStatusPending, wr.Status)
+
+	req := httptest.NewRequest("POST", fmt.Sprintf("/api/withdrawal-requests/%d/approve", wr.ID), bytes.NewBufferString(`{}`))
+	req.SetPathValue("id", strconv.FormatInt(wr.ID, 10))
+	req = testutil.SetRequestContext(req, "parent", parent.ID, family.ID)
+	rr = httptest.NewRecorder()
+	handler.HandleApprove(rr, req)
+	require.Equal(t, http.StatusOK, rr.Code, rr.Body.String())
+
+	approved, err := wrRepo.GetByID(wr.ID)
+	require.NoError(t, err)
+	assert.Equal(t, models.WithdrawalRequestStatusApproved, approved.Status)
+
+	purchased, err := goalRepo.GetByID(goal.ID)
+	require.NoError(t, err)
+	assert.Equal(t, "purchased", purchased.Status)
+	assert.Equal(t, approved.TransactionID, purchased.PurchaseTransactionID)
+
+	balance, err := childRepo.GetBalance(child.ID)
+	require.NoError(t, err)
+	assert.Equal(t, int64(2500), balance)
+}
+
+func TestGoalRedeem_CancelsPendingGoalRequest(t *testing.T) {
+	db := testutil.SetupTestDB(t)
+	family := testutil.CreateTestFamily(t, db)
+	parent := testutil.CreateTestParent(t, db, family.ID)
+	child := testutil.CreateTestChild(t, db, family.ID, "Alice")
+
+	_, wrRepo, txRepo, childRepo, goalRepo := setupHandlerForDB(db)
+	_, _, err := txRepo.Deposit(child.ID, parent.ID, 5000, "seed")
+	require.NoError(t, err)
+	goal, err := goalRepo.Create(child.ID, "Headphones", 2500, nil)
+	require.NoError(t, err)
+	_, err = goalRepo.Allocate(goal.ID, child.ID, 2500)
+	require.NoError(t, err)
+
+	wr, err := wrRepo.Create(&models.WithdrawalRequest{
+		ChildID: child.ID, FamilyID: family.ID, AmountCents: 2500, Reason: "Purchase: Headphones", SavingsGoalID: &goal.ID,
+	})
+	require.NoError(t, err)
+
+	// A parent redeems the goal directly, which cancels the child's pending request
+	_, _, _, err = goalRepo.Redeem(goal.ID, child.ID, parent.ID)
+	require.NoError(t, err)
+
+	got, err := wrRepo.GetByID(wr.ID)
+	require.NoError(t, err)
+	assert.Equal(t, models.WithdrawalRequestStatusCancelled, got.Status)
+
+	balance, err := childRepo.GetBalance(child.ID)
+	require.NoError(t, err)
+	assert.Equal(t, int64(2500), balance)
+}
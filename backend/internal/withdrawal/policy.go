@@ -0,0 +1,146 @@
+package withdrawal
+
+import (
+	"encoding/json"
+	"errors"
+	"log"
+	"net/http"
+	"strconv"
+
+	"bank-of-dad/internal/middleware"
+	"bank-of-dad/models"
+	"bank-of-dad/repositories"
+)
+
+// MaxPolicyApprovals is how many recent policy approvals are returned with a policy.
+const MaxPolicyApprovals = 20
+
+// SetPolicyRequest represents the request body for setting a child's withdrawal policy.
+type SetPolicyRequest struct {
+	MaxAmountCents int  `json:"max_amount_cents"`
+	WeeklyCapCents *int `json:"weekly_cap_cents,omitempty"`
+}
+
+// validatePolicy checks a withdrawal policy's limits. Returns an error message or empty string.
+func validatePolicy(req SetPolicyRequest) string {
+	if req.MaxAmountCents <= 0 || req.MaxAmountCents > MaxAmountCents {
+		return "max_amount_cents must be between 1 cent and $999,999.99."
+	}
+	if req.WeeklyCapCents != nil && (*req.WeeklyCapCents < req.MaxAmountCents || *req.WeeklyCapCents > MaxAmountCents) {
+		return "weekly_cap_cents must be at least max_amount_cents and at most $999,999.99."
+	}
+	return ""
+}
+
+// autoApprove approves a just-submitted request if the child's withdrawal policy covers it.
+// Returns the approved request and the child's new balance, or nil if it stays pending.
+func (h *Handler) autoApprove(wr *models.WithdrawalRequest) (*models.WithdrawalRequest, *int64) {
+	if h.policyRepo == nil {
+		return nil, nil
+	}
+	_, newBalance, err := h.policyRepo.Apply(wr.ID, "Withdrawal request: "+wr.Reason)
+	if err != nil {
+		if !errors.Is(err, repositories.ErrPolicyNotSatisfied) {
+			log.Printf("Error auto-approving withdrawal request %d: %v", wr.ID, err)
+		}
+		return nil, nil
+	}
+	updated, err := h.wrRepo.GetByID(wr.ID)
+	if err != nil || updated == nil {
+		log.Printf("Error fetching auto-approved withdrawal request %d: %v", wr.ID, err)
+		return nil, nil
+	}
+	return updated, &newBalance
+}
+
+// familyChild returns the child named by the {id} path value if they belong to the parent's family,
+// writing an error response and returning nil otherwise.
+func (h *Handler) familyChild(w http.ResponseWriter, r *http.Request) *models.Child {
+	childID, err := strconv.ParseInt(r.PathValue("id"), 10, 64)
+	if err != nil {
+		writeJSON(w, http.StatusBadRequest, ErrorResponse{Error: "invalid_id", Message: "Invalid child ID."})
+		return nil
+	}
+	child, err := h.childRepo.GetByID(childID)
+	if err != nil {
+		writeJSON(w, http.StatusInternalServerError, ErrorResponse{Error: "internal_error", Message: "Failed to lookup child."})
+		return nil
+	}
+	if child == nil || child.FamilyID != middleware.GetFamilyID(r) {
+		writeJSON(w, http.StatusNotFound, ErrorResponse{Error: "not_found", Message: "Child not found."})
+		return nil
+	}
+	return child
+}
+
+// HandleGetPolicy handles GET /api/children/{id}/withdrawal-policy
+// Returns the child's policy (null if none) and the requests it recently approved.
+func (h *Handler) HandleGetPolicy(w http.ResponseWriter, r *http.Request) {
+	child := h.familyChild(w, r)
+	if child == nil {
+		return
+	}
+
+	policy, err := h.policyRepo.GetByChild(child.ID)
+	if err != nil {
+		writeJSON(w, http.StatusInternalServerError, ErrorResponse{Error: "internal_error", Message: "Failed to load withdrawal policy."})
+		return
+	}
+	approvals, err := h.policyRepo.ListApprovals(child.ID, MaxPolicyApprovals)
+	if err != nil {
+		writeJSON(w, http.StatusInternalServerError, ErrorResponse{Error: "internal_error", Message: "Failed to load policy approvals."})
+		return
+	}
+
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"policy":    policy,
+		"approvals": approvals,
+	})
+}
+
+// HandleSetPolicy handles PUT /api/children/{id}/withdrawal-policy
+func (h *Handler) HandleSetPolicy(w http.ResponseWriter, r *http.Request) {
+	child := h.familyChild(w, r)
+	if child == nil {
+		return
+	}
+
+	var req SetPolicyRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeJSON(w, http.StatusBadRequest, ErrorResponse{Error: "invalid_request", Message: "Invalid request body."})
+		return
+	}
+	if errMsg := validatePolicy(req); errMsg != "" {
+		writeJSON(w, http.StatusBadRequest, ErrorResponse{Error: "invalid_request", Message: errMsg})
+		return
+	}
+
+	policy, err := h.policyRepo.Upsert(&models.WithdrawalPolicy{
+		ChildID:           child.ID,
+		FamilyID:          child.FamilyID,
+		MaxAmountCents:    req.MaxAmountCents,
+		WeeklyCapCents:    req.WeeklyCapCents,
+		UpdatedByParentID: middleware.GetUserID(r),
+	})
+	if err != nil {
+		writeJSON(w, http.StatusInternalServerError, ErrorResponse{Error: "internal_error", Message: "Failed to save withdrawal policy."})
+		return
+	}
+
+	writeJSON(w, http.StatusOK, map[string]interface{}{"policy": policy})
+}
+
+// HandleDeletePolicy handles DELETE /api/children/{id}/withdrawal-policy
+// The child's requests go back to needing a parent's approval.
+func (h *Handler) HandleDeletePolicy(w http.ResponseWriter, r *http.Request) {
+	child := h.familyChild(w, r)
+	if child == nil {
+		return
+	}
+
+	if err := h.policyRepo.Delete(child.ID); err != nil {
+		writeJSON(w, http.StatusInternalServerError, ErrorResponse{Error: "internal_error", Message: "Failed to delete withdrawal policy."})
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
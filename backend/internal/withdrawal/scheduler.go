@@ -0,0 +1,149 @@
+package withdrawal
+
+import (
+	"fmt"
+	"log"
+	"strconv"
+	"strings"
+	"time"
+
+	"bank-of-dad/models"
+	"bank-of-dad/repositories"
+)
+
+// finalReminderWindow is how long before a pending request expires that parents get a final reminder.
+const finalReminderWindow = 24 * time.Hour
+
+// maxQuotedReason is how much of a request's reason notification messages quote.
+const maxQuotedReason = 60
+
+// ExpiryScheduler expires stale withdrawal requests and reminds parents of pending ones,
+// escalating as they near expiry.
+type ExpiryScheduler struct {
+	wrRepo *repositories.WithdrawalRequestRepo
+}
+
+// NewScheduler creates a new ExpiryScheduler.
+func NewScheduler(wrRepo *repositories.WithdrawalRequestRepo) *ExpiryScheduler {
+	return &ExpiryScheduler{wrRepo: wrRepo}
+}
+
+// Start begins the background withdrawal request expiry goroutine.
+func (s *ExpiryScheduler) Start(interval time.Duration, stop <-chan struct{}) {
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		// Process immediately on start
+		s.ExpireRequests()
+		s.SendReminders()
+
+		for {
+			select {
+			case <-ticker.C:
+				s.ExpireRequests()
+				s.SendReminders()
+			case <-stop:
+				return
+			}
+		}
+	}()
+}
+
+// reminderLevel returns the reminder parents should have been sent by now about a request created
+// at createdAt that expires after expiryDays: a first reminder halfway to expiry, and a final one
+// in its last day. Short windows go straight to the final reminder.
+func reminderLevel(createdAt time.Time, expiryDays int, now time.Time) int {
+	window := time.Duration(expiryDays) * 24 * time.Hour
+	expiresAt := createdAt.Add(window)
+	if !now.Before(expiresAt.Add(-finalReminderWindow)) {
+		return models.WithdrawalReminderFinal
+	}
+	if !now.Before(createdAt.Add(window / 2)) {
+		return models.WithdrawalReminderFirst
+	}
+	return models.WithdrawalReminderNone
+}
+
+// formatCents renders an amount in cents as dollars, e.g. 1050 as "$10.50".
+func formatCents(cents int) string {
+	return "$" + strconv.FormatFloat(float64(cents)/100, 'f', 2, 64)
+}
+
+// quoteReason shortens a request's reason for quoting in a notification message.
+func quoteReason(reason string) string {
+	runes := []rune(reason)
+	if len(runes) <= maxQuotedReason {
+		return reason
+	}
+	return strings.TrimSpace(string(runes[:maxQuotedReason-1])) + "…"
+}
+
+// loadTimezone returns the named location, or UTC if it is empty or unknown.
+func loadTimezone(tz string) *time.Location {
+	if tz != "" {
+		if loc, err := time.LoadLocation(tz); err == nil {
+			return loc
+		}
+	}
+	return time.UTC
+}
+
+// ExpireRequests expires pending requests no parent responded to in time and notifies their children.
+// Each request is expired on its own, so one that fails doesn't hold back the rest.
+func (s *ExpiryScheduler) ExpireRequests() {
+	stale, err := s.wrRepo.ListStale()
+	if err != nil {
+		log.Printf("Error listing stale withdrawal requests: %v", err)
+		return
+	}
+
+	count := 0
+	for _, wr := range stale {
+		message := fmt.Sprintf("Your request to withdraw %s (%s) expired before a parent responded. You can ask again.",
+			formatCents(wr.AmountCents), quoteReason(wr.Reason))
+		expired, err := s.wrRepo.Expire(wr.ID, message)
+		if err != nil {
+			log.Printf("Error expiring withdrawal request %d: %v", wr.ID, err)
+			continue
+		}
+		if expired {
+			count++
+		}
+	}
+	if count > 0 {
+		log.Printf("Expired %d stale withdrawal requests", count)
+	}
+}
+
+// SendReminders notifies parents of pending requests that are due a more urgent reminder
+// than they have been sent.
+func (s *ExpiryScheduler) SendReminders() {
+	pending, err := s.wrRepo.ListPending()
+	if err != nil {
+		log.Printf("Error listing pending withdrawal requests: %v", err)
+		return
+	}
+
+	now := time.Now()
+	for _, wr := range pending {
+		level := reminderLevel(wr.CreatedAt, wr.WithdrawalExpiryDays, now)
+		if level <= wr.ReminderLevel {
+			continue
+		}
+
+		expiresAt := wr.CreatedAt.AddDate(0, 0, wr.WithdrawalExpiryDays)
+		kind := models.NotificationKindWithdrawalReminder
+		message := fmt.Sprintf("%s is waiting for a response to their request to withdraw %s (%s). It expires on %s.",
+			wr.ChildName, formatCents(wr.AmountCents), quoteReason(wr.Reason), expiresAt.In(loadTimezone(wr.Timezone)).Format("Jan 2"))
+		if level == models.WithdrawalReminderFinal {
+			kind = models.NotificationKindWithdrawalFinalReminder
+			message = fmt.Sprintf("Last chance: %s's request to withdraw %s (%s) expires in less than a day.",
+				wr.ChildName, formatCents(wr.AmountCents), quoteReason(wr.Reason))
+		}
+
+		if _, err := s.wrRepo.Remind(wr.ID, level, kind, message); err != nil {
+			log.Printf("Error sending reminder for withdrawal request %d: %v", wr.ID, err)
+		}
+	}
+}
@@ -0,0 +1,230 @@
+package withdrawal
+
+import (
+	"strings"
+	"testing"
+	"time"
+	"unicode/utf8"
+
+	"bank-of-dad/internal/testutil"
+	"bank-of-dad/models"
+	"bank-of-dad/repositories"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+)
+
+func TestReminderLevel(t *testing.T) {
+	created := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name       string
+		expiryDays int
+		now        time.Time
+		want       int
+	}{
+		{"just created", 7, created.Add(time.Hour), models.WithdrawalReminderNone},
+		{"before halfway", 7, created.Add(83 * time.Hour), models.WithdrawalReminderNone},
+		{"halfway", 7, created.Add(84 * time.Hour), models.WithdrawalReminderFirst},
+		{"day before expiry", 7, created.AddDate(0, 0, 6), models.WithdrawalReminderFinal},
+		{"past expiry", 7, created.AddDate(0, 0, 8), models.WithdrawalReminderFinal},
+		{"one day window goes straight to final", 1, created.Add(time.Minute), models.WithdrawalReminderFinal},
+		{"two day window halfway is final", 2, created.AddDate(0, 0, 1), models.WithdrawalReminderFinal},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, reminderLevel(created, tt.expiryDays, tt.now))
+		})
+	}
+}
+
+func TestFormatCents(t *testing.T) {
+	assert.Equal(t, "$10.50", formatCents(1050))
+	assert.Equal(t, "$0.05", formatCents(5))
+}
+
+func TestQuoteReason(t *testing.T) {
+	assert.Equal(t, "Comic book", quoteReason("Comic book"))
+	quoted := quoteReason(strings.Repeat("é", 500))
+	assert.Equal(t, maxQuotedReason, utf8.RuneCountInString(quoted))
+	assert.True(t, strings.HasSuffix(quoted, "…"))
+}
+
+// createAgedRequest creates a pending withdrawal request and backdates it by age.
+func createAgedRequest(t *testing.T, db *gorm.DB, child *models.Child, age time.Duration) *models.WithdrawalRequest {
+	t.Helper()
+	wr, err := repositories.NewWithdrawalRequestRepo(db).Create(&models.WithdrawalRequest{
+		ChildID:     child.ID,
+		FamilyID:    child.FamilyID,
+		AmountCents: 500,
+		Reason:      "Comic book",
+	})
+	require.NoError(t, err)
+	require.NoError(t, db.Model(wr).Update("created_at", time.Now().Add(-age)).Error)
+	return wr
+}
+
+func TestExpiryScheduler_ExpiresStaleRequestsAndNotifiesChild(t *testing.T) {
+	db := testutil.SetupTestDB(t)
+	family := testutil.CreateTestFamily(t, db)
+	testutil.CreateTestParent(t, db, family.ID)
+	stale := testutil.CreateTestChild(t, db, family.ID, "Emma")
+	fresh := testutil.CreateTestChild(t, db, family.ID, "Liam")
+
+	staleReq := createAgedRequest(t, db, stale, 8*24*time.Hour)
+	freshReq := createAgedRequest(t, db, fresh, 2*24*time.Hour)
+
+	wrRepo := repositories.NewWithdrawalRequestRepo(db)
+	NewScheduler(wrRepo).ExpireRequests()
+
+	got, err := wrRepo.GetByID(staleReq.ID)
+	require.NoError(t, err)
+	assert.Equal(t, models.WithdrawalRequestStatusExpired, got.Status)
+	assert.NotNil(t, got.ExpiredAt)
+
+	got, err = wrRepo.GetByID(freshReq.ID)
+	require.NoError(t, err)
+	assert.Equal(t, models.WithdrawalRequestStatusPending, got.Status)
+
+	notifications, err := repositories.NewNotificationRepo(db).ListForChild(stale.ID, 10)
+	require.NoError(t, err)
+	require.Len(t, notifications, 1)
+	assert.Equal(t, models.NotificationKindWithdrawalExpired, notifications[0].Kind)
+	assert.Contains(t, notifications[0].Message, "$5.00")
+	assert.Equal(t, staleReq.ID, *notifications[0].WithdrawalRequestID)
+
+	// The child can ask again once their request has expired
+	_, err = wrRepo.Create(&models.WithdrawalRequest{ChildID: stale.ID, FamilyID: family.ID, AmountCents: 500, Reason: "Comic book"})
+	assert.NoError(t, err)
+}
+
+func TestExpiryScheduler_LongReasonDoesNotBlockOthers(t *testing.T) {
+	db := testutil.SetupTestDB(t)
+	family := testutil.CreateTestFamily(t, db)
+	parent := testutil.CreateTestParent(t, db, family.ID)
+	emma := testutil.CreateTestChild(t, db, family.ID, "Emma")
+	liam := testutil.CreateTestChild(t, db, family.ID, "Liam")
+
+	wrRepo := repositories.NewWithdrawalRequestRepo(db)
+	long, err := wrRepo.Create(&models.WithdrawalRequest{
+		ChildID:     emma.ID,
+		FamilyID:    family.ID,
+		AmountCents: 500,
+		Reason:      strings.Repeat("x", MaxReasonLength),
+	})
+	require.NoError(t, err)
+	require.NoError(t, db.Model(long).Update("created_at", time.Now().Add(-8*24*time.Hour)).Error)
+	short := createAgedRequest(t, db, liam, 8*24*time.Hour)
+
+	scheduler := NewScheduler(wrRepo)
+	scheduler.ExpireRequests()
+
+	for _, id := range []int64{long.ID, short.ID} {
+		got, err := wrRepo.GetByID(id)
+		require.NoError(t, err)
+		assert.Equal(t, models.WithdrawalRequestStatusExpired, got.Status)
+	}
+	notifications, err := repositories.NewNotificationRepo(db).ListForChild(emma.ID, 10)
+	require.NoError(t, err)
+	require.Len(t, notifications, 1)
+	assert.Contains(t, notifications[0].Message, "…")
+
+	// Reminders quote the long reason the same way
+	pending, err := wrRepo.Create(&models.WithdrawalRequest{
+		ChildID:     emma.ID,
+		FamilyID:    family.ID,
+		AmountCents: 700,
+		Reason:      strings.Repeat("y", MaxReasonLength),
+	})
+	require.NoError(t, err)
+	require.NoError(t, db.Model(pending).Update("created_at", time.Now().Add(-4*24*time.Hour)).Error)
+	scheduler.SendReminders()
+
+	notifications, err = repositories.NewNotificationRepo(db).ListForParent(parent.ID, 10)
+	require.NoError(t, err)
+	require.Len(t, notifications, 1)
+	assert.Equal(t, models.NotificationKindWithdrawalReminder, notifications[0].Kind)
+}
+
+func TestExpiryScheduler_RespectsFamilyExpiryDays(t *testing.T) {
+	db := testutil.SetupTestDB(t)
+	family := testutil.CreateTestFamily(t, db)
+	child := testutil.CreateTestChild(t, db, family.ID, "Emma")
+	require.NoError(t, repositories.NewFamilyRepo(db).UpdateWithdrawalExpiryDays(family.ID, 14))
+
+	wr := createAgedRequest(t, db, child, 8*24*time.Hour)
+
+	wrRepo := repositories.NewWithdrawalRequestRepo(db)
+	NewScheduler(wrRepo).ExpireRequests()
+
+	got, err := wrRepo.GetByID(wr.ID)
+	require.NoError(t, err)
+	assert.Equal(t, models.WithdrawalRequestStatusPending, got.Status)
+}
+
+func TestExpiryScheduler_RemindersEscalateOnce(t *testing.T) {
+	db := testutil.SetupTestDB(t)
+	family := testutil.CreateTestFamily(t, db)
+	parent := testutil.CreateTestParent(t, db, family.ID)
+	child := testutil.CreateTestChild(t, db, family.ID, "Emma")
+
+	require.NoError(t, repositories.NewFamilyRepo(db).UpdateTimezone(family.ID, "Pacific/Kiritimati"))
+
+	wr := createAgedRequest(t, db, child, 4*24*time.Hour)
+
+	wrRepo := repositories.NewWithdrawalRequestRepo(db)
+	notificationRepo := repositories.NewNotificationRepo(db)
+	scheduler := NewScheduler(wrRepo)
+
+	scheduler.SendReminders()
+	scheduler.SendReminders()
+
+	notifications, err := notificationRepo.ListForParent(parent.ID, 10)
+	require.NoError(t, err)
+	require.Len(t, notifications, 1)
+	assert.Equal(t, models.NotificationKindWithdrawalReminder, notifications[0].Kind)
+	assert.Contains(t, notifications[0].Message, "Emma")
+
+	// The expiry date is given in the family's timezone
+	got, err := wrRepo.GetByID(wr.ID)
+	require.NoError(t, err)
+	loc, err := time.LoadLocation("Pacific/Kiritimati")
+	require.NoError(t, err)
+	assert.Contains(t, notifications[0].Message, got.CreatedAt.AddDate(0, 0, 7).In(loc).Format("Jan 2"))
+
+	got, err = wrRepo.GetByID(wr.ID)
+	require.NoError(t, err)
+	assert.Equal(t, models.WithdrawalReminderFirst, got.ReminderLevel)
+
+	// In its last day the request escalates to a final reminder
+	require.NoError(t, db.Model(wr).Update("created_at", time.Now().Add(-6*24*time.Hour-time.Hour)).Error)
+	scheduler.SendReminders()
+	scheduler.SendReminders()
+
+	notifications, err = notificationRepo.ListForParent(parent.ID, 10)
+	require.NoError(t, err)
+	require.Len(t, notifications, 2)
+	assert.Equal(t, models.NotificationKindWithdrawalFinalReminder, notifications[0].Kind)
+
+	got, err = wrRepo.GetByID(wr.ID)
+	require.NoError(t, err)
+	assert.Equal(t, models.WithdrawalReminderFinal, got.ReminderLevel)
+}
+
+func TestExpiryScheduler_NoRemindersForAnsweredRequests(t *testing.T) {
+	db := testutil.SetupTestDB(t)
+	family := testutil.CreateTestFamily(t, db)
+	parent := testutil.CreateTestParent(t, db, family.ID)
+	child := testutil.CreateTestChild(t, db, family.ID, "Emma")
+
+	wr := createAgedRequest(t, db, child, 6*24*time.Hour+time.Hour)
+	wrRepo := repositories.NewWithdrawalRequestRepo(db)
+	require.NoError(t, wrRepo.Deny(wr.ID, parent.ID, ""))
+
+	NewScheduler(wrRepo).SendReminders()
+
+	notifications, err := repositories.NewNotificationRepo(db).ListForParent(parent.ID, 10)
+	require.NoError(t, err)
+	assert.Empty(t, notifications)
+}
@@ -14,15 +14,18 @@ import (
 	"bank-of-dad/internal/allowance"
 	"bank-of-dad/internal/auth"
 	"bank-of-dad/internal/balance"
+	"bank-of-dad/internal/calendar"
 	"bank-of-dad/internal/chore"
-	"bank-of-dad/internal/withdrawal"
 	"bank-of-dad/internal/config"
 	"bank-of-dad/internal/family"
 	"bank-of-dad/internal/goals"
 	"bank-of-dad/internal/interest"
 	"bank-of-dad/internal/middleware"
+	"bank-of-dad/internal/notification"
 	"bank-of-dad/internal/settings"
+	"bank-of-dad/internal/storage"
 	"bank-of-dad/internal/subscription"
+	"bank-of-dad/internal/withdrawal"
 	"bank-of-dad/repositories"
 )
 
@@ -84,28 +87,45 @@ func main() {
 	balanceHandler := balance.NewHandler(txRepo, childRepo, interestRepo, interestScheduleRepo, goalRepo)
 	scheduleRepo := repositories.NewScheduleRepo(db)
 	allowanceHandler := allowance.NewHandler(scheduleRepo, childRepo, familyRepo)
+	allowanceHandler.SetGoalRepo(goalRepo)
 	interestHandler := interest.NewHandler(interestRepo, childRepo, interestScheduleRepo, familyRepo)
 	settingsHandlers := settings.NewHandlers(familyRepo)
 	goalsHandler := goals.NewHandler(goalRepo, childRepo, goalAllocationRepo)
+	goalsHandler.SetAllocationRuleRepo(repositories.NewGoalAllocationRuleRepo(db))
+	goalsHandler.SetForecastRepos(scheduleRepo, interestScheduleRepo, familyRepo)
+	goalsHandler.SetSharedGoalRepo(repositories.NewSharedGoalRepo(db))
 	webhookEventRepo := repositories.NewWebhookEventRepo(db)
 	subscriptionHandlers := subscription.NewHandlers(familyRepo, parentRepo, childRepo, webhookEventRepo, cfg.StripeSecretKey, cfg.StripeWebhookSecret, cfg.FrontendURL)
 	contactHandler := contact.NewHandler(brevoClient, cfg.ContactRecipientEmail, cfg.ContactRecipientName, parentRepo)
 	choreRepo := repositories.NewChoreRepo(db)
 	choreInstanceRepo := repositories.NewChoreInstanceRepo(db)
 	choreHandler := chore.NewHandler(choreRepo, choreInstanceRepo, txRepo, childRepo)
+	choreHandler.SetStreakRepo(repositories.NewChoreStreakRepo(db))
+	choreHandler.SetFamilyRepo(familyRepo)
+	photoStore, err := storage.NewLocalStore(cfg.AttachmentDir)
+	if err != nil {
+		log.Fatalf("Failed to open attachment storage: %v", err)
+	}
+	choreHandler.SetPhotoStore(photoStore)
+	allowanceHandler.SetChoreInstanceRepo(choreInstanceRepo)
+	calendarHandler := calendar.NewHandler(familyRepo, childRepo, scheduleRepo, interestScheduleRepo, choreInstanceRepo)
 	wrRepo := repositories.NewWithdrawalRequestRepo(db)
 	withdrawalHandler := withdrawal.NewHandler(wrRepo, txRepo, childRepo, goalRepo)
+	withdrawalHandler.SetPolicyRepo(repositories.NewWithdrawalPolicyRepo(db))
+	notificationHandler := notification.NewHandler(repositories.NewNotificationRepo(db))
 
 	// Start allowance scheduler goroutine (check every 5 minutes)
 	stopAllowanceScheduler := make(chan struct{})
 	defer close(stopAllowanceScheduler)
 	allowanceScheduler := allowance.NewScheduler(scheduleRepo, txRepo, childRepo)
+	allowanceScheduler.SetChoreInstanceRepo(choreInstanceRepo)
 	allowanceScheduler.Start(5*time.Minute, stopAllowanceScheduler)
 
 	// Start chore scheduler goroutine (check every 5 minutes)
 	stopChoreScheduler := make(chan struct{})
 	defer close(stopChoreScheduler)
 	choreScheduler := chore.NewScheduler(choreRepo, choreInstanceRepo, childRepo, familyRepo)
+	choreScheduler.SetPhotoStore(photoStore)
 	choreScheduler.Start(5*time.Minute, stopChoreScheduler)
 
 	// Start interest accrual scheduler goroutine (check every hour)
@@ -114,6 +134,12 @@ func main() {
 	interestScheduler := interest.NewScheduler(interestRepo)
 	interestScheduler.Start(1*time.Hour, stopInterestScheduler)
 
+	// Start withdrawal request expiry scheduler goroutine (check every hour)
+	stopWithdrawalScheduler := make(chan struct{})
+	defer close(stopWithdrawalScheduler)
+	withdrawalScheduler := withdrawal.NewScheduler(wrRepo)
+	withdrawalScheduler.Start(1*time.Hour, stopWithdrawalScheduler)
+
 	// Auth middleware
 	requireAuth := middleware.RequireAuth(jwtKey)
 	requireParent := middleware.RequireParent(jwtKey)
@@ -142,6 +168,7 @@ func main() {
 	// US5: Child credential management (parent auth required)
 	mux.Handle("PUT /api/children/{id}/password", requireParent(http.HandlerFunc(familyHandlers.HandleResetPassword)))
 	mux.Handle("PUT /api/children/{id}/name", requireParent(http.HandlerFunc(familyHandlers.HandleUpdateName)))
+	mux.Handle("PUT /api/children/{id}/birthdate", requireParent(http.HandlerFunc(familyHandlers.HandleUpdateBirthdate)))
 	mux.Handle("DELETE /api/children/{id}", requireParent(http.HandlerFunc(familyHandlers.HandleDeleteChild)))
 
 	// Token refresh (public — access token may be expired)
@@ -172,6 +199,9 @@ func main() {
 	mux.Handle("DELETE /api/schedules/{id}", requireParent(http.HandlerFunc(allowanceHandler.HandleDeleteSchedule)))
 	mux.Handle("POST /api/schedules/{id}/pause", requireParent(http.HandlerFunc(allowanceHandler.HandlePauseSchedule)))
 	mux.Handle("POST /api/schedules/{id}/resume", requireParent(http.HandlerFunc(allowanceHandler.HandleResumeSchedule)))
+	mux.Handle("GET /api/schedules/{id}/overrides", requireParent(http.HandlerFunc(allowanceHandler.HandleListOverrides)))
+	mux.Handle("PUT /api/schedules/{id}/overrides/{date}", requireParent(http.HandlerFunc(allowanceHandler.HandleSetOverride)))
+	mux.Handle("DELETE /api/schedules/{id}/overrides/{date}", requireParent(http.HandlerFunc(allowanceHandler.HandleDeleteOverride)))
 	mux.Handle("GET /api/children/{childId}/upcoming-allowances", requireAuth(http.HandlerFunc(allowanceHandler.HandleGetUpcomingAllowances)))
 
 	// Interest schedule endpoints (006-account-management-enhancements)
@@ -185,6 +215,8 @@ func main() {
 	mux.Handle("GET /api/settings", requireParent(http.HandlerFunc(settingsHandlers.HandleGetSettings)))
 	mux.Handle("PUT /api/settings/timezone", requireParent(http.HandlerFunc(settingsHandlers.HandleUpdateTimezone)))
 	mux.Handle("PUT /api/settings/bank-name", requireParent(http.HandlerFunc(settingsHandlers.HandleUpdateBankName)))
+	mux.Handle("PUT /api/settings/withdrawal-expiry", requireParent(http.HandlerFunc(settingsHandlers.HandleUpdateWithdrawalExpiry)))
+	mux.Handle("PUT /api/settings/withdrawal-quorum", requireParent(http.HandlerFunc(settingsHandlers.HandleUpdateWithdrawalQuorum)))
 
 	// Subscription (024-stripe-subscription)
 	mux.Handle("GET /api/subscription", requireParent(http.HandlerFunc(subscriptionHandlers.HandleGetSubscription)))
@@ -199,6 +231,17 @@ func main() {
 	mux.Handle("DELETE /api/children/{id}/savings-goals/{goalId}", requireAuth(http.HandlerFunc(goalsHandler.HandleDelete)))
 	mux.Handle("POST /api/children/{id}/savings-goals/{goalId}/allocate", requireAuth(http.HandlerFunc(goalsHandler.HandleAllocate)))
 	mux.Handle("GET /api/children/{id}/savings-goals/{goalId}/allocations", requireAuth(http.HandlerFunc(goalsHandler.HandleListAllocations)))
+	mux.Handle("POST /api/children/{id}/savings-goals/{goalId}/redeem", requireParent(http.HandlerFunc(goalsHandler.HandleRedeem)))
+	mux.Handle("GET /api/children/{id}/goal-allocation-rules", requireAuth(http.HandlerFunc(goalsHandler.HandleListRules)))
+	mux.Handle("POST /api/children/{id}/goal-allocation-rules", requireAuth(http.HandlerFunc(goalsHandler.HandleCreateRule)))
+	mux.Handle("DELETE /api/children/{id}/goal-allocation-rules/{ruleId}", requireAuth(http.HandlerFunc(goalsHandler.HandleDeleteRule)))
+
+	// Shared family goals
+	mux.Handle("GET /api/shared-goals", requireAuth(http.HandlerFunc(goalsHandler.HandleListShared)))
+	mux.Handle("POST /api/shared-goals", requireAuth(http.HandlerFunc(goalsHandler.HandleCreateShared)))
+	mux.Handle("POST /api/shared-goals/{goalId}/allocate", requireAuth(http.HandlerFunc(goalsHandler.HandleAllocateShared)))
+	mux.Handle("DELETE /api/shared-goals/{goalId}", requireAuth(http.HandlerFunc(goalsHandler.HandleDeleteShared)))
+	mux.Handle("POST /api/shared-goals/{goalId}/redeem", requireParent(http.HandlerFunc(goalsHandler.HandleRedeemShared)))
 
 	// Account deletion
 	mux.Handle("DELETE /api/account", requireParent(http.HandlerFunc(familyHandlers.HandleDeleteAccount)))
@@ -207,11 +250,13 @@ func main() {
 	mux.Handle("POST /api/contact", requireParent(http.HandlerFunc(contactHandler.HandleContactSubmission)))
 
 	// Child-scoped allowance endpoints (006-account-management-enhancements)
+	mux.Handle("GET /api/children/{childId}/allowances", requireAuth(http.HandlerFunc(allowanceHandler.HandleListChildAllowances)))
 	mux.Handle("GET /api/children/{childId}/allowance", requireAuth(http.HandlerFunc(allowanceHandler.HandleGetChildAllowance)))
 	mux.Handle("PUT /api/children/{childId}/allowance", requireParent(http.HandlerFunc(allowanceHandler.HandleSetChildAllowance)))
 	mux.Handle("DELETE /api/children/{childId}/allowance", requireParent(http.HandlerFunc(allowanceHandler.HandleDeleteChildAllowance)))
 	mux.Handle("POST /api/children/{childId}/allowance/pause", requireParent(http.HandlerFunc(allowanceHandler.HandlePauseChildAllowance)))
 	mux.Handle("POST /api/children/{childId}/allowance/resume", requireParent(http.HandlerFunc(allowanceHandler.HandleResumeChildAllowance)))
+	mux.Handle("GET /api/children/{childId}/allowance/raises", requireAuth(http.HandlerFunc(allowanceHandler.HandleListChildRaises)))
 
 	// Chore System (031-chore-system)
 	mux.Handle("POST /api/chores", requireParent(http.HandlerFunc(choreHandler.HandleCreateChore)))
@@ -220,6 +265,7 @@ func main() {
 	// Chore instances — child endpoints
 	mux.Handle("GET /api/child/chores", requireAuth(http.HandlerFunc(choreHandler.HandleChildListChores)))
 	mux.Handle("POST /api/child/chores/{id}/complete", requireAuth(http.HandlerFunc(choreHandler.HandleCompleteChore)))
+	mux.Handle("POST /api/child/chores/{id}/claim", requireAuth(http.HandlerFunc(choreHandler.HandleClaimChore)))
 	mux.Handle("GET /api/child/chores/earnings", requireAuth(http.HandlerFunc(choreHandler.HandleChildEarnings)))
 
 	// Chore instances — parent endpoints
@@ -227,10 +273,24 @@ func main() {
 	mux.Handle("GET /api/chores/completed", requireParent(http.HandlerFunc(choreHandler.HandleListCompleted)))
 	mux.Handle("POST /api/chore-instances/{id}/approve", requireParent(http.HandlerFunc(choreHandler.HandleApprove)))
 	mux.Handle("POST /api/chore-instances/{id}/reject", requireParent(http.HandlerFunc(choreHandler.HandleReject)))
+	mux.Handle("POST /api/chore-instances/{id}/redo", requireParent(http.HandlerFunc(choreHandler.HandleRedo)))
+	mux.Handle("POST /api/chore-instances/batch", requireParent(http.HandlerFunc(choreHandler.HandleBatchReview)))
+	mux.Handle("GET /api/chore-instances/{id}/history", requireAuth(http.HandlerFunc(choreHandler.HandleListReviewRounds)))
 	mux.Handle("PUT /api/chores/{id}", requireParent(http.HandlerFunc(choreHandler.HandleUpdateChore)))
 	mux.Handle("DELETE /api/chores/{id}", requireParent(http.HandlerFunc(choreHandler.HandleDeleteChore)))
 	mux.Handle("PATCH /api/chores/{id}/activate", requireParent(http.HandlerFunc(choreHandler.HandleActivate)))
 	mux.Handle("PATCH /api/chores/{id}/deactivate", requireParent(http.HandlerFunc(choreHandler.HandleDeactivate)))
+	mux.Handle("GET /api/chore-photos/{id}", requireAuth(http.HandlerFunc(choreHandler.HandleGetPhoto)))
+	mux.Handle("GET /api/chore-photos/{id}/thumbnail", requireAuth(http.HandlerFunc(choreHandler.HandleGetPhotoThumbnail)))
+	mux.Handle("GET /api/chores/{id}/rotation", requireParent(http.HandlerFunc(choreHandler.HandleGetRotation)))
+	mux.Handle("PUT /api/chores/{id}/rotation", requireParent(http.HandlerFunc(choreHandler.HandleSetRotation)))
+	mux.Handle("GET /api/chores/{id}/streak-bonuses", requireParent(http.HandlerFunc(choreHandler.HandleListStreakBonuses)))
+	mux.Handle("PUT /api/chores/{id}/streak-bonuses", requireParent(http.HandlerFunc(choreHandler.HandleSetStreakBonuses)))
+	mux.Handle("GET /api/chores/analytics", requireParent(http.HandlerFunc(choreHandler.HandleChoreAnalytics)))
+	mux.Handle("GET /api/chores/export", requireParent(http.HandlerFunc(choreHandler.HandleExportChores)))
+	mux.Handle("POST /api/chores/import", requireParent(http.HandlerFunc(choreHandler.HandleImportChores)))
+	mux.Handle("GET /api/chore-templates", requireParent(http.HandlerFunc(choreHandler.HandleListTemplates)))
+	mux.Handle("POST /api/chore-templates/instantiate", requireParent(http.HandlerFunc(choreHandler.HandleInstantiateTemplates)))
 
 	// Withdrawal Requests (032-withdrawal-requests)
 	mux.Handle("POST /api/child/withdrawal-requests", requireAuth(http.HandlerFunc(withdrawalHandler.HandleSubmitRequest)))
@@ -239,7 +299,22 @@ func main() {
 	mux.Handle("GET /api/withdrawal-requests", requireParent(http.HandlerFunc(withdrawalHandler.HandleParentListRequests)))
 	mux.Handle("POST /api/withdrawal-requests/{id}/approve", requireParent(http.HandlerFunc(withdrawalHandler.HandleApprove)))
 	mux.Handle("POST /api/withdrawal-requests/{id}/deny", requireParent(http.HandlerFunc(withdrawalHandler.HandleDeny)))
+	mux.Handle("POST /api/withdrawal-requests/batch", requireParent(http.HandlerFunc(withdrawalHandler.HandleBatchReview)))
 	mux.Handle("GET /api/withdrawal-requests/pending/count", requireParent(http.HandlerFunc(withdrawalHandler.HandlePendingCount)))
+	mux.Handle("GET /api/children/{id}/withdrawal-policy", requireParent(http.HandlerFunc(withdrawalHandler.HandleGetPolicy)))
+	mux.Handle("PUT /api/children/{id}/withdrawal-policy", requireParent(http.HandlerFunc(withdrawalHandler.HandleSetPolicy)))
+	mux.Handle("DELETE /api/children/{id}/withdrawal-policy", requireParent(http.HandlerFunc(withdrawalHandler.HandleDeletePolicy)))
+
+	// Notifications for parents and children
+	mux.Handle("GET /api/notifications", requireAuth(http.HandlerFunc(notificationHandler.HandleList)))
+	mux.Handle("POST /api/notifications/{id}/read", requireAuth(http.HandlerFunc(notificationHandler.HandleMarkRead)))
+
+	// Family calendar and iCalendar feed
+	mux.Handle("GET /api/calendar", requireParent(http.HandlerFunc(calendarHandler.HandleGetCalendar)))
+	mux.Handle("POST /api/calendar/feed", requireParent(http.HandlerFunc(calendarHandler.HandleCreateFeedToken)))
+	mux.Handle("DELETE /api/calendar/feed", requireParent(http.HandlerFunc(calendarHandler.HandleDeleteFeedToken)))
+	calendarFeedRateLimit := middleware.RateLimit(30, 1*time.Minute)
+	mux.Handle("GET /api/calendar/feed/{token}", calendarFeedRateLimit(http.HandlerFunc(calendarHandler.HandleFeed)))
 
 	// Apply middleware chain: CORS → Logging → Routes
 	corsMiddleware := middleware.CORS(cfg.FrontendURL)
@@ -0,0 +1,8 @@
+DROP TABLE IF EXISTS allowance_splits;
+
+-- Keep only the oldest schedule per child so the unique index can be restored
+DELETE FROM allowance_schedules a
+USING allowance_schedules b
+WHERE a.child_id = b.child_id AND a.id > b.id;
+
+CREATE UNIQUE INDEX idx_allowance_schedules_unique_child ON allowance_schedules(child_id);
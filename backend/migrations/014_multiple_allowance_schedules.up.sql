@@ -0,0 +1,15 @@
+-- Allow several concurrent allowance schedules per child
+DROP INDEX IF EXISTS idx_allowance_schedules_unique_child;
+
+-- Allowance splits: a percentage of each payout allocated to a savings goal.
+-- Any remainder not covered by splits stays in the child's main balance.
+CREATE TABLE allowance_splits (
+    id           SERIAL PRIMARY KEY,
+    schedule_id  INTEGER NOT NULL REFERENCES allowance_schedules(id) ON DELETE CASCADE,
+    goal_id      INTEGER NOT NULL REFERENCES savings_goals(id) ON DELETE CASCADE,
+    percent      INTEGER NOT NULL CHECK(percent >= 1 AND percent <= 100),
+    created_at   TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    UNIQUE(schedule_id, goal_id)
+);
+
+CREATE INDEX idx_allowance_splits_schedule ON allowance_splits(schedule_id);
@@ -0,0 +1,7 @@
+DROP INDEX IF EXISTS idx_chore_instances_child_created;
+
+ALTER TABLE allowance_schedules
+    DROP CONSTRAINT IF EXISTS chk_allowance_chore_threshold,
+    DROP CONSTRAINT IF EXISTS chk_allowance_chore_rule_valid,
+    DROP COLUMN IF EXISTS chore_threshold_percent,
+    DROP COLUMN IF EXISTS chore_rule;
@@ -0,0 +1,10 @@
+ALTER TABLE allowance_schedules
+    ADD COLUMN chore_rule TEXT NOT NULL DEFAULT 'none',
+    ADD COLUMN chore_threshold_percent INTEGER,
+    ADD CONSTRAINT chk_allowance_chore_rule_valid CHECK (chore_rule IN ('none', 'threshold', 'proportional')),
+    ADD CONSTRAINT chk_allowance_chore_threshold CHECK (
+        (chore_rule = 'threshold' AND chore_threshold_percent BETWEEN 1 AND 100) OR
+        (chore_rule <> 'threshold' AND chore_threshold_percent IS NULL)
+    );
+
+CREATE INDEX idx_chore_instances_child_created ON chore_instances(child_id, created_at);
@@ -0,0 +1,31 @@
+UPDATE chores SET recurrence = 'one_time', is_active = FALSE WHERE recurrence = 'custom';
+DELETE FROM interest_schedules WHERE frequency = 'custom';
+DELETE FROM allowance_schedules WHERE frequency = 'custom';
+
+ALTER TABLE chores
+    DROP CONSTRAINT IF EXISTS chk_chore_rrule,
+    DROP CONSTRAINT IF EXISTS chk_recurrence_valid,
+    ADD CONSTRAINT chk_recurrence_valid CHECK (recurrence IN ('one_time', 'daily', 'weekly', 'monthly')),
+    DROP COLUMN IF EXISTS rrule;
+
+ALTER TABLE interest_schedules
+    DROP CONSTRAINT IF EXISTS interest_schedules_check,
+    DROP CONSTRAINT IF EXISTS interest_schedules_frequency_check,
+    ADD CONSTRAINT interest_schedules_frequency_check CHECK (frequency IN ('weekly', 'biweekly', 'monthly')),
+    ADD CONSTRAINT interest_schedules_check CHECK (
+        (frequency = 'weekly' AND day_of_week IS NOT NULL) OR
+        (frequency = 'biweekly' AND day_of_week IS NOT NULL) OR
+        (frequency = 'monthly' AND day_of_month IS NOT NULL)
+    ),
+    DROP COLUMN IF EXISTS rrule;
+
+ALTER TABLE allowance_schedules
+    DROP CONSTRAINT IF EXISTS allowance_schedules_check,
+    DROP CONSTRAINT IF EXISTS allowance_schedules_frequency_check,
+    ADD CONSTRAINT allowance_schedules_frequency_check CHECK (frequency IN ('weekly', 'biweekly', 'monthly')),
+    ADD CONSTRAINT allowance_schedules_check CHECK (
+        (frequency = 'weekly' AND day_of_week IS NOT NULL) OR
+        (frequency = 'biweekly' AND day_of_week IS NOT NULL) OR
+        (frequency = 'monthly' AND day_of_month IS NOT NULL)
+    ),
+    DROP COLUMN IF EXISTS rrule;
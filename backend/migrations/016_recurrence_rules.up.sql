@@ -0,0 +1,29 @@
+ALTER TABLE allowance_schedules
+    ADD COLUMN rrule TEXT,
+    DROP CONSTRAINT IF EXISTS allowance_schedules_frequency_check,
+    DROP CONSTRAINT IF EXISTS allowance_schedules_check,
+    ADD CONSTRAINT allowance_schedules_frequency_check CHECK (frequency IN ('weekly', 'biweekly', 'monthly', 'custom')),
+    ADD CONSTRAINT allowance_schedules_check CHECK (
+        (frequency = 'weekly' AND day_of_week IS NOT NULL) OR
+        (frequency = 'biweekly' AND day_of_week IS NOT NULL) OR
+        (frequency = 'monthly' AND day_of_month IS NOT NULL) OR
+        (frequency = 'custom' AND rrule IS NOT NULL)
+    );
+
+ALTER TABLE interest_schedules
+    ADD COLUMN rrule TEXT,
+    DROP CONSTRAINT IF EXISTS interest_schedules_frequency_check,
+    DROP CONSTRAINT IF EXISTS interest_schedules_check,
+    ADD CONSTRAINT interest_schedules_frequency_check CHECK (frequency IN ('weekly', 'biweekly', 'monthly', 'custom')),
+    ADD CONSTRAINT interest_schedules_check CHECK (
+        (frequency = 'weekly' AND day_of_week IS NOT NULL) OR
+        (frequency = 'biweekly' AND day_of_week IS NOT NULL) OR
+        (frequency = 'monthly' AND day_of_month IS NOT NULL) OR
+        (frequency = 'custom' AND rrule IS NOT NULL)
+    );
+
+ALTER TABLE chores
+    ADD COLUMN rrule TEXT,
+    DROP CONSTRAINT IF EXISTS chk_recurrence_valid,
+    ADD CONSTRAINT chk_recurrence_valid CHECK (recurrence IN ('one_time', 'daily', 'weekly', 'monthly', 'custom')),
+    ADD CONSTRAINT chk_chore_rrule CHECK (recurrence <> 'custom' OR rrule IS NOT NULL);
@@ -0,0 +1,10 @@
+DROP TABLE IF EXISTS allowance_raises;
+DROP TABLE IF EXISTS allowance_age_steps;
+
+ALTER TABLE allowance_schedules
+    DROP CONSTRAINT IF EXISTS chk_allowance_cents_per_year,
+    DROP CONSTRAINT IF EXISTS chk_allowance_amount_formula_valid,
+    DROP COLUMN IF EXISTS cents_per_year_of_age,
+    DROP COLUMN IF EXISTS amount_formula;
+
+ALTER TABLE children DROP COLUMN IF EXISTS birthdate;
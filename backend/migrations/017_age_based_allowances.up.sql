@@ -0,0 +1,34 @@
+ALTER TABLE children ADD COLUMN birthdate DATE;
+
+-- Age-based allowance formulas: the amount follows the child's age and is recalculated on birthdays
+ALTER TABLE allowance_schedules
+    ADD COLUMN amount_formula TEXT NOT NULL DEFAULT 'flat',
+    ADD COLUMN cents_per_year_of_age BIGINT,
+    ADD CONSTRAINT chk_allowance_amount_formula_valid CHECK (amount_formula IN ('flat', 'per_year_of_age', 'stepped')),
+    ADD CONSTRAINT chk_allowance_cents_per_year CHECK (
+        (amount_formula = 'per_year_of_age' AND cents_per_year_of_age > 0) OR
+        (amount_formula <> 'per_year_of_age' AND cents_per_year_of_age IS NULL)
+    );
+
+-- Stepped allowance table: from min_age onwards the schedule pays amount_cents
+CREATE TABLE allowance_age_steps (
+    id           SERIAL PRIMARY KEY,
+    schedule_id  INTEGER NOT NULL REFERENCES allowance_schedules(id) ON DELETE CASCADE,
+    min_age      INTEGER NOT NULL CHECK(min_age >= 0),
+    amount_cents BIGINT NOT NULL CHECK(amount_cents > 0),
+    created_at   TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    UNIQUE(schedule_id, min_age)
+);
+
+-- Raise history: one row per automatic amount change on an age-based schedule
+CREATE TABLE allowance_raises (
+    id               SERIAL PRIMARY KEY,
+    schedule_id      INTEGER NOT NULL REFERENCES allowance_schedules(id) ON DELETE CASCADE,
+    child_id         INTEGER NOT NULL REFERENCES children(id) ON DELETE CASCADE,
+    age              INTEGER NOT NULL,
+    old_amount_cents BIGINT NOT NULL,
+    new_amount_cents BIGINT NOT NULL,
+    created_at       TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX idx_allowance_raises_child ON allowance_raises(child_id, created_at);
@@ -0,0 +1,3 @@
+ALTER TABLE allowance_schedules DROP COLUMN IF EXISTS held_cents;
+
+DROP TABLE IF EXISTS allowance_overrides;
@@ -0,0 +1,18 @@
+-- Per-run allowance overrides: skip, hold, or pay a custom amount/note for one run
+CREATE TABLE allowance_overrides (
+    id                   SERIAL PRIMARY KEY,
+    schedule_id          INTEGER NOT NULL REFERENCES allowance_schedules(id) ON DELETE CASCADE,
+    run_date             DATE NOT NULL,
+    action               TEXT NOT NULL CHECK(action IN ('skip', 'hold', 'custom')),
+    amount_cents         BIGINT CHECK(amount_cents > 0),
+    note                 TEXT,
+    created_by_parent_id INTEGER NOT NULL REFERENCES parents(id) ON DELETE CASCADE,
+    created_at           TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    UNIQUE(schedule_id, run_date),
+    CONSTRAINT chk_allowance_override_custom CHECK (
+        action <> 'custom' OR amount_cents IS NOT NULL OR note IS NOT NULL
+    )
+);
+
+-- Payouts of held runs, added to the next paid run
+ALTER TABLE allowance_schedules ADD COLUMN held_cents BIGINT NOT NULL DEFAULT 0;
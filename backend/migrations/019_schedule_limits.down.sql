@@ -0,0 +1,8 @@
+UPDATE allowance_schedules SET status = 'paused' WHERE status = 'completed';
+
+ALTER TABLE allowance_schedules
+    DROP CONSTRAINT IF EXISTS allowance_schedules_status_check,
+    ADD CONSTRAINT allowance_schedules_status_check CHECK (status IN ('active', 'paused')),
+    DROP COLUMN IF EXISTS run_count,
+    DROP COLUMN IF EXISTS max_runs,
+    DROP COLUMN IF EXISTS end_date;
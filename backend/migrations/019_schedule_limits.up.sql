@@ -0,0 +1,7 @@
+-- Optional end date and run limit for allowance schedules; schedules that reach either are completed
+ALTER TABLE allowance_schedules
+    ADD COLUMN end_date DATE,
+    ADD COLUMN max_runs INTEGER CHECK(max_runs > 0),
+    ADD COLUMN run_count INTEGER NOT NULL DEFAULT 0,
+    DROP CONSTRAINT IF EXISTS allowance_schedules_status_check,
+    ADD CONSTRAINT allowance_schedules_status_check CHECK (status IN ('active', 'paused', 'completed'));
@@ -0,0 +1 @@
+ALTER TABLE families DROP COLUMN IF EXISTS calendar_token_hash;
@@ -0,0 +1,2 @@
+-- SHA-256 hash of the family's calendar feed token; NULL when no feed is published
+ALTER TABLE families ADD COLUMN calendar_token_hash TEXT UNIQUE;
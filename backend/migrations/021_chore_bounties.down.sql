@@ -0,0 +1,15 @@
+DROP INDEX IF EXISTS idx_chore_instances_claim_expires_at;
+
+UPDATE chore_instances SET status = 'available' WHERE status = 'claimed';
+UPDATE chore_instances SET status = 'expired' WHERE status = 'taken';
+
+ALTER TABLE chore_instances
+    DROP CONSTRAINT IF EXISTS chk_instance_status_valid,
+    ADD CONSTRAINT chk_instance_status_valid CHECK (status IN ('available', 'pending_approval', 'approved', 'expired')),
+    DROP COLUMN IF EXISTS claim_expires_at,
+    DROP COLUMN IF EXISTS claimed_at;
+
+ALTER TABLE chores
+    DROP CONSTRAINT IF EXISTS chk_claim_hours_range,
+    DROP COLUMN IF EXISTS claim_hours,
+    DROP COLUMN IF EXISTS is_bounty;
@@ -0,0 +1,13 @@
+ALTER TABLE chores
+    ADD COLUMN is_bounty BOOLEAN NOT NULL DEFAULT FALSE,
+    ADD COLUMN claim_hours INTEGER,
+    ADD CONSTRAINT chk_claim_hours_range CHECK (claim_hours IS NULL OR (claim_hours >= 1 AND claim_hours <= 168));
+
+ALTER TABLE chore_instances
+    ADD COLUMN claimed_at TIMESTAMPTZ,
+    ADD COLUMN claim_expires_at TIMESTAMPTZ,
+    DROP CONSTRAINT IF EXISTS chk_instance_status_valid,
+    ADD CONSTRAINT chk_instance_status_valid CHECK (status IN ('available', 'pending_approval', 'approved', 'expired', 'claimed', 'taken'));
+
+-- Claims the scheduler has to release once they lapse
+CREATE INDEX idx_chore_instances_claim_expires_at ON chore_instances(claim_expires_at) WHERE status = 'claimed';
@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS chore_streak_awards;
+DROP TABLE IF EXISTS chore_streak_bonuses;
@@ -0,0 +1,26 @@
+-- Bonuses paid when a child's streak on a recurring chore reaches a milestone
+CREATE TABLE chore_streak_bonuses (
+    id BIGSERIAL PRIMARY KEY,
+    chore_id BIGINT NOT NULL REFERENCES chores(id) ON DELETE CASCADE,
+    streak_length INTEGER NOT NULL,
+    bonus_cents BIGINT NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    CONSTRAINT chk_streak_length_range CHECK (streak_length >= 2 AND streak_length <= 365),
+    CONSTRAINT chk_streak_bonus_cents_positive CHECK (bonus_cents > 0),
+    CONSTRAINT uq_chore_streak_bonuses_chore_length UNIQUE (chore_id, streak_length)
+);
+
+-- Streak bonuses already paid; the unique constraint keeps a milestone from paying twice per streak
+CREATE TABLE chore_streak_awards (
+    id BIGSERIAL PRIMARY KEY,
+    chore_id BIGINT NOT NULL REFERENCES chores(id) ON DELETE CASCADE,
+    child_id BIGINT NOT NULL REFERENCES children(id) ON DELETE CASCADE,
+    streak_length INTEGER NOT NULL,
+    streak_started_on DATE NOT NULL,
+    bonus_cents BIGINT NOT NULL,
+    transaction_id BIGINT REFERENCES transactions(id) ON DELETE SET NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    CONSTRAINT uq_chore_streak_awards_streak UNIQUE (chore_id, child_id, streak_length, streak_started_on)
+);
+
+CREATE INDEX idx_chore_streak_awards_child_id ON chore_streak_awards(child_id);
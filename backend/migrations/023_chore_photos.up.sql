@@ -0,0 +1,13 @@
+-- Photos children attach as proof when completing a chore
+CREATE TABLE chore_photos (
+    id BIGSERIAL PRIMARY KEY,
+    chore_instance_id BIGINT NOT NULL REFERENCES chore_instances(id) ON DELETE CASCADE,
+    storage_key TEXT NOT NULL,
+    thumbnail_key TEXT NOT NULL,
+    content_type VARCHAR(50) NOT NULL,
+    size_bytes BIGINT NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    CONSTRAINT chk_chore_photo_size_positive CHECK (size_bytes > 0)
+);
+
+CREATE INDEX idx_chore_photos_chore_instance_id ON chore_photos(chore_instance_id);
@@ -0,0 +1,6 @@
+ALTER TABLE chore_assignments
+    DROP COLUMN IF EXISTS position;
+
+ALTER TABLE chores
+    DROP COLUMN IF EXISTS rotation_child_id,
+    DROP COLUMN IF EXISTS is_rotation;
@@ -0,0 +1,7 @@
+ALTER TABLE chores
+    ADD COLUMN is_rotation BOOLEAN NOT NULL DEFAULT FALSE,
+    ADD COLUMN rotation_child_id BIGINT REFERENCES children(id) ON DELETE SET NULL;
+
+-- Order of the children in a rotation
+ALTER TABLE chore_assignments
+    ADD COLUMN position INTEGER NOT NULL DEFAULT 0;
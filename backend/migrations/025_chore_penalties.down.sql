@@ -0,0 +1,14 @@
+ALTER TABLE chore_instances
+    DROP COLUMN IF EXISTS penalty_transaction_id;
+
+-- Penalties already came off balances, so keep them as plain withdrawals
+UPDATE transactions SET transaction_type = 'withdrawal' WHERE transaction_type = 'chore_penalty';
+
+-- Revert transaction_type check constraint
+ALTER TABLE transactions DROP CONSTRAINT IF EXISTS transactions_transaction_type_check;
+ALTER TABLE transactions ADD CONSTRAINT transactions_transaction_type_check
+    CHECK (transaction_type IN ('deposit', 'withdrawal', 'allowance', 'interest', 'chore', 'withdrawal_request'));
+
+ALTER TABLE chores
+    DROP CONSTRAINT IF EXISTS chk_penalty_cents_non_negative,
+    DROP COLUMN IF EXISTS penalty_cents;
@@ -0,0 +1,12 @@
+ALTER TABLE chores
+    ADD COLUMN penalty_cents INTEGER NOT NULL DEFAULT 0,
+    ADD CONSTRAINT chk_penalty_cents_non_negative CHECK (penalty_cents >= 0);
+
+-- The deduction made when an instance expired unfinished
+ALTER TABLE chore_instances
+    ADD COLUMN penalty_transaction_id BIGINT REFERENCES transactions(id);
+
+-- Add 'chore_penalty' to the allowed transaction_type values
+ALTER TABLE transactions DROP CONSTRAINT IF EXISTS transactions_transaction_type_check;
+ALTER TABLE transactions ADD CONSTRAINT transactions_transaction_type_check
+    CHECK (transaction_type IN ('deposit', 'withdrawal', 'allowance', 'interest', 'chore', 'withdrawal_request', 'chore_penalty'));
@@ -0,0 +1,8 @@
+ALTER TABLE chore_instances
+    DROP CONSTRAINT IF EXISTS chk_instance_bonus_cents_non_negative,
+    DROP CONSTRAINT IF EXISTS chk_instance_payout_percent_range,
+    DROP CONSTRAINT IF EXISTS chk_instance_rating_range,
+    DROP COLUMN IF EXISTS paid_cents,
+    DROP COLUMN IF EXISTS bonus_cents,
+    DROP COLUMN IF EXISTS payout_percent,
+    DROP COLUMN IF EXISTS rating;
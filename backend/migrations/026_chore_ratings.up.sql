@@ -0,0 +1,8 @@
+ALTER TABLE chore_instances
+    ADD COLUMN rating SMALLINT,
+    ADD COLUMN payout_percent SMALLINT,
+    ADD COLUMN bonus_cents INTEGER NOT NULL DEFAULT 0,
+    ADD COLUMN paid_cents INTEGER,
+    ADD CONSTRAINT chk_instance_rating_range CHECK (rating IS NULL OR (rating >= 1 AND rating <= 5)),
+    ADD CONSTRAINT chk_instance_payout_percent_range CHECK (payout_percent IS NULL OR (payout_percent >= 0 AND payout_percent <= 100)),
+    ADD CONSTRAINT chk_instance_bonus_cents_non_negative CHECK (bonus_cents >= 0);
@@ -0,0 +1,8 @@
+DROP TABLE IF EXISTS chore_review_rounds;
+
+ALTER TABLE chore_instances
+    DROP COLUMN IF EXISTS redo_count;
+
+ALTER TABLE chores
+    DROP CONSTRAINT IF EXISTS chk_max_redos_range,
+    DROP COLUMN IF EXISTS max_redos;
@@ -0,0 +1,20 @@
+ALTER TABLE chores
+    ADD COLUMN max_redos INTEGER,
+    ADD CONSTRAINT chk_max_redos_range CHECK (max_redos IS NULL OR (max_redos >= 0 AND max_redos <= 10));
+
+ALTER TABLE chore_instances
+    ADD COLUMN redo_count INTEGER NOT NULL DEFAULT 0;
+
+-- One row per submit/review round of a chore instance
+CREATE TABLE chore_review_rounds (
+    id BIGSERIAL PRIMARY KEY,
+    chore_instance_id BIGINT NOT NULL REFERENCES chore_instances(id) ON DELETE CASCADE,
+    round INTEGER NOT NULL,
+    submitted_at TIMESTAMPTZ,
+    outcome VARCHAR(20) NOT NULL,
+    feedback VARCHAR(500),
+    reviewed_by_parent_id BIGINT NOT NULL REFERENCES parents(id),
+    reviewed_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    CONSTRAINT chk_review_round_outcome_valid CHECK (outcome IN ('approved', 'rejected', 'redo')),
+    CONSTRAINT uq_chore_review_rounds_instance_round UNIQUE (chore_instance_id, round)
+);
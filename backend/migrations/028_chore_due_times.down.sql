@@ -0,0 +1,11 @@
+ALTER TABLE chore_instances
+    DROP COLUMN IF EXISTS completed_late,
+    DROP COLUMN IF EXISTS due_at;
+
+ALTER TABLE chores
+    DROP CONSTRAINT IF EXISTS chk_late_payout_percent_range,
+    DROP CONSTRAINT IF EXISTS chk_due_weekday_range,
+    DROP CONSTRAINT IF EXISTS chk_due_time_format,
+    DROP COLUMN IF EXISTS late_payout_percent,
+    DROP COLUMN IF EXISTS due_weekday,
+    DROP COLUMN IF EXISTS due_time;
@@ -0,0 +1,12 @@
+-- Optional time of day (HH:MM, family timezone) and weekday within the period a chore is due by
+ALTER TABLE chores
+    ADD COLUMN due_time VARCHAR(5),
+    ADD COLUMN due_weekday SMALLINT,
+    ADD COLUMN late_payout_percent SMALLINT,
+    ADD CONSTRAINT chk_due_time_format CHECK (due_time IS NULL OR due_time ~ '^([01][0-9]|2[0-3]):[0-5][0-9]$'),
+    ADD CONSTRAINT chk_due_weekday_range CHECK (due_weekday IS NULL OR (due_weekday >= 0 AND due_weekday <= 6)),
+    ADD CONSTRAINT chk_late_payout_percent_range CHECK (late_payout_percent IS NULL OR (late_payout_percent >= 0 AND late_payout_percent <= 100));
+
+ALTER TABLE chore_instances
+    ADD COLUMN due_at TIMESTAMPTZ,
+    ADD COLUMN completed_late BOOLEAN NOT NULL DEFAULT FALSE;
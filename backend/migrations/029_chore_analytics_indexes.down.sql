@@ -0,0 +1 @@
+DROP INDEX IF EXISTS idx_chore_instances_chore_created;
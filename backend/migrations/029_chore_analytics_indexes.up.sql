@@ -0,0 +1,2 @@
+-- Chore analytics aggregate a family's instances by chore over a created_at range
+CREATE INDEX idx_chore_instances_chore_created ON chore_instances(chore_id, created_at);
@@ -0,0 +1,6 @@
+DROP TABLE IF EXISTS withdrawal_policy_approvals;
+
+ALTER TABLE withdrawal_requests
+    DROP COLUMN IF EXISTS auto_approved;
+
+DROP TABLE IF EXISTS withdrawal_policies;
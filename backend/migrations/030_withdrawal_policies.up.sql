@@ -0,0 +1,31 @@
+-- Per-child policy for approving small withdrawal requests without a parent
+CREATE TABLE withdrawal_policies (
+    id BIGSERIAL PRIMARY KEY,
+    child_id BIGINT NOT NULL UNIQUE REFERENCES children(id) ON DELETE CASCADE,
+    family_id BIGINT NOT NULL REFERENCES families(id) ON DELETE CASCADE,
+    max_amount_cents INTEGER NOT NULL,
+    weekly_cap_cents INTEGER,
+    updated_by_parent_id BIGINT NOT NULL REFERENCES parents(id),
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    CONSTRAINT chk_wp_max_amount_positive CHECK (max_amount_cents > 0),
+    CONSTRAINT chk_wp_weekly_cap_valid CHECK (weekly_cap_cents IS NULL OR weekly_cap_cents >= max_amount_cents)
+);
+
+ALTER TABLE withdrawal_requests
+    ADD COLUMN auto_approved BOOLEAN NOT NULL DEFAULT FALSE;
+
+-- Audit trail of requests approved by a policy, with the policy limits applied at the time
+CREATE TABLE withdrawal_policy_approvals (
+    id BIGSERIAL PRIMARY KEY,
+    withdrawal_request_id BIGINT NOT NULL UNIQUE REFERENCES withdrawal_requests(id) ON DELETE CASCADE,
+    policy_id BIGINT REFERENCES withdrawal_policies(id) ON DELETE SET NULL,
+    child_id BIGINT NOT NULL REFERENCES children(id) ON DELETE CASCADE,
+    amount_cents INTEGER NOT NULL,
+    max_amount_cents INTEGER NOT NULL,
+    weekly_cap_cents INTEGER,
+    week_total_cents BIGINT NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX idx_withdrawal_policy_approvals_child_created ON withdrawal_policy_approvals(child_id, created_at);
@@ -0,0 +1,13 @@
+DROP TABLE IF EXISTS notifications;
+
+UPDATE withdrawal_requests SET status = 'cancelled' WHERE status = 'expired';
+
+ALTER TABLE withdrawal_requests
+    DROP CONSTRAINT IF EXISTS chk_wr_status_valid,
+    ADD CONSTRAINT chk_wr_status_valid CHECK (status IN ('pending', 'approved', 'denied', 'cancelled')),
+    DROP COLUMN IF EXISTS expired_at,
+    DROP COLUMN IF EXISTS reminder_level;
+
+ALTER TABLE families
+    DROP CONSTRAINT IF EXISTS chk_withdrawal_expiry_days_range,
+    DROP COLUMN IF EXISTS withdrawal_expiry_days;
@@ -0,0 +1,28 @@
+-- Pending withdrawal requests expire after this many days without a parent's response
+ALTER TABLE families
+    ADD COLUMN withdrawal_expiry_days INTEGER NOT NULL DEFAULT 7,
+    ADD CONSTRAINT chk_withdrawal_expiry_days_range CHECK (withdrawal_expiry_days >= 1 AND withdrawal_expiry_days <= 90);
+
+-- reminder_level: 0 = no reminder yet, 1 = reminder sent, 2 = final reminder sent
+ALTER TABLE withdrawal_requests
+    ADD COLUMN reminder_level SMALLINT NOT NULL DEFAULT 0,
+    ADD COLUMN expired_at TIMESTAMPTZ,
+    DROP CONSTRAINT IF EXISTS chk_wr_status_valid,
+    ADD CONSTRAINT chk_wr_status_valid CHECK (status IN ('pending', 'approved', 'denied', 'cancelled', 'expired'));
+
+-- In-app notifications for a parent or a child
+CREATE TABLE notifications (
+    id BIGSERIAL PRIMARY KEY,
+    family_id BIGINT NOT NULL REFERENCES families(id) ON DELETE CASCADE,
+    parent_id BIGINT REFERENCES parents(id) ON DELETE CASCADE,
+    child_id BIGINT REFERENCES children(id) ON DELETE CASCADE,
+    kind VARCHAR(50) NOT NULL,
+    message VARCHAR(500) NOT NULL,
+    withdrawal_request_id BIGINT REFERENCES withdrawal_requests(id) ON DELETE CASCADE,
+    read_at TIMESTAMPTZ,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    CONSTRAINT chk_notification_one_recipient CHECK ((parent_id IS NULL) <> (child_id IS NULL))
+);
+
+CREATE INDEX idx_notifications_parent_created ON notifications(parent_id, created_at) WHERE parent_id IS NOT NULL;
+CREATE INDEX idx_notifications_child_created ON notifications(child_id, created_at) WHERE child_id IS NOT NULL;
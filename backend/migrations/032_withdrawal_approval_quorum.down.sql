@@ -0,0 +1,7 @@
+DROP TABLE IF EXISTS withdrawal_request_approvals;
+
+ALTER TABLE families
+    DROP CONSTRAINT IF EXISTS chk_withdrawal_quorum_approvals_range,
+    DROP CONSTRAINT IF EXISTS chk_withdrawal_quorum_threshold_valid,
+    DROP COLUMN IF EXISTS withdrawal_quorum_approvals,
+    DROP COLUMN IF EXISTS withdrawal_quorum_threshold_cents;
@@ -0,0 +1,16 @@
+-- Withdrawal requests above the threshold need this many distinct parent approvals;
+-- a NULL threshold turns the quorum off
+ALTER TABLE families
+    ADD COLUMN withdrawal_quorum_threshold_cents INTEGER,
+    ADD COLUMN withdrawal_quorum_approvals SMALLINT NOT NULL DEFAULT 2,
+    ADD CONSTRAINT chk_withdrawal_quorum_threshold_valid CHECK (withdrawal_quorum_threshold_cents IS NULL OR withdrawal_quorum_threshold_cents >= 0),
+    ADD CONSTRAINT chk_withdrawal_quorum_approvals_range CHECK (withdrawal_quorum_approvals >= 2 AND withdrawal_quorum_approvals <= 10);
+
+-- Each parent's approval of a withdrawal request
+CREATE TABLE withdrawal_request_approvals (
+    id BIGSERIAL PRIMARY KEY,
+    withdrawal_request_id BIGINT NOT NULL REFERENCES withdrawal_requests(id) ON DELETE CASCADE,
+    parent_id BIGINT NOT NULL REFERENCES parents(id) ON DELETE CASCADE,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    CONSTRAINT uq_withdrawal_request_approvals_parent UNIQUE (withdrawal_request_id, parent_id)
+);
@@ -0,0 +1,12 @@
+ALTER TABLE withdrawal_requests
+    DROP COLUMN IF EXISTS savings_goal_id;
+
+ALTER TABLE savings_goals
+    DROP COLUMN IF EXISTS purchase_transaction_id,
+    DROP COLUMN IF EXISTS purchased_at;
+
+-- Purchased goals go back to completed
+UPDATE savings_goals SET status = 'completed' WHERE status = 'purchased';
+ALTER TABLE savings_goals DROP CONSTRAINT IF EXISTS savings_goals_status_check;
+ALTER TABLE savings_goals ADD CONSTRAINT savings_goals_status_check
+    CHECK (status IN ('active', 'completed'));
@@ -0,0 +1,12 @@
+-- Completed goals can be redeemed: their saved funds are withdrawn and the goal marked purchased
+ALTER TABLE savings_goals DROP CONSTRAINT IF EXISTS savings_goals_status_check;
+ALTER TABLE savings_goals ADD CONSTRAINT savings_goals_status_check
+    CHECK (status IN ('active', 'completed', 'purchased'));
+
+ALTER TABLE savings_goals
+    ADD COLUMN purchased_at TIMESTAMPTZ,
+    ADD COLUMN purchase_transaction_id BIGINT REFERENCES transactions(id);
+
+-- A child's request to redeem a completed goal, subject to parent approval
+ALTER TABLE withdrawal_requests
+    ADD COLUMN savings_goal_id INTEGER REFERENCES savings_goals(id) ON DELETE SET NULL;
@@ -0,0 +1,5 @@
+ALTER TABLE goal_allocations
+    DROP COLUMN IF EXISTS transaction_id,
+    DROP COLUMN IF EXISTS rule_id;
+
+DROP TABLE IF EXISTS goal_allocation_rules;
@@ -0,0 +1,26 @@
+-- Standing rules that move part of a child's incoming money into a savings goal when it is posted.
+-- percent rules allocate that share of the incoming amount; round_up rules allocate the spare change
+-- needed to round the incoming amount up to a multiple of round_to_cents.
+CREATE TABLE goal_allocation_rules (
+    id BIGSERIAL PRIMARY KEY,
+    child_id INTEGER NOT NULL REFERENCES children(id) ON DELETE CASCADE,
+    goal_id INTEGER NOT NULL REFERENCES savings_goals(id) ON DELETE CASCADE,
+    source VARCHAR(20) NOT NULL,
+    kind VARCHAR(20) NOT NULL,
+    percent SMALLINT,
+    round_to_cents INTEGER,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    CONSTRAINT chk_gar_source_valid CHECK (source IN ('allowance', 'chore', 'interest', 'deposit')),
+    CONSTRAINT chk_gar_kind_valid CHECK (
+        (kind = 'percent' AND percent BETWEEN 1 AND 100 AND round_to_cents IS NULL)
+        OR (kind = 'round_up' AND round_to_cents BETWEEN 2 AND 10000 AND percent IS NULL)
+    ),
+    CONSTRAINT uq_goal_allocation_rules_goal_source UNIQUE (goal_id, source)
+);
+
+CREATE INDEX idx_goal_allocation_rules_child_source ON goal_allocation_rules(child_id, source);
+
+-- Allocations made by a rule record the rule and the transaction that triggered them
+ALTER TABLE goal_allocations
+    ADD COLUMN rule_id BIGINT REFERENCES goal_allocation_rules(id) ON DELETE SET NULL,
+    ADD COLUMN transaction_id BIGINT REFERENCES transactions(id) ON DELETE CASCADE;
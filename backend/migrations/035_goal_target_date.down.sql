@@ -0,0 +1 @@
+ALTER TABLE savings_goals DROP COLUMN target_date;
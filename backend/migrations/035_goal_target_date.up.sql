@@ -0,0 +1 @@
+ALTER TABLE savings_goals ADD COLUMN target_date DATE;
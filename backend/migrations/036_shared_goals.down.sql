@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS shared_goal_allocations;
+DROP TABLE IF EXISTS shared_goal_contributions;
+DROP TABLE IF EXISTS shared_goals;
@@ -0,0 +1,39 @@
+-- Family-level savings goals that several children can allocate to
+CREATE TABLE shared_goals (
+    id BIGSERIAL PRIMARY KEY,
+    family_id INTEGER NOT NULL REFERENCES families(id) ON DELETE CASCADE,
+    name TEXT NOT NULL,
+    target_cents BIGINT NOT NULL CHECK (target_cents > 0),
+    saved_cents BIGINT NOT NULL DEFAULT 0 CHECK (saved_cents >= 0),
+    emoji TEXT,
+    status TEXT NOT NULL DEFAULT 'active' CHECK (status IN ('active', 'completed', 'purchased')),
+    created_by_child_id INTEGER REFERENCES children(id) ON DELETE SET NULL,
+    completed_at TIMESTAMPTZ,
+    purchased_at TIMESTAMPTZ,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX idx_shared_goals_family_status ON shared_goals(family_id, status);
+
+-- Each contributor's tally: what they have set aside for the goal out of their own balance
+CREATE TABLE shared_goal_contributions (
+    shared_goal_id BIGINT NOT NULL REFERENCES shared_goals(id) ON DELETE CASCADE,
+    child_id INTEGER NOT NULL REFERENCES children(id) ON DELETE CASCADE,
+    saved_cents BIGINT NOT NULL DEFAULT 0 CHECK (saved_cents >= 0),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (shared_goal_id, child_id)
+);
+
+CREATE INDEX idx_shared_goal_contributions_child ON shared_goal_contributions(child_id);
+
+-- Audit trail of fund movements to and from shared goals, per contributor
+CREATE TABLE shared_goal_allocations (
+    id BIGSERIAL PRIMARY KEY,
+    shared_goal_id BIGINT NOT NULL REFERENCES shared_goals(id) ON DELETE CASCADE,
+    child_id INTEGER NOT NULL REFERENCES children(id) ON DELETE CASCADE,
+    amount_cents BIGINT NOT NULL CHECK (amount_cents != 0),
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX idx_shared_goal_allocations_goal ON shared_goal_allocations(shared_goal_id, created_at DESC);
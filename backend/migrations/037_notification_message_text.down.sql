@@ -0,0 +1 @@
+ALTER TABLE notifications ALTER COLUMN message TYPE VARCHAR(500) USING LEFT(message, 500);
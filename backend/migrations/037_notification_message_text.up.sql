@@ -0,0 +1,3 @@
+-- Messages quote user-entered text such as withdrawal reasons, which can be as long as the
+-- old 500-character limit on their own
+ALTER TABLE notifications ALTER COLUMN message TYPE TEXT;
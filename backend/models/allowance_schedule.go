@@ -9,6 +9,8 @@ const (
 	FrequencyWeekly   Frequency = "weekly"
 	FrequencyBiweekly Frequency = "biweekly"
 	FrequencyMonthly  Frequency = "monthly"
+	// FrequencyCustom schedules follow an RFC 5545 RRULE stored alongside the schedule.
+	FrequencyCustom Frequency = "custom"
 )
 
 // ScheduleStatus represents the current state of a schedule.
@@ -17,9 +19,48 @@ type ScheduleStatus string
 const (
 	ScheduleStatusActive ScheduleStatus = "active"
 	ScheduleStatusPaused ScheduleStatus = "paused"
+	// ScheduleStatusCompleted marks an allowance schedule that reached its end date or run limit.
+	ScheduleStatusCompleted ScheduleStatus = "completed"
+)
+
+// ChoreRule controls how a child's chore completion affects an allowance payout.
+type ChoreRule string
+
+const (
+	// ChoreRuleNone pays the full amount regardless of chores.
+	ChoreRuleNone ChoreRule = "none"
+	// ChoreRuleThreshold pays the full amount only if the approval rate meets ChoreThresholdPercent.
+	ChoreRuleThreshold ChoreRule = "threshold"
+	// ChoreRuleProportional pays the amount scaled by the approval rate.
+	ChoreRuleProportional ChoreRule = "proportional"
+)
+
+// AmountFormula controls how a schedule's amount is derived.
+type AmountFormula string
+
+const (
+	// AmountFormulaFlat pays the fixed AmountCents.
+	AmountFormulaFlat AmountFormula = "flat"
+	// AmountFormulaPerYearOfAge pays CentsPerYearOfAge for each year of the child's age.
+	AmountFormulaPerYearOfAge AmountFormula = "per_year_of_age"
+	// AmountFormulaStepped pays the amount of the highest age step the child has reached.
+	AmountFormulaStepped AmountFormula = "stepped"
+)
+
+// OverrideAction is what an override does to a single allowance run.
+type OverrideAction string
+
+const (
+	// OverrideActionSkip cancels the run; nothing is paid.
+	OverrideActionSkip OverrideAction = "skip"
+	// OverrideActionHold defers the run's payout and adds it to the next paid run.
+	OverrideActionHold OverrideAction = "hold"
+	// OverrideActionCustom pays the run with a custom amount and/or note.
+	OverrideActionCustom OverrideAction = "custom"
 )
 
 // AllowanceSchedule represents a recurring deposit configuration.
+// A child may have several concurrent schedules, each with its own destination.
 type AllowanceSchedule struct {
 	ID          int64          `gorm:"primaryKey" json:"id"`
 	ChildID     int64          `gorm:"not null" json:"child_id"`
@@ -28,14 +69,84 @@ type AllowanceSchedule struct {
 	Frequency   Frequency      `gorm:"not null" json:"frequency"`
 	DayOfWeek   *int           `json:"day_of_week,omitempty"`
 	DayOfMonth  *int           `json:"day_of_month,omitempty"`
+	RRule       *string        `gorm:"column:rrule" json:"rrule,omitempty"`
 	Note        *string        `json:"note,omitempty"`
 	Status      ScheduleStatus `gorm:"not null;default:active" json:"status"`
 	NextRunAt   *time.Time     `json:"next_run_at,omitempty"`
 	CreatedAt   time.Time      `gorm:"autoCreateTime" json:"created_at"`
 	UpdatedAt   time.Time      `gorm:"autoUpdateTime" json:"updated_at"`
 
+	// ChoreRule gates or prorates the payout on chore instances approved during the period.
+	ChoreRule             ChoreRule `gorm:"not null;default:none" json:"chore_rule"`
+	ChoreThresholdPercent *int      `json:"chore_threshold_percent,omitempty"`
+
+	// AmountFormula derives AmountCents from the child's age. For age-based formulas the
+	// amount is recalculated on the child's birthday and each increase is recorded as an AllowanceRaise.
+	AmountFormula     AmountFormula      `gorm:"not null;default:flat" json:"amount_formula"`
+	CentsPerYearOfAge *int64             `json:"cents_per_year_of_age,omitempty"`
+	AgeSteps          []AllowanceAgeStep `gorm:"foreignKey:ScheduleID" json:"age_steps,omitempty"`
+
+	// EndDate and MaxRuns optionally limit the schedule. Every run counts towards MaxRuns,
+	// including skipped and held ones; the schedule completes once either limit is reached.
+	EndDate  *time.Time `gorm:"type:date" json:"end_date,omitempty"`
+	MaxRuns  *int       `json:"max_runs,omitempty"`
+	RunCount int        `gorm:"not null;default:0" json:"run_count"`
+
+	// HeldCents is the total of held runs, paid out with the next run that is not skipped or held.
+	HeldCents int64 `gorm:"not null;default:0" json:"held_cents"`
+
+	// Splits directs percentages of each payout to savings goals.
+	// Whatever the splits do not cover is deposited to the main balance.
+	Splits []AllowanceSplit `gorm:"foreignKey:ScheduleID" json:"splits"`
+
 	// Associations
 	Child        Child         `gorm:"foreignKey:ChildID" json:"-"`
 	Parent       Parent        `gorm:"foreignKey:ParentID" json:"-"`
 	Transactions []Transaction `gorm:"foreignKey:ScheduleID" json:"-"`
 }
+
+// AllowanceSplit sends a percentage of an allowance payout to a savings goal.
+type AllowanceSplit struct {
+	ID         int64     `gorm:"primaryKey" json:"id"`
+	ScheduleID int64     `gorm:"not null" json:"schedule_id"`
+	GoalID     int64     `gorm:"not null" json:"goal_id"`
+	Percent    int       `gorm:"not null" json:"percent"`
+	CreatedAt  time.Time `gorm:"autoCreateTime" json:"created_at"`
+
+	// Associations
+	SavingsGoal SavingsGoal `gorm:"foreignKey:GoalID" json:"-"`
+}
+
+// AllowanceAgeStep is one row of a stepped allowance table: from MinAge onwards the
+// schedule pays AmountCents, until the child reaches the next step.
+type AllowanceAgeStep struct {
+	ID          int64     `gorm:"primaryKey" json:"id"`
+	ScheduleID  int64     `gorm:"not null" json:"schedule_id"`
+	MinAge      int       `gorm:"not null" json:"min_age"`
+	AmountCents int64     `gorm:"not null" json:"amount_cents"`
+	CreatedAt   time.Time `gorm:"autoCreateTime" json:"created_at"`
+}
+
+// AllowanceRaise records an automatic increase of an age-based schedule's amount.
+type AllowanceRaise struct {
+	ID             int64     `gorm:"primaryKey" json:"id"`
+	ScheduleID     int64     `gorm:"not null" json:"schedule_id"`
+	ChildID        int64     `gorm:"not null" json:"child_id"`
+	Age            int       `gorm:"not null" json:"age"`
+	OldAmountCents int64     `gorm:"not null" json:"old_amount_cents"`
+	NewAmountCents int64     `gorm:"not null" json:"new_amount_cents"`
+	CreatedAt      time.Time `gorm:"autoCreateTime" json:"created_at"`
+}
+
+// AllowanceOverride changes a single run of a schedule, identified by its date in the
+// family's timezone. The schedule resumes its normal cadence afterwards.
+type AllowanceOverride struct {
+	ID                int64          `gorm:"primaryKey" json:"id"`
+	ScheduleID        int64          `gorm:"not null" json:"schedule_id"`
+	RunDate           time.Time      `gorm:"type:date;not null" json:"run_date"`
+	Action            OverrideAction `gorm:"not null" json:"action"`
+	AmountCents       *int64         `json:"amount_cents,omitempty"`
+	Note              *string        `json:"note,omitempty"`
+	CreatedByParentID int64          `gorm:"not null" json:"created_by_parent_id"`
+	CreatedAt         time.Time      `gorm:"autoCreateTime" json:"created_at"`
+}
@@ -16,6 +16,7 @@ type Child struct {
 	LastInterestAt      *time.Time `json:"last_interest_at,omitempty"`
 	Avatar              *string    `json:"avatar,omitempty"`
 	Theme               *string    `json:"theme,omitempty"`
+	Birthdate           *time.Time `gorm:"type:date" json:"birthdate,omitempty"`
 	CreatedAt           time.Time  `gorm:"autoCreateTime" json:"created_at"`
 	UpdatedAt           time.Time  `gorm:"autoUpdateTime" json:"updated_at"`
 
@@ -10,6 +10,8 @@ const (
 	ChoreRecurrenceDaily   ChoreRecurrence = "daily"
 	ChoreRecurrenceWeekly  ChoreRecurrence = "weekly"
 	ChoreRecurrenceMonthly ChoreRecurrence = "monthly"
+	// ChoreRecurrenceCustom chores follow an RFC 5545 RRULE; each occurrence starts a new period.
+	ChoreRecurrenceCustom ChoreRecurrence = "custom"
 )
 
 // ChoreInstanceStatus represents the current state of a chore instance.
@@ -20,9 +22,23 @@ const (
 	ChoreInstanceStatusPendingApproval ChoreInstanceStatus = "pending_approval"
 	ChoreInstanceStatusApproved        ChoreInstanceStatus = "approved"
 	ChoreInstanceStatusExpired         ChoreInstanceStatus = "expired"
+	// ChoreInstanceStatusClaimed is a bounty instance reserved by its child until claim_expires_at.
+	ChoreInstanceStatusClaimed ChoreInstanceStatus = "claimed"
+	// ChoreInstanceStatusTaken is a bounty instance hidden because a sibling claimed or completed the bounty.
+	ChoreInstanceStatusTaken ChoreInstanceStatus = "taken"
 )
 
+// DefaultClaimHours is how long a bounty claim lasts when the chore does not set claim_hours.
+const DefaultClaimHours = 24
+
 // Chore is a task template defined by a parent, belonging to a family.
+// A bounty chore goes to whichever assigned child claims or completes it first each period.
+// A rotation chore goes to one assigned child per period, taking turns in assignment order;
+// RotationChildID is the child given the most recent period.
+// PenaltyCents, if set, is deducted from a child's balance when their instance expires unfinished.
+// MaxRedos, if set, limits how many times one instance can be sent back for redo.
+// DueTime ("HH:MM" in the family's timezone) and DueWeekday set when in each period the chore is due;
+// instances completed after that are late and paid LatePayoutPercent of the reward, if set.
 type Chore struct {
 	ID                int64           `gorm:"primaryKey" json:"id"`
 	FamilyID          int64           `gorm:"not null" json:"family_id"`
@@ -33,7 +49,17 @@ type Chore struct {
 	Recurrence        ChoreRecurrence `gorm:"not null;default:one_time" json:"recurrence"`
 	DayOfWeek         *int            `json:"day_of_week,omitempty"`
 	DayOfMonth        *int            `json:"day_of_month,omitempty"`
+	RRule             *string         `gorm:"column:rrule" json:"rrule,omitempty"`
 	IsActive          bool            `gorm:"not null;default:true" json:"is_active"`
+	IsBounty          bool            `gorm:"not null;default:false" json:"is_bounty"`
+	ClaimHours        *int            `json:"claim_hours,omitempty"`
+	IsRotation        bool            `gorm:"not null;default:false" json:"is_rotation"`
+	RotationChildID   *int64          `json:"rotation_child_id,omitempty"`
+	PenaltyCents      int             `gorm:"not null;default:0" json:"penalty_cents"`
+	MaxRedos          *int            `json:"max_redos,omitempty"`
+	DueTime           *string         `json:"due_time,omitempty"`
+	DueWeekday        *int            `json:"due_weekday,omitempty"`
+	LatePayoutPercent *int            `json:"late_payout_percent,omitempty"`
 	CreatedAt         time.Time       `gorm:"autoCreateTime" json:"created_at"`
 	UpdatedAt         time.Time       `gorm:"autoUpdateTime" json:"updated_at"`
 
@@ -44,11 +70,12 @@ type Chore struct {
 	Instances   []ChoreInstance   `gorm:"foreignKey:ChoreID" json:"-"`
 }
 
-// ChoreAssignment links a chore to a child.
+// ChoreAssignment links a chore to a child. Position orders the children of a rotation.
 type ChoreAssignment struct {
 	ID        int64     `gorm:"primaryKey" json:"id"`
 	ChoreID   int64     `gorm:"not null" json:"chore_id"`
 	ChildID   int64     `gorm:"not null" json:"child_id"`
+	Position  int       `gorm:"not null;default:0" json:"position"`
 	CreatedAt time.Time `gorm:"autoCreateTime" json:"created_at"`
 
 	// Associations
@@ -57,24 +84,102 @@ type ChoreAssignment struct {
 }
 
 // ChoreInstance is a specific occurrence of a chore for a specific child.
+// On approval a parent may rate the work (1-5 stars), pay only PayoutPercent of the reward, and add
+// BonusCents; PaidCents is what the child was paid, or nil for instances approved at full reward.
+// DueAt is when a recurring instance is due within its period; CompletedLate marks completions after it.
 type ChoreInstance struct {
-	ID                  int64               `gorm:"primaryKey" json:"id"`
-	ChoreID             int64               `gorm:"not null" json:"chore_id"`
-	ChildID             int64               `gorm:"not null" json:"child_id"`
-	RewardCents         int                 `gorm:"not null" json:"reward_cents"`
-	Status              ChoreInstanceStatus `gorm:"not null;default:available" json:"status"`
-	PeriodStart         *time.Time          `gorm:"type:date" json:"period_start,omitempty"`
-	PeriodEnd           *time.Time          `gorm:"type:date" json:"period_end,omitempty"`
-	CompletedAt         *time.Time          `json:"completed_at,omitempty"`
-	ReviewedAt          *time.Time          `json:"reviewed_at,omitempty"`
-	ReviewedByParentID  *int64              `json:"reviewed_by_parent_id,omitempty"`
-	RejectionReason     *string             `json:"rejection_reason,omitempty"`
-	TransactionID       *int64              `json:"transaction_id,omitempty"`
-	CreatedAt           time.Time           `gorm:"autoCreateTime" json:"created_at"`
-	UpdatedAt           time.Time           `gorm:"autoUpdateTime" json:"updated_at"`
+	ID                   int64               `gorm:"primaryKey" json:"id"`
+	ChoreID              int64               `gorm:"not null" json:"chore_id"`
+	ChildID              int64               `gorm:"not null" json:"child_id"`
+	RewardCents          int                 `gorm:"not null" json:"reward_cents"`
+	Status               ChoreInstanceStatus `gorm:"not null;default:available" json:"status"`
+	PeriodStart          *time.Time          `gorm:"type:date" json:"period_start,omitempty"`
+	PeriodEnd            *time.Time          `gorm:"type:date" json:"period_end,omitempty"`
+	CompletedAt          *time.Time          `json:"completed_at,omitempty"`
+	ReviewedAt           *time.Time          `json:"reviewed_at,omitempty"`
+	ReviewedByParentID   *int64              `json:"reviewed_by_parent_id,omitempty"`
+	RejectionReason      *string             `json:"rejection_reason,omitempty"`
+	TransactionID        *int64              `json:"transaction_id,omitempty"`
+	ClaimedAt            *time.Time          `json:"claimed_at,omitempty"`
+	ClaimExpiresAt       *time.Time          `json:"claim_expires_at,omitempty"`
+	PenaltyTransactionID *int64              `json:"penalty_transaction_id,omitempty"`
+	Rating               *int                `json:"rating,omitempty"`
+	PayoutPercent        *int                `json:"payout_percent,omitempty"`
+	BonusCents           int                 `gorm:"not null;default:0" json:"bonus_cents"`
+	PaidCents            *int                `json:"paid_cents,omitempty"`
+	RedoCount            int                 `gorm:"not null;default:0" json:"redo_count"`
+	DueAt                *time.Time          `json:"due_at,omitempty"`
+	CompletedLate        bool                `gorm:"not null;default:false" json:"completed_late"`
+	CreatedAt            time.Time           `gorm:"autoCreateTime" json:"created_at"`
+	UpdatedAt            time.Time           `gorm:"autoUpdateTime" json:"updated_at"`
 
 	// Associations
 	Chore       Chore        `gorm:"foreignKey:ChoreID" json:"-"`
 	Child       Child        `gorm:"foreignKey:ChildID" json:"-"`
 	Transaction *Transaction `gorm:"foreignKey:TransactionID" json:"-"`
 }
+
+// ClaimDuration returns how long a claim on the chore's bounty lasts.
+func (c *Chore) ClaimDuration() time.Duration {
+	hours := DefaultClaimHours
+	if c.ClaimHours != nil {
+		hours = *c.ClaimHours
+	}
+	return time.Duration(hours) * time.Hour
+}
+
+// ChoreReviewOutcome is how a parent ruled on one submission of a chore instance.
+type ChoreReviewOutcome string
+
+const (
+	ChoreReviewOutcomeApproved ChoreReviewOutcome = "approved"
+	ChoreReviewOutcomeRejected ChoreReviewOutcome = "rejected"
+	// ChoreReviewOutcomeRedo sends the instance back to the child to try again.
+	ChoreReviewOutcomeRedo ChoreReviewOutcome = "redo"
+)
+
+// ChoreReviewRound records one submit/review round of a chore instance.
+type ChoreReviewRound struct {
+	ID                 int64              `gorm:"primaryKey" json:"id"`
+	ChoreInstanceID    int64              `gorm:"not null" json:"chore_instance_id"`
+	Round              int                `gorm:"not null" json:"round"`
+	SubmittedAt        *time.Time         `json:"submitted_at,omitempty"`
+	Outcome            ChoreReviewOutcome `gorm:"not null" json:"outcome"`
+	Feedback           *string            `json:"feedback,omitempty"`
+	ReviewedByParentID int64              `gorm:"not null" json:"reviewed_by_parent_id"`
+	ReviewedAt         time.Time          `gorm:"autoCreateTime" json:"reviewed_at"`
+}
+
+// ChoreStreakBonus is a bonus paid when a child's streak on a recurring chore reaches StreakLength periods.
+type ChoreStreakBonus struct {
+	ID           int64     `gorm:"primaryKey" json:"id"`
+	ChoreID      int64     `gorm:"not null" json:"chore_id"`
+	StreakLength int       `gorm:"not null" json:"streak_length"`
+	BonusCents   int64     `gorm:"not null" json:"bonus_cents"`
+	CreatedAt    time.Time `gorm:"autoCreateTime" json:"created_at"`
+}
+
+// ChoreStreakAward records a streak bonus paid to a child. A streak is identified by the date its
+// first period started, so each streak pays each milestone once.
+type ChoreStreakAward struct {
+	ID              int64     `gorm:"primaryKey" json:"id"`
+	ChoreID         int64     `gorm:"not null" json:"chore_id"`
+	ChildID         int64     `gorm:"not null" json:"child_id"`
+	StreakLength    int       `gorm:"not null" json:"streak_length"`
+	StreakStartedOn time.Time `gorm:"type:date;not null" json:"streak_started_on"`
+	BonusCents      int64     `gorm:"not null" json:"bonus_cents"`
+	TransactionID   *int64    `json:"transaction_id,omitempty"`
+	CreatedAt       time.Time `gorm:"autoCreateTime" json:"created_at"`
+}
+
+// ChorePhoto is a photo a child attached as proof when completing a chore instance. The image and
+// its thumbnail live in attachment storage under StorageKey and ThumbnailKey.
+type ChorePhoto struct {
+	ID              int64     `gorm:"primaryKey" json:"id"`
+	ChoreInstanceID int64     `gorm:"not null" json:"chore_instance_id"`
+	StorageKey      string    `gorm:"not null" json:"-"`
+	ThumbnailKey    string    `gorm:"not null" json:"-"`
+	ContentType     string    `gorm:"not null" json:"content_type"`
+	SizeBytes       int64     `gorm:"not null" json:"size_bytes"`
+	CreatedAt       time.Time `gorm:"autoCreateTime" json:"created_at"`
+}
@@ -21,23 +21,36 @@ func ValidateSlug(slug string) error {
 
 // Family represents a family group identified by a unique slug.
 type Family struct {
-	ID                            int64      `gorm:"primaryKey" json:"id"`
-	Slug                          string     `gorm:"uniqueIndex;not null" json:"slug"`
-	Timezone                      string     `gorm:"not null;default:America/New_York" json:"timezone"`
-	BankName                      string     `gorm:"not null;default:Dad" json:"bank_name"`
-	AccountType                   string     `gorm:"not null;default:free" json:"account_type"`
-	StripeCustomerID              *string    `gorm:"uniqueIndex" json:"stripe_customer_id,omitempty"`
-	StripeSubscriptionID          *string    `gorm:"uniqueIndex" json:"stripe_subscription_id,omitempty"`
-	SubscriptionStatus            *string    `json:"subscription_status,omitempty"`
-	SubscriptionCurrentPeriodEnd  *time.Time `json:"subscription_current_period_end,omitempty"`
-	SubscriptionCancelAtPeriodEnd bool       `gorm:"not null;default:false" json:"subscription_cancel_at_period_end"`
-	CreatedAt                     time.Time  `gorm:"autoCreateTime" json:"created_at"`
+	ID                             int64      `gorm:"primaryKey" json:"id"`
+	Slug                           string     `gorm:"uniqueIndex;not null" json:"slug"`
+	Timezone                       string     `gorm:"not null;default:America/New_York" json:"timezone"`
+	BankName                       string     `gorm:"not null;default:Dad" json:"bank_name"`
+	AccountType                    string     `gorm:"not null;default:free" json:"account_type"`
+	StripeCustomerID               *string    `gorm:"uniqueIndex" json:"stripe_customer_id,omitempty"`
+	StripeSubscriptionID           *string    `gorm:"uniqueIndex" json:"stripe_subscription_id,omitempty"`
+	SubscriptionStatus             *string    `json:"subscription_status,omitempty"`
+	SubscriptionCurrentPeriodEnd   *time.Time `json:"subscription_current_period_end,omitempty"`
+	SubscriptionCancelAtPeriodEnd  bool       `gorm:"not null;default:false" json:"subscription_cancel_at_period_end"`
+	CalendarTokenHash              *string    `gorm:"uniqueIndex" json:"-"`
+	WithdrawalExpiryDays           int        `gorm:"not null;default:7" json:"withdrawal_expiry_days"`
+	WithdrawalQuorumThresholdCents *int       `json:"withdrawal_quorum_threshold_cents,omitempty"`
+	WithdrawalQuorumApprovals      int        `gorm:"not null;default:2" json:"withdrawal_quorum_approvals"`
+	CreatedAt                      time.Time  `gorm:"autoCreateTime" json:"created_at"`
 
 	// Associations
 	Parents  []Parent `gorm:"foreignKey:FamilyID" json:"-"`
 	Children []Child  `gorm:"foreignKey:FamilyID" json:"-"`
 }
 
+// RequiredWithdrawalApprovals returns how many distinct parents must approve a withdrawal request
+// for amountCents: the family's quorum if the amount is above its threshold, otherwise one.
+func (f *Family) RequiredWithdrawalApprovals(amountCents int) int {
+	if f.WithdrawalQuorumThresholdCents != nil && amountCents > *f.WithdrawalQuorumThresholdCents {
+		return f.WithdrawalQuorumApprovals
+	}
+	return 1
+}
+
 func (Family) TableName() string {
 	return "families"
 }
@@ -3,14 +3,83 @@ package models
 import "time"
 
 // GoalAllocation represents an audit trail entry for goal fund movements.
+// Allocations made automatically by a GoalAllocationRule record the rule and the incoming transaction.
 type GoalAllocation struct {
-	ID          int64     `gorm:"primaryKey" json:"id"`
-	GoalID      int64     `gorm:"not null" json:"goal_id"`
-	ChildID     int64     `gorm:"not null" json:"child_id"`
-	AmountCents int64     `gorm:"not null" json:"amount_cents"`
-	CreatedAt   time.Time `gorm:"autoCreateTime" json:"created_at"`
+	ID            int64     `gorm:"primaryKey" json:"id"`
+	GoalID        int64     `gorm:"not null" json:"goal_id"`
+	ChildID       int64     `gorm:"not null" json:"child_id"`
+	AmountCents   int64     `gorm:"not null" json:"amount_cents"`
+	RuleID        *int64    `json:"rule_id,omitempty"`
+	TransactionID *int64    `json:"transaction_id,omitempty"`
+	CreatedAt     time.Time `gorm:"autoCreateTime" json:"created_at"`
 
 	// Associations
 	SavingsGoal SavingsGoal `gorm:"foreignKey:GoalID" json:"-"`
 	Child       Child       `gorm:"foreignKey:ChildID" json:"-"`
 }
+
+// AllocationSource is the kind of incoming money a GoalAllocationRule applies to.
+type AllocationSource string
+
+const (
+	AllocationSourceAllowance AllocationSource = "allowance"
+	AllocationSourceChore     AllocationSource = "chore"
+	AllocationSourceInterest  AllocationSource = "interest"
+	AllocationSourceDeposit   AllocationSource = "deposit"
+)
+
+// AllocationSourceFor returns the allocation source of a transaction type, or "" if incoming
+// money of that type is never allocated by rules.
+func AllocationSourceFor(t TransactionType) AllocationSource {
+	switch t {
+	case TransactionTypeAllowance:
+		return AllocationSourceAllowance
+	case TransactionTypeChore:
+		return AllocationSourceChore
+	case TransactionTypeInterest:
+		return AllocationSourceInterest
+	case TransactionTypeDeposit:
+		return AllocationSourceDeposit
+	}
+	return ""
+}
+
+// AllocationRuleKind is how a GoalAllocationRule decides how much to allocate.
+type AllocationRuleKind string
+
+const (
+	// AllocationRulePercent allocates Percent of the incoming amount.
+	AllocationRulePercent AllocationRuleKind = "percent"
+	// AllocationRuleRoundUp allocates the spare change that rounds the incoming amount up to a
+	// multiple of RoundToCents, out of what earlier splits and rules left of the incoming amount.
+	AllocationRuleRoundUp AllocationRuleKind = "round_up"
+)
+
+// GoalAllocationRule is a child's standing instruction to move part of their incoming money of
+// one source into a savings goal as soon as it is posted.
+type GoalAllocationRule struct {
+	ID           int64              `gorm:"primaryKey" json:"id"`
+	ChildID      int64              `gorm:"not null" json:"child_id"`
+	GoalID       int64              `gorm:"not null" json:"goal_id"`
+	Source       AllocationSource   `gorm:"not null" json:"source"`
+	Kind         AllocationRuleKind `gorm:"not null" json:"kind"`
+	Percent      *int               `json:"percent,omitempty"`
+	RoundToCents *int               `json:"round_to_cents,omitempty"`
+	CreatedAt    time.Time          `gorm:"autoCreateTime" json:"created_at"`
+}
+
+// Share returns how much of an incoming amount the rule allocates, before any cap.
+func (r *GoalAllocationRule) Share(amountCents int64) int64 {
+	switch r.Kind {
+	case AllocationRulePercent:
+		if r.Percent != nil {
+			return amountCents * int64(*r.Percent) / 100
+		}
+	case AllocationRuleRoundUp:
+		if r.RoundToCents != nil && *r.RoundToCents > 0 {
+			unit := int64(*r.RoundToCents)
+			return (unit - amountCents%unit) % unit
+		}
+	}
+	return 0
+}
@@ -10,6 +10,7 @@ type InterestSchedule struct {
 	Frequency  Frequency      `gorm:"not null" json:"frequency"`
 	DayOfWeek  *int           `json:"day_of_week,omitempty"`
 	DayOfMonth *int           `json:"day_of_month,omitempty"`
+	RRule      *string        `gorm:"column:rrule" json:"rrule,omitempty"`
 	Status     ScheduleStatus `gorm:"not null;default:active" json:"status"`
 	NextRunAt  *time.Time     `json:"next_run_at,omitempty"`
 	CreatedAt  time.Time      `gorm:"autoCreateTime" json:"created_at"`
@@ -0,0 +1,29 @@
+package models
+
+import "time"
+
+// NotificationKind identifies what a notification is about.
+type NotificationKind string
+
+const (
+	// NotificationKindWithdrawalReminder reminds parents of a pending withdrawal request.
+	NotificationKindWithdrawalReminder NotificationKind = "withdrawal_request_reminder"
+	// NotificationKindWithdrawalFinalReminder warns parents a pending withdrawal request is about to expire.
+	NotificationKindWithdrawalFinalReminder NotificationKind = "withdrawal_request_final_reminder"
+	// NotificationKindWithdrawalExpired tells a child their withdrawal request expired unanswered.
+	NotificationKindWithdrawalExpired NotificationKind = "withdrawal_request_expired"
+)
+
+// Notification is an in-app message for one parent or one child; exactly one of ParentID and
+// ChildID is set. ReadAt is nil until the recipient marks it read.
+type Notification struct {
+	ID                  int64            `gorm:"primaryKey" json:"id"`
+	FamilyID            int64            `gorm:"not null" json:"family_id"`
+	ParentID            *int64           `json:"parent_id,omitempty"`
+	ChildID             *int64           `json:"child_id,omitempty"`
+	Kind                NotificationKind `gorm:"not null" json:"kind"`
+	Message             string           `gorm:"not null" json:"message"`
+	WithdrawalRequestID *int64           `json:"withdrawal_request_id,omitempty"`
+	ReadAt              *time.Time       `json:"read_at,omitempty"`
+	CreatedAt           time.Time        `gorm:"autoCreateTime" json:"created_at"`
+}
@@ -2,18 +2,23 @@ package models
 
 import "time"
 
-// SavingsGoal represents a child's savings target.
+// SavingsGoal represents a child's savings target. Status is active, completed once SavedCents
+// reaches TargetCents, or purchased once the saved funds have been spent by
+// PurchaseTransactionID. TargetDate optionally sets the date the child aims to reach the target by.
 type SavingsGoal struct {
-	ID          int64      `gorm:"primaryKey" json:"id"`
-	ChildID     int64      `gorm:"not null" json:"child_id"`
-	Name        string     `gorm:"not null" json:"name"`
-	TargetCents int64      `gorm:"not null" json:"target_cents"`
-	SavedCents  int64      `gorm:"not null;default:0" json:"saved_cents"`
-	Emoji       *string    `json:"emoji,omitempty"`
-	Status      string     `gorm:"not null;default:active" json:"status"`
-	CompletedAt *time.Time `json:"completed_at,omitempty"`
-	CreatedAt   time.Time  `gorm:"autoCreateTime" json:"created_at"`
-	UpdatedAt   time.Time  `gorm:"autoUpdateTime" json:"updated_at"`
+	ID                    int64      `gorm:"primaryKey" json:"id"`
+	ChildID               int64      `gorm:"not null" json:"child_id"`
+	Name                  string     `gorm:"not null" json:"name"`
+	TargetCents           int64      `gorm:"not null" json:"target_cents"`
+	SavedCents            int64      `gorm:"not null;default:0" json:"saved_cents"`
+	Emoji                 *string    `json:"emoji,omitempty"`
+	Status                string     `gorm:"not null;default:active" json:"status"`
+	TargetDate            *time.Time `gorm:"type:date" json:"target_date,omitempty"`
+	CompletedAt           *time.Time `json:"completed_at,omitempty"`
+	PurchasedAt           *time.Time `json:"purchased_at,omitempty"`
+	PurchaseTransactionID *int64     `json:"purchase_transaction_id,omitempty"`
+	CreatedAt             time.Time  `gorm:"autoCreateTime" json:"created_at"`
+	UpdatedAt             time.Time  `gorm:"autoUpdateTime" json:"updated_at"`
 
 	// Associations
 	Child           Child            `gorm:"foreignKey:ChildID" json:"-"`
@@ -0,0 +1,42 @@
+package models
+
+import "time"
+
+// SharedGoal is a family-level savings goal that several children can allocate to. Each
+// contributor's share is tracked in a SharedGoalContribution and set aside from their own balance,
+// and SavedCents is the total of those shares. Status is active, completed once SavedCents reaches
+// TargetCents, or purchased once a parent has spent the contributions.
+type SharedGoal struct {
+	ID               int64      `gorm:"primaryKey" json:"id"`
+	FamilyID         int64      `gorm:"not null" json:"family_id"`
+	Name             string     `gorm:"not null" json:"name"`
+	TargetCents      int64      `gorm:"not null" json:"target_cents"`
+	SavedCents       int64      `gorm:"not null;default:0" json:"saved_cents"`
+	Emoji            *string    `json:"emoji,omitempty"`
+	Status           string     `gorm:"not null;default:active" json:"status"`
+	CreatedByChildID *int64     `json:"created_by_child_id,omitempty"`
+	CompletedAt      *time.Time `json:"completed_at,omitempty"`
+	PurchasedAt      *time.Time `json:"purchased_at,omitempty"`
+	CreatedAt        time.Time  `gorm:"autoCreateTime" json:"created_at"`
+	UpdatedAt        time.Time  `gorm:"autoUpdateTime" json:"updated_at"`
+
+	Contributions []SharedGoalContribution `gorm:"foreignKey:SharedGoalID" json:"contributions"`
+}
+
+// SharedGoalContribution is one child's tally towards a shared goal.
+type SharedGoalContribution struct {
+	SharedGoalID int64     `gorm:"primaryKey" json:"shared_goal_id"`
+	ChildID      int64     `gorm:"primaryKey" json:"child_id"`
+	ChildName    string    `gorm:"->" json:"child_name"`
+	SavedCents   int64     `gorm:"not null;default:0" json:"saved_cents"`
+	UpdatedAt    time.Time `gorm:"autoUpdateTime" json:"updated_at"`
+}
+
+// SharedGoalAllocation is an audit trail entry for a contributor's fund movement to or from a shared goal.
+type SharedGoalAllocation struct {
+	ID           int64     `gorm:"primaryKey" json:"id"`
+	SharedGoalID int64     `gorm:"not null" json:"shared_goal_id"`
+	ChildID      int64     `gorm:"not null" json:"child_id"`
+	AmountCents  int64     `gorm:"not null" json:"amount_cents"`
+	CreatedAt    time.Time `gorm:"autoCreateTime" json:"created_at"`
+}
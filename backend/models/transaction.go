@@ -15,6 +15,8 @@ const (
 	TransactionTypeInterest   TransactionType = "interest"
 	TransactionTypeChore             TransactionType = "chore"
 	TransactionTypeWithdrawalRequest TransactionType = "withdrawal_request"
+	// TransactionTypeChorePenalty is a deduction for a chore that expired unfinished.
+	TransactionTypeChorePenalty TransactionType = "chore_penalty"
 )
 
 // ErrInsufficientFunds is returned when a withdrawal exceeds the available balance.
@@ -10,9 +10,21 @@ const (
 	WithdrawalRequestStatusApproved  WithdrawalRequestStatus = "approved"
 	WithdrawalRequestStatusDenied    WithdrawalRequestStatus = "denied"
 	WithdrawalRequestStatusCancelled WithdrawalRequestStatus = "cancelled"
+	// WithdrawalRequestStatusExpired is a request no parent responded to within the family's withdrawal_expiry_days.
+	WithdrawalRequestStatusExpired WithdrawalRequestStatus = "expired"
+)
+
+// Reminder levels of a pending withdrawal request, escalating as it nears expiry.
+const (
+	WithdrawalReminderNone  = 0
+	WithdrawalReminderFirst = 1
+	WithdrawalReminderFinal = 2
 )
 
 // WithdrawalRequest represents a child's request to withdraw funds, subject to parent approval.
+// AutoApproved requests were approved by the child's WithdrawalPolicy and have no reviewing parent.
+// ReminderLevel is the most urgent reminder parents have been sent about the pending request.
+// A request with a SavingsGoalID redeems that completed goal, marking it purchased when approved.
 type WithdrawalRequest struct {
 	ID                 int64                   `gorm:"primaryKey" json:"id"`
 	ChildID            int64                   `gorm:"not null" json:"child_id"`
@@ -24,6 +36,10 @@ type WithdrawalRequest struct {
 	ReviewedByParentID *int64                  `json:"reviewed_by_parent_id,omitempty"`
 	ReviewedAt         *time.Time              `json:"reviewed_at,omitempty"`
 	TransactionID      *int64                  `json:"transaction_id,omitempty"`
+	AutoApproved       bool                    `gorm:"not null;default:false" json:"auto_approved"`
+	ReminderLevel      int                     `gorm:"not null;default:0" json:"reminder_level"`
+	ExpiredAt          *time.Time              `json:"expired_at,omitempty"`
+	SavingsGoalID      *int64                  `json:"savings_goal_id,omitempty"`
 	CreatedAt          time.Time               `gorm:"autoCreateTime" json:"created_at"`
 	UpdatedAt          time.Time               `gorm:"autoUpdateTime" json:"updated_at"`
 
@@ -32,3 +48,41 @@ type WithdrawalRequest struct {
 	Family      Family       `gorm:"foreignKey:FamilyID" json:"-"`
 	Transaction *Transaction `gorm:"foreignKey:TransactionID" json:"-"`
 }
+
+// WithdrawalPolicy lets a child's withdrawal requests of up to MaxAmountCents be approved without a
+// parent, as long as they leave savings goal allocations untouched. WeeklyCapCents, if set, limits
+// the total approved this way in any 7 days.
+type WithdrawalPolicy struct {
+	ID                int64     `gorm:"primaryKey" json:"id"`
+	ChildID           int64     `gorm:"not null" json:"child_id"`
+	FamilyID          int64     `gorm:"not null" json:"family_id"`
+	MaxAmountCents    int       `gorm:"not null" json:"max_amount_cents"`
+	WeeklyCapCents    *int      `json:"weekly_cap_cents,omitempty"`
+	UpdatedByParentID int64     `gorm:"not null" json:"updated_by_parent_id"`
+	CreatedAt         time.Time `gorm:"autoCreateTime" json:"created_at"`
+	UpdatedAt         time.Time `gorm:"autoUpdateTime" json:"updated_at"`
+}
+
+// WithdrawalPolicyApproval is the audit record of a withdrawal request approved by a policy, with
+// the limits in force at the time. WeekTotalCents is what the policy had approved in the 7 days
+// before, not counting this request.
+type WithdrawalPolicyApproval struct {
+	ID                  int64     `gorm:"primaryKey" json:"id"`
+	WithdrawalRequestID int64     `gorm:"not null" json:"withdrawal_request_id"`
+	PolicyID            *int64    `json:"policy_id,omitempty"`
+	ChildID             int64     `gorm:"not null" json:"child_id"`
+	AmountCents         int       `gorm:"not null" json:"amount_cents"`
+	MaxAmountCents      int       `gorm:"not null" json:"max_amount_cents"`
+	WeeklyCapCents      *int      `json:"weekly_cap_cents,omitempty"`
+	WeekTotalCents      int64     `gorm:"not null" json:"week_total_cents"`
+	CreatedAt           time.Time `gorm:"autoCreateTime" json:"created_at"`
+}
+
+// WithdrawalRequestApproval records one parent's approval of a withdrawal request. Requests above
+// the family's quorum threshold are only paid out once enough distinct parents have approved.
+type WithdrawalRequestApproval struct {
+	ID                  int64     `gorm:"primaryKey" json:"id"`
+	WithdrawalRequestID int64     `gorm:"not null" json:"withdrawal_request_id"`
+	ParentID            int64     `gorm:"not null" json:"parent_id"`
+	CreatedAt           time.Time `gorm:"autoCreateTime" json:"created_at"`
+}
@@ -2,6 +2,7 @@ package repositories
 
 import (
 	"fmt"
+	"time"
 
 	"bank-of-dad/models"
 
@@ -200,8 +201,23 @@ func (r *ChildRepo) UpdateAvatar(childID int64, avatar *string) error {
 	return nil
 }
 
+// UpdateBirthdate sets the child's birthdate (or clears it if birthdate is nil).
+func (r *ChildRepo) UpdateBirthdate(childID int64, birthdate *time.Time) error {
+	result := r.db.Exec(
+		`UPDATE children SET birthdate = ?, updated_at = NOW() WHERE id = ?`,
+		birthdate, childID,
+	)
+	if result.Error != nil {
+		return fmt.Errorf("update birthdate: %w", result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return fmt.Errorf("child not found")
+	}
+	return nil
+}
+
 // Delete permanently removes a child and all associated data in a single
-// atomic transaction.
+// atomic transaction. Their shared goal contributions are taken out of the goals' totals first.
 func (r *ChildRepo) Delete(id int64) error {
 	return r.db.Transaction(func(tx *gorm.DB) error {
 		if err := tx.Exec(`DELETE FROM refresh_tokens WHERE user_type = 'child' AND user_id = ?`, id).Error; err != nil {
@@ -210,6 +226,9 @@ func (r *ChildRepo) Delete(id int64) error {
 		if err := tx.Exec(`DELETE FROM auth_events WHERE user_type = 'child' AND user_id = ?`, id).Error; err != nil {
 			return fmt.Errorf("delete child auth events: %w", err)
 		}
+		if err := releaseChildContributions(tx, id); err != nil {
+			return err
+		}
 		if err := tx.Exec(`DELETE FROM children WHERE id = ?`, id).Error; err != nil {
 			return fmt.Errorf("delete child: %w", err)
 		}
@@ -8,11 +8,14 @@ import (
 	"bank-of-dad/models"
 
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 var (
 	ErrInvalidStatusTransition = errors.New("invalid status transition")
 	ErrInstanceNotFound        = errors.New("chore instance not found")
+	ErrBountyTaken             = errors.New("bounty already taken by a sibling")
+	ErrRedoLimitReached        = errors.New("redo limit reached")
 )
 
 // ChoreInstanceWithDetails extends ChoreInstance with joined chore fields.
@@ -20,6 +23,9 @@ type ChoreInstanceWithDetails struct {
 	models.ChoreInstance
 	ChoreName        string  `json:"chore_name" gorm:"column:chore_name"`
 	ChoreDescription *string `json:"chore_description" gorm:"column:chore_description"`
+	IsBounty         bool    `json:"is_bounty" gorm:"column:is_bounty"`
+	IsRotation       bool    `json:"is_rotation" gorm:"column:is_rotation"`
+	PenaltyCents     int     `json:"penalty_cents" gorm:"column:penalty_cents"`
 }
 
 // PendingChoreInstance extends ChoreInstance with joined chore and child name fields.
@@ -61,15 +67,16 @@ func (r *ChoreInstanceRepo) GetByID(id int64) (*models.ChoreInstance, error) {
 }
 
 // ListByChild returns non-expired instances for a child, grouped by status.
-// available = status 'available', pending = status 'pending_approval', completed = status 'approved'.
+// available = status 'available' or 'claimed', pending = status 'pending_approval', completed = status 'approved'.
+// Bounty instances taken by a sibling are left out.
 // Each group is ordered by created_at DESC. Includes chore name and description.
 func (r *ChoreInstanceRepo) ListByChild(childID int64) (available []ChoreInstanceWithDetails, pending []ChoreInstanceWithDetails, completed []ChoreInstanceWithDetails, err error) {
-	queryByStatus := func(status models.ChoreInstanceStatus) ([]ChoreInstanceWithDetails, error) {
+	queryByStatus := func(statuses ...models.ChoreInstanceStatus) ([]ChoreInstanceWithDetails, error) {
 		var results []ChoreInstanceWithDetails
 		err := r.db.Table("chore_instances").
-			Select("chore_instances.*, chores.name as chore_name, chores.description as chore_description").
+			Select("chore_instances.*, chores.name as chore_name, chores.description as chore_description, chores.is_bounty, chores.penalty_cents").
 			Joins("JOIN chores ON chores.id = chore_instances.chore_id").
-			Where("chore_instances.child_id = ? AND chore_instances.status = ?", childID, status).
+			Where("chore_instances.child_id = ? AND chore_instances.status IN ?", childID, statuses).
 			Order("chore_instances.created_at DESC").
 			Find(&results).Error
 		if err != nil {
@@ -78,7 +85,7 @@ func (r *ChoreInstanceRepo) ListByChild(childID int64) (available []ChoreInstanc
 		return results, nil
 	}
 
-	available, err = queryByStatus(models.ChoreInstanceStatusAvailable)
+	available, err = queryByStatus(models.ChoreInstanceStatusAvailable, models.ChoreInstanceStatusClaimed)
 	if err != nil {
 		return nil, nil, nil, fmt.Errorf("list available instances: %w", err)
 	}
@@ -94,6 +101,22 @@ func (r *ChoreInstanceRepo) ListByChild(childID int64) (available []ChoreInstanc
 	return available, pending, completed, nil
 }
 
+// ListPeriodsByChild returns a child's instances of recurring chores, ordered by chore and then
+// by period_start ASC, for computing streaks. Includes chore name and description.
+func (r *ChoreInstanceRepo) ListPeriodsByChild(childID int64) ([]ChoreInstanceWithDetails, error) {
+	var results []ChoreInstanceWithDetails
+	err := r.db.Table("chore_instances").
+		Select("chore_instances.*, chores.name as chore_name, chores.description as chore_description, chores.is_bounty, chores.is_rotation, chores.penalty_cents").
+		Joins("JOIN chores ON chores.id = chore_instances.chore_id").
+		Where("chore_instances.child_id = ? AND chore_instances.period_start IS NOT NULL", childID).
+		Order("chore_instances.chore_id ASC, chore_instances.period_start ASC").
+		Find(&results).Error
+	if err != nil {
+		return nil, fmt.Errorf("list chore periods by child: %w", err)
+	}
+	return results, nil
+}
+
 // ListPendingByFamily returns all pending_approval instances for children in a given family.
 // Includes chore name and child name. Ordered by completed_at ASC (oldest first).
 func (r *ChoreInstanceRepo) ListPendingByFamily(familyID int64) ([]PendingChoreInstance, error) {
@@ -111,15 +134,24 @@ func (r *ChoreInstanceRepo) ListPendingByFamily(familyID int64) ([]PendingChoreI
 	return results, nil
 }
 
+// completedLate flags a completion made after the instance's due_at.
+var completedLate = gorm.Expr("due_at IS NOT NULL AND NOW() > due_at")
+
 // MarkComplete transitions an instance from 'available' to 'pending_approval'.
 // Sets completed_at to NOW() and clears rejection_reason.
 // Verifies the instance belongs to the specified child and is currently 'available'.
 func (r *ChoreInstanceRepo) MarkComplete(instanceID, childID int64) error {
-	result := r.db.Model(&models.ChoreInstance{}).
+	return markComplete(r.db, instanceID, childID)
+}
+
+// markComplete is MarkComplete within db.
+func markComplete(db *gorm.DB, instanceID, childID int64) error {
+	result := db.Model(&models.ChoreInstance{}).
 		Where("id = ? AND child_id = ? AND status = ?", instanceID, childID, "available").
 		Updates(map[string]interface{}{
 			"status":           models.ChoreInstanceStatusPendingApproval,
 			"completed_at":     gorm.Expr("NOW()"),
+			"completed_late":   completedLate,
 			"rejection_reason": nil,
 			"updated_at":       gorm.Expr("NOW()"),
 		})
@@ -136,52 +168,197 @@ func (r *ChoreInstanceRepo) MarkComplete(instanceID, childID int64) error {
 // Sets reviewed_at to NOW(), reviewed_by_parent_id, and transaction_id.
 // Verifies the instance is currently 'pending_approval'.
 func (r *ChoreInstanceRepo) Approve(instanceID int64, parentID int64, transactionID *int64) error {
-	result := r.db.Model(&models.ChoreInstance{}).
-		Where("id = ? AND status = ?", instanceID, "pending_approval").
-		Updates(map[string]interface{}{
-			"status":               models.ChoreInstanceStatusApproved,
-			"reviewed_at":          gorm.Expr("NOW()"),
-			"reviewed_by_parent_id": parentID,
-			"transaction_id":       transactionID,
-			"updated_at":           gorm.Expr("NOW()"),
-		})
-	if result.Error != nil {
-		return fmt.Errorf("approve chore instance: %w", result.Error)
+	return r.ApproveWithReview(instanceID, parentID, transactionID, nil)
+}
+
+// ChoreReview is a parent's assessment of a chore given on approval.
+type ChoreReview struct {
+	Rating        *int
+	PayoutPercent *int
+	BonusCents    int
+	PaidCents     int
+}
+
+// ApproveWithReview is Approve that also records the parent's rating and what the child was paid.
+// A nil review approves at the full reward.
+func (r *ChoreInstanceRepo) ApproveWithReview(instanceID int64, parentID int64, transactionID *int64, review *ChoreReview) error {
+	updates := approvalUpdates(parentID, review)
+	updates["transaction_id"] = transactionID
+	return r.reviewRound(instanceID, parentID, models.ChoreReviewOutcomeApproved, "", updates, nil)
+}
+
+// ApproveAndPay approves a 'pending_approval' instance and deposits review.PaidCents as a chore
+// transaction in one atomic operation, so an instance is never paid twice.
+// Returns the deposit's transaction ID (nil if nothing was paid) and the child's new balance.
+func (r *ChoreInstanceRepo) ApproveAndPay(instanceID int64, parentID int64, note string, review *ChoreReview) (*int64, int64, error) {
+	updates := approvalUpdates(parentID, review)
+	var transactionID *int64
+	var newBalance int64
+
+	pay := func(tx *gorm.DB, instance *models.ChoreInstance) error {
+		var child models.Child
+		err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			Select("id, balance_cents").
+			First(&child, instance.ChildID).Error
+		if err != nil {
+			return fmt.Errorf("get current balance: %w", err)
+		}
+		newBalance = child.BalanceCents
+		if review.PaidCents <= 0 {
+			return nil
+		}
+
+		amount := int64(review.PaidCents)
+		transaction := models.Transaction{
+			ChildID:         instance.ChildID,
+			ParentID:        parentID,
+			AmountCents:     amount,
+			TransactionType: models.TransactionTypeChore,
+			Note:            nullableString(note),
+		}
+		if err := tx.Create(&transaction).Error; err != nil {
+			return fmt.Errorf("insert transaction: %w", err)
+		}
+		if err := tx.Exec(
+			`UPDATE children SET balance_cents = balance_cents + ?, updated_at = NOW() WHERE id = ?`,
+			amount, instance.ChildID,
+		).Error; err != nil {
+			return fmt.Errorf("update balance: %w", err)
+		}
+		if err := applyAllocationRules(tx, &transaction); err != nil {
+			return err
+		}
+		newBalance += amount
+		transactionID = &transaction.ID
+		updates["transaction_id"] = transaction.ID
+		return nil
 	}
-	if result.RowsAffected == 0 {
-		return ErrInvalidStatusTransition
+
+	if err := r.reviewRound(instanceID, parentID, models.ChoreReviewOutcomeApproved, "", updates, pay); err != nil {
+		return nil, 0, err
 	}
-	return nil
+	return transactionID, newBalance, nil
+}
+
+// approvalUpdates returns the columns set when an instance is approved with the given review.
+func approvalUpdates(parentID int64, review *ChoreReview) map[string]interface{} {
+	updates := map[string]interface{}{
+		"status":                models.ChoreInstanceStatusApproved,
+		"reviewed_at":           gorm.Expr("NOW()"),
+		"reviewed_by_parent_id": parentID,
+	}
+	if review != nil {
+		updates["rating"] = review.Rating
+		updates["payout_percent"] = review.PayoutPercent
+		updates["bonus_cents"] = review.BonusCents
+		updates["paid_cents"] = review.PaidCents
+	}
+	return updates
 }
 
 // Reject transitions an instance from 'pending_approval' back to 'available'.
 // Sets reviewed_at to NOW(), reviewed_by_parent_id, rejection_reason, and clears completed_at.
 // Verifies the instance is currently 'pending_approval'.
 func (r *ChoreInstanceRepo) Reject(instanceID int64, parentID int64, reason string) error {
-	result := r.db.Model(&models.ChoreInstance{}).
-		Where("id = ? AND status = ?", instanceID, "pending_approval").
-		Updates(map[string]interface{}{
-			"status":               models.ChoreInstanceStatusAvailable,
-			"reviewed_at":          gorm.Expr("NOW()"),
-			"reviewed_by_parent_id": parentID,
-			"rejection_reason":     reason,
-			"completed_at":         nil,
-			"updated_at":           gorm.Expr("NOW()"),
-		})
-	if result.Error != nil {
-		return fmt.Errorf("reject chore instance: %w", result.Error)
+	updates := map[string]interface{}{
+		"status":                models.ChoreInstanceStatusAvailable,
+		"reviewed_at":           gorm.Expr("NOW()"),
+		"reviewed_by_parent_id": parentID,
+		"rejection_reason":      reason,
+		"completed_at":          nil,
+		"completed_late":        false,
 	}
-	if result.RowsAffected == 0 {
-		return ErrInvalidStatusTransition
+	return r.reviewRound(instanceID, parentID, models.ChoreReviewOutcomeRejected, reason, updates, nil)
+}
+
+// RequestRedo sends an instance from 'pending_approval' back to 'available' for the child to try
+// again, with the parent's feedback in rejection_reason, and counts the redo.
+// Returns ErrRedoLimitReached if the instance was already sent back maxRedos times.
+func (r *ChoreInstanceRepo) RequestRedo(instanceID int64, parentID int64, feedback string, maxRedos *int) error {
+	updates := map[string]interface{}{
+		"status":                models.ChoreInstanceStatusAvailable,
+		"reviewed_at":           gorm.Expr("NOW()"),
+		"reviewed_by_parent_id": parentID,
+		"rejection_reason":      feedback,
+		"completed_at":          nil,
+		"completed_late":        false,
+		"redo_count":            gorm.Expr("redo_count + 1"),
 	}
-	return nil
+	check := func(tx *gorm.DB, instance *models.ChoreInstance) error {
+		if maxRedos != nil && instance.RedoCount >= *maxRedos {
+			return ErrRedoLimitReached
+		}
+		return nil
+	}
+	return r.reviewRound(instanceID, parentID, models.ChoreReviewOutcomeRedo, feedback, updates, check)
+}
+
+// reviewRound closes the current submit/review round of a 'pending_approval' instance: it records the
+// round in the instance's history and applies updates, atomically. before, if set, runs first in the
+// same transaction and can veto the review or make changes of its own.
+// Returns ErrInvalidStatusTransition if the instance is not pending approval.
+func (r *ChoreInstanceRepo) reviewRound(instanceID, parentID int64, outcome models.ChoreReviewOutcome, feedback string, updates map[string]interface{}, before func(tx *gorm.DB, instance *models.ChoreInstance) error) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		var instance models.ChoreInstance
+		err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			Where("id = ? AND status = ?", instanceID, models.ChoreInstanceStatusPendingApproval).
+			First(&instance).Error
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return ErrInvalidStatusTransition
+		}
+		if err != nil {
+			return fmt.Errorf("lock chore instance: %w", err)
+		}
+		if before != nil {
+			if err := before(tx, &instance); err != nil {
+				return err
+			}
+		}
+
+		var lastRound int
+		err = tx.Model(&models.ChoreReviewRound{}).
+			Select("COALESCE(MAX(round), 0)").
+			Where("chore_instance_id = ?", instanceID).
+			Scan(&lastRound).Error
+		if err != nil {
+			return fmt.Errorf("get last review round: %w", err)
+		}
+		round := &models.ChoreReviewRound{
+			ChoreInstanceID:    instanceID,
+			Round:              lastRound + 1,
+			SubmittedAt:        instance.CompletedAt,
+			Outcome:            outcome,
+			Feedback:           nullableString(feedback),
+			ReviewedByParentID: parentID,
+		}
+		if err := tx.Create(round).Error; err != nil {
+			return fmt.Errorf("insert review round: %w", err)
+		}
+
+		updates["updated_at"] = gorm.Expr("NOW()")
+		if err := tx.Model(&models.ChoreInstance{}).Where("id = ?", instanceID).Updates(updates).Error; err != nil {
+			return fmt.Errorf("update chore instance: %w", err)
+		}
+		return nil
+	})
+}
+
+// ListReviewRounds returns an instance's submit/review history, oldest round first.
+func (r *ChoreInstanceRepo) ListReviewRounds(instanceID int64) ([]models.ChoreReviewRound, error) {
+	var rounds []models.ChoreReviewRound
+	err := r.db.Where("chore_instance_id = ?", instanceID).Order("round ASC").Find(&rounds).Error
+	if err != nil {
+		return nil, fmt.Errorf("list review rounds: %w", err)
+	}
+	return rounds, nil
 }
 
 // ExistsForPeriod checks if an instance already exists for the given chore, child, and period_start.
+// periodStart is compared by its date in its own location, as it is stored.
 func (r *ChoreInstanceRepo) ExistsForPeriod(choreID, childID int64, periodStart time.Time) (bool, error) {
 	var count int64
 	err := r.db.Model(&models.ChoreInstance{}).
-		Where("chore_id = ? AND child_id = ? AND period_start = ?", choreID, childID, periodStart).
+		Where("chore_id = ? AND child_id = ? AND period_start = ?", choreID, childID, periodStart.Format(time.DateOnly)).
 		Count(&count).Error
 	if err != nil {
 		return false, fmt.Errorf("check instance exists for period: %w", err)
@@ -189,14 +366,54 @@ func (r *ChoreInstanceRepo) ExistsForPeriod(choreID, childID int64, periodStart
 	return count > 0, nil
 }
 
+// ExistsForChorePeriod checks if any child already has an instance of the chore for the given period_start.
+// periodStart is compared by its date in its own location, as it is stored.
+func (r *ChoreInstanceRepo) ExistsForChorePeriod(choreID int64, periodStart time.Time) (bool, error) {
+	var count int64
+	err := r.db.Model(&models.ChoreInstance{}).
+		Where("chore_id = ? AND period_start = ?", choreID, periodStart.Format(time.DateOnly)).
+		Count(&count).Error
+	if err != nil {
+		return false, fmt.Errorf("check chore period exists: %w", err)
+	}
+	return count > 0, nil
+}
+
+// CountApprovedInRange returns how many of a child's chore instances belonging to [start, end)
+// were approved, along with the total number of those instances. A recurring instance belongs to
+// the range if its period ends within it; start and end are local midnights in the family's
+// timezone, compared as dates. A one-time instance belongs to the range if it was created within it.
+// Instances still pending approval are left out, as are bounty instances taken by a sibling.
+func (r *ChoreInstanceRepo) CountApprovedInRange(childID int64, start, end time.Time) (approved int64, total int64, err error) {
+	type countResult struct {
+		Approved int64 `gorm:"column:approved"`
+		Total    int64 `gorm:"column:total"`
+	}
+	var res countResult
+	err = r.db.Table("chore_instances").
+		Select("COUNT(*) FILTER (WHERE status = ?) as approved, COUNT(*) as total", models.ChoreInstanceStatusApproved).
+		Where("child_id = ? AND status NOT IN ?", childID,
+			[]models.ChoreInstanceStatus{models.ChoreInstanceStatusTaken, models.ChoreInstanceStatusPendingApproval}).
+		Where("(period_end IS NOT NULL AND period_end >= ? AND period_end < ?) OR (period_end IS NULL AND created_at >= ? AND created_at < ?)",
+			start.Format(time.DateOnly), end.Format(time.DateOnly), start, end).
+		Scan(&res).Error
+	if err != nil {
+		return 0, 0, fmt.Errorf("count approved instances in range: %w", err)
+	}
+	return res.Approved, res.Total, nil
+}
+
 // ChoreEarning represents a single earning from a completed chore.
 type ChoreEarning struct {
-	ChoreName  string    `json:"chore_name" gorm:"column:chore_name"`
-	RewardCents int      `json:"reward_cents" gorm:"column:reward_cents"`
-	ApprovedAt time.Time `json:"approved_at" gorm:"column:approved_at"`
+	ChoreName   string    `json:"chore_name" gorm:"column:chore_name"`
+	RewardCents int       `json:"reward_cents" gorm:"column:reward_cents"`
+	PaidCents   int       `json:"paid_cents" gorm:"column:paid_cents"`
+	Rating      *int      `json:"rating,omitempty" gorm:"column:rating"`
+	ApprovedAt  time.Time `json:"approved_at" gorm:"column:approved_at"`
 }
 
 // GetEarnings returns chore earnings for a child: total earned, count completed, and recent approved instances.
+// Earnings are what the child was actually paid, including partial payouts and bonuses.
 func (r *ChoreInstanceRepo) GetEarnings(childID int64, recentLimit int) (totalCents int64, completedCount int64, recent []ChoreEarning, err error) {
 	// Aggregate totals
 	type aggregateResult struct {
@@ -205,7 +422,7 @@ func (r *ChoreInstanceRepo) GetEarnings(childID int64, recentLimit int) (totalCe
 	}
 	var agg aggregateResult
 	err = r.db.Table("chore_instances").
-		Select("COALESCE(SUM(reward_cents), 0) as total_cents, COUNT(*) as completed_count").
+		Select("COALESCE(SUM(COALESCE(paid_cents, reward_cents)), 0) as total_cents, COUNT(*) as completed_count").
 		Where("child_id = ? AND status = ?", childID, "approved").
 		Scan(&agg).Error
 	if err != nil {
@@ -214,7 +431,7 @@ func (r *ChoreInstanceRepo) GetEarnings(childID int64, recentLimit int) (totalCe
 
 	// Recent approved instances
 	err = r.db.Table("chore_instances").
-		Select("chores.name as chore_name, chore_instances.reward_cents, chore_instances.reviewed_at as approved_at").
+		Select("chores.name as chore_name, chore_instances.reward_cents, COALESCE(chore_instances.paid_cents, chore_instances.reward_cents) as paid_cents, chore_instances.rating, chore_instances.reviewed_at as approved_at").
 		Joins("JOIN chores ON chores.id = chore_instances.chore_id").
 		Where("chore_instances.child_id = ? AND chore_instances.status = ?", childID, "approved").
 		Order("chore_instances.reviewed_at DESC").
@@ -227,6 +444,95 @@ func (r *ChoreInstanceRepo) GetEarnings(childID int64, recentLimit int) (totalCe
 	return agg.TotalCents, agg.CompletedCount, recent, nil
 }
 
+// RatingSummary returns the average quality rating of a child's approved chores and how many were
+// rated. The average is nil if none were.
+func (r *ChoreInstanceRepo) RatingSummary(childID int64) (average *float64, rated int64, err error) {
+	type summaryResult struct {
+		Average *float64 `gorm:"column:average"`
+		Rated   int64    `gorm:"column:rated"`
+	}
+	var res summaryResult
+	err = r.db.Table("chore_instances").
+		Select("AVG(rating)::float8 as average, COUNT(rating) as rated").
+		Where("child_id = ? AND status = ?", childID, models.ChoreInstanceStatusApproved).
+		Scan(&res).Error
+	if err != nil {
+		return nil, 0, fmt.Errorf("get chore rating summary: %w", err)
+	}
+	return res.Average, res.Rated, nil
+}
+
+// ChoreStats summarizes one child's instances of one chore over a date range.
+// Approvals, rejections and redos count review rounds, so an instance rejected once and then
+// approved counts once in each. AvgSecondsToComplete is nil if no instance was completed.
+type ChoreStats struct {
+	ChoreID              int64    `json:"chore_id" gorm:"column:chore_id"`
+	ChoreName            string   `json:"chore_name" gorm:"column:chore_name"`
+	ChildID              int64    `json:"child_id,omitempty" gorm:"column:child_id"`
+	ChildName            string   `json:"child_name,omitempty" gorm:"column:child_name"`
+	RewardCents          int      `json:"reward_cents" gorm:"column:reward_cents"`
+	InstanceCount        int64    `json:"instance_count" gorm:"column:instance_count"`
+	ApprovedCount        int64    `json:"approved_count" gorm:"column:approved_count"`
+	ExpiredCount         int64    `json:"expired_count" gorm:"column:expired_count"`
+	PendingCount         int64    `json:"pending_count" gorm:"column:pending_count"`
+	CompletedCount       int64    `json:"completed_count" gorm:"column:completed_count"`
+	AvgSecondsToComplete *float64 `json:"avg_seconds_to_complete,omitempty" gorm:"column:avg_seconds_to_complete"`
+	Approvals            int64    `json:"approvals" gorm:"column:approvals"`
+	Rejections           int64    `json:"rejections" gorm:"column:rejections"`
+	Redos                int64    `json:"redos" gorm:"column:redos"`
+	EarnedCents          int64    `json:"earned_cents" gorm:"column:earned_cents"`
+}
+
+// ListChoreStats returns per-chore, per-child statistics for a family's chore instances created in
+// [from, to), optionally narrowed to one child or one chore. Bounty instances taken by a sibling are
+// not counted. Ordered by chore name, then child name.
+func (r *ChoreInstanceRepo) ListChoreStats(familyID int64, from, to time.Time, childID, choreID *int64) ([]ChoreStats, error) {
+	query := r.db.Table("chore_instances ci").
+		Select(`ci.chore_id, c.name as chore_name, ci.child_id, ch.first_name as child_name, c.reward_cents,
+			COUNT(*) as instance_count,
+			COUNT(*) FILTER (WHERE ci.status = ?) as approved_count,
+			COUNT(*) FILTER (WHERE ci.status = ?) as expired_count,
+			COUNT(*) FILTER (WHERE ci.status = ?) as pending_count,
+			COUNT(ci.completed_at) as completed_count,
+			(AVG(EXTRACT(EPOCH FROM ci.completed_at - ci.created_at)) FILTER (WHERE ci.completed_at IS NOT NULL))::float8 as avg_seconds_to_complete,
+			COALESCE(SUM(rr.approvals), 0) as approvals,
+			COALESCE(SUM(rr.rejections), 0) as rejections,
+			COALESCE(SUM(rr.redos), 0) as redos,
+			COALESCE(SUM(COALESCE(ci.paid_cents, ci.reward_cents)) FILTER (WHERE ci.status = ?), 0) as earned_cents`,
+			models.ChoreInstanceStatusApproved,
+			models.ChoreInstanceStatusExpired,
+			models.ChoreInstanceStatusPendingApproval,
+			models.ChoreInstanceStatusApproved).
+		Joins("JOIN chores c ON c.id = ci.chore_id").
+		Joins("JOIN children ch ON ch.id = ci.child_id").
+		Joins(`LEFT JOIN LATERAL (
+			SELECT COUNT(*) FILTER (WHERE outcome = ?) as approvals,
+				COUNT(*) FILTER (WHERE outcome = ?) as rejections,
+				COUNT(*) FILTER (WHERE outcome = ?) as redos
+			FROM chore_review_rounds WHERE chore_instance_id = ci.id
+		) rr ON true`,
+			models.ChoreReviewOutcomeApproved,
+			models.ChoreReviewOutcomeRejected,
+			models.ChoreReviewOutcomeRedo).
+		Where("c.family_id = ? AND ci.created_at >= ? AND ci.created_at < ? AND ci.status <> ?", familyID, from, to, models.ChoreInstanceStatusTaken)
+	if childID != nil {
+		query = query.Where("ci.child_id = ?", *childID)
+	}
+	if choreID != nil {
+		query = query.Where("ci.chore_id = ?", *choreID)
+	}
+
+	var stats []ChoreStats
+	err := query.
+		Group("ci.chore_id, c.name, c.reward_cents, ci.child_id, ch.first_name").
+		Order("c.name, ch.first_name").
+		Scan(&stats).Error
+	if err != nil {
+		return nil, fmt.Errorf("list chore stats: %w", err)
+	}
+	return stats, nil
+}
+
 // ListCompletedByFamily returns approved chore instances for a family with pagination.
 // Includes chore name and child name. Ordered by reviewed_at DESC (most recent first).
 // Returns instances for the current page and the total count of completed instances.
@@ -257,6 +563,25 @@ func (r *ChoreInstanceRepo) ListCompletedByFamily(familyID int64, limit, offset
 	return results, total, nil
 }
 
+// ListOpenByFamilyDue returns a family's available, claimed and pending_approval instances whose
+// period ends on a date in [from, to). Includes chore name and child name. Ordered by period_end ASC.
+func (r *ChoreInstanceRepo) ListOpenByFamilyDue(familyID int64, from, to time.Time) ([]PendingChoreInstance, error) {
+	var results []PendingChoreInstance
+	err := r.db.Table("chore_instances").
+		Select("chore_instances.*, chores.name as chore_name, children.first_name as child_name").
+		Joins("JOIN chores ON chores.id = chore_instances.chore_id").
+		Joins("JOIN children ON children.id = chore_instances.child_id").
+		Where("children.family_id = ? AND chore_instances.status IN ?", familyID,
+			[]models.ChoreInstanceStatus{models.ChoreInstanceStatusAvailable, models.ChoreInstanceStatusClaimed, models.ChoreInstanceStatusPendingApproval}).
+		Where("chore_instances.period_end >= ? AND chore_instances.period_end < ?", from.Format(time.DateOnly), to.Format(time.DateOnly)).
+		Order("chore_instances.period_end ASC").
+		Find(&results).Error
+	if err != nil {
+		return nil, fmt.Errorf("list open instances by family: %w", err)
+	}
+	return results, nil
+}
+
 // DeleteByChoreID deletes all instances for a specific chore (used before chore deletion).
 func (r *ChoreInstanceRepo) DeleteByChoreID(choreID int64) error {
 	if err := r.db.Where("chore_id = ?", choreID).Delete(&models.ChoreInstance{}).Error; err != nil {
@@ -265,17 +590,460 @@ func (r *ChoreInstanceRepo) DeleteByChoreID(choreID int64) error {
 	return nil
 }
 
-// ExpireByPeriod updates all 'available' instances where period_end < before to 'expired'.
-// Returns the count of expired rows.
-func (r *ChoreInstanceRepo) ExpireByPeriod(before time.Time) (int64, error) {
-	result := r.db.Model(&models.ChoreInstance{}).
-		Where("status = ? AND period_end < ?", "available", before).
+// expirableStatuses are the statuses of instances that expire once their period ends.
+var expirableStatuses = []models.ChoreInstanceStatus{models.ChoreInstanceStatusAvailable, models.ChoreInstanceStatusClaimed}
+
+// ListExpirable returns the IDs of 'available' and 'claimed' instances whose period_end is before
+// before, oldest first.
+func (r *ChoreInstanceRepo) ListExpirable(before time.Time) ([]int64, error) {
+	var ids []int64
+	err := r.db.Model(&models.ChoreInstance{}).
+		Where("status IN ? AND period_end < ?", expirableStatuses, before).
+		Order("id").
+		Pluck("id", &ids).Error
+	if err != nil {
+		return nil, fmt.Errorf("list instances to expire: %w", err)
+	}
+	return ids, nil
+}
+
+// Expire marks an 'available' or 'claimed' instance whose period_end is before before as 'expired',
+// in its own transaction. If its chore has a penalty, it is deducted from the child's balance as a
+// chore_penalty transaction, never taking the balance below zero. Disabled children are not
+// penalized. Returns false if the instance is no longer open or its period has not ended.
+func (r *ChoreInstanceRepo) Expire(id int64, before time.Time) (bool, error) {
+	expired := false
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		var instance models.ChoreInstance
+		err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			Where("id = ? AND status IN ? AND period_end < ?", id, expirableStatuses, before).
+			Limit(1).
+			Find(&instance).Error
+		if err != nil {
+			return fmt.Errorf("lock instance to expire: %w", err)
+		}
+		if instance.ID == 0 {
+			return nil
+		}
+
+		err = tx.Model(&models.ChoreInstance{}).
+			Where("id = ?", id).
+			Updates(map[string]interface{}{
+				"status":     models.ChoreInstanceStatusExpired,
+				"updated_at": gorm.Expr("NOW()"),
+			}).Error
+		if err != nil {
+			return fmt.Errorf("expire chore instance: %w", err)
+		}
+		expired = true
+
+		var chore models.Chore
+		if err := tx.First(&chore, instance.ChoreID).Error; err != nil {
+			return fmt.Errorf("get chore for penalty: %w", err)
+		}
+		if chore.PenaltyCents > 0 {
+			return applyPenalty(tx, &instance, &chore)
+		}
+		return nil
+	})
+	if err != nil {
+		return false, fmt.Errorf("expire chore instance %d: %w", id, err)
+	}
+	return expired, nil
+}
+
+// applyPenalty deducts a chore's penalty for an expired instance, capped at the child's balance.
+// If the child's goals then hold more than the balance, they are reduced proportionally to match.
+func applyPenalty(tx *gorm.DB, instance *models.ChoreInstance, chore *models.Chore) error {
+	var child models.Child
+	err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+		Select("id, balance_cents, is_disabled").
+		First(&child, instance.ChildID).Error
+	if err != nil {
+		return fmt.Errorf("get child for penalty: %w", err)
+	}
+	amount := min(int64(chore.PenaltyCents), child.BalanceCents)
+	if child.IsDisabled || amount <= 0 {
+		return nil
+	}
+
+	transaction := models.Transaction{
+		ChildID:         instance.ChildID,
+		ParentID:        chore.CreatedByParentID,
+		AmountCents:     amount,
+		TransactionType: models.TransactionTypeChorePenalty,
+		Note:            nullableString("Missed chore: " + chore.Name),
+	}
+	if err := tx.Create(&transaction).Error; err != nil {
+		return fmt.Errorf("insert penalty transaction: %w", err)
+	}
+	if err := tx.Exec(
+		`UPDATE children SET balance_cents = balance_cents - ?, updated_at = NOW() WHERE id = ?`,
+		amount, instance.ChildID,
+	).Error; err != nil {
+		return fmt.Errorf("update balance: %w", err)
+	}
+	reserved, err := reservedCents(tx, instance.ChildID)
+	if err != nil {
+		return err
+	}
+	if newBalance := child.BalanceCents - amount; reserved > newBalance {
+		if err := reduceGoalsProportionally(tx, instance.ChildID, reserved-newBalance); err != nil {
+			return err
+		}
+	}
+	if err := tx.Model(&models.ChoreInstance{}).
+		Where("id = ?", instance.ID).
+		Update("penalty_transaction_id", transaction.ID).Error; err != nil {
+		return fmt.Errorf("link penalty transaction: %w", err)
+	}
+	return nil
+}
+
+// bountyPeriod scopes a query to the instances of one bounty period: those of the chore with the
+// same period_start (or none, for one-time chores).
+func bountyPeriod(db *gorm.DB, choreID int64, periodStart *time.Time) *gorm.DB {
+	db = db.Where("chore_id = ?", choreID)
+	if periodStart == nil {
+		return db.Where("period_start IS NULL")
+	}
+	return db.Where("period_start = ?", periodStart.Format(time.DateOnly))
+}
+
+// holdsBounty reports whether an instance keeps the bounty from its siblings at now.
+func holdsBounty(instance *models.ChoreInstance, now time.Time) bool {
+	switch instance.Status {
+	case models.ChoreInstanceStatusPendingApproval, models.ChoreInstanceStatusApproved:
+		return true
+	case models.ChoreInstanceStatusClaimed:
+		return instance.ClaimExpiresAt != nil && instance.ClaimExpiresAt.After(now)
+	}
+	return false
+}
+
+// takeBounty moves a child's bounty instance to status, applying updates, and marks the sibling
+// instances of the same period as taken. All of the period's instances are locked first, so
+// concurrent claims from siblings are serialized and only the first one wins. then, if set, runs
+// in the same transaction afterwards.
+// Returns ErrBountyTaken if a sibling already holds the bounty.
+func (r *ChoreInstanceRepo) takeBounty(instanceID, childID int64, status models.ChoreInstanceStatus, updates map[string]interface{}, then func(tx *gorm.DB) error) error {
+	instance, err := r.GetByID(instanceID)
+	if err != nil {
+		return err
+	}
+	if instance == nil || instance.ChildID != childID {
+		return ErrInvalidStatusTransition
+	}
+
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		var period []models.ChoreInstance
+		err := bountyPeriod(tx.Clauses(clause.Locking{Strength: "UPDATE"}), instance.ChoreID, instance.PeriodStart).
+			Order("id").
+			Find(&period).Error
+		if err != nil {
+			return fmt.Errorf("lock bounty instances: %w", err)
+		}
+
+		now := time.Now()
+		var own *models.ChoreInstance
+		for i := range period {
+			if period[i].ID == instanceID {
+				own = &period[i]
+				continue
+			}
+			if holdsBounty(&period[i], now) {
+				return ErrBountyTaken
+			}
+		}
+		if own == nil {
+			return ErrInvalidStatusTransition
+		}
+		switch own.Status {
+		case models.ChoreInstanceStatusAvailable, models.ChoreInstanceStatusTaken:
+			// Taken only by a sibling whose claim has lapsed
+		case models.ChoreInstanceStatusClaimed:
+			if status == models.ChoreInstanceStatusClaimed {
+				return ErrInvalidStatusTransition
+			}
+		default:
+			return ErrInvalidStatusTransition
+		}
+
+		updates["status"] = status
+		updates["updated_at"] = gorm.Expr("NOW()")
+		if err := tx.Model(&models.ChoreInstance{}).Where("id = ?", instanceID).Updates(updates).Error; err != nil {
+			return fmt.Errorf("take bounty: %w", err)
+		}
+
+		err = bountyPeriod(tx.Model(&models.ChoreInstance{}), instance.ChoreID, instance.PeriodStart).
+			Where("id <> ? AND status IN ?", instanceID,
+				[]models.ChoreInstanceStatus{models.ChoreInstanceStatusAvailable, models.ChoreInstanceStatusClaimed}).
+			Updates(map[string]interface{}{
+				"status":           models.ChoreInstanceStatusTaken,
+				"claimed_at":       nil,
+				"claim_expires_at": nil,
+				"updated_at":       gorm.Expr("NOW()"),
+			}).Error
+		if err != nil {
+			return fmt.Errorf("hide sibling bounty instances: %w", err)
+		}
+		if then != nil {
+			return then(tx)
+		}
+		return nil
+	})
+}
+
+// ClaimBounty reserves a child's bounty instance until expiresAt and hides the siblings' instances.
+// Returns ErrBountyTaken if a sibling already holds the bounty.
+func (r *ChoreInstanceRepo) ClaimBounty(instanceID, childID int64, expiresAt time.Time) error {
+	return r.takeBounty(instanceID, childID, models.ChoreInstanceStatusClaimed, map[string]interface{}{
+		"claimed_at":       gorm.Expr("NOW()"),
+		"claim_expires_at": expiresAt,
+	}, nil)
+}
+
+// CompleteBounty is MarkComplete for bounty instances: it moves a child's available or claimed
+// instance to 'pending_approval' and hides the siblings' instances.
+// Returns ErrBountyTaken if a sibling already holds the bounty.
+func (r *ChoreInstanceRepo) CompleteBounty(instanceID, childID int64) error {
+	return r.completeBounty(instanceID, childID, nil)
+}
+
+// completeBounty is CompleteBounty that runs then in the same transaction, if set.
+func (r *ChoreInstanceRepo) completeBounty(instanceID, childID int64, then func(tx *gorm.DB) error) error {
+	return r.takeBounty(instanceID, childID, models.ChoreInstanceStatusPendingApproval, map[string]interface{}{
+		"completed_at":     gorm.Expr("NOW()"),
+		"completed_late":   completedLate,
+		"claim_expires_at": nil,
+		"rejection_reason": nil,
+	}, then)
+}
+
+// CompleteWithPhotos is MarkComplete, or CompleteBounty for a bounty instance, that also replaces
+// the instance's photos with photos in the same transaction, so a completion is never left without
+// its proof. Returns the replaced photos; their stored files are the caller's to remove once the
+// completion has committed.
+func (r *ChoreInstanceRepo) CompleteWithPhotos(instanceID, childID int64, bounty bool, photos []models.ChorePhoto) ([]models.ChorePhoto, error) {
+	var replaced []models.ChorePhoto
+	replacePhotos := func(tx *gorm.DB) error {
+		if err := tx.Where("chore_instance_id = ?", instanceID).Order("id ASC").Find(&replaced).Error; err != nil {
+			return fmt.Errorf("list chore photos: %w", err)
+		}
+		if len(replaced) > 0 {
+			if err := tx.Where("chore_instance_id = ?", instanceID).Delete(&models.ChorePhoto{}).Error; err != nil {
+				return fmt.Errorf("delete chore photos: %w", err)
+			}
+		}
+		for i := range photos {
+			if err := tx.Create(&photos[i]).Error; err != nil {
+				return fmt.Errorf("insert chore photo: %w", err)
+			}
+		}
+		return nil
+	}
+
+	var err error
+	if bounty {
+		err = r.completeBounty(instanceID, childID, replacePhotos)
+	} else {
+		err = r.db.Transaction(func(tx *gorm.DB) error {
+			if err := markComplete(tx, instanceID, childID); err != nil {
+				return err
+			}
+			return replacePhotos(tx)
+		})
+	}
+	if err != nil {
+		return nil, err
+	}
+	return replaced, nil
+}
+
+// ReopenBounty makes the taken instances of a bounty period available again, e.g. after the
+// holder's completion was rejected.
+func (r *ChoreInstanceRepo) ReopenBounty(choreID int64, periodStart *time.Time) error {
+	err := bountyPeriod(r.db.Model(&models.ChoreInstance{}), choreID, periodStart).
+		Where("status = ?", models.ChoreInstanceStatusTaken).
 		Updates(map[string]interface{}{
-			"status":     models.ChoreInstanceStatusExpired,
+			"status":     models.ChoreInstanceStatusAvailable,
 			"updated_at": gorm.Expr("NOW()"),
+		}).Error
+	if err != nil {
+		return fmt.Errorf("reopen bounty: %w", err)
+	}
+	return nil
+}
+
+// ClearBounty returns a chore's claimed and taken instances to 'available', for when the chore
+// stops being a bounty. Instances of periods that ended before today are left alone.
+func (r *ChoreInstanceRepo) ClearBounty(choreID int64, today time.Time) error {
+	err := r.db.Model(&models.ChoreInstance{}).
+		Where("chore_id = ? AND status IN ?", choreID,
+			[]models.ChoreInstanceStatus{models.ChoreInstanceStatusClaimed, models.ChoreInstanceStatusTaken}).
+		Where("period_end IS NULL OR period_end >= ?", today.Format(time.DateOnly)).
+		Updates(map[string]interface{}{
+			"status":           models.ChoreInstanceStatusAvailable,
+			"claimed_at":       nil,
+			"claim_expires_at": nil,
+			"updated_at":       gorm.Expr("NOW()"),
+		}).Error
+	if err != nil {
+		return fmt.Errorf("clear bounty: %w", err)
+	}
+	return nil
+}
+
+// BountyHeld reports whether any instance of a bounty period holds the bounty at now.
+func (r *ChoreInstanceRepo) BountyHeld(choreID int64, periodStart *time.Time, now time.Time) (bool, error) {
+	var period []models.ChoreInstance
+	if err := bountyPeriod(r.db, choreID, periodStart).Find(&period).Error; err != nil {
+		return false, fmt.Errorf("list bounty instances: %w", err)
+	}
+	for i := range period {
+		if holdsBounty(&period[i], now) {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// ReleaseExpiredClaims returns bounty claims that lapsed before now to 'available' and reopens
+// their siblings' instances. Returns the count of released claims.
+func (r *ChoreInstanceRepo) ReleaseExpiredClaims(now time.Time) (int64, error) {
+	var lapsed []models.ChoreInstance
+	err := r.db.Where("status = ? AND claim_expires_at < ?", models.ChoreInstanceStatusClaimed, now).
+		Find(&lapsed).Error
+	if err != nil {
+		return 0, fmt.Errorf("list expired claims: %w", err)
+	}
+
+	var released int64
+	for _, claim := range lapsed {
+		var ok bool
+		err := r.db.Transaction(func(tx *gorm.DB) error {
+			// Lock the period in the same order as takeBounty
+			var period []models.ChoreInstance
+			err := bountyPeriod(tx.Clauses(clause.Locking{Strength: "UPDATE"}), claim.ChoreID, claim.PeriodStart).
+				Order("id").
+				Find(&period).Error
+			if err != nil {
+				return fmt.Errorf("lock bounty instances: %w", err)
+			}
+
+			// The claim may have been completed since it was listed
+			result := tx.Model(&models.ChoreInstance{}).
+				Where("id = ? AND status = ? AND claim_expires_at < ?", claim.ID, models.ChoreInstanceStatusClaimed, now).
+				Updates(map[string]interface{}{
+					"status":           models.ChoreInstanceStatusAvailable,
+					"claimed_at":       nil,
+					"claim_expires_at": nil,
+					"updated_at":       gorm.Expr("NOW()"),
+				})
+			if result.Error != nil {
+				return fmt.Errorf("release claim: %w", result.Error)
+			}
+			if result.RowsAffected == 0 {
+				return nil
+			}
+			ok = true
+
+			err = bountyPeriod(tx.Model(&models.ChoreInstance{}), claim.ChoreID, claim.PeriodStart).
+				Where("status = ?", models.ChoreInstanceStatusTaken).
+				Updates(map[string]interface{}{
+					"status":     models.ChoreInstanceStatusAvailable,
+					"updated_at": gorm.Expr("NOW()"),
+				}).Error
+			if err != nil {
+				return fmt.Errorf("reopen bounty: %w", err)
+			}
+			return nil
 		})
-	if result.Error != nil {
-		return 0, fmt.Errorf("expire chore instances: %w", result.Error)
+		if err != nil {
+			return released, err
+		}
+		if ok {
+			released++
+		}
 	}
-	return result.RowsAffected, nil
+	return released, nil
+}
+
+// CreatePhotos inserts the photos attached to a chore instance in a single transaction.
+func (r *ChoreInstanceRepo) CreatePhotos(photos []models.ChorePhoto) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		for i := range photos {
+			if err := tx.Create(&photos[i]).Error; err != nil {
+				return fmt.Errorf("insert chore photo: %w", err)
+			}
+		}
+		return nil
+	})
+}
+
+// GetPhoto retrieves a chore photo by its ID. Returns (nil, nil) if not found.
+func (r *ChoreInstanceRepo) GetPhoto(id int64) (*models.ChorePhoto, error) {
+	var photo models.ChorePhoto
+	err := r.db.First(&photo, id).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("get chore photo by id: %w", err)
+	}
+	return &photo, nil
+}
+
+// ListPhotos returns the photos of the given instances, keyed by instance ID and ordered by ID.
+func (r *ChoreInstanceRepo) ListPhotos(instanceIDs []int64) (map[int64][]models.ChorePhoto, error) {
+	byInstance := make(map[int64][]models.ChorePhoto)
+	if len(instanceIDs) == 0 {
+		return byInstance, nil
+	}
+	var photos []models.ChorePhoto
+	if err := r.db.Where("chore_instance_id IN ?", instanceIDs).Order("id ASC").Find(&photos).Error; err != nil {
+		return nil, fmt.Errorf("list chore photos: %w", err)
+	}
+	for _, p := range photos {
+		byInstance[p.ChoreInstanceID] = append(byInstance[p.ChoreInstanceID], p)
+	}
+	return byInstance, nil
+}
+
+// ListPhotosByChore returns the photos of all of a chore's instances.
+func (r *ChoreInstanceRepo) ListPhotosByChore(choreID int64) ([]models.ChorePhoto, error) {
+	var photos []models.ChorePhoto
+	err := r.db.Joins("JOIN chore_instances ON chore_instances.id = chore_photos.chore_instance_id").
+		Where("chore_instances.chore_id = ?", choreID).
+		Find(&photos).Error
+	if err != nil {
+		return nil, fmt.Errorf("list chore photos by chore: %w", err)
+	}
+	return photos, nil
+}
+
+// ListClosedPhotos returns up to limit photos whose instances were approved or expired, and so
+// are no longer needed as proof.
+func (r *ChoreInstanceRepo) ListClosedPhotos(limit int) ([]models.ChorePhoto, error) {
+	var photos []models.ChorePhoto
+	err := r.db.Joins("JOIN chore_instances ON chore_instances.id = chore_photos.chore_instance_id").
+		Where("chore_instances.status IN ?", []models.ChoreInstanceStatus{models.ChoreInstanceStatusApproved, models.ChoreInstanceStatusExpired}).
+		Order("chore_photos.id ASC").
+		Limit(limit).
+		Find(&photos).Error
+	if err != nil {
+		return nil, fmt.Errorf("list closed chore photos: %w", err)
+	}
+	return photos, nil
+}
+
+// DeletePhotos deletes chore photo records by ID. The stored files must be removed separately.
+func (r *ChoreInstanceRepo) DeletePhotos(ids []int64) error {
+	if len(ids) == 0 {
+		return nil
+	}
+	if err := r.db.Where("id IN ?", ids).Delete(&models.ChorePhoto{}).Error; err != nil {
+		return fmt.Errorf("delete chore photos: %w", err)
+	}
+	return nil
 }
@@ -1,6 +1,7 @@
 package repositories
 
 import (
+	"sync"
 	"testing"
 	"time"
 
@@ -432,7 +433,24 @@ func TestChoreInstanceRepo_Reject(t *testing.T) {
 	assert.Error(t, err)
 }
 
-func TestChoreInstanceRepo_ExpireByPeriod(t *testing.T) {
+// expireAll expires every instance whose period ended before before, one at a time as the
+// scheduler does, and returns how many were expired.
+func expireAll(t *testing.T, repo *ChoreInstanceRepo, before time.Time) int64 {
+	t.Helper()
+	ids, err := repo.ListExpirable(before)
+	require.NoError(t, err)
+	var count int64
+	for _, id := range ids {
+		expired, err := repo.Expire(id, before)
+		require.NoError(t, err)
+		if expired {
+			count++
+		}
+	}
+	return count
+}
+
+func TestChoreInstanceRepo_Expire(t *testing.T) {
 	db := testDB(t)
 	repo := NewChoreInstanceRepo(db)
 
@@ -494,9 +512,7 @@ func TestChoreInstanceRepo_ExpireByPeriod(t *testing.T) {
 	})
 	require.NoError(t, err)
 
-	count, err := repo.ExpireByPeriod(time.Now().UTC())
-	require.NoError(t, err)
-	assert.Equal(t, int64(2), count)
+	assert.Equal(t, int64(2), expireAll(t, repo, time.Now().UTC()))
 
 	// Verify the expired instances
 	var expiredCount int64
@@ -504,7 +520,648 @@ func TestChoreInstanceRepo_ExpireByPeriod(t *testing.T) {
 	assert.Equal(t, int64(2), expiredCount)
 
 	// Running again should expire 0
-	count, err = repo.ExpireByPeriod(time.Now().UTC())
+	assert.Equal(t, int64(0), expireAll(t, repo, time.Now().UTC()))
+}
+
+func TestChoreInstanceRepo_CountApprovedInRange(t *testing.T) {
+	db := testDB(t)
+	repo := NewChoreInstanceRepo(db)
+
+	fam := createChoreTestFamily(t, db)
+	parent := createChoreTestParent(t, db, fam.ID)
+	child := createChoreTestChild(t, db, fam.ID, "RangeKid")
+
+	chore, err := NewChoreRepo(db).Create(&models.Chore{
+		FamilyID:          fam.ID,
+		CreatedByParentID: parent.ID,
+		Name:              "Feed Cat",
+		RewardCents:       100,
+		Recurrence:        models.ChoreRecurrenceDaily,
+		IsActive:          true,
+	})
+	require.NoError(t, err)
+
+	start := time.Date(2026, time.March, 1, 0, 0, 0, 0, time.UTC)
+	end := start.AddDate(0, 0, 7)
+	for _, inst := range []struct {
+		status    models.ChoreInstanceStatus
+		createdAt time.Time
+	}{
+		{models.ChoreInstanceStatusApproved, start},
+		{models.ChoreInstanceStatusApproved, start.AddDate(0, 0, 3)},
+		{models.ChoreInstanceStatusExpired, start.AddDate(0, 0, 4)},
+		{models.ChoreInstanceStatusApproved, end},                     // excluded: end is exclusive
+		{models.ChoreInstanceStatusApproved, start.AddDate(0, 0, -1)}, // excluded: before range
+	} {
+		_, err := repo.CreateInstance(&models.ChoreInstance{
+			ChoreID:     chore.ID,
+			ChildID:     child.ID,
+			RewardCents: 100,
+			Status:      inst.status,
+			CreatedAt:   inst.createdAt,
+		})
+		require.NoError(t, err)
+	}
+
+	approved, total, err := repo.CountApprovedInRange(child.ID, start, end)
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), approved)
+	assert.Equal(t, int64(3), total)
+}
+
+func TestChoreInstanceRepo_CountApprovedInRange_ByPeriod(t *testing.T) {
+	db := testDB(t)
+	repo := NewChoreInstanceRepo(db)
+
+	fam := createChoreTestFamily(t, db)
+	parent := createChoreTestParent(t, db, fam.ID)
+	child := createChoreTestChild(t, db, fam.ID, "PeriodKid")
+
+	chore, err := NewChoreRepo(db).Create(&models.Chore{
+		FamilyID:          fam.ID,
+		CreatedByParentID: parent.ID,
+		Name:              "Feed Cat",
+		RewardCents:       100,
+		Recurrence:        models.ChoreRecurrenceDaily,
+		IsActive:          true,
+	})
+	require.NoError(t, err)
+
+	loc, err := time.LoadLocation("America/New_York")
+	require.NoError(t, err)
+	start := time.Date(2026, time.March, 1, 0, 0, 0, 0, loc)
+	end := start.AddDate(0, 0, 7)
+	for _, inst := range []struct {
+		status models.ChoreInstanceStatus
+		day    time.Time
+	}{
+		{models.ChoreInstanceStatusApproved, start},
+		{models.ChoreInstanceStatusApproved, start.AddDate(0, 0, 1)},
+		{models.ChoreInstanceStatusExpired, start.AddDate(0, 0, 6)},
+		{models.ChoreInstanceStatusPendingApproval, start.AddDate(0, 0, 5)}, // excluded: not reviewed yet
+		{models.ChoreInstanceStatusApproved, end},                           // excluded: next period
+		{models.ChoreInstanceStatusApproved, start.AddDate(0, 0, -1)},       // excluded: previous period
+	} {
+		periodStart := time.Date(inst.day.Year(), inst.day.Month(), inst.day.Day(), 0, 0, 0, 0, time.UTC)
+		_, err := repo.CreateInstance(&models.ChoreInstance{
+			ChoreID:     chore.ID,
+			ChildID:     child.ID,
+			RewardCents: 100,
+			Status:      inst.status,
+			PeriodStart: &periodStart,
+			PeriodEnd:   &periodStart,
+			// Creation time does not matter for recurring instances
+			CreatedAt: start.AddDate(0, 0, -20),
+		})
+		require.NoError(t, err)
+	}
+
+	approved, total, err := repo.CountApprovedInRange(child.ID, start, end)
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), approved)
+	assert.Equal(t, int64(3), total)
+}
+
+// createBountyInstances creates a one-time bounty chore with an available instance for each child.
+func createBountyInstances(t *testing.T, repo *ChoreInstanceRepo, choreRepo *ChoreRepo, familyID, parentID int64, childIDs ...int64) []*models.ChoreInstance {
+	t.Helper()
+	chore, err := choreRepo.Create(&models.Chore{
+		FamilyID:          familyID,
+		CreatedByParentID: parentID,
+		Name:              "Wash Car",
+		RewardCents:       1000,
+		Recurrence:        models.ChoreRecurrenceOneTime,
+		IsActive:          true,
+		IsBounty:          true,
+	})
+	require.NoError(t, err)
+
+	instances := make([]*models.ChoreInstance, len(childIDs))
+	for i, childID := range childIDs {
+		instances[i], err = repo.CreateInstance(&models.ChoreInstance{
+			ChoreID:     chore.ID,
+			ChildID:     childID,
+			RewardCents: 1000,
+			Status:      models.ChoreInstanceStatusAvailable,
+		})
+		require.NoError(t, err)
+	}
+	return instances
+}
+
+func TestChoreInstanceRepo_ClaimBounty(t *testing.T) {
+	db := testDB(t)
+	repo := NewChoreInstanceRepo(db)
+
+	fam := createChoreTestFamily(t, db)
+	parent := createChoreTestParent(t, db, fam.ID)
+	alice := createChoreTestChild(t, db, fam.ID, "Alice")
+	bob := createChoreTestChild(t, db, fam.ID, "Bob")
+	instances := createBountyInstances(t, repo, NewChoreRepo(db), fam.ID, parent.ID, alice.ID, bob.ID)
+
+	expiresAt := time.Now().Add(24 * time.Hour)
+	require.NoError(t, repo.ClaimBounty(instances[0].ID, alice.ID, expiresAt))
+
+	claimed, err := repo.GetByID(instances[0].ID)
+	require.NoError(t, err)
+	assert.Equal(t, models.ChoreInstanceStatusClaimed, claimed.Status)
+	assert.NotNil(t, claimed.ClaimedAt)
+	require.NotNil(t, claimed.ClaimExpiresAt)
+	assert.WithinDuration(t, expiresAt, *claimed.ClaimExpiresAt, time.Second)
+
+	// Bob's instance is hidden and can no longer be claimed or completed
+	sibling, err := repo.GetByID(instances[1].ID)
+	require.NoError(t, err)
+	assert.Equal(t, models.ChoreInstanceStatusTaken, sibling.Status)
+	available, _, _, err := repo.ListByChild(bob.ID)
+	require.NoError(t, err)
+	assert.Empty(t, available)
+
+	assert.ErrorIs(t, repo.ClaimBounty(instances[1].ID, bob.ID, expiresAt), ErrBountyTaken)
+	assert.ErrorIs(t, repo.CompleteBounty(instances[1].ID, bob.ID), ErrBountyTaken)
+
+	// Claiming twice is not allowed, but the claimant can complete
+	assert.ErrorIs(t, repo.ClaimBounty(instances[0].ID, alice.ID, expiresAt), ErrInvalidStatusTransition)
+	require.NoError(t, repo.CompleteBounty(instances[0].ID, alice.ID))
+	completed, err := repo.GetByID(instances[0].ID)
+	require.NoError(t, err)
+	assert.Equal(t, models.ChoreInstanceStatusPendingApproval, completed.Status)
+	assert.NotNil(t, completed.CompletedAt)
+	assert.Nil(t, completed.ClaimExpiresAt)
+}
+
+func TestChoreInstanceRepo_ClaimBounty_Concurrent(t *testing.T) {
+	db := testDB(t)
+	repo := NewChoreInstanceRepo(db)
+
+	fam := createChoreTestFamily(t, db)
+	parent := createChoreTestParent(t, db, fam.ID)
+	var childIDs []int64
+	for _, name := range []string{"Alice", "Bob", "Cara", "Dan"} {
+		childIDs = append(childIDs, createChoreTestChild(t, db, fam.ID, name).ID)
+	}
+	instances := createBountyInstances(t, repo, NewChoreRepo(db), fam.ID, parent.ID, childIDs...)
+
+	errs := make(chan error, len(instances))
+	var wg sync.WaitGroup
+	for _, inst := range instances {
+		wg.Add(1)
+		go func(inst *models.ChoreInstance) {
+			defer wg.Done()
+			errs <- repo.ClaimBounty(inst.ID, inst.ChildID, time.Now().Add(time.Hour))
+		}(inst)
+	}
+	wg.Wait()
+	close(errs)
+
+	wins := 0
+	for err := range errs {
+		if err == nil {
+			wins++
+		} else {
+			assert.ErrorIs(t, err, ErrBountyTaken)
+		}
+	}
+	assert.Equal(t, 1, wins)
+
+	var claimedCount int64
+	db.Model(&models.ChoreInstance{}).Where("status = ?", models.ChoreInstanceStatusClaimed).Count(&claimedCount)
+	assert.Equal(t, int64(1), claimedCount)
+}
+
+func TestChoreInstanceRepo_ReleaseExpiredClaims(t *testing.T) {
+	db := testDB(t)
+	repo := NewChoreInstanceRepo(db)
+
+	fam := createChoreTestFamily(t, db)
+	parent := createChoreTestParent(t, db, fam.ID)
+	alice := createChoreTestChild(t, db, fam.ID, "Alice")
+	bob := createChoreTestChild(t, db, fam.ID, "Bob")
+	instances := createBountyInstances(t, repo, NewChoreRepo(db), fam.ID, parent.ID, alice.ID, bob.ID)
+
+	require.NoError(t, repo.ClaimBounty(instances[0].ID, alice.ID, time.Now().Add(time.Hour)))
+
+	// Not yet lapsed
+	count, err := repo.ReleaseExpiredClaims(time.Now())
 	require.NoError(t, err)
 	assert.Equal(t, int64(0), count)
+
+	count, err = repo.ReleaseExpiredClaims(time.Now().Add(2 * time.Hour))
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), count)
+
+	for _, inst := range instances {
+		fetched, err := repo.GetByID(inst.ID)
+		require.NoError(t, err)
+		assert.Equal(t, models.ChoreInstanceStatusAvailable, fetched.Status)
+		assert.Nil(t, fetched.ClaimExpiresAt)
+	}
+
+	// Bob can take it now
+	require.NoError(t, repo.CompleteBounty(instances[1].ID, bob.ID))
+}
+
+func TestChoreInstanceRepo_CompleteBounty_LapsedClaim(t *testing.T) {
+	db := testDB(t)
+	repo := NewChoreInstanceRepo(db)
+
+	fam := createChoreTestFamily(t, db)
+	parent := createChoreTestParent(t, db, fam.ID)
+	alice := createChoreTestChild(t, db, fam.ID, "Alice")
+	bob := createChoreTestChild(t, db, fam.ID, "Bob")
+	instances := createBountyInstances(t, repo, NewChoreRepo(db), fam.ID, parent.ID, alice.ID, bob.ID)
+
+	// Alice's claim has lapsed but the scheduler has not released it yet
+	require.NoError(t, repo.ClaimBounty(instances[0].ID, alice.ID, time.Now().Add(-time.Minute)))
+
+	require.NoError(t, repo.CompleteBounty(instances[1].ID, bob.ID))
+	aliceInst, err := repo.GetByID(instances[0].ID)
+	require.NoError(t, err)
+	assert.Equal(t, models.ChoreInstanceStatusTaken, aliceInst.Status)
+
+	// Rejecting Bob's work reopens the bounty
+	require.NoError(t, repo.Reject(instances[1].ID, parent.ID, "Still dirty"))
+	require.NoError(t, repo.ReopenBounty(instances[0].ChoreID, nil))
+	aliceInst, err = repo.GetByID(instances[0].ID)
+	require.NoError(t, err)
+	assert.Equal(t, models.ChoreInstanceStatusAvailable, aliceInst.Status)
+}
+
+func TestChoreInstanceRepo_Photos(t *testing.T) {
+	db := testDB(t)
+	repo := NewChoreInstanceRepo(db)
+
+	fam := createChoreTestFamily(t, db)
+	parent := createChoreTestParent(t, db, fam.ID)
+	child := createChoreTestChild(t, db, fam.ID, "PhotoKid")
+
+	chore, err := NewChoreRepo(db).Create(&models.Chore{
+		FamilyID:          fam.ID,
+		CreatedByParentID: parent.ID,
+		Name:              "Tidy Desk",
+		RewardCents:       100,
+		Recurrence:        models.ChoreRecurrenceOneTime,
+		IsActive:          true,
+	})
+	require.NoError(t, err)
+
+	pending, err := repo.CreateInstance(&models.ChoreInstance{
+		ChoreID: chore.ID, ChildID: child.ID, RewardCents: 100, Status: models.ChoreInstanceStatusPendingApproval,
+	})
+	require.NoError(t, err)
+	approved, err := repo.CreateInstance(&models.ChoreInstance{
+		ChoreID: chore.ID, ChildID: child.ID, RewardCents: 100, Status: models.ChoreInstanceStatusApproved,
+	})
+	require.NoError(t, err)
+
+	require.NoError(t, repo.CreatePhotos([]models.ChorePhoto{
+		{ChoreInstanceID: pending.ID, StorageKey: "p/1", ThumbnailKey: "p/1_thumb.jpg", ContentType: "image/png", SizeBytes: 10},
+		{ChoreInstanceID: pending.ID, StorageKey: "p/2", ThumbnailKey: "p/2_thumb.jpg", ContentType: "image/jpeg", SizeBytes: 20},
+		{ChoreInstanceID: approved.ID, StorageKey: "a/1", ThumbnailKey: "a/1_thumb.jpg", ContentType: "image/png", SizeBytes: 30},
+	}))
+
+	byInstance, err := repo.ListPhotos([]int64{pending.ID, approved.ID})
+	require.NoError(t, err)
+	require.Len(t, byInstance[pending.ID], 2)
+	require.Len(t, byInstance[approved.ID], 1)
+
+	photo, err := repo.GetPhoto(byInstance[pending.ID][0].ID)
+	require.NoError(t, err)
+	require.NotNil(t, photo)
+	assert.Equal(t, "p/1", photo.StorageKey)
+
+	missing, err := repo.GetPhoto(999999)
+	require.NoError(t, err)
+	assert.Nil(t, missing)
+
+	byChore, err := repo.ListPhotosByChore(chore.ID)
+	require.NoError(t, err)
+	assert.Len(t, byChore, 3)
+
+	// Only the approved instance's photo is due for cleanup
+	closed, err := repo.ListClosedPhotos(10)
+	require.NoError(t, err)
+	require.Len(t, closed, 1)
+	assert.Equal(t, "a/1", closed[0].StorageKey)
+
+	require.NoError(t, repo.DeletePhotos([]int64{closed[0].ID}))
+	closed, err = repo.ListClosedPhotos(10)
+	require.NoError(t, err)
+	assert.Empty(t, closed)
+}
+
+func TestChoreInstanceRepo_Expire_Penalty(t *testing.T) {
+	db := testDB(t)
+	repo := NewChoreInstanceRepo(db)
+	txRepo := NewTransactionRepo(db)
+
+	fam := createChoreTestFamily(t, db)
+	parent := createChoreTestParent(t, db, fam.ID)
+	rich := createChoreTestChild(t, db, fam.ID, "PenaltyRich")
+	poor := createChoreTestChild(t, db, fam.ID, "PenaltyPoor")
+	broke := createChoreTestChild(t, db, fam.ID, "PenaltyBroke")
+	_, _, err := txRepo.Deposit(rich.ID, parent.ID, 1000, "")
+	require.NoError(t, err)
+	_, _, err = txRepo.Deposit(poor.ID, parent.ID, 30, "")
+	require.NoError(t, err)
+
+	chore, err := NewChoreRepo(db).Create(&models.Chore{
+		FamilyID:          fam.ID,
+		CreatedByParentID: parent.ID,
+		Name:              "Make Bed",
+		RewardCents:       50,
+		Recurrence:        models.ChoreRecurrenceDaily,
+		IsActive:          true,
+		PenaltyCents:      100,
+	})
+	require.NoError(t, err)
+
+	yesterday := time.Now().UTC().Truncate(24*time.Hour).AddDate(0, 0, -1)
+	instances := make(map[int64]*models.ChoreInstance)
+	for _, child := range []*models.Child{rich, poor, broke} {
+		inst, err := repo.CreateInstance(&models.ChoreInstance{
+			ChoreID:     chore.ID,
+			ChildID:     child.ID,
+			RewardCents: 50,
+			Status:      models.ChoreInstanceStatusAvailable,
+			PeriodStart: &yesterday,
+			PeriodEnd:   &yesterday,
+		})
+		require.NoError(t, err)
+		instances[child.ID] = inst
+	}
+
+	assert.Equal(t, int64(3), expireAll(t, repo, time.Now().UTC()))
+
+	// Full penalty, penalty capped at the balance, and nothing to take
+	for _, tc := range []struct {
+		child       *models.Child
+		wantBalance int64
+		wantPenalty int64
+	}{
+		{child: rich, wantBalance: 900, wantPenalty: 100},
+		{child: poor, wantBalance: 0, wantPenalty: 30},
+		{child: broke, wantBalance: 0, wantPenalty: 0},
+	} {
+		balance, err := NewChildRepo(db).GetBalance(tc.child.ID)
+		require.NoError(t, err)
+		assert.Equal(t, tc.wantBalance, balance, tc.child.FirstName)
+
+		inst, err := repo.GetByID(instances[tc.child.ID].ID)
+		require.NoError(t, err)
+		assert.Equal(t, models.ChoreInstanceStatusExpired, inst.Status)
+		if tc.wantPenalty == 0 {
+			assert.Nil(t, inst.PenaltyTransactionID, tc.child.FirstName)
+			continue
+		}
+		require.NotNil(t, inst.PenaltyTransactionID, tc.child.FirstName)
+		var tx models.Transaction
+		require.NoError(t, db.First(&tx, *inst.PenaltyTransactionID).Error)
+		assert.Equal(t, models.TransactionTypeChorePenalty, tx.TransactionType)
+		assert.Equal(t, tc.wantPenalty, tx.AmountCents)
+	}
+}
+
+func TestChoreInstanceRepo_Expire_PenaltyReducesGoals(t *testing.T) {
+	db := testDB(t)
+	repo := NewChoreInstanceRepo(db)
+	goalRepo := NewSavingsGoalRepo(db)
+
+	fam := createChoreTestFamily(t, db)
+	parent := createChoreTestParent(t, db, fam.ID)
+	child := createChoreTestChild(t, db, fam.ID, "PenaltySaver")
+	_, _, err := NewTransactionRepo(db).Deposit(child.ID, parent.ID, 1000, "")
+	require.NoError(t, err)
+	goal, err := goalRepo.Create(child.ID, "Bike", 5000, nil)
+	require.NoError(t, err)
+	_, err = goalRepo.Allocate(goal.ID, child.ID, 800)
+	require.NoError(t, err)
+
+	chore, err := NewChoreRepo(db).Create(&models.Chore{
+		FamilyID:          fam.ID,
+		CreatedByParentID: parent.ID,
+		Name:              "Make Bed",
+		RewardCents:       50,
+		Recurrence:        models.ChoreRecurrenceDaily,
+		IsActive:          true,
+		PenaltyCents:      500,
+	})
+	require.NoError(t, err)
+
+	yesterday := time.Now().UTC().Truncate(24*time.Hour).AddDate(0, 0, -1)
+	_, err = repo.CreateInstance(&models.ChoreInstance{
+		ChoreID:     chore.ID,
+		ChildID:     child.ID,
+		RewardCents: 50,
+		Status:      models.ChoreInstanceStatusAvailable,
+		PeriodStart: &yesterday,
+		PeriodEnd:   &yesterday,
+	})
+	require.NoError(t, err)
+
+	expireAll(t, repo, time.Now().UTC())
+
+	// The penalty comes out of the balance and the goal gives up what the balance no longer covers
+	balance, err := NewChildRepo(db).GetBalance(child.ID)
+	require.NoError(t, err)
+	assert.Equal(t, int64(500), balance)
+
+	updated, err := goalRepo.GetByID(goal.ID)
+	require.NoError(t, err)
+	assert.Equal(t, int64(500), updated.SavedCents)
+
+	available, err := goalRepo.GetAvailableBalance(child.ID)
+	require.NoError(t, err)
+	assert.Equal(t, int64(0), available)
+}
+
+func TestChoreInstanceRepo_RequestRedo(t *testing.T) {
+	db := testDB(t)
+	repo := NewChoreInstanceRepo(db)
+
+	fam := createChoreTestFamily(t, db)
+	parent := createChoreTestParent(t, db, fam.ID)
+	child := createChoreTestChild(t, db, fam.ID, "RedoKid")
+
+	choreRepo := NewChoreRepo(db)
+	chore, err := choreRepo.Create(&models.Chore{
+		FamilyID:          fam.ID,
+		CreatedByParentID: parent.ID,
+		Name:              "Wash Car",
+		RewardCents:       500,
+		Recurrence:        models.ChoreRecurrenceOneTime,
+		IsActive:          true,
+	})
+	require.NoError(t, err)
+
+	instance, err := repo.CreateInstance(&models.ChoreInstance{
+		ChoreID:     chore.ID,
+		ChildID:     child.ID,
+		RewardCents: 500,
+		Status:      models.ChoreInstanceStatusAvailable,
+	})
+	require.NoError(t, err)
+
+	maxRedos := 1
+	require.NoError(t, repo.MarkComplete(instance.ID, child.ID))
+	require.NoError(t, repo.RequestRedo(instance.ID, parent.ID, "Wheels are still muddy", &maxRedos))
+
+	fetched, err := repo.GetByID(instance.ID)
+	require.NoError(t, err)
+	assert.Equal(t, models.ChoreInstanceStatusAvailable, fetched.Status)
+	assert.Equal(t, 1, fetched.RedoCount)
+	assert.Equal(t, "Wheels are still muddy", *fetched.RejectionReason)
+	assert.Nil(t, fetched.CompletedAt)
+
+	// Redo on available should fail (not pending_approval)
+	assert.ErrorIs(t, repo.RequestRedo(instance.ID, parent.ID, "Again", &maxRedos), ErrInvalidStatusTransition)
+
+	// Second redo is over the limit; the instance stays pending
+	require.NoError(t, repo.MarkComplete(instance.ID, child.ID))
+	assert.ErrorIs(t, repo.RequestRedo(instance.ID, parent.ID, "Still muddy", &maxRedos), ErrRedoLimitReached)
+	fetched, err = repo.GetByID(instance.ID)
+	require.NoError(t, err)
+	assert.Equal(t, models.ChoreInstanceStatusPendingApproval, fetched.Status)
+
+	require.NoError(t, repo.Approve(instance.ID, parent.ID, nil))
+
+	rounds, err := repo.ListReviewRounds(instance.ID)
+	require.NoError(t, err)
+	require.Len(t, rounds, 2)
+	assert.Equal(t, 1, rounds[0].Round)
+	assert.Equal(t, models.ChoreReviewOutcomeRedo, rounds[0].Outcome)
+	assert.Equal(t, "Wheels are still muddy", *rounds[0].Feedback)
+	assert.NotNil(t, rounds[0].SubmittedAt)
+	assert.Equal(t, 2, rounds[1].Round)
+	assert.Equal(t, models.ChoreReviewOutcomeApproved, rounds[1].Outcome)
+	assert.Nil(t, rounds[1].Feedback)
+	assert.Equal(t, parent.ID, rounds[1].ReviewedByParentID)
+}
+
+func TestChoreInstanceRepo_MarkComplete_Late(t *testing.T) {
+	db := testDB(t)
+	repo := NewChoreInstanceRepo(db)
+
+	fam := createChoreTestFamily(t, db)
+	parent := createChoreTestParent(t, db, fam.ID)
+	child := createChoreTestChild(t, db, fam.ID, "LateKid")
+
+	choreRepo := NewChoreRepo(db)
+	chore, err := choreRepo.Create(&models.Chore{
+		FamilyID:          fam.ID,
+		CreatedByParentID: parent.ID,
+		Name:              "Walk Dog",
+		RewardCents:       100,
+		Recurrence:        models.ChoreRecurrenceDaily,
+		IsActive:          true,
+	})
+	require.NoError(t, err)
+
+	past := time.Now().Add(-time.Hour)
+	future := time.Now().Add(time.Hour)
+	yesterday := time.Now().UTC().AddDate(0, 0, -1)
+	today := time.Now().UTC()
+	late, err := repo.CreateInstance(&models.ChoreInstance{
+		ChoreID: chore.ID, ChildID: child.ID, RewardCents: 100,
+		Status: models.ChoreInstanceStatusAvailable, PeriodStart: &yesterday, DueAt: &past,
+	})
+	require.NoError(t, err)
+	onTime, err := repo.CreateInstance(&models.ChoreInstance{
+		ChoreID: chore.ID, ChildID: child.ID, RewardCents: 100,
+		Status: models.ChoreInstanceStatusAvailable, PeriodStart: &today, DueAt: &future,
+	})
+	require.NoError(t, err)
+
+	require.NoError(t, repo.MarkComplete(late.ID, child.ID))
+	require.NoError(t, repo.MarkComplete(onTime.ID, child.ID))
+
+	fetched, err := repo.GetByID(late.ID)
+	require.NoError(t, err)
+	assert.True(t, fetched.CompletedLate)
+
+	fetched, err = repo.GetByID(onTime.ID)
+	require.NoError(t, err)
+	assert.False(t, fetched.CompletedLate)
+}
+
+func TestChoreInstanceRepo_ListChoreStats(t *testing.T) {
+	db := testDB(t)
+	repo := NewChoreInstanceRepo(db)
+
+	fam := createChoreTestFamily(t, db)
+	parent := createChoreTestParent(t, db, fam.ID)
+	alice := createChoreTestChild(t, db, fam.ID, "StatsAlice")
+	bob := createChoreTestChild(t, db, fam.ID, "StatsBob")
+
+	choreRepo := NewChoreRepo(db)
+	chore, err := choreRepo.Create(&models.Chore{
+		FamilyID:          fam.ID,
+		CreatedByParentID: parent.ID,
+		Name:              "Dishes",
+		RewardCents:       200,
+		Recurrence:        models.ChoreRecurrenceDaily,
+		IsActive:          true,
+	})
+	require.NoError(t, err)
+
+	newInstance := func(childID int64, daysAgo int) *models.ChoreInstance {
+		start := time.Now().UTC().AddDate(0, 0, -daysAgo).Truncate(24 * time.Hour)
+		inst, err := repo.CreateInstance(&models.ChoreInstance{
+			ChoreID:     chore.ID,
+			ChildID:     childID,
+			RewardCents: 200,
+			Status:      models.ChoreInstanceStatusAvailable,
+			PeriodStart: &start,
+		})
+		require.NoError(t, err)
+		return inst
+	}
+
+	// Alice: one rejected then approved at half pay, one expired
+	first := newInstance(alice.ID, 2)
+	require.NoError(t, repo.MarkComplete(first.ID, alice.ID))
+	require.NoError(t, repo.Reject(first.ID, parent.ID, "Still greasy"))
+	require.NoError(t, repo.MarkComplete(first.ID, alice.ID))
+	percent := 50
+	_, _, err = repo.ApproveAndPay(first.ID, parent.ID, "Dishes", &ChoreReview{PayoutPercent: &percent, PaidCents: 100})
+	require.NoError(t, err)
+	second := newInstance(alice.ID, 1)
+	require.NoError(t, db.Model(second).Update("status", models.ChoreInstanceStatusExpired).Error)
+
+	// Bob: one awaiting approval
+	third := newInstance(bob.ID, 1)
+	require.NoError(t, repo.MarkComplete(third.ID, bob.ID))
+
+	from := time.Now().AddDate(0, 0, -7)
+	to := time.Now().Add(time.Hour)
+	stats, err := repo.ListChoreStats(fam.ID, from, to, nil, nil)
+	require.NoError(t, err)
+	require.Len(t, stats, 2)
+
+	a := stats[0]
+	assert.Equal(t, alice.ID, a.ChildID)
+	assert.Equal(t, "Dishes", a.ChoreName)
+	assert.Equal(t, int64(2), a.InstanceCount)
+	assert.Equal(t, int64(1), a.ApprovedCount)
+	assert.Equal(t, int64(1), a.ExpiredCount)
+	assert.Equal(t, int64(1), a.CompletedCount)
+	require.NotNil(t, a.AvgSecondsToComplete)
+	assert.Equal(t, int64(1), a.Approvals)
+	assert.Equal(t, int64(1), a.Rejections)
+	assert.Equal(t, int64(100), a.EarnedCents)
+
+	b := stats[1]
+	assert.Equal(t, bob.ID, b.ChildID)
+	assert.Equal(t, int64(1), b.PendingCount)
+	assert.Equal(t, int64(0), b.EarnedCents)
+
+	// Narrowed to one child, and to a range with nothing in it
+	stats, err = repo.ListChoreStats(fam.ID, from, to, &bob.ID, nil)
+	require.NoError(t, err)
+	require.Len(t, stats, 1)
+	assert.Equal(t, bob.ID, stats[0].ChildID)
+
+	stats, err = repo.ListChoreStats(fam.ID, from.AddDate(0, -1, 0), from, nil, &chore.ID)
+	require.NoError(t, err)
+	assert.Empty(t, stats)
 }
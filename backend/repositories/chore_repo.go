@@ -7,6 +7,7 @@ import (
 	"bank-of-dad/models"
 
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 // ChoreAssignmentInfo holds the child_id and child name for a chore assignment.
@@ -40,6 +41,50 @@ func (r *ChoreRepo) Create(chore *models.Chore) (*models.Chore, error) {
 	return chore, nil
 }
 
+// NewChore is a chore to create together with the children assigned to it, in rotation order.
+type NewChore struct {
+	Chore    *models.Chore
+	ChildIDs []int64
+}
+
+// CreateMany inserts chores with their assignments in a single transaction, so either all of them
+// are created or none are. Active one-time chores get an available instance for each assigned child.
+func (r *ChoreRepo) CreateMany(chores []NewChore) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		for _, nc := range chores {
+			// is_active defaults to true, so an inactive chore must be switched off after insert.
+			active := nc.Chore.IsActive
+			if err := tx.Omit(clause.Associations).Create(nc.Chore).Error; err != nil {
+				return fmt.Errorf("insert chore: %w", err)
+			}
+			if !active {
+				if err := tx.Model(nc.Chore).Update("is_active", false).Error; err != nil {
+					return fmt.Errorf("deactivate chore: %w", err)
+				}
+			}
+			for i, childID := range nc.ChildIDs {
+				assignment := &models.ChoreAssignment{ChoreID: nc.Chore.ID, ChildID: childID, Position: i}
+				if err := tx.Omit(clause.Associations).Create(assignment).Error; err != nil {
+					return fmt.Errorf("insert chore assignment: %w", err)
+				}
+				if nc.Chore.Recurrence != models.ChoreRecurrenceOneTime || !active {
+					continue
+				}
+				instance := &models.ChoreInstance{
+					ChoreID:     nc.Chore.ID,
+					ChildID:     childID,
+					RewardCents: nc.Chore.RewardCents,
+					Status:      models.ChoreInstanceStatusAvailable,
+				}
+				if err := tx.Omit(clause.Associations).Create(instance).Error; err != nil {
+					return fmt.Errorf("insert chore instance: %w", err)
+				}
+			}
+		}
+		return nil
+	})
+}
+
 // GetByID retrieves a chore by its ID. Returns (nil, nil) if not found.
 func (r *ChoreRepo) GetByID(id int64) (*models.Chore, error) {
 	var chore models.Chore
@@ -81,6 +126,7 @@ func (r *ChoreRepo) ListByFamily(familyID int64) ([]ChoreWithAssignments, error)
 		Select("a.chore_id, a.child_id, c.first_name as child_name").
 		Joins("JOIN children c ON c.id = a.child_id").
 		Where("a.chore_id IN ?", choreIDs).
+		Order("a.position, a.id").
 		Find(&assignmentRows).Error
 	if err != nil {
 		return nil, fmt.Errorf("list chore assignments: %w", err)
@@ -156,19 +202,29 @@ func (r *ChoreRepo) Delete(id int64) error {
 	return nil
 }
 
-// Update updates a chore's name, description, reward_cents, recurrence, day_of_week, day_of_month, and is_active.
+// Update updates a chore's name, description, reward_cents, recurrence, day_of_week, day_of_month,
+// bounty settings, is_rotation, penalty_cents, max_redos, due settings, and is_active.
 func (r *ChoreRepo) Update(chore *models.Chore) (*models.Chore, error) {
 	err := r.db.Model(&models.Chore{}).
 		Where("id = ?", chore.ID).
 		Updates(map[string]interface{}{
-			"name":         chore.Name,
-			"description":  chore.Description,
-			"reward_cents": chore.RewardCents,
-			"recurrence":   chore.Recurrence,
-			"day_of_week":  chore.DayOfWeek,
-			"day_of_month": chore.DayOfMonth,
-			"is_active":    chore.IsActive,
-			"updated_at":   gorm.Expr("NOW()"),
+			"name":                chore.Name,
+			"description":         chore.Description,
+			"reward_cents":        chore.RewardCents,
+			"recurrence":          chore.Recurrence,
+			"day_of_week":         chore.DayOfWeek,
+			"day_of_month":        chore.DayOfMonth,
+			"rrule":               chore.RRule,
+			"is_active":           chore.IsActive,
+			"is_bounty":           chore.IsBounty,
+			"claim_hours":         chore.ClaimHours,
+			"is_rotation":         chore.IsRotation,
+			"penalty_cents":       chore.PenaltyCents,
+			"max_redos":           chore.MaxRedos,
+			"due_time":            chore.DueTime,
+			"due_weekday":         chore.DueWeekday,
+			"late_payout_percent": chore.LatePayoutPercent,
+			"updated_at":          gorm.Expr("NOW()"),
 		}).Error
 	if err != nil {
 		return nil, fmt.Errorf("update chore: %w", err)
@@ -176,13 +232,66 @@ func (r *ChoreRepo) Update(chore *models.Chore) (*models.Chore, error) {
 	return r.GetByID(chore.ID)
 }
 
+// ListAssignedChildIDs returns the IDs of the children assigned to a chore, in rotation order.
+func (r *ChoreRepo) ListAssignedChildIDs(choreID int64) ([]int64, error) {
+	var childIDs []int64
+	err := r.db.Model(&models.ChoreAssignment{}).
+		Where("chore_id = ?", choreID).
+		Order("position, id").
+		Pluck("child_id", &childIDs).Error
+	if err != nil {
+		return nil, fmt.Errorf("list assigned children: %w", err)
+	}
+	return childIDs, nil
+}
+
+// ReplaceAssignments replaces a chore's assignments with childIDs, in that rotation order, and sets
+// the child the rotation continues after, in a single transaction.
+func (r *ChoreRepo) ReplaceAssignments(choreID int64, childIDs []int64, rotationChildID *int64) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("chore_id = ?", choreID).Delete(&models.ChoreAssignment{}).Error; err != nil {
+			return fmt.Errorf("delete chore assignments: %w", err)
+		}
+		for i, childID := range childIDs {
+			assignment := &models.ChoreAssignment{ChoreID: choreID, ChildID: childID, Position: i}
+			if err := tx.Create(assignment).Error; err != nil {
+				return fmt.Errorf("insert chore assignment: %w", err)
+			}
+		}
+		err := tx.Model(&models.Chore{}).
+			Where("id = ?", choreID).
+			Updates(map[string]interface{}{
+				"rotation_child_id": rotationChildID,
+				"updated_at":        gorm.Expr("NOW()"),
+			}).Error
+		if err != nil {
+			return fmt.Errorf("update rotation: %w", err)
+		}
+		return nil
+	})
+}
+
+// SetRotationChild records the child given the most recent period of a rotation chore.
+func (r *ChoreRepo) SetRotationChild(choreID, childID int64) error {
+	err := r.db.Model(&models.Chore{}).
+		Where("id = ?", choreID).
+		Updates(map[string]interface{}{
+			"rotation_child_id": childID,
+			"updated_at":        gorm.Expr("NOW()"),
+		}).Error
+	if err != nil {
+		return fmt.Errorf("set rotation child: %w", err)
+	}
+	return nil
+}
+
 // ChoreWithAssignmentIDs holds a chore and its assigned child IDs for scheduler use.
 type ChoreWithAssignmentIDs struct {
 	models.Chore
 	ChildIDs []int64
 }
 
-// ListAllActiveRecurring returns all active recurring chores with their assigned child IDs.
+// ListAllActiveRecurring returns all active recurring chores with their assigned child IDs, in rotation order.
 func (r *ChoreRepo) ListAllActiveRecurring() ([]ChoreWithAssignmentIDs, error) {
 	var chores []models.Chore
 	if err := r.db.Where("is_active = ? AND recurrence != ?", true, "one_time").Find(&chores).Error; err != nil {
@@ -198,7 +307,7 @@ func (r *ChoreRepo) ListAllActiveRecurring() ([]ChoreWithAssignmentIDs, error) {
 	}
 
 	var assignments []models.ChoreAssignment
-	if err := r.db.Where("chore_id IN ?", choreIDs).Find(&assignments).Error; err != nil {
+	if err := r.db.Where("chore_id IN ?", choreIDs).Order("position, id").Find(&assignments).Error; err != nil {
 		return nil, fmt.Errorf("list assignments for recurring: %w", err)
 	}
 
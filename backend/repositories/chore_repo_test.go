@@ -358,3 +358,128 @@ func TestChoreRepo_SetActive(t *testing.T) {
 	require.NoError(t, err)
 	assert.True(t, fetched.IsActive)
 }
+
+func TestChoreRepo_ReplaceAssignments(t *testing.T) {
+	db := testDB(t)
+	repo := NewChoreRepo(db)
+
+	fam := createChoreTestFamily(t, db)
+	parent := createChoreTestParent(t, db, fam.ID)
+	alice := createChoreTestChild(t, db, fam.ID, "RotAlice")
+	bob := createChoreTestChild(t, db, fam.ID, "RotBob")
+	carol := createChoreTestChild(t, db, fam.ID, "RotCarol")
+
+	created, err := repo.Create(&models.Chore{
+		FamilyID:          fam.ID,
+		CreatedByParentID: parent.ID,
+		Name:              "Dishes",
+		RewardCents:       100,
+		Recurrence:        models.ChoreRecurrenceWeekly,
+		IsActive:          true,
+		IsRotation:        true,
+	})
+	require.NoError(t, err)
+
+	require.NoError(t, repo.ReplaceAssignments(created.ID, []int64{carol.ID, alice.ID, bob.ID}, nil))
+	ids, err := repo.ListAssignedChildIDs(created.ID)
+	require.NoError(t, err)
+	assert.Equal(t, []int64{carol.ID, alice.ID, bob.ID}, ids)
+
+	require.NoError(t, repo.ReplaceAssignments(created.ID, []int64{bob.ID, carol.ID}, &carol.ID))
+	ids, err = repo.ListAssignedChildIDs(created.ID)
+	require.NoError(t, err)
+	assert.Equal(t, []int64{bob.ID, carol.ID}, ids)
+
+	fetched, err := repo.GetByID(created.ID)
+	require.NoError(t, err)
+	require.NotNil(t, fetched.RotationChildID)
+	assert.Equal(t, carol.ID, *fetched.RotationChildID)
+
+	require.NoError(t, repo.SetRotationChild(created.ID, bob.ID))
+	fetched, err = repo.GetByID(created.ID)
+	require.NoError(t, err)
+	assert.Equal(t, bob.ID, *fetched.RotationChildID)
+
+	// ListAllActiveRecurring returns the children in rotation order
+	recurring, err := repo.ListAllActiveRecurring()
+	require.NoError(t, err)
+	require.Len(t, recurring, 1)
+	assert.Equal(t, []int64{bob.ID, carol.ID}, recurring[0].ChildIDs)
+}
+
+func TestChoreRepo_CreateMany(t *testing.T) {
+	db := testDB(t)
+	repo := NewChoreRepo(db)
+
+	fam := createChoreTestFamily(t, db)
+	parent := createChoreTestParent(t, db, fam.ID)
+	alice := createChoreTestChild(t, db, fam.ID, "ManyAlice")
+	bob := createChoreTestChild(t, db, fam.ID, "ManyBob")
+
+	weekly := &models.Chore{
+		FamilyID:          fam.ID,
+		CreatedByParentID: parent.ID,
+		Name:              "Vacuum",
+		RewardCents:       300,
+		Recurrence:        models.ChoreRecurrenceWeekly,
+		DayOfWeek:         intP(6),
+		IsActive:          true,
+	}
+	oneTime := &models.Chore{
+		FamilyID:          fam.ID,
+		CreatedByParentID: parent.ID,
+		Name:              "Clean garage",
+		RewardCents:       1000,
+		Recurrence:        models.ChoreRecurrenceOneTime,
+		IsActive:          true,
+	}
+
+	err := repo.CreateMany([]NewChore{
+		{Chore: weekly, ChildIDs: []int64{bob.ID, alice.ID}},
+		{Chore: oneTime, ChildIDs: []int64{alice.ID}},
+	})
+	require.NoError(t, err)
+	require.True(t, weekly.ID > 0)
+	require.True(t, oneTime.ID > 0)
+
+	ids, err := repo.ListAssignedChildIDs(weekly.ID)
+	require.NoError(t, err)
+	assert.Equal(t, []int64{bob.ID, alice.ID}, ids)
+
+	// Only the one-time chore gets instances up front
+	var count int64
+	require.NoError(t, db.Model(&models.ChoreInstance{}).Where("chore_id = ?", weekly.ID).Count(&count).Error)
+	assert.Equal(t, int64(0), count)
+	var instances []models.ChoreInstance
+	require.NoError(t, db.Where("chore_id = ?", oneTime.ID).Find(&instances).Error)
+	require.Len(t, instances, 1)
+	assert.Equal(t, alice.ID, instances[0].ChildID)
+	assert.Equal(t, 1000, instances[0].RewardCents)
+	assert.Equal(t, models.ChoreInstanceStatusAvailable, instances[0].Status)
+
+	// An inactive one-time chore stays inactive and gets no instances
+	paused := &models.Chore{
+		FamilyID:          fam.ID,
+		CreatedByParentID: parent.ID,
+		Name:              "Paint fence",
+		RewardCents:       500,
+		Recurrence:        models.ChoreRecurrenceOneTime,
+		IsActive:          false,
+	}
+	require.NoError(t, repo.CreateMany([]NewChore{{Chore: paused, ChildIDs: []int64{alice.ID}}}))
+	stored, err := repo.GetByID(paused.ID)
+	require.NoError(t, err)
+	assert.False(t, stored.IsActive)
+	require.NoError(t, db.Model(&models.ChoreInstance{}).Where("chore_id = ?", paused.ID).Count(&count).Error)
+	assert.Equal(t, int64(0), count)
+
+	// A failing chore rolls back the whole batch
+	err = repo.CreateMany([]NewChore{
+		{Chore: &models.Chore{FamilyID: fam.ID, CreatedByParentID: parent.ID, Name: "Dust", Recurrence: models.ChoreRecurrenceDaily, IsActive: true}, ChildIDs: []int64{alice.ID}},
+		{Chore: &models.Chore{FamilyID: fam.ID, CreatedByParentID: parent.ID, Name: "Mop", Recurrence: models.ChoreRecurrenceDaily, IsActive: true}, ChildIDs: []int64{999999}},
+	})
+	require.Error(t, err)
+	all, err := repo.ListByFamily(fam.ID)
+	require.NoError(t, err)
+	assert.Len(t, all, 3)
+}
@@ -0,0 +1,112 @@
+package repositories
+
+import (
+	"errors"
+	"fmt"
+
+	"bank-of-dad/models"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// ErrStreakBonusAwarded is returned when a streak milestone has already been paid.
+var ErrStreakBonusAwarded = errors.New("streak bonus already awarded")
+
+// ChoreStreakRepo handles database operations for chore streak bonuses using GORM.
+type ChoreStreakRepo struct {
+	db *gorm.DB
+}
+
+// NewChoreStreakRepo creates a new ChoreStreakRepo.
+func NewChoreStreakRepo(db *gorm.DB) *ChoreStreakRepo {
+	return &ChoreStreakRepo{db: db}
+}
+
+// ListBonuses returns a chore's streak bonuses, ordered by streak length.
+func (r *ChoreStreakRepo) ListBonuses(choreID int64) ([]models.ChoreStreakBonus, error) {
+	var bonuses []models.ChoreStreakBonus
+	err := r.db.Where("chore_id = ?", choreID).Order("streak_length ASC").Find(&bonuses).Error
+	if err != nil {
+		return nil, fmt.Errorf("list streak bonuses: %w", err)
+	}
+	return bonuses, nil
+}
+
+// ReplaceBonuses replaces all streak bonuses for a chore in a single transaction.
+func (r *ChoreStreakRepo) ReplaceBonuses(choreID int64, bonuses []models.ChoreStreakBonus) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("chore_id = ?", choreID).Delete(&models.ChoreStreakBonus{}).Error; err != nil {
+			return fmt.Errorf("delete streak bonuses: %w", err)
+		}
+		for i := range bonuses {
+			bonuses[i].ID = 0
+			bonuses[i].ChoreID = choreID
+			if err := tx.Create(&bonuses[i]).Error; err != nil {
+				return fmt.Errorf("insert streak bonus: %w", err)
+			}
+		}
+		return nil
+	})
+}
+
+// Award pays a streak bonus as a chore transaction and records the award, atomically.
+// Returns ErrStreakBonusAwarded if this streak already paid the milestone.
+func (r *ChoreStreakRepo) Award(award *models.ChoreStreakAward, parentID int64, note string) (*models.Transaction, int64, error) {
+	if award.BonusCents <= 0 {
+		return nil, 0, fmt.Errorf("bonus must be positive")
+	}
+
+	var transaction models.Transaction
+	var newBalance int64
+
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		// The unique streak constraint makes concurrent approvals pay the milestone once
+		result := tx.Clauses(clause.OnConflict{DoNothing: true}).Create(award)
+		if result.Error != nil {
+			return fmt.Errorf("insert streak award: %w", result.Error)
+		}
+		if result.RowsAffected == 0 {
+			return ErrStreakBonusAwarded
+		}
+
+		transaction = models.Transaction{
+			ChildID:         award.ChildID,
+			ParentID:        parentID,
+			AmountCents:     award.BonusCents,
+			TransactionType: models.TransactionTypeChore,
+			Note:            nullableString(note),
+		}
+		if err := tx.Create(&transaction).Error; err != nil {
+			return fmt.Errorf("insert transaction: %w", err)
+		}
+
+		if err := tx.Exec(
+			`UPDATE children SET balance_cents = balance_cents + ?, updated_at = NOW() WHERE id = ?`,
+			award.BonusCents, award.ChildID,
+		).Error; err != nil {
+			return fmt.Errorf("update balance: %w", err)
+		}
+		if err := applyAllocationRules(tx, &transaction); err != nil {
+			return err
+		}
+
+		if err := tx.Model(award).Update("transaction_id", transaction.ID).Error; err != nil {
+			return fmt.Errorf("link streak award transaction: %w", err)
+		}
+		award.TransactionID = &transaction.ID
+
+		var child models.Child
+		if err := tx.Select("balance_cents").First(&child, award.ChildID).Error; err != nil {
+			return fmt.Errorf("get new balance: %w", err)
+		}
+		newBalance = child.BalanceCents
+
+		return nil
+	})
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return &transaction, newBalance, nil
+}
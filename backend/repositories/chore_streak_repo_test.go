@@ -0,0 +1,89 @@
+package repositories
+
+import (
+	"testing"
+	"time"
+
+	"bank-of-dad/models"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestChoreStreakRepo_ReplaceBonuses(t *testing.T) {
+	db := testDB(t)
+	repo := NewChoreStreakRepo(db)
+
+	fam := createChoreTestFamily(t, db)
+	parent := createChoreTestParent(t, db, fam.ID)
+	chore, err := NewChoreRepo(db).Create(&models.Chore{
+		FamilyID:          fam.ID,
+		CreatedByParentID: parent.ID,
+		Name:              "Make Bed",
+		RewardCents:       50,
+		Recurrence:        models.ChoreRecurrenceDaily,
+		IsActive:          true,
+	})
+	require.NoError(t, err)
+
+	require.NoError(t, repo.ReplaceBonuses(chore.ID, []models.ChoreStreakBonus{
+		{StreakLength: 30, BonusCents: 500},
+		{StreakLength: 7, BonusCents: 100},
+	}))
+	bonuses, err := repo.ListBonuses(chore.ID)
+	require.NoError(t, err)
+	require.Len(t, bonuses, 2)
+	assert.Equal(t, 7, bonuses[0].StreakLength)
+	assert.Equal(t, 30, bonuses[1].StreakLength)
+
+	require.NoError(t, repo.ReplaceBonuses(chore.ID, nil))
+	bonuses, err = repo.ListBonuses(chore.ID)
+	require.NoError(t, err)
+	assert.Empty(t, bonuses)
+}
+
+func TestChoreStreakRepo_AwardOncePerStreak(t *testing.T) {
+	db := testDB(t)
+	repo := NewChoreStreakRepo(db)
+
+	fam := createChoreTestFamily(t, db)
+	parent := createChoreTestParent(t, db, fam.ID)
+	child := createChoreTestChild(t, db, fam.ID, "StreakKid")
+	chore, err := NewChoreRepo(db).Create(&models.Chore{
+		FamilyID:          fam.ID,
+		CreatedByParentID: parent.ID,
+		Name:              "Make Bed",
+		RewardCents:       50,
+		Recurrence:        models.ChoreRecurrenceDaily,
+		IsActive:          true,
+	})
+	require.NoError(t, err)
+
+	startedOn := time.Date(2026, time.March, 1, 0, 0, 0, 0, time.UTC)
+	newAward := func(start time.Time) *models.ChoreStreakAward {
+		return &models.ChoreStreakAward{
+			ChoreID:         chore.ID,
+			ChildID:         child.ID,
+			StreakLength:    7,
+			StreakStartedOn: start,
+			BonusCents:      100,
+		}
+	}
+
+	award := newAward(startedOn)
+	tx, balance, err := repo.Award(award, parent.ID, "Streak bonus: 7 in a row on Make Bed")
+	require.NoError(t, err)
+	assert.Equal(t, models.TransactionTypeChore, tx.TransactionType)
+	assert.Equal(t, int64(100), balance)
+	require.NotNil(t, award.TransactionID)
+	assert.Equal(t, tx.ID, *award.TransactionID)
+
+	// Same streak again pays nothing
+	_, _, err = repo.Award(newAward(startedOn), parent.ID, "again")
+	assert.ErrorIs(t, err, ErrStreakBonusAwarded)
+
+	// A later streak reaching the same milestone pays again
+	_, balance, err = repo.Award(newAward(startedOn.AddDate(0, 1, 0)), parent.ID, "next streak")
+	require.NoError(t, err)
+	assert.Equal(t, int64(200), balance)
+}
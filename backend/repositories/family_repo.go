@@ -1,6 +1,8 @@
 package repositories
 
 import (
+	"crypto/rand"
+	"encoding/base64"
 	"fmt"
 	"time"
 
@@ -119,6 +121,103 @@ func (r *FamilyRepo) UpdateBankName(familyID int64, bankName string) error {
 	return nil
 }
 
+// GetWithdrawalExpiryDays returns how many days a family's pending withdrawal requests last.
+func (r *FamilyRepo) GetWithdrawalExpiryDays(familyID int64) (int, error) {
+	var f models.Family
+	err := r.db.Select("withdrawal_expiry_days").First(&f, familyID).Error
+	if err != nil {
+		return 0, fmt.Errorf("get withdrawal expiry days: %w", err)
+	}
+	return f.WithdrawalExpiryDays, nil
+}
+
+// UpdateWithdrawalExpiryDays sets how many days a family's pending withdrawal requests last.
+func (r *FamilyRepo) UpdateWithdrawalExpiryDays(familyID int64, days int) error {
+	result := r.db.Model(&models.Family{}).Where("id = ?", familyID).Update("withdrawal_expiry_days", days)
+	if result.Error != nil {
+		return fmt.Errorf("update withdrawal expiry days: %w", result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return fmt.Errorf("family not found: %d", familyID)
+	}
+	return nil
+}
+
+// GetWithdrawalQuorum returns the amount above which a family's withdrawal requests need several
+// parents' approval, or nil if any one parent can approve, and how many parents must approve.
+func (r *FamilyRepo) GetWithdrawalQuorum(familyID int64) (*int, int, error) {
+	var f models.Family
+	err := r.db.Select("withdrawal_quorum_threshold_cents, withdrawal_quorum_approvals").First(&f, familyID).Error
+	if err != nil {
+		return nil, 0, fmt.Errorf("get withdrawal quorum: %w", err)
+	}
+	return f.WithdrawalQuorumThresholdCents, f.WithdrawalQuorumApprovals, nil
+}
+
+// UpdateWithdrawalQuorum sets the amount above which a family's withdrawal requests need approvals
+// from several parents, and how many. A nil threshold lets any one parent approve any request.
+func (r *FamilyRepo) UpdateWithdrawalQuorum(familyID int64, thresholdCents *int, approvals int) error {
+	result := r.db.Model(&models.Family{}).Where("id = ?", familyID).Updates(map[string]interface{}{
+		"withdrawal_quorum_threshold_cents": thresholdCents,
+		"withdrawal_quorum_approvals":       approvals,
+	})
+	if result.Error != nil {
+		return fmt.Errorf("update withdrawal quorum: %w", result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return fmt.Errorf("family not found: %d", familyID)
+	}
+	return nil
+}
+
+// CountParents returns how many parents belong to a family.
+func (r *FamilyRepo) CountParents(familyID int64) (int64, error) {
+	var count int64
+	if err := r.db.Model(&models.Parent{}).Where("family_id = ?", familyID).Count(&count).Error; err != nil {
+		return 0, fmt.Errorf("count parents: %w", err)
+	}
+	return count, nil
+}
+
+// RotateCalendarToken generates a new calendar feed token for a family, replacing any
+// previous one, and returns the raw token. Only its SHA-256 hash is stored.
+func (r *FamilyRepo) RotateCalendarToken(familyID int64) (string, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", fmt.Errorf("generate calendar token: %w", err)
+	}
+	rawToken := base64.RawURLEncoding.EncodeToString(b)
+	result := r.db.Model(&models.Family{}).Where("id = ?", familyID).Update("calendar_token_hash", HashToken(rawToken))
+	if result.Error != nil {
+		return "", fmt.Errorf("update calendar token: %w", result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return "", fmt.Errorf("family not found: %d", familyID)
+	}
+	return rawToken, nil
+}
+
+// ClearCalendarToken revokes a family's calendar feed token.
+func (r *FamilyRepo) ClearCalendarToken(familyID int64) error {
+	if err := r.db.Model(&models.Family{}).Where("id = ?", familyID).Update("calendar_token_hash", nil).Error; err != nil {
+		return fmt.Errorf("clear calendar token: %w", err)
+	}
+	return nil
+}
+
+// GetByCalendarToken retrieves the family a raw calendar feed token belongs to. Returns (nil, nil) if not found.
+func (r *FamilyRepo) GetByCalendarToken(rawToken string) (*models.Family, error) {
+	var f models.Family
+	err := r.db.Where("calendar_token_hash = ?", HashToken(rawToken)).First(&f).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("get family by calendar token: %w", err)
+	}
+	return &f, nil
+}
+
 // SlugExists checks whether a slug is already in use.
 func (r *FamilyRepo) SlugExists(slug string) (bool, error) {
 	var count int64
@@ -479,3 +479,31 @@ func TestGetSubscriptionByFamilyID_NotFound(t *testing.T) {
 	require.NoError(t, err)
 	assert.Nil(t, info)
 }
+
+func TestCalendarToken_RotateAndLookup(t *testing.T) {
+	db := testDB(t)
+	fr := NewFamilyRepo(db)
+
+	fam, err := fr.Create("calendar-family")
+	require.NoError(t, err)
+
+	first, err := fr.RotateCalendarToken(fam.ID)
+	require.NoError(t, err)
+	found, err := fr.GetByCalendarToken(first)
+	require.NoError(t, err)
+	require.NotNil(t, found)
+	assert.Equal(t, fam.ID, found.ID)
+
+	// Rotating revokes the previous token
+	second, err := fr.RotateCalendarToken(fam.ID)
+	require.NoError(t, err)
+	assert.NotEqual(t, first, second)
+	found, err = fr.GetByCalendarToken(first)
+	require.NoError(t, err)
+	assert.Nil(t, found)
+
+	require.NoError(t, fr.ClearCalendarToken(fam.ID))
+	found, err = fr.GetByCalendarToken(second)
+	require.NoError(t, err)
+	assert.Nil(t, found)
+}
@@ -0,0 +1,178 @@
+package repositories
+
+import (
+	"errors"
+	"fmt"
+
+	"bank-of-dad/models"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+var (
+	ErrAllocationRuleNotFound = errors.New("allocation rule not found")
+	ErrAllocationRuleExists   = errors.New("goal already has an allocation rule for this source")
+)
+
+// GoalAllocationRuleRepo handles database operations for goal allocation rules using GORM.
+type GoalAllocationRuleRepo struct {
+	db *gorm.DB
+}
+
+// NewGoalAllocationRuleRepo creates a new GoalAllocationRuleRepo.
+func NewGoalAllocationRuleRepo(db *gorm.DB) *GoalAllocationRuleRepo {
+	return &GoalAllocationRuleRepo{db: db}
+}
+
+// ListByChild returns a child's allocation rules in the order they are applied.
+func (r *GoalAllocationRuleRepo) ListByChild(childID int64) ([]models.GoalAllocationRule, error) {
+	var rules []models.GoalAllocationRule
+	if err := r.db.Where("child_id = ?", childID).Order("id").Find(&rules).Error; err != nil {
+		return nil, fmt.Errorf("list allocation rules: %w", err)
+	}
+	return rules, nil
+}
+
+// CountByChild returns how many allocation rules a child has.
+func (r *GoalAllocationRuleRepo) CountByChild(childID int64) (int64, error) {
+	var count int64
+	if err := r.db.Model(&models.GoalAllocationRule{}).Where("child_id = ?", childID).Count(&count).Error; err != nil {
+		return 0, fmt.Errorf("count allocation rules: %w", err)
+	}
+	return count, nil
+}
+
+// Create inserts an allocation rule.
+// Returns ErrAllocationRuleExists if the goal already has a rule for the same source.
+func (r *GoalAllocationRuleRepo) Create(rule *models.GoalAllocationRule) (*models.GoalAllocationRule, error) {
+	if err := r.db.Create(rule).Error; err != nil {
+		if isDuplicateKey(err) {
+			return nil, ErrAllocationRuleExists
+		}
+		return nil, fmt.Errorf("create allocation rule: %w", err)
+	}
+	return rule, nil
+}
+
+// Delete removes one of a child's allocation rules. Allocations it already made are kept.
+// Returns ErrAllocationRuleNotFound if the child has no such rule.
+func (r *GoalAllocationRuleRepo) Delete(id, childID int64) error {
+	result := r.db.Where("id = ? AND child_id = ?", id, childID).Delete(&models.GoalAllocationRule{})
+	if result.Error != nil {
+		return fmt.Errorf("delete allocation rule: %w", result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return ErrAllocationRuleNotFound
+	}
+	return nil
+}
+
+// applyAllocationRules moves shares of a just-posted incoming transaction into the child's savings
+// goals, recording each as a goal allocation. An allowance payout first follows its schedule's
+// splits, then the child's allocation rules apply oldest first. Goals that are no longer active are
+// skipped. Each allocation is capped at the goal's remaining target and the child's available
+// balance, and splits and rules together never allocate more than the transaction brought in.
+// The caller must already have credited the balance.
+func applyAllocationRules(tx *gorm.DB, transaction *models.Transaction) error {
+	source := models.AllocationSourceFor(transaction.TransactionType)
+	if source == "" || transaction.AmountCents <= 0 {
+		return nil
+	}
+
+	var splits []models.AllowanceSplit
+	if transaction.TransactionType == models.TransactionTypeAllowance && transaction.ScheduleID != nil {
+		if err := tx.Where("schedule_id = ?", *transaction.ScheduleID).Order("id").Find(&splits).Error; err != nil {
+			return fmt.Errorf("list allowance splits: %w", err)
+		}
+	}
+
+	var rules []models.GoalAllocationRule
+	err := tx.Where("child_id = ? AND source = ?", transaction.ChildID, source).
+		Order("id").
+		Find(&rules).Error
+	if err != nil {
+		return fmt.Errorf("list allocation rules: %w", err)
+	}
+	if len(splits) == 0 && len(rules) == 0 {
+		return nil
+	}
+
+	available, err := availableCents(tx, transaction.ChildID)
+	if err != nil {
+		return err
+	}
+
+	unallocated := transaction.AmountCents
+	for _, split := range splits {
+		share := min(transaction.AmountCents*int64(split.Percent)/100, unallocated, available)
+		allocated, err := allocateToGoal(tx, transaction, split.GoalID, share, nil)
+		if err != nil {
+			return err
+		}
+		available -= allocated
+		unallocated -= allocated
+	}
+	for i := range rules {
+		rule := &rules[i]
+		share := min(rule.Share(transaction.AmountCents), unallocated, available)
+		allocated, err := allocateToGoal(tx, transaction, rule.GoalID, share, &rule.ID)
+		if err != nil {
+			return err
+		}
+		available -= allocated
+		unallocated -= allocated
+	}
+	return nil
+}
+
+// allocateToGoal moves up to share cents of an incoming transaction into the child's goal goalID,
+// capped at the goal's remaining target, and records the allocation with the rule that made it, if
+// any. Returns how much was allocated, which is 0 when the goal is gone or no longer active.
+func allocateToGoal(tx *gorm.DB, transaction *models.Transaction, goalID, share int64, ruleID *int64) (int64, error) {
+	if share <= 0 {
+		return 0, nil
+	}
+
+	var goal models.SavingsGoal
+	err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+		Where("id = ?", goalID).
+		First(&goal).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return 0, nil
+	}
+	if err != nil {
+		return 0, fmt.Errorf("lock goal: %w", err)
+	}
+	if goal.Status != "active" || goal.ChildID != transaction.ChildID {
+		return 0, nil
+	}
+	if remaining := goal.TargetCents - goal.SavedCents; share > remaining {
+		share = remaining
+	}
+	if share <= 0 {
+		return 0, nil
+	}
+
+	newSavedCents := goal.SavedCents + share
+	updates := map[string]interface{}{"saved_cents": newSavedCents, "updated_at": gorm.Expr("NOW()")}
+	if newSavedCents >= goal.TargetCents {
+		updates["status"] = "completed"
+		updates["completed_at"] = gorm.Expr("NOW()")
+	}
+	if err := tx.Model(&models.SavingsGoal{}).Where("id = ?", goal.ID).Updates(updates).Error; err != nil {
+		return 0, fmt.Errorf("update saved_cents: %w", err)
+	}
+
+	alloc := models.GoalAllocation{
+		GoalID:        goal.ID,
+		ChildID:       transaction.ChildID,
+		AmountCents:   share,
+		RuleID:        ruleID,
+		TransactionID: &transaction.ID,
+	}
+	if err := tx.Create(&alloc).Error; err != nil {
+		return 0, fmt.Errorf("insert allocation: %w", err)
+	}
+	return share, nil
+}
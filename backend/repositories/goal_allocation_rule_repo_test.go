@@ -0,0 +1,228 @@
+package repositories
+
+import (
+	"testing"
+
+	"bank-of-dad/models"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+)
+
+func intPtr(v int) *int { return &v }
+
+func createAllocationRule(t *testing.T, db *gorm.DB, childID, goalID int64, source models.AllocationSource, kind models.AllocationRuleKind, percent, roundTo *int) *models.GoalAllocationRule {
+	t.Helper()
+	rule, err := NewGoalAllocationRuleRepo(db).Create(&models.GoalAllocationRule{
+		ChildID:      childID,
+		GoalID:       goalID,
+		Source:       source,
+		Kind:         kind,
+		Percent:      percent,
+		RoundToCents: roundTo,
+	})
+	require.NoError(t, err)
+	return rule
+}
+
+func TestGoalAllocationRule_Share(t *testing.T) {
+	tests := []struct {
+		name   string
+		rule   models.GoalAllocationRule
+		amount int64
+		want   int64
+	}{
+		{"thirty percent", models.GoalAllocationRule{Kind: models.AllocationRulePercent, Percent: intPtr(30)}, 1000, 300},
+		{"percent rounds down", models.GoalAllocationRule{Kind: models.AllocationRulePercent, Percent: intPtr(30)}, 333, 99},
+		{"all of it", models.GoalAllocationRule{Kind: models.AllocationRulePercent, Percent: intPtr(100)}, 167, 167},
+		{"round up to dollar", models.GoalAllocationRule{Kind: models.AllocationRuleRoundUp, RoundToCents: intPtr(100)}, 1234, 66},
+		{"already round", models.GoalAllocationRule{Kind: models.AllocationRuleRoundUp, RoundToCents: intPtr(100)}, 1200, 0},
+		{"round up to five dollars", models.GoalAllocationRule{Kind: models.AllocationRuleRoundUp, RoundToCents: intPtr(500)}, 1234, 266},
+		{"missing percent", models.GoalAllocationRule{Kind: models.AllocationRulePercent}, 1000, 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, tt.rule.Share(tt.amount))
+		})
+	}
+}
+
+func TestAllocationSourceFor(t *testing.T) {
+	assert.Equal(t, models.AllocationSourceAllowance, models.AllocationSourceFor(models.TransactionTypeAllowance))
+	assert.Equal(t, models.AllocationSourceChore, models.AllocationSourceFor(models.TransactionTypeChore))
+	assert.Equal(t, models.AllocationSourceInterest, models.AllocationSourceFor(models.TransactionTypeInterest))
+	assert.Equal(t, models.AllocationSourceDeposit, models.AllocationSourceFor(models.TransactionTypeDeposit))
+	assert.Equal(t, models.AllocationSource(""), models.AllocationSourceFor(models.TransactionTypeWithdrawal))
+}
+
+func TestGoalAllocationRuleRepo_CreateDuplicate(t *testing.T) {
+	db := testDB(t)
+	_, _, child := createTestFamilyWithParentAndChild(t, db)
+	goal, err := NewSavingsGoalRepo(db).Create(child.ID, "Bike", 10000, nil)
+	require.NoError(t, err)
+
+	createAllocationRule(t, db, child.ID, goal.ID, models.AllocationSourceAllowance, models.AllocationRulePercent, intPtr(30), nil)
+
+	_, err = NewGoalAllocationRuleRepo(db).Create(&models.GoalAllocationRule{
+		ChildID: child.ID,
+		GoalID:  goal.ID,
+		Source:  models.AllocationSourceAllowance,
+		Kind:    models.AllocationRulePercent,
+		Percent: intPtr(50),
+	})
+	assert.Equal(t, ErrAllocationRuleExists, err)
+}
+
+func TestGoalAllocationRuleRepo_Delete(t *testing.T) {
+	db := testDB(t)
+	_, _, child := createTestFamilyWithParentAndChild(t, db)
+	goal, err := NewSavingsGoalRepo(db).Create(child.ID, "Bike", 10000, nil)
+	require.NoError(t, err)
+	rule := createAllocationRule(t, db, child.ID, goal.ID, models.AllocationSourceDeposit, models.AllocationRuleRoundUp, nil, intPtr(100))
+
+	repo := NewGoalAllocationRuleRepo(db)
+	assert.Equal(t, ErrAllocationRuleNotFound, repo.Delete(rule.ID, child.ID+1))
+	require.NoError(t, repo.Delete(rule.ID, child.ID))
+	assert.Equal(t, ErrAllocationRuleNotFound, repo.Delete(rule.ID, child.ID))
+}
+
+func TestApplyAllocationRules_DepositRoundUp(t *testing.T) {
+	db := testDB(t)
+	_, parent, child := createTestFamilyWithParentAndChild(t, db)
+	goal, err := NewSavingsGoalRepo(db).Create(child.ID, "Lego", 10000, nil)
+	require.NoError(t, err)
+	rule := createAllocationRule(t, db, child.ID, goal.ID, models.AllocationSourceDeposit, models.AllocationRuleRoundUp, nil, intPtr(100))
+
+	tx, newBalance, err := NewTransactionRepo(db).Deposit(child.ID, parent.ID, 1234, "Birthday")
+	require.NoError(t, err)
+	assert.Equal(t, int64(1234), newBalance)
+
+	updated, err := NewSavingsGoalRepo(db).GetByID(goal.ID)
+	require.NoError(t, err)
+	assert.Equal(t, int64(66), updated.SavedCents)
+
+	allocs, err := NewGoalAllocationRepo(db).ListByGoal(goal.ID)
+	require.NoError(t, err)
+	require.Len(t, allocs, 1)
+	assert.Equal(t, int64(66), allocs[0].AmountCents)
+	require.NotNil(t, allocs[0].RuleID)
+	assert.Equal(t, rule.ID, *allocs[0].RuleID)
+	require.NotNil(t, allocs[0].TransactionID)
+	assert.Equal(t, tx.ID, *allocs[0].TransactionID)
+}
+
+func TestApplyAllocationRules_AllowancePercent(t *testing.T) {
+	db := testDB(t)
+	_, parent, child := createTestFamilyWithParentAndChild(t, db)
+	goal, err := NewSavingsGoalRepo(db).Create(child.ID, "Bike", 10000, nil)
+	require.NoError(t, err)
+	createAllocationRule(t, db, child.ID, goal.ID, models.AllocationSourceAllowance, models.AllocationRulePercent, intPtr(30), nil)
+
+	dow := 1
+	schedule := &models.AllowanceSchedule{
+		ChildID:     child.ID,
+		ParentID:    parent.ID,
+		AmountCents: 1000,
+		Frequency:   models.FrequencyWeekly,
+		DayOfWeek:   &dow,
+	}
+	require.NoError(t, db.Create(schedule).Error)
+
+	tr := NewTransactionRepo(db)
+	_, _, err = tr.DepositAllowance(child.ID, parent.ID, 1000, schedule.ID, "Weekly allowance")
+	require.NoError(t, err)
+	// Deposits don't match an allowance rule
+	_, _, err = tr.Deposit(child.ID, parent.ID, 1000, "Gift")
+	require.NoError(t, err)
+
+	updated, err := NewSavingsGoalRepo(db).GetByID(goal.ID)
+	require.NoError(t, err)
+	assert.Equal(t, int64(300), updated.SavedCents)
+}
+
+func TestApplyAllocationRules_InterestCompletesGoal(t *testing.T) {
+	db := testDB(t)
+	_, parent, child := createTestFamilyWithParentAndChild(t, db)
+	depositForChild(t, db, child.ID, parent.ID, 20000)
+	goalRepo := NewSavingsGoalRepo(db)
+	goal, err := goalRepo.Create(child.ID, "Lego", 1000, nil)
+	require.NoError(t, err)
+	_, err = goalRepo.Allocate(goal.ID, child.ID, 900)
+	require.NoError(t, err)
+	createAllocationRule(t, db, child.ID, goal.ID, models.AllocationSourceInterest, models.AllocationRulePercent, intPtr(100), nil)
+
+	// 20000 * 1000 / 12 / 10000 = 167 cents of interest, capped at the 100 cents the goal still needs
+	ir := NewInterestRepo(db)
+	require.NoError(t, ir.ApplyInterest(child.ID, parent.ID, 1000, models.FrequencyMonthly))
+
+	updated, err := goalRepo.GetByID(goal.ID)
+	require.NoError(t, err)
+	assert.Equal(t, int64(1000), updated.SavedCents)
+	assert.Equal(t, "completed", updated.Status)
+	assert.NotNil(t, updated.CompletedAt)
+}
+
+func TestApplyAllocationRules_SkipsInactiveGoals(t *testing.T) {
+	db := testDB(t)
+	_, parent, child := createTestFamilyWithParentAndChild(t, db)
+	depositForChild(t, db, child.ID, parent.ID, 1000)
+	goalRepo := NewSavingsGoalRepo(db)
+	done, err := goalRepo.Create(child.ID, "Done", 1000, nil)
+	require.NoError(t, err)
+	createAllocationRule(t, db, child.ID, done.ID, models.AllocationSourceDeposit, models.AllocationRulePercent, intPtr(50), nil)
+	_, err = goalRepo.Allocate(done.ID, child.ID, 1000)
+	require.NoError(t, err)
+
+	_, _, err = NewTransactionRepo(db).Deposit(child.ID, parent.ID, 500, "Gift")
+	require.NoError(t, err)
+
+	allocs, err := NewGoalAllocationRepo(db).ListByGoal(done.ID)
+	require.NoError(t, err)
+	assert.Len(t, allocs, 1)
+}
+
+func TestApplyAllocationRules_SplitAndRulesShareOnePayout(t *testing.T) {
+	db := testDB(t)
+	_, parent, child := createTestFamilyWithParentAndChild(t, db)
+	depositForChild(t, db, child.ID, parent.ID, 5000)
+	goalRepo := NewSavingsGoalRepo(db)
+	bike, err := goalRepo.Create(child.ID, "Bike", 10000, nil)
+	require.NoError(t, err)
+	lego, err := goalRepo.Create(child.ID, "Lego", 10000, nil)
+	require.NoError(t, err)
+	game, err := goalRepo.Create(child.ID, "Game", 10000, nil)
+	require.NoError(t, err)
+
+	sched := createTestSchedule(t, db, child.ID, parent.ID)
+	require.NoError(t, NewScheduleRepo(db).ReplaceSplits(sched.ID, []models.AllowanceSplit{{GoalID: bike.ID, Percent: 80}}))
+	// Rounding 1000 up to $20 would take 1000 and 50% would take 500, but only 200 is left after the split
+	createAllocationRule(t, db, child.ID, lego.ID, models.AllocationSourceAllowance, models.AllocationRuleRoundUp, nil, intPtr(2000))
+	createAllocationRule(t, db, child.ID, game.ID, models.AllocationSourceAllowance, models.AllocationRulePercent, intPtr(50), nil)
+
+	_, _, err = NewTransactionRepo(db).DepositAllowance(child.ID, parent.ID, 1000, sched.ID, "Weekly allowance")
+	require.NoError(t, err)
+
+	for goalID, want := range map[int64]int64{bike.ID: 800, lego.ID: 200, game.ID: 0} {
+		updated, err := goalRepo.GetByID(goalID)
+		require.NoError(t, err)
+		assert.Equal(t, want, updated.SavedCents, updated.Name)
+	}
+}
+
+func TestApplyAllocationRules_RoundUpCappedByAmount(t *testing.T) {
+	db := testDB(t)
+	_, parent, child := createTestFamilyWithParentAndChild(t, db)
+	depositForChild(t, db, child.ID, parent.ID, 5000)
+	goal, err := NewSavingsGoalRepo(db).Create(child.ID, "Lego", 10000, nil)
+	require.NoError(t, err)
+	createAllocationRule(t, db, child.ID, goal.ID, models.AllocationSourceDeposit, models.AllocationRuleRoundUp, nil, intPtr(10000))
+
+	// Rounding 5 cents up to $100 would take 9995 cents; the rule only moves what came in
+	_, _, err = NewTransactionRepo(db).Deposit(child.ID, parent.ID, 5, "Found a nickel")
+	require.NoError(t, err)
+
+	updated, err := NewSavingsGoalRepo(db).GetByID(goal.ID)
+	require.NoError(t, err)
+	assert.Equal(t, int64(5), updated.SavedCents)
+}
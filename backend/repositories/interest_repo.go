@@ -85,6 +85,26 @@ func (r *InterestRepo) ListDueForInterest() ([]InterestDue, error) {
 	return dues, nil
 }
 
+// InterestForPeriod returns the interest, rounded to the nearest cent, that one period of the
+// given frequency earns on a balance at an annual rate in basis points.
+func InterestForPeriod(balanceCents int64, rateBps int, frequency models.Frequency) int64 {
+	var periodsPerYear int
+	switch frequency {
+	case models.FrequencyWeekly:
+		periodsPerYear = 52
+	case models.FrequencyBiweekly:
+		periodsPerYear = 26
+	case models.FrequencyMonthly:
+		periodsPerYear = 12
+	default:
+		periodsPerYear = 12
+	}
+
+	// Calculate interest: balance_cents * rate_bps / periodsPerYear / 10000
+	interestFloat := float64(balanceCents) * float64(rateBps) / float64(periodsPerYear) / 10000.0
+	return int64(math.Round(interestFloat))
+}
+
 // ApplyInterest atomically calculates interest, creates a transaction, updates the balance,
 // and sets last_interest_at. frequency controls proration: monthly=12, biweekly=26, weekly=52 periods per year.
 // Returns an error if the calculated interest rounds to zero.
@@ -105,22 +125,7 @@ func (r *InterestRepo) ApplyInterest(childID, parentID int64, rateBps int, frequ
 		return fmt.Errorf("no interest with zero rate")
 	}
 
-	var periodsPerYear int
-	switch frequency {
-	case models.FrequencyWeekly:
-		periodsPerYear = 52
-	case models.FrequencyBiweekly:
-		periodsPerYear = 26
-	case models.FrequencyMonthly:
-		periodsPerYear = 12
-	default:
-		periodsPerYear = 12
-	}
-
-	// Calculate interest: balance_cents * rate_bps / periodsPerYear / 10000
-	interestFloat := float64(balanceCents) * float64(rateBps) / float64(periodsPerYear) / 10000.0
-	interestCents := int64(math.Round(interestFloat))
-
+	interestCents := InterestForPeriod(balanceCents, rateBps, frequency)
 	if interestCents <= 0 {
 		return fmt.Errorf("calculated interest rounds to zero")
 	}
@@ -150,6 +155,10 @@ func (r *InterestRepo) ApplyInterest(childID, parentID int64, rateBps int, frequ
 			return fmt.Errorf("update balance: %w", err)
 		}
 
+		if err := applyAllocationRules(tx, &transaction); err != nil {
+			return err
+		}
+
 		return nil
 	})
 }
@@ -68,6 +68,7 @@ func (r *InterestScheduleRepo) Update(sched *models.InterestSchedule) (*models.I
 			"frequency":    sched.Frequency,
 			"day_of_week":  sched.DayOfWeek,
 			"day_of_month": sched.DayOfMonth,
+			"rrule":        sched.RRule,
 			"next_run_at":  sched.NextRunAt,
 			"updated_at":   gorm.Expr("NOW()"),
 		}).Error
@@ -121,6 +122,22 @@ func (r *InterestScheduleRepo) ListAllActiveWithTimezone() ([]DueInterestSchedul
 	return results, nil
 }
 
+// ListActiveByFamily returns the active interest schedules of a family's enabled children.
+func (r *InterestScheduleRepo) ListActiveByFamily(familyID int64) ([]models.InterestSchedule, error) {
+	var results []models.InterestSchedule
+	err := r.db.
+		Table("interest_schedules s").
+		Select("s.*").
+		Joins("JOIN children c ON c.id = s.child_id").
+		Where("c.family_id = ? AND s.status = ? AND c.is_disabled = ?", familyID, "active", false).
+		Order("s.next_run_at ASC").
+		Find(&results).Error
+	if err != nil {
+		return nil, fmt.Errorf("list active interest schedules by family: %w", err)
+	}
+	return results, nil
+}
+
 // UpdateNextRunAt sets the next_run_at for an interest schedule.
 func (r *InterestScheduleRepo) UpdateNextRunAt(id int64, nextRunAt time.Time) error {
 	err := r.db.Model(&models.InterestSchedule{}).
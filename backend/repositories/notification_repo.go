@@ -0,0 +1,70 @@
+package repositories
+
+import (
+	"errors"
+	"fmt"
+
+	"bank-of-dad/models"
+
+	"gorm.io/gorm"
+)
+
+// ErrNotificationNotFound is returned when a notification does not exist or belongs to someone else.
+var ErrNotificationNotFound = errors.New("notification not found")
+
+// NotificationRepo handles database operations for in-app notifications using GORM.
+type NotificationRepo struct {
+	db *gorm.DB
+}
+
+// NewNotificationRepo creates a new NotificationRepo.
+func NewNotificationRepo(db *gorm.DB) *NotificationRepo {
+	return &NotificationRepo{db: db}
+}
+
+// ListForParent returns a parent's notifications, newest first.
+func (r *NotificationRepo) ListForParent(parentID int64, limit int) ([]models.Notification, error) {
+	return r.list("parent_id", parentID, limit)
+}
+
+// ListForChild returns a child's notifications, newest first.
+func (r *NotificationRepo) ListForChild(childID int64, limit int) ([]models.Notification, error) {
+	return r.list("child_id", childID, limit)
+}
+
+func (r *NotificationRepo) list(column string, recipientID int64, limit int) ([]models.Notification, error) {
+	var notifications []models.Notification
+	err := r.db.Where(column+" = ?", recipientID).
+		Order("created_at DESC, id DESC").
+		Limit(limit).
+		Find(&notifications).Error
+	if err != nil {
+		return nil, fmt.Errorf("list notifications: %w", err)
+	}
+	return notifications, nil
+}
+
+// MarkReadForParent marks one of a parent's notifications read.
+// Returns ErrNotificationNotFound if it is not theirs.
+func (r *NotificationRepo) MarkReadForParent(id, parentID int64) error {
+	return r.markRead(id, "parent_id", parentID)
+}
+
+// MarkReadForChild marks one of a child's notifications read.
+// Returns ErrNotificationNotFound if it is not theirs.
+func (r *NotificationRepo) MarkReadForChild(id, childID int64) error {
+	return r.markRead(id, "child_id", childID)
+}
+
+func (r *NotificationRepo) markRead(id int64, column string, recipientID int64) error {
+	result := r.db.Model(&models.Notification{}).
+		Where("id = ? AND "+column+" = ?", id, recipientID).
+		Update("read_at", gorm.Expr("COALESCE(read_at, NOW())"))
+	if result.Error != nil {
+		return fmt.Errorf("mark notification read: %w", result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return ErrNotificationNotFound
+	}
+	return nil
+}
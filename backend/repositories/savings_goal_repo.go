@@ -3,6 +3,7 @@ package repositories
 import (
 	"errors"
 	"fmt"
+	"time"
 
 	"bank-of-dad/models"
 
@@ -11,10 +12,12 @@ import (
 )
 
 var (
-	ErrGoalNotFound             = errors.New("goal not found or not active")
-	ErrInsufficientAvailable    = errors.New("amount exceeds available balance")
-	ErrDeallocationExceedsSaved = errors.New("de-allocation exceeds saved amount")
-	ErrZeroAllocation           = errors.New("allocation amount must be non-zero")
+	ErrGoalNotFound              = errors.New("goal not found or not active")
+	ErrInsufficientAvailable     = errors.New("amount exceeds available balance")
+	ErrDeallocationExceedsSaved  = errors.New("de-allocation exceeds saved amount")
+	ErrZeroAllocation            = errors.New("allocation amount must be non-zero")
+	ErrGoalNotRedeemable         = errors.New("goal is not completed or already purchased")
+	ErrSharedGoalHasContributors = errors.New("others have contributed to the shared goal")
 )
 
 // UpdateGoalParams contains the optional fields for updating a savings goal.
@@ -23,12 +26,17 @@ type UpdateGoalParams struct {
 	TargetCents *int64
 	Emoji       *string
 	EmojiSet    bool // if true and Emoji is nil, clears the emoji
+
+	TargetDate    *time.Time
+	TargetDateSet bool // if true and TargetDate is nil, clears the target date
 }
 
 // AffectedGoalInfo represents a goal affected by a withdrawal.
+// For a shared goal, the saved amounts are the child's own contribution.
 type AffectedGoalInfo struct {
 	ID                int64  `json:"id"`
 	Name              string `json:"name"`
+	Shared            bool   `json:"shared,omitempty"`
 	CurrentSavedCents int64  `json:"current_saved_cents"`
 	NewSavedCents     int64  `json:"new_saved_cents"`
 }
@@ -45,11 +53,17 @@ func NewSavingsGoalRepo(db *gorm.DB) *SavingsGoalRepo {
 
 // Create inserts a new savings goal and returns it.
 func (r *SavingsGoalRepo) Create(childID int64, name string, targetCents int64, emoji *string) (*models.SavingsGoal, error) {
+	return r.CreateWithTargetDate(childID, name, targetCents, emoji, nil)
+}
+
+// CreateWithTargetDate creates a new savings goal the child aims to reach by targetDate, if set.
+func (r *SavingsGoalRepo) CreateWithTargetDate(childID int64, name string, targetCents int64, emoji *string, targetDate *time.Time) (*models.SavingsGoal, error) {
 	goal := models.SavingsGoal{
 		ChildID:     childID,
 		Name:        name,
 		TargetCents: targetCents,
 		Emoji:       emoji,
+		TargetDate:  targetDate,
 	}
 	if err := r.db.Create(&goal).Error; err != nil {
 		return nil, fmt.Errorf("insert savings goal: %w", err)
@@ -123,16 +137,9 @@ func (r *SavingsGoalRepo) Allocate(goalID, childID, amountCents int64) (*models.
 
 		if amountCents > 0 {
 			// Positive allocation: check available balance
-			var availableBalance int64
-			err = tx.Raw(
-				`SELECT c.balance_cents - COALESCE(SUM(sg.saved_cents), 0)
-				 FROM children c
-				 LEFT JOIN savings_goals sg ON sg.child_id = c.id AND sg.status = 'active'
-				 WHERE c.id = ?
-				 GROUP BY c.balance_cents`, childID,
-			).Scan(&availableBalance).Error
+			availableBalance, err := availableCents(tx, childID)
 			if err != nil {
-				return fmt.Errorf("check available balance: %w", err)
+				return err
 			}
 			if amountCents > availableBalance {
 				return ErrInsufficientAvailable
@@ -207,6 +214,9 @@ func (r *SavingsGoalRepo) Update(goalID, childID int64, params *UpdateGoalParams
 		if params.EmojiSet {
 			updates["emoji"] = params.Emoji
 		}
+		if params.TargetDateSet {
+			updates["target_date"] = params.TargetDate
+		}
 
 		if err := tx.Model(&models.SavingsGoal{}).Where("id = ?", goalID).Updates(updates).Error; err != nil {
 			return fmt.Errorf("update goal: %w", err)
@@ -265,156 +275,327 @@ func (r *SavingsGoalRepo) Delete(goalID, childID int64) (int64, error) {
 	return savedCents, nil
 }
 
-// ReduceGoalsProportionally reduces active goals' saved_cents proportionally to release totalToRelease cents.
-// Records de-allocation entries for each affected goal. All within a single DB transaction.
-func (r *SavingsGoalRepo) ReduceGoalsProportionally(childID, totalToRelease int64) error {
-	if totalToRelease <= 0 {
-		return nil
-	}
+// Redeem spends a completed goal's saved funds: it withdraws them from the child's balance on
+// behalf of parentID and marks the goal purchased, linked to the withdrawal, in one transaction.
+// Any pending withdrawal request to redeem the goal is cancelled. Returns ErrGoalNotRedeemable if
+// the goal is not completed, or models.ErrInsufficientFunds if the balance no longer covers the
+// goal after other goals' allocations.
+func (r *SavingsGoalRepo) Redeem(goalID, childID, parentID int64) (*models.SavingsGoal, *models.Transaction, int64, error) {
+	var transaction models.Transaction
+	var newBalance int64
 
-	return r.db.Transaction(func(tx *gorm.DB) error {
-		// Get all active goals with saved_cents > 0
-		var goals []models.SavingsGoal
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		// Lock in the same order as approving a withdrawal request: request, child, then goal
+		var pending []models.WithdrawalRequest
 		err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
-			Where("child_id = ? AND status = 'active' AND saved_cents > 0", childID).
-			Find(&goals).Error
+			Where("savings_goal_id = ? AND status = ?", goalID, models.WithdrawalRequestStatusPending).
+			Find(&pending).Error
 		if err != nil {
-			return fmt.Errorf("query active goals: %w", err)
+			return fmt.Errorf("lock goal withdrawal requests: %w", err)
 		}
 
-		if len(goals) == 0 {
-			return nil
-		}
-
-		var totalSaved int64
-		for _, g := range goals {
-			totalSaved += g.SavedCents
+		var child models.Child
+		err = tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			Select("id, balance_cents").
+			First(&child, childID).Error
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return ErrGoalNotFound
 		}
-		if totalSaved == 0 {
-			return nil
+		if err != nil {
+			return fmt.Errorf("get current balance: %w", err)
 		}
 
-		// Cap the release to total saved
-		release := totalToRelease
-		if release > totalSaved {
-			release = totalSaved
+		goal, err := lockCompletedGoal(tx, goalID, childID)
+		if err != nil {
+			return err
 		}
 
-		// Proportionally reduce each goal
-		var released int64
-		for i, g := range goals {
-			var reduction int64
-			if i == len(goals)-1 {
-				reduction = release - released
-			} else {
-				reduction = g.SavedCents * release / totalSaved
-			}
-
-			if reduction <= 0 {
-				continue
-			}
-			if reduction > g.SavedCents {
-				reduction = g.SavedCents
-			}
-
-			newSaved := g.SavedCents - reduction
-			if err := tx.Model(&models.SavingsGoal{}).Where("id = ?", g.ID).
-				Updates(map[string]interface{}{"saved_cents": newSaved, "updated_at": gorm.Expr("NOW()")}).Error; err != nil {
-				return fmt.Errorf("update goal saved_cents: %w", err)
-			}
+		totalSaved, err := reservedCents(tx, childID)
+		if err != nil {
+			return err
+		}
+		if child.BalanceCents-totalSaved < goal.SavedCents {
+			return models.ErrInsufficientFunds
+		}
 
-			// Record de-allocation
-			alloc := models.GoalAllocation{
-				GoalID:      g.ID,
-				ChildID:     childID,
-				AmountCents: -reduction,
-			}
-			if err := tx.Create(&alloc).Error; err != nil {
-				return fmt.Errorf("insert de-allocation: %w", err)
-			}
+		note := "Goal purchase: " + goal.Name
+		transaction = models.Transaction{
+			ChildID:         childID,
+			ParentID:        parentID,
+			AmountCents:     goal.SavedCents,
+			TransactionType: models.TransactionTypeWithdrawal,
+			Note:            &note,
+		}
+		if err := tx.Create(&transaction).Error; err != nil {
+			return fmt.Errorf("insert transaction: %w", err)
+		}
+		if err := tx.Exec(
+			`UPDATE children SET balance_cents = balance_cents - ?, updated_at = NOW() WHERE id = ?`,
+			goal.SavedCents, childID,
+		).Error; err != nil {
+			return fmt.Errorf("update balance: %w", err)
+		}
+		newBalance = child.BalanceCents - goal.SavedCents
 
-			released += reduction
+		if err := markGoalPurchased(tx, goalID, transaction.ID); err != nil {
+			return err
 		}
 
+		err = tx.Model(&models.WithdrawalRequest{}).
+			Where("savings_goal_id = ? AND status = ?", goalID, models.WithdrawalRequestStatusPending).
+			Updates(map[string]interface{}{
+				"status":     models.WithdrawalRequestStatusCancelled,
+				"updated_at": gorm.Expr("NOW()"),
+			}).Error
+		if err != nil {
+			return fmt.Errorf("cancel goal withdrawal requests: %w", err)
+		}
 		return nil
 	})
+	if err != nil {
+		return nil, nil, 0, err
+	}
+
+	goal, err := r.GetByID(goalID)
+	if err != nil {
+		return nil, nil, 0, err
+	}
+	return goal, &transaction, newBalance, nil
 }
 
-// GetTotalSavedByChild returns the sum of saved_cents across all active goals for a child.
-func (r *SavingsGoalRepo) GetTotalSavedByChild(childID int64) (int64, error) {
-	var total *int64
-	err := r.db.Model(&models.SavingsGoal{}).
-		Where("child_id = ? AND status = 'active'", childID).
-		Select("COALESCE(SUM(saved_cents), 0)").
-		Scan(&total).Error
+// lockCompletedGoal locks one of a child's goals for redemption.
+// Returns ErrGoalNotFound if the child has no such goal, or ErrGoalNotRedeemable if it is not completed.
+func lockCompletedGoal(tx *gorm.DB, goalID, childID int64) (models.SavingsGoal, error) {
+	var goal models.SavingsGoal
+	err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+		Where("id = ?", goalID).
+		First(&goal).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return goal, ErrGoalNotFound
+	}
 	if err != nil {
+		return goal, fmt.Errorf("lock goal for redemption: %w", err)
+	}
+	if goal.ChildID != childID {
+		return goal, ErrGoalNotFound
+	}
+	if goal.Status != "completed" {
+		return goal, ErrGoalNotRedeemable
+	}
+	return goal, nil
+}
+
+// markGoalPurchased marks a locked, completed goal purchased by the given withdrawal transaction.
+func markGoalPurchased(tx *gorm.DB, goalID, transactionID int64) error {
+	err := tx.Model(&models.SavingsGoal{}).Where("id = ?", goalID).
+		Updates(map[string]interface{}{
+			"status":                  "purchased",
+			"purchased_at":            gorm.Expr("NOW()"),
+			"purchase_transaction_id": transactionID,
+			"updated_at":              gorm.Expr("NOW()"),
+		}).Error
+	if err != nil {
+		return fmt.Errorf("mark goal purchased: %w", err)
+	}
+	return nil
+}
+
+// reservedCentsSQL sums what a child has set aside in savings goals: the saved funds of their
+// active goals plus their contributions to active shared goals. It takes the child ID twice.
+const reservedCentsSQL = `(SELECT COALESCE(SUM(saved_cents), 0) FROM savings_goals WHERE child_id = ? AND status = 'active')
+	 + (SELECT COALESCE(SUM(sgc.saved_cents), 0)
+	    FROM shared_goal_contributions sgc
+	    JOIN shared_goals shg ON shg.id = sgc.shared_goal_id
+	    WHERE sgc.child_id = ? AND shg.status = 'active')`
+
+// reservedCents returns what a child has set aside in active goals, including shared goals.
+func reservedCents(db *gorm.DB, childID int64) (int64, error) {
+	var reserved int64
+	if err := db.Raw("SELECT "+reservedCentsSQL, childID, childID).Scan(&reserved).Error; err != nil {
 		return 0, fmt.Errorf("get total saved: %w", err)
 	}
-	if total == nil {
-		return 0, nil
+	return reserved, nil
+}
+
+// availableCents returns a child's balance less what they have set aside in active goals.
+func availableCents(db *gorm.DB, childID int64) (int64, error) {
+	var available int64
+	err := db.Raw(
+		"SELECT c.balance_cents - ("+reservedCentsSQL+") FROM children c WHERE c.id = ?",
+		childID, childID, childID,
+	).Scan(&available).Error
+	if err != nil {
+		return 0, fmt.Errorf("get available balance: %w", err)
 	}
-	return *total, nil
+	return available, nil
 }
 
-// GetAffectedGoals returns active goals that would be impacted by reducing totalToRelease cents.
-func (r *SavingsGoalRepo) GetAffectedGoals(childID, totalToRelease int64) ([]AffectedGoalInfo, error) {
+// goalRelease is how much a release takes from one of a child's goal savings: a goal of their
+// own, or their contribution to the shared goal SharedGoalID.
+type goalRelease struct {
+	goalID       int64
+	sharedGoalID int64
+	name         string
+	savedCents   int64
+	reduction    int64
+}
+
+// planRelease works out how to release totalToRelease cents of a child's goal savings, taking from
+// each of their active goals and shared goal contributions in proportion to its size. A shared
+// goal only gives up the child's own contribution, never another contributor's. With lock set,
+// the goals and contributions are locked for update.
+func planRelease(db *gorm.DB, childID, totalToRelease int64, lock bool) ([]goalRelease, error) {
+	query := db
+	sharedQuery := `SELECT sgc.shared_goal_id, shg.name, sgc.saved_cents
+		 FROM shared_goal_contributions sgc
+		 JOIN shared_goals shg ON shg.id = sgc.shared_goal_id
+		 WHERE sgc.child_id = ? AND shg.status = 'active' AND sgc.saved_cents > 0
+		 ORDER BY sgc.shared_goal_id`
+	if lock {
+		query = db.Clauses(clause.Locking{Strength: "UPDATE"})
+		sharedQuery += " FOR UPDATE"
+	}
+
 	var goals []models.SavingsGoal
-	err := r.db.Where("child_id = ? AND status = 'active' AND saved_cents > 0", childID).
+	err := query.Where("child_id = ? AND status = 'active' AND saved_cents > 0", childID).
 		Order("id").
 		Find(&goals).Error
 	if err != nil {
-		return nil, fmt.Errorf("query affected goals: %w", err)
+		return nil, fmt.Errorf("query active goals: %w", err)
+	}
+	var shares []struct {
+		SharedGoalID int64
+		Name         string
+		SavedCents   int64
+	}
+	if err := db.Raw(sharedQuery, childID).Scan(&shares).Error; err != nil {
+		return nil, fmt.Errorf("query shared goal contributions: %w", err)
 	}
 
+	releases := make([]goalRelease, 0, len(goals)+len(shares))
 	var totalSaved int64
 	for _, g := range goals {
+		releases = append(releases, goalRelease{goalID: g.ID, name: g.Name, savedCents: g.SavedCents})
 		totalSaved += g.SavedCents
 	}
+	for _, sh := range shares {
+		releases = append(releases, goalRelease{sharedGoalID: sh.SharedGoalID, name: sh.Name, savedCents: sh.SavedCents})
+		totalSaved += sh.SavedCents
+	}
+	if totalSaved == 0 {
+		return nil, nil
+	}
 
+	// Cap the release to total saved
 	release := totalToRelease
 	if release > totalSaved {
 		release = totalSaved
 	}
 
-	var affected []AffectedGoalInfo
+	// Proportionally reduce each goal
+	planned := releases[:0]
 	var released int64
-	for i, g := range goals {
-		var reduction int64
-		if i == len(goals)-1 {
-			reduction = release - released
+	for i, g := range releases {
+		if i == len(releases)-1 {
+			g.reduction = release - released
 		} else {
-			reduction = g.SavedCents * release / totalSaved
+			g.reduction = g.savedCents * release / totalSaved
 		}
-		if reduction <= 0 {
+		if g.reduction <= 0 {
 			continue
 		}
-		if reduction > g.SavedCents {
-			reduction = g.SavedCents
+		if g.reduction > g.savedCents {
+			g.reduction = g.savedCents
 		}
-		affected = append(affected, AffectedGoalInfo{
-			ID:                g.ID,
-			Name:              g.Name,
-			CurrentSavedCents: g.SavedCents,
-			NewSavedCents:     g.SavedCents - reduction,
-		})
-		released += reduction
+		planned = append(planned, g)
+		released += g.reduction
 	}
+	return planned, nil
+}
 
-	return affected, nil
+// ReduceGoalsProportionally reduces active goals' saved_cents proportionally to release totalToRelease cents.
+// Records de-allocation entries for each affected goal. All within a single DB transaction.
+// The child's contributions to shared goals are reduced alongside their own goals; other
+// contributors' shares are left untouched.
+func (r *SavingsGoalRepo) ReduceGoalsProportionally(childID, totalToRelease int64) error {
+	if totalToRelease <= 0 {
+		return nil
+	}
+
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		return reduceGoalsProportionally(tx, childID, totalToRelease)
+	})
 }
 
-// GetAvailableBalance returns the child's balance minus the sum of active goals' saved_cents.
-func (r *SavingsGoalRepo) GetAvailableBalance(childID int64) (int64, error) {
-	var available int64
-	err := r.db.Raw(
-		`SELECT c.balance_cents - COALESCE(SUM(sg.saved_cents), 0)
-		 FROM children c
-		 LEFT JOIN savings_goals sg ON sg.child_id = c.id AND sg.status = 'active'
-		 WHERE c.id = ?
-		 GROUP BY c.balance_cents`, childID,
-	).Scan(&available).Error
+// reduceGoalsProportionally releases totalToRelease cents of a child's goal savings within tx,
+// as ReduceGoalsProportionally does.
+func reduceGoalsProportionally(tx *gorm.DB, childID, totalToRelease int64) error {
+	releases, err := planRelease(tx, childID, totalToRelease, true)
 	if err != nil {
-		return 0, fmt.Errorf("get available balance: %w", err)
+		return err
 	}
-	return available, nil
+
+	for _, g := range releases {
+		if g.sharedGoalID != 0 {
+			if err := releaseSharedContribution(tx, g.sharedGoalID, childID, g.reduction); err != nil {
+				return err
+			}
+			continue
+		}
+
+		if err := tx.Model(&models.SavingsGoal{}).Where("id = ?", g.goalID).
+			Updates(map[string]interface{}{"saved_cents": g.savedCents - g.reduction, "updated_at": gorm.Expr("NOW()")}).Error; err != nil {
+			return fmt.Errorf("update goal saved_cents: %w", err)
+		}
+
+		// Record de-allocation
+		alloc := models.GoalAllocation{
+			GoalID:      g.goalID,
+			ChildID:     childID,
+			AmountCents: -g.reduction,
+		}
+		if err := tx.Create(&alloc).Error; err != nil {
+			return fmt.Errorf("insert de-allocation: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// GetTotalSavedByChild returns what a child has set aside across all active goals, including their
+// contributions to active shared goals.
+func (r *SavingsGoalRepo) GetTotalSavedByChild(childID int64) (int64, error) {
+	return reservedCents(r.db, childID)
+}
+
+// GetAffectedGoals returns active goals that would be impacted by reducing totalToRelease cents.
+// For shared goals the saved amounts are the child's own contribution.
+func (r *SavingsGoalRepo) GetAffectedGoals(childID, totalToRelease int64) ([]AffectedGoalInfo, error) {
+	releases, err := planRelease(r.db, childID, totalToRelease, false)
+	if err != nil {
+		return nil, fmt.Errorf("query affected goals: %w", err)
+	}
+
+	var affected []AffectedGoalInfo
+	for _, g := range releases {
+		info := AffectedGoalInfo{
+			ID:                g.goalID,
+			Name:              g.name,
+			CurrentSavedCents: g.savedCents,
+			NewSavedCents:     g.savedCents - g.reduction,
+		}
+		if g.sharedGoalID != 0 {
+			info.ID = g.sharedGoalID
+			info.Shared = true
+		}
+		affected = append(affected, info)
+	}
+
+	return affected, nil
+}
+
+// GetAvailableBalance returns the child's balance minus what they have set aside in active goals,
+// including their contributions to active shared goals.
+func (r *SavingsGoalRepo) GetAvailableBalance(childID int64) (int64, error) {
+	return availableCents(r.db, childID)
 }
@@ -22,6 +22,25 @@ type DueAllowanceSchedule struct {
 	FamilyTimezone string `json:"family_timezone"`
 }
 
+// AgeBasedSchedule extends AllowanceSchedule with the child's birthdate and the family's
+// timezone, for recalculating age-based amounts on birthdays.
+type AgeBasedSchedule struct {
+	models.AllowanceSchedule
+	ChildBirthdate time.Time `json:"child_birthdate"`
+	FamilyTimezone string    `json:"family_timezone"`
+}
+
+// AllowanceRun is the outcome of one run of a schedule, recorded by ScheduleRepo.RecordRun.
+type AllowanceRun struct {
+	// DepositCents is paid to the child as an allowance transaction with Note; 0 pays nothing.
+	DepositCents int64
+	Note         string
+	// HeldCents is the schedule's total of held payouts after the run.
+	HeldCents int64
+	NextRunAt time.Time
+	Completed bool
+}
+
 // ScheduleRepo handles database operations for allowance schedules using GORM.
 type ScheduleRepo struct {
 	db *gorm.DB
@@ -40,10 +59,10 @@ func (r *ScheduleRepo) Create(sched *models.AllowanceSchedule) (*models.Allowanc
 	return sched, nil
 }
 
-// GetByID retrieves a schedule by its ID. Returns (nil, nil) if not found.
+// GetByID retrieves a schedule and its splits by ID. Returns (nil, nil) if not found.
 func (r *ScheduleRepo) GetByID(id int64) (*models.AllowanceSchedule, error) {
 	var sched models.AllowanceSchedule
-	err := r.db.First(&sched, id).Error
+	err := r.db.Preload("Splits", orderSplits).Preload("AgeSteps", orderAgeSteps).First(&sched, id).Error
 	if errors.Is(err, gorm.ErrRecordNotFound) {
 		return nil, nil
 	}
@@ -53,10 +72,14 @@ func (r *ScheduleRepo) GetByID(id int64) (*models.AllowanceSchedule, error) {
 	return &sched, nil
 }
 
-// GetByChildID returns the single allowance schedule for a child (any status), or nil if none exists.
+// GetByChildID returns the child's primary (earliest-created) allowance schedule (any status),
+// or nil if none exists. Used by the child-scoped /allowance endpoints.
 func (r *ScheduleRepo) GetByChildID(childID int64) (*models.AllowanceSchedule, error) {
 	var sched models.AllowanceSchedule
-	err := r.db.Where("child_id = ?", childID).First(&sched).Error
+	err := r.db.Preload("Splits", orderSplits).Preload("AgeSteps", orderAgeSteps).
+		Where("child_id = ?", childID).
+		Order("created_at ASC, id ASC").
+		First(&sched).Error
 	if errors.Is(err, gorm.ErrRecordNotFound) {
 		return nil, nil
 	}
@@ -79,21 +102,308 @@ func (r *ScheduleRepo) ListByParentFamily(familyID int64) ([]ScheduleWithChild,
 	if err != nil {
 		return nil, fmt.Errorf("list schedules: %w", err)
 	}
+
+	scheduleIDs := make([]int64, len(results))
+	for i := range results {
+		scheduleIDs[i] = results[i].ID
+	}
+	splitsBySchedule, err := r.listSplitsBySchedule(scheduleIDs)
+	if err != nil {
+		return nil, err
+	}
+	stepsBySchedule, err := r.listAgeStepsBySchedule(scheduleIDs)
+	if err != nil {
+		return nil, err
+	}
+	for i := range results {
+		results[i].Splits = splitsBySchedule[results[i].ID]
+		results[i].AgeSteps = stepsBySchedule[results[i].ID]
+	}
 	return results, nil
 }
 
-// Update updates a schedule's amount, frequency, day, note, and next_run_at fields.
+// ListByChild returns all schedules for a child (any status) with their splits, oldest first.
+func (r *ScheduleRepo) ListByChild(childID int64) ([]models.AllowanceSchedule, error) {
+	var schedules []models.AllowanceSchedule
+	err := r.db.Preload("Splits", orderSplits).Preload("AgeSteps", orderAgeSteps).
+		Where("child_id = ?", childID).
+		Order("created_at ASC, id ASC").
+		Find(&schedules).Error
+	if err != nil {
+		return nil, fmt.Errorf("list schedules by child: %w", err)
+	}
+	return schedules, nil
+}
+
+// CountByChild returns the number of schedules (any status) for a child.
+func (r *ScheduleRepo) CountByChild(childID int64) (int, error) {
+	var count int64
+	err := r.db.Model(&models.AllowanceSchedule{}).
+		Where("child_id = ?", childID).
+		Count(&count).Error
+	if err != nil {
+		return 0, fmt.Errorf("count schedules by child: %w", err)
+	}
+	return int(count), nil
+}
+
+// ListSplits returns the splits configured for a schedule.
+func (r *ScheduleRepo) ListSplits(scheduleID int64) ([]models.AllowanceSplit, error) {
+	var splits []models.AllowanceSplit
+	err := r.db.Where("schedule_id = ?", scheduleID).Order("id ASC").Find(&splits).Error
+	if err != nil {
+		return nil, fmt.Errorf("list allowance splits: %w", err)
+	}
+	return splits, nil
+}
+
+// ReplaceSplits atomically replaces all splits for a schedule. An empty slice
+// sends the whole payout to the main balance.
+func (r *ScheduleRepo) ReplaceSplits(scheduleID int64, splits []models.AllowanceSplit) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("schedule_id = ?", scheduleID).Delete(&models.AllowanceSplit{}).Error; err != nil {
+			return fmt.Errorf("delete allowance splits: %w", err)
+		}
+		for i := range splits {
+			split := models.AllowanceSplit{
+				ScheduleID: scheduleID,
+				GoalID:     splits[i].GoalID,
+				Percent:    splits[i].Percent,
+			}
+			if err := tx.Create(&split).Error; err != nil {
+				return fmt.Errorf("insert allowance split: %w", err)
+			}
+		}
+		return nil
+	})
+}
+
+// listSplitsBySchedule batch-loads splits for the given schedules, keyed by schedule ID.
+func (r *ScheduleRepo) listSplitsBySchedule(scheduleIDs []int64) (map[int64][]models.AllowanceSplit, error) {
+	result := make(map[int64][]models.AllowanceSplit)
+	if len(scheduleIDs) == 0 {
+		return result, nil
+	}
+	var splits []models.AllowanceSplit
+	if err := r.db.Where("schedule_id IN ?", scheduleIDs).Order("id ASC").Find(&splits).Error; err != nil {
+		return nil, fmt.Errorf("list allowance splits: %w", err)
+	}
+	for _, split := range splits {
+		result[split.ScheduleID] = append(result[split.ScheduleID], split)
+	}
+	return result, nil
+}
+
+// orderSplits orders preloaded splits by creation.
+func orderSplits(db *gorm.DB) *gorm.DB {
+	return db.Order("allowance_splits.id ASC")
+}
+
+// ReplaceAgeSteps atomically replaces the stepped allowance table for a schedule.
+func (r *ScheduleRepo) ReplaceAgeSteps(scheduleID int64, steps []models.AllowanceAgeStep) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("schedule_id = ?", scheduleID).Delete(&models.AllowanceAgeStep{}).Error; err != nil {
+			return fmt.Errorf("delete allowance age steps: %w", err)
+		}
+		for i := range steps {
+			step := models.AllowanceAgeStep{
+				ScheduleID:  scheduleID,
+				MinAge:      steps[i].MinAge,
+				AmountCents: steps[i].AmountCents,
+			}
+			if err := tx.Create(&step).Error; err != nil {
+				return fmt.Errorf("insert allowance age step: %w", err)
+			}
+		}
+		return nil
+	})
+}
+
+// listAgeStepsBySchedule batch-loads age steps for the given schedules, keyed by schedule ID.
+func (r *ScheduleRepo) listAgeStepsBySchedule(scheduleIDs []int64) (map[int64][]models.AllowanceAgeStep, error) {
+	result := make(map[int64][]models.AllowanceAgeStep)
+	if len(scheduleIDs) == 0 {
+		return result, nil
+	}
+	var steps []models.AllowanceAgeStep
+	if err := r.db.Where("schedule_id IN ?", scheduleIDs).Order("min_age ASC").Find(&steps).Error; err != nil {
+		return nil, fmt.Errorf("list allowance age steps: %w", err)
+	}
+	for _, step := range steps {
+		result[step.ScheduleID] = append(result[step.ScheduleID], step)
+	}
+	return result, nil
+}
+
+// orderAgeSteps orders preloaded age steps from youngest to oldest.
+func orderAgeSteps(db *gorm.DB) *gorm.DB {
+	return db.Order("allowance_age_steps.min_age ASC")
+}
+
+// ListAgeBased returns all age-based schedules (any status) of enabled children with a birthdate,
+// including their age steps, the child's birthdate, and the family's timezone.
+func (r *ScheduleRepo) ListAgeBased() ([]AgeBasedSchedule, error) {
+	var results []AgeBasedSchedule
+	err := r.db.
+		Table("allowance_schedules s").
+		Select("s.*, c.birthdate as child_birthdate, COALESCE(f.timezone, '') as family_timezone").
+		Joins("JOIN children c ON c.id = s.child_id").
+		Joins("JOIN families f ON f.id = c.family_id").
+		Where("s.amount_formula <> ? AND c.birthdate IS NOT NULL AND c.is_disabled = ?", models.AmountFormulaFlat, false).
+		Order("s.id ASC").
+		Find(&results).Error
+	if err != nil {
+		return nil, fmt.Errorf("list age-based schedules: %w", err)
+	}
+
+	scheduleIDs := make([]int64, len(results))
+	for i := range results {
+		scheduleIDs[i] = results[i].ID
+	}
+	stepsBySchedule, err := r.listAgeStepsBySchedule(scheduleIDs)
+	if err != nil {
+		return nil, err
+	}
+	for i := range results {
+		results[i].AgeSteps = stepsBySchedule[results[i].ID]
+	}
+	return results, nil
+}
+
+// ApplyRaise changes an age-based schedule's amount from oldAmount to newAmount and, when the
+// amount goes up, records the change in the raise history. Returns false without changes if the
+// amount is no longer oldAmount (e.g. a concurrent edit or an earlier run already applied the raise).
+func (r *ScheduleRepo) ApplyRaise(sched *models.AllowanceSchedule, age int, oldAmount, newAmount int64) (bool, error) {
+	applied := false
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		result := tx.Model(&models.AllowanceSchedule{}).
+			Where("id = ? AND amount_cents = ?", sched.ID, oldAmount).
+			Updates(map[string]interface{}{
+				"amount_cents": newAmount,
+				"updated_at":   gorm.Expr("NOW()"),
+			})
+		if result.Error != nil {
+			return fmt.Errorf("update schedule amount: %w", result.Error)
+		}
+		if result.RowsAffected == 0 {
+			return nil
+		}
+		applied = true
+		if newAmount <= oldAmount {
+			return nil
+		}
+
+		raise := models.AllowanceRaise{
+			ScheduleID:     sched.ID,
+			ChildID:        sched.ChildID,
+			Age:            age,
+			OldAmountCents: oldAmount,
+			NewAmountCents: newAmount,
+		}
+		if err := tx.Create(&raise).Error; err != nil {
+			return fmt.Errorf("insert allowance raise: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return false, err
+	}
+	return applied, nil
+}
+
+// ListRaisesByChild returns a child's allowance raise history, newest first.
+func (r *ScheduleRepo) ListRaisesByChild(childID int64) ([]models.AllowanceRaise, error) {
+	var raises []models.AllowanceRaise
+	err := r.db.Where("child_id = ?", childID).
+		Order("created_at DESC, id DESC").
+		Find(&raises).Error
+	if err != nil {
+		return nil, fmt.Errorf("list allowance raises: %w", err)
+	}
+	return raises, nil
+}
+
+// SetOverride creates or replaces the override for a schedule's run on o.RunDate.
+func (r *ScheduleRepo) SetOverride(o *models.AllowanceOverride) (*models.AllowanceOverride, error) {
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("schedule_id = ? AND run_date = ?", o.ScheduleID, o.RunDate.Format(time.DateOnly)).
+			Delete(&models.AllowanceOverride{}).Error; err != nil {
+			return fmt.Errorf("delete allowance override: %w", err)
+		}
+		if err := tx.Create(o).Error; err != nil {
+			return fmt.Errorf("insert allowance override: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return o, nil
+}
+
+// GetOverride returns the override for a schedule's run on runDate. Returns (nil, nil) if there is none.
+func (r *ScheduleRepo) GetOverride(scheduleID int64, runDate time.Time) (*models.AllowanceOverride, error) {
+	var o models.AllowanceOverride
+	err := r.db.Where("schedule_id = ? AND run_date = ?", scheduleID, runDate.Format(time.DateOnly)).First(&o).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("get allowance override: %w", err)
+	}
+	return &o, nil
+}
+
+// ListOverrides returns a schedule's overrides for runs on or after from, earliest first.
+func (r *ScheduleRepo) ListOverrides(scheduleID int64, from time.Time) ([]models.AllowanceOverride, error) {
+	var overrides []models.AllowanceOverride
+	err := r.db.Where("schedule_id = ? AND run_date >= ?", scheduleID, from.Format(time.DateOnly)).
+		Order("run_date ASC").
+		Find(&overrides).Error
+	if err != nil {
+		return nil, fmt.Errorf("list allowance overrides: %w", err)
+	}
+	return overrides, nil
+}
+
+// DeleteOverride removes the override for a schedule's run on runDate.
+// Returns false if there was none.
+func (r *ScheduleRepo) DeleteOverride(scheduleID int64, runDate time.Time) (bool, error) {
+	result := r.db.Where("schedule_id = ? AND run_date = ?", scheduleID, runDate.Format(time.DateOnly)).
+		Delete(&models.AllowanceOverride{})
+	if result.Error != nil {
+		return false, fmt.Errorf("delete allowance override: %w", result.Error)
+	}
+	return result.RowsAffected > 0, nil
+}
+
+// Update updates a schedule's amount, formula, frequency, day, note, limits, and next_run_at fields.
 func (r *ScheduleRepo) Update(sched *models.AllowanceSchedule) (*models.AllowanceSchedule, error) {
+	choreRule := sched.ChoreRule
+	if choreRule == "" {
+		choreRule = models.ChoreRuleNone
+	}
+	amountFormula := sched.AmountFormula
+	if amountFormula == "" {
+		amountFormula = models.AmountFormulaFlat
+	}
 	err := r.db.Model(&models.AllowanceSchedule{}).
 		Where("id = ?", sched.ID).
 		Updates(map[string]interface{}{
-			"amount_cents": sched.AmountCents,
-			"frequency":    sched.Frequency,
-			"day_of_week":  sched.DayOfWeek,
-			"day_of_month": sched.DayOfMonth,
-			"note":         sched.Note,
-			"next_run_at":  sched.NextRunAt,
-			"updated_at":   gorm.Expr("NOW()"),
+			"amount_cents":            sched.AmountCents,
+			"frequency":               sched.Frequency,
+			"day_of_week":             sched.DayOfWeek,
+			"day_of_month":            sched.DayOfMonth,
+			"rrule":                   sched.RRule,
+			"note":                    sched.Note,
+			"next_run_at":             sched.NextRunAt,
+			"chore_rule":              choreRule,
+			"chore_threshold_percent": sched.ChoreThresholdPercent,
+			"amount_formula":          amountFormula,
+			"cents_per_year_of_age":   sched.CentsPerYearOfAge,
+			"end_date":                sched.EndDate,
+			"max_runs":                sched.MaxRuns,
+			"updated_at":              gorm.Expr("NOW()"),
 		}).Error
 	if err != nil {
 		return nil, fmt.Errorf("update schedule: %w", err)
@@ -159,6 +469,62 @@ func (r *ScheduleRepo) UpdateNextRunAt(id int64, nextRunAt time.Time) error {
 	return nil
 }
 
+// RecordRun records a finished run of a schedule in one transaction: it pays run.DepositCents to
+// the child, sets the schedule's held total to run.HeldCents, counts the run and either advances
+// the schedule to run.NextRunAt or, when run.Completed is true, marks it completed with no next run.
+func (r *ScheduleRepo) RecordRun(sched *models.AllowanceSchedule, run AllowanceRun) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if run.DepositCents > 0 {
+			if _, err := depositAllowance(tx, sched.ChildID, sched.ParentID, run.DepositCents, sched.ID, run.Note); err != nil {
+				return err
+			}
+		}
+
+		updates := map[string]interface{}{
+			"run_count":   gorm.Expr("run_count + 1"),
+			"held_cents":  run.HeldCents,
+			"next_run_at": run.NextRunAt,
+			"updated_at":  gorm.Expr("NOW()"),
+		}
+		if run.Completed {
+			updates["status"] = models.ScheduleStatusCompleted
+			updates["next_run_at"] = nil
+		}
+		err := tx.Model(&models.AllowanceSchedule{}).
+			Where("id = ?", sched.ID).
+			Updates(updates).Error
+		if err != nil {
+			return fmt.Errorf("record schedule run: %w", err)
+		}
+		return nil
+	})
+}
+
+// Complete marks a schedule completed with no next run without counting a run. Allowance still
+// held for a later run is paid to the child in the same transaction with note.
+func (r *ScheduleRepo) Complete(sched *models.AllowanceSchedule, note string) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if sched.HeldCents > 0 {
+			if _, err := depositAllowance(tx, sched.ChildID, sched.ParentID, sched.HeldCents, sched.ID, note); err != nil {
+				return err
+			}
+		}
+
+		err := tx.Model(&models.AllowanceSchedule{}).
+			Where("id = ?", sched.ID).
+			Updates(map[string]interface{}{
+				"status":      models.ScheduleStatusCompleted,
+				"next_run_at": nil,
+				"held_cents":  0,
+				"updated_at":  gorm.Expr("NOW()"),
+			}).Error
+		if err != nil {
+			return fmt.Errorf("complete schedule: %w", err)
+		}
+		return nil
+	})
+}
+
 // UpdateStatus sets the status of a schedule.
 func (r *ScheduleRepo) UpdateStatus(id int64, status models.ScheduleStatus) error {
 	err := r.db.Model(&models.AllowanceSchedule{}).
@@ -173,10 +539,10 @@ func (r *ScheduleRepo) UpdateStatus(id int64, status models.ScheduleStatus) erro
 	return nil
 }
 
-// ListActiveByChild returns all active schedules for a child, sorted by next_run_at.
+// ListActiveByChild returns all active schedules for a child with their splits, sorted by next_run_at.
 func (r *ScheduleRepo) ListActiveByChild(childID int64) ([]models.AllowanceSchedule, error) {
 	var schedules []models.AllowanceSchedule
-	err := r.db.
+	err := r.db.Preload("Splits", orderSplits).
 		Where("child_id = ? AND status = ?", childID, "active").
 		Order("next_run_at ASC").
 		Find(&schedules).Error
@@ -496,3 +496,225 @@ func TestScheduleRepo_CascadeDeleteOnChildRemoval(t *testing.T) {
 	require.NoError(t, err)
 	assert.Nil(t, deletedSched, "Schedule should be deleted when child is deleted (CASCADE)")
 }
+
+func TestScheduleRepo_ListByChild_MultipleSchedules(t *testing.T) {
+	db := testDB(t)
+	sr := NewScheduleRepo(db)
+
+	fam := createTestFamily(t, db)
+	parent := createTestParent(t, db, fam.ID)
+	child := createTestChild(t, db, fam.ID)
+
+	first := createTestSchedule(t, db, child.ID, parent.ID)
+	second := createTestSchedule(t, db, child.ID, parent.ID)
+
+	schedules, err := sr.ListByChild(child.ID)
+	require.NoError(t, err)
+	require.Len(t, schedules, 2)
+	assert.Equal(t, first.ID, schedules[0].ID)
+	assert.Equal(t, second.ID, schedules[1].ID)
+
+	count, err := sr.CountByChild(child.ID)
+	require.NoError(t, err)
+	assert.Equal(t, 2, count)
+
+	// GetByChildID returns the primary (earliest) schedule
+	primary, err := sr.GetByChildID(child.ID)
+	require.NoError(t, err)
+	require.NotNil(t, primary)
+	assert.Equal(t, first.ID, primary.ID)
+}
+
+func TestScheduleRepo_ReplaceSplits(t *testing.T) {
+	db := testDB(t)
+	sr := NewScheduleRepo(db)
+	gr := NewSavingsGoalRepo(db)
+
+	fam := createTestFamily(t, db)
+	parent := createTestParent(t, db, fam.ID)
+	child := createTestChild(t, db, fam.ID)
+	sched := createTestSchedule(t, db, child.ID, parent.ID)
+
+	bike, err := gr.Create(child.ID, "Bike", 10000, nil)
+	require.NoError(t, err)
+	game, err := gr.Create(child.ID, "Game", 5000, nil)
+	require.NoError(t, err)
+
+	err = sr.ReplaceSplits(sched.ID, []models.AllowanceSplit{
+		{GoalID: bike.ID, Percent: 25},
+		{GoalID: game.ID, Percent: 10},
+	})
+	require.NoError(t, err)
+
+	fetched, err := sr.GetByID(sched.ID)
+	require.NoError(t, err)
+	require.Len(t, fetched.Splits, 2)
+	assert.Equal(t, bike.ID, fetched.Splits[0].GoalID)
+	assert.Equal(t, 25, fetched.Splits[0].Percent)
+
+	// Replacing with an empty list clears all splits
+	err = sr.ReplaceSplits(sched.ID, nil)
+	require.NoError(t, err)
+
+	splits, err := sr.ListSplits(sched.ID)
+	require.NoError(t, err)
+	assert.Empty(t, splits)
+}
+
+func TestScheduleRepo_ReplaceAgeSteps(t *testing.T) {
+	db := testDB(t)
+	sr := NewScheduleRepo(db)
+
+	fam := createTestFamily(t, db)
+	parent := createTestParent(t, db, fam.ID)
+	child := createTestChild(t, db, fam.ID)
+	sched := createTestSchedule(t, db, child.ID, parent.ID)
+
+	err := sr.ReplaceAgeSteps(sched.ID, []models.AllowanceAgeStep{
+		{MinAge: 12, AmountCents: 1500},
+		{MinAge: 6, AmountCents: 500},
+	})
+	require.NoError(t, err)
+
+	fetched, err := sr.GetByID(sched.ID)
+	require.NoError(t, err)
+	require.Len(t, fetched.AgeSteps, 2)
+	assert.Equal(t, 6, fetched.AgeSteps[0].MinAge)
+	assert.Equal(t, 12, fetched.AgeSteps[1].MinAge)
+}
+
+func TestScheduleRepo_ApplyRaise(t *testing.T) {
+	db := testDB(t)
+	sr := NewScheduleRepo(db)
+
+	fam := createTestFamily(t, db)
+	parent := createTestParent(t, db, fam.ID)
+	child := createTestChild(t, db, fam.ID)
+	sched := createTestSchedule(t, db, child.ID, parent.ID)
+
+	applied, err := sr.ApplyRaise(sched, 11, 1000, 1100)
+	require.NoError(t, err)
+	assert.True(t, applied)
+
+	// A stale old amount does not apply twice
+	applied, err = sr.ApplyRaise(sched, 11, 1000, 1100)
+	require.NoError(t, err)
+	assert.False(t, applied)
+
+	fetched, err := sr.GetByID(sched.ID)
+	require.NoError(t, err)
+	assert.Equal(t, int64(1100), fetched.AmountCents)
+
+	raises, err := sr.ListRaisesByChild(child.ID)
+	require.NoError(t, err)
+	require.Len(t, raises, 1)
+	assert.Equal(t, 11, raises[0].Age)
+	assert.Equal(t, int64(1000), raises[0].OldAmountCents)
+	assert.Equal(t, int64(1100), raises[0].NewAmountCents)
+
+	// A lower amount is applied but is not a raise
+	applied, err = sr.ApplyRaise(sched, 11, 1100, 900)
+	require.NoError(t, err)
+	assert.True(t, applied)
+
+	fetched, err = sr.GetByID(sched.ID)
+	require.NoError(t, err)
+	assert.Equal(t, int64(900), fetched.AmountCents)
+
+	raises, err = sr.ListRaisesByChild(child.ID)
+	require.NoError(t, err)
+	assert.Len(t, raises, 1)
+}
+
+func TestScheduleRepo_Overrides(t *testing.T) {
+	db := testDB(t)
+	sr := NewScheduleRepo(db)
+
+	fam := createTestFamily(t, db)
+	parent := createTestParent(t, db, fam.ID)
+	child := createTestChild(t, db, fam.ID)
+	sched := createTestSchedule(t, db, child.ID, parent.ID)
+	runDate := time.Date(2026, time.February, 7, 0, 0, 0, 0, time.UTC)
+
+	_, err := sr.SetOverride(&models.AllowanceOverride{
+		ScheduleID:        sched.ID,
+		RunDate:           runDate,
+		Action:            models.OverrideActionSkip,
+		CreatedByParentID: parent.ID,
+	})
+	require.NoError(t, err)
+
+	// Setting again replaces the existing override for that run
+	amount := int64(2500)
+	_, err = sr.SetOverride(&models.AllowanceOverride{
+		ScheduleID:        sched.ID,
+		RunDate:           runDate,
+		Action:            models.OverrideActionCustom,
+		AmountCents:       &amount,
+		CreatedByParentID: parent.ID,
+	})
+	require.NoError(t, err)
+
+	fetched, err := sr.GetOverride(sched.ID, runDate)
+	require.NoError(t, err)
+	require.NotNil(t, fetched)
+	assert.Equal(t, models.OverrideActionCustom, fetched.Action)
+	assert.Equal(t, int64(2500), *fetched.AmountCents)
+
+	overrides, err := sr.ListOverrides(sched.ID, runDate)
+	require.NoError(t, err)
+	assert.Len(t, overrides, 1)
+
+	deleted, err := sr.DeleteOverride(sched.ID, runDate)
+	require.NoError(t, err)
+	assert.True(t, deleted)
+
+	fetched, err = sr.GetOverride(sched.ID, runDate)
+	require.NoError(t, err)
+	assert.Nil(t, fetched)
+
+	deleted, err = sr.DeleteOverride(sched.ID, runDate)
+	require.NoError(t, err)
+	assert.False(t, deleted)
+}
+
+func TestScheduleRepo_RecordRun(t *testing.T) {
+	db := testDB(t)
+	sr := NewScheduleRepo(db)
+
+	fam := createTestFamily(t, db)
+	parent := createTestParent(t, db, fam.ID)
+	child := createTestChild(t, db, fam.ID)
+	sched := createTestSchedule(t, db, child.ID, parent.ID)
+
+	// A held run pays nothing and keeps the money for later
+	nextRun := time.Date(2026, time.February, 14, 0, 0, 0, 0, time.UTC)
+	require.NoError(t, sr.RecordRun(sched, AllowanceRun{HeldCents: 1000, NextRunAt: nextRun}))
+
+	fetched, err := sr.GetByID(sched.ID)
+	require.NoError(t, err)
+	assert.Equal(t, 1, fetched.RunCount)
+	assert.Equal(t, int64(1000), fetched.HeldCents)
+	assert.Equal(t, models.ScheduleStatusActive, fetched.Status)
+	require.NotNil(t, fetched.NextRunAt)
+	assert.True(t, nextRun.Equal(*fetched.NextRunAt))
+
+	// The next run pays the held money with its own and resets the held total
+	require.NoError(t, sr.RecordRun(sched, AllowanceRun{
+		DepositCents: 2000,
+		Note:         "Includes held allowance",
+		NextRunAt:    nextRun.AddDate(0, 0, 7),
+		Completed:    true,
+	}))
+
+	fetched, err = sr.GetByID(sched.ID)
+	require.NoError(t, err)
+	assert.Equal(t, 2, fetched.RunCount)
+	assert.Equal(t, int64(0), fetched.HeldCents)
+	assert.Equal(t, models.ScheduleStatusCompleted, fetched.Status)
+	assert.Nil(t, fetched.NextRunAt)
+
+	balance, err := NewChildRepo(db).GetBalance(child.ID)
+	require.NoError(t, err)
+	assert.Equal(t, int64(2000), balance)
+}
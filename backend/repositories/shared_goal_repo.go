@@ -0,0 +1,380 @@
+package repositories
+
+import (
+	"errors"
+	"fmt"
+
+	"bank-of-dad/models"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// SharedGoalRepo provides GORM-based access to family shared goals and their contributions.
+type SharedGoalRepo struct {
+	db *gorm.DB
+}
+
+// NewSharedGoalRepo creates a new SharedGoalRepo.
+func NewSharedGoalRepo(db *gorm.DB) *SharedGoalRepo {
+	return &SharedGoalRepo{db: db}
+}
+
+// Create inserts a new shared goal for a family. createdByChildID is nil when a parent creates it.
+func (r *SharedGoalRepo) Create(familyID int64, name string, targetCents int64, emoji *string, createdByChildID *int64) (*models.SharedGoal, error) {
+	goal := models.SharedGoal{
+		FamilyID:         familyID,
+		Name:             name,
+		TargetCents:      targetCents,
+		Emoji:            emoji,
+		CreatedByChildID: createdByChildID,
+	}
+	if err := r.db.Omit("Contributions").Create(&goal).Error; err != nil {
+		return nil, fmt.Errorf("insert shared goal: %w", err)
+	}
+	goal.Contributions = []models.SharedGoalContribution{}
+	return &goal, nil
+}
+
+// GetByID returns one of a family's shared goals with its contributions.
+// Returns nil, nil if the family has no such goal.
+func (r *SharedGoalRepo) GetByID(id, familyID int64) (*models.SharedGoal, error) {
+	var goal models.SharedGoal
+	err := r.db.Where("id = ? AND family_id = ?", id, familyID).First(&goal).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("get shared goal: %w", err)
+	}
+
+	goals := []models.SharedGoal{goal}
+	if err := r.loadContributions(goals); err != nil {
+		return nil, err
+	}
+	return &goals[0], nil
+}
+
+// ListByFamily returns a family's shared goals with their contributions, newest first.
+func (r *SharedGoalRepo) ListByFamily(familyID int64) ([]models.SharedGoal, error) {
+	var goals []models.SharedGoal
+	err := r.db.Where("family_id = ?", familyID).
+		Order("created_at DESC, id DESC").
+		Find(&goals).Error
+	if err != nil {
+		return nil, fmt.Errorf("list shared goals: %w", err)
+	}
+	if err := r.loadContributions(goals); err != nil {
+		return nil, err
+	}
+	return goals, nil
+}
+
+// loadContributions fills in each goal's contributions with the contributors' names,
+// largest contribution first.
+func (r *SharedGoalRepo) loadContributions(goals []models.SharedGoal) error {
+	if len(goals) == 0 {
+		return nil
+	}
+	ids := make([]int64, len(goals))
+	byGoal := make(map[int64]*models.SharedGoal, len(goals))
+	for i := range goals {
+		ids[i] = goals[i].ID
+		goals[i].Contributions = []models.SharedGoalContribution{}
+		byGoal[goals[i].ID] = &goals[i]
+	}
+
+	var contributions []models.SharedGoalContribution
+	err := r.db.Table("shared_goal_contributions sgc").
+		Select("sgc.*, c.first_name AS child_name").
+		Joins("JOIN children c ON c.id = sgc.child_id").
+		Where("sgc.shared_goal_id IN ?", ids).
+		Order("sgc.saved_cents DESC, sgc.child_id").
+		Scan(&contributions).Error
+	if err != nil {
+		return fmt.Errorf("list shared goal contributions: %w", err)
+	}
+	for _, c := range contributions {
+		goal := byGoal[c.SharedGoalID]
+		goal.Contributions = append(goal.Contributions, c)
+	}
+	return nil
+}
+
+// CountActiveByFamily returns the number of active shared goals in a family.
+func (r *SharedGoalRepo) CountActiveByFamily(familyID int64) (int, error) {
+	var count int64
+	err := r.db.Model(&models.SharedGoal{}).
+		Where("family_id = ? AND status = 'active'", familyID).
+		Count(&count).Error
+	if err != nil {
+		return 0, fmt.Errorf("count active shared goals: %w", err)
+	}
+	return int(count), nil
+}
+
+// lockSharedGoal locks one of a family's shared goals.
+// Returns ErrGoalNotFound if the family has no such goal.
+func lockSharedGoal(tx *gorm.DB, goalID, familyID int64) (models.SharedGoal, error) {
+	var goal models.SharedGoal
+	err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+		Where("id = ? AND family_id = ?", goalID, familyID).
+		First(&goal).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return goal, ErrGoalNotFound
+	}
+	if err != nil {
+		return goal, fmt.Errorf("lock shared goal: %w", err)
+	}
+	return goal, nil
+}
+
+// Allocate moves funds from a child's available balance into their contribution to an active
+// shared goal (positive), or back out of it (negative). A contribution is capped at what the goal
+// still needs, so no child can fund more than the target, and a child can only take back what they
+// contributed themselves. Completes the goal once it reaches its target.
+func (r *SharedGoalRepo) Allocate(goalID, familyID, childID, amountCents int64) (*models.SharedGoal, error) {
+	if amountCents == 0 {
+		return nil, ErrZeroAllocation
+	}
+
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		goal, err := lockSharedGoal(tx, goalID, familyID)
+		if err != nil {
+			return err
+		}
+		if goal.Status != "active" {
+			return ErrGoalNotFound
+		}
+
+		var contribution models.SharedGoalContribution
+		err = tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			Where("shared_goal_id = ? AND child_id = ?", goalID, childID).
+			Limit(1).
+			Find(&contribution).Error
+		if err != nil {
+			return fmt.Errorf("lock contribution: %w", err)
+		}
+
+		if amountCents > 0 {
+			if remaining := goal.TargetCents - goal.SavedCents; amountCents > remaining {
+				amountCents = remaining
+			}
+			available, err := availableCents(tx, childID)
+			if err != nil {
+				return err
+			}
+			if amountCents > available {
+				return ErrInsufficientAvailable
+			}
+		} else if -amountCents > contribution.SavedCents {
+			return ErrDeallocationExceedsSaved
+		}
+
+		err = tx.Exec(
+			`INSERT INTO shared_goal_contributions (shared_goal_id, child_id, saved_cents)
+			 VALUES (?, ?, ?)
+			 ON CONFLICT (shared_goal_id, child_id)
+			 DO UPDATE SET saved_cents = shared_goal_contributions.saved_cents + EXCLUDED.saved_cents, updated_at = NOW()`,
+			goalID, childID, amountCents,
+		).Error
+		if err != nil {
+			return fmt.Errorf("update contribution: %w", err)
+		}
+
+		newSavedCents := goal.SavedCents + amountCents
+		updates := map[string]interface{}{"saved_cents": newSavedCents, "updated_at": gorm.Expr("NOW()")}
+		if newSavedCents >= goal.TargetCents {
+			updates["status"] = "completed"
+			updates["completed_at"] = gorm.Expr("NOW()")
+		}
+		if err := tx.Model(&models.SharedGoal{}).Where("id = ?", goalID).Updates(updates).Error; err != nil {
+			return fmt.Errorf("update shared goal saved_cents: %w", err)
+		}
+
+		alloc := models.SharedGoalAllocation{
+			SharedGoalID: goalID,
+			ChildID:      childID,
+			AmountCents:  amountCents,
+		}
+		if err := tx.Create(&alloc).Error; err != nil {
+			return fmt.Errorf("insert shared goal allocation: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return r.GetByID(goalID, familyID)
+}
+
+// releaseSharedContribution takes amountCents out of a child's locked contribution to a shared goal
+// and records the de-allocation. Other contributors' shares are not affected.
+func releaseSharedContribution(tx *gorm.DB, goalID, childID, amountCents int64) error {
+	err := tx.Model(&models.SharedGoalContribution{}).
+		Where("shared_goal_id = ? AND child_id = ?", goalID, childID).
+		Updates(map[string]interface{}{
+			"saved_cents": gorm.Expr("saved_cents - ?", amountCents),
+			"updated_at":  gorm.Expr("NOW()"),
+		}).Error
+	if err != nil {
+		return fmt.Errorf("update contribution: %w", err)
+	}
+
+	err = tx.Model(&models.SharedGoal{}).Where("id = ?", goalID).
+		Updates(map[string]interface{}{
+			"saved_cents": gorm.Expr("saved_cents - ?", amountCents),
+			"updated_at":  gorm.Expr("NOW()"),
+		}).Error
+	if err != nil {
+		return fmt.Errorf("update shared goal saved_cents: %w", err)
+	}
+
+	alloc := models.SharedGoalAllocation{
+		SharedGoalID: goalID,
+		ChildID:      childID,
+		AmountCents:  -amountCents,
+	}
+	if err := tx.Create(&alloc).Error; err != nil {
+		return fmt.Errorf("insert shared goal de-allocation: %w", err)
+	}
+	return nil
+}
+
+// Delete removes an active or completed shared goal, releasing every contribution back to its
+// contributor's available balance. byChildID is the child deleting it, or nil for a parent; a child
+// gets ErrSharedGoalHasContributors if anyone else has money in the goal. The check is made with
+// the goal locked, so nobody can contribute between the check and the delete.
+func (r *SharedGoalRepo) Delete(goalID, familyID int64, byChildID *int64) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		goal, err := lockSharedGoal(tx, goalID, familyID)
+		if err != nil {
+			return err
+		}
+		if goal.Status != "active" && goal.Status != "completed" {
+			return ErrGoalNotFound
+		}
+
+		if byChildID != nil {
+			var others int64
+			err := tx.Model(&models.SharedGoalContribution{}).
+				Where("shared_goal_id = ? AND child_id != ? AND saved_cents > 0", goalID, *byChildID).
+				Count(&others).Error
+			if err != nil {
+				return fmt.Errorf("count other contributors: %w", err)
+			}
+			if others > 0 {
+				return ErrSharedGoalHasContributors
+			}
+		}
+
+		// Delete the goal (cascades to contributions and allocations via DB constraint)
+		if err := tx.Delete(&models.SharedGoal{}, goalID).Error; err != nil {
+			return fmt.Errorf("delete shared goal: %w", err)
+		}
+		return nil
+	})
+}
+
+// releaseChildContributions takes a child's contributions out of the totals of the active and
+// completed shared goals they contributed to, before the child is deleted and their contributions
+// with them. A completed goal that falls short of its target again becomes active.
+func releaseChildContributions(tx *gorm.DB, childID int64) error {
+	err := tx.Exec(`UPDATE shared_goals shg
+		SET saved_cents = shg.saved_cents - sgc.saved_cents,
+			status = CASE WHEN shg.saved_cents - sgc.saved_cents < shg.target_cents THEN 'active' ELSE shg.status END,
+			completed_at = CASE WHEN shg.saved_cents - sgc.saved_cents < shg.target_cents THEN NULL ELSE shg.completed_at END,
+			updated_at = NOW()
+		FROM shared_goal_contributions sgc
+		WHERE sgc.shared_goal_id = shg.id
+			AND sgc.child_id = ?
+			AND sgc.saved_cents > 0
+			AND shg.status IN ('active', 'completed')`, childID).Error
+	if err != nil {
+		return fmt.Errorf("release shared goal contributions: %w", err)
+	}
+	return nil
+}
+
+// Redeem spends a completed shared goal: on behalf of parentID, it withdraws each contributor's
+// share from their own balance and marks the goal purchased, in one transaction. Returns
+// ErrGoalNotRedeemable if the goal is not completed, or models.ErrInsufficientFunds if any
+// contributor's balance no longer covers their share after their other goals' allocations.
+func (r *SharedGoalRepo) Redeem(goalID, familyID, parentID int64) (*models.SharedGoal, []models.Transaction, error) {
+	var transactions []models.Transaction
+
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		goal, err := lockSharedGoal(tx, goalID, familyID)
+		if err != nil {
+			return err
+		}
+		if goal.Status != "completed" {
+			return ErrGoalNotRedeemable
+		}
+
+		var contributions []models.SharedGoalContribution
+		err = tx.Where("shared_goal_id = ? AND saved_cents > 0", goalID).
+			Order("child_id").
+			Find(&contributions).Error
+		if err != nil {
+			return fmt.Errorf("list contributions: %w", err)
+		}
+
+		note := "Shared goal purchase: " + goal.Name
+		for _, c := range contributions {
+			var child models.Child
+			err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+				Select("id, balance_cents").
+				First(&child, c.ChildID).Error
+			if err != nil {
+				return fmt.Errorf("get current balance: %w", err)
+			}
+			totalSaved, err := reservedCents(tx, c.ChildID)
+			if err != nil {
+				return err
+			}
+			if child.BalanceCents-totalSaved < c.SavedCents {
+				return models.ErrInsufficientFunds
+			}
+
+			transaction := models.Transaction{
+				ChildID:         c.ChildID,
+				ParentID:        parentID,
+				AmountCents:     c.SavedCents,
+				TransactionType: models.TransactionTypeWithdrawal,
+				Note:            &note,
+			}
+			if err := tx.Create(&transaction).Error; err != nil {
+				return fmt.Errorf("insert transaction: %w", err)
+			}
+			if err := tx.Exec(
+				`UPDATE children SET balance_cents = balance_cents - ?, updated_at = NOW() WHERE id = ?`,
+				c.SavedCents, c.ChildID,
+			).Error; err != nil {
+				return fmt.Errorf("update balance: %w", err)
+			}
+			transactions = append(transactions, transaction)
+		}
+
+		err = tx.Model(&models.SharedGoal{}).Where("id = ?", goalID).
+			Updates(map[string]interface{}{
+				"status":       "purchased",
+				"purchased_at": gorm.Expr("NOW()"),
+				"updated_at":   gorm.Expr("NOW()"),
+			}).Error
+		if err != nil {
+			return fmt.Errorf("mark shared goal purchased: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	goal, err := r.GetByID(goalID, familyID)
+	if err != nil {
+		return nil, nil, err
+	}
+	return goal, transactions, nil
+}
@@ -0,0 +1,290 @@
+package repositories
+
+import (
+	"testing"
+
+	"bank-of-dad/models"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+)
+
+func createSibling(t *testing.T, db *gorm.DB, familyID int64, name string) *models.Child {
+	t.Helper()
+	child := models.Child{FamilyID: familyID, FirstName: name, PasswordHash: "hash123"}
+	require.NoError(t, db.Create(&child).Error)
+	return &child
+}
+
+func TestSharedGoalRepo_CreateAndList(t *testing.T) {
+	db := testDB(t)
+	fam, _, child := createTestFamilyWithParentAndChild(t, db)
+	repo := NewSharedGoalRepo(db)
+
+	goal, err := repo.Create(fam.ID, "Trampoline", 30000, nil, &child.ID)
+	require.NoError(t, err)
+	assert.Equal(t, "active", goal.Status)
+	assert.Equal(t, child.ID, *goal.CreatedByChildID)
+	assert.Empty(t, goal.Contributions)
+
+	goals, err := repo.ListByFamily(fam.ID)
+	require.NoError(t, err)
+	require.Len(t, goals, 1)
+	assert.Equal(t, "Trampoline", goals[0].Name)
+
+	count, err := repo.CountActiveByFamily(fam.ID)
+	require.NoError(t, err)
+	assert.Equal(t, 1, count)
+
+	missing, err := repo.GetByID(goal.ID, fam.ID+1)
+	require.NoError(t, err)
+	assert.Nil(t, missing)
+}
+
+func TestSharedGoalRepo_AllocateTracksEachContributor(t *testing.T) {
+	db := testDB(t)
+	fam, parent, child := createTestFamilyWithParentAndChild(t, db)
+	sibling := createSibling(t, db, fam.ID, "Sib")
+	depositForChild(t, db, child.ID, parent.ID, 5000)
+	depositForChild(t, db, sibling.ID, parent.ID, 5000)
+	repo := NewSharedGoalRepo(db)
+
+	goal, err := repo.Create(fam.ID, "Trampoline", 6000, nil, nil)
+	require.NoError(t, err)
+
+	_, err = repo.Allocate(goal.ID, fam.ID, child.ID, 2000)
+	require.NoError(t, err)
+	updated, err := repo.Allocate(goal.ID, fam.ID, sibling.ID, 3000)
+	require.NoError(t, err)
+
+	assert.Equal(t, int64(5000), updated.SavedCents)
+	require.Len(t, updated.Contributions, 2)
+	assert.Equal(t, "Sib", updated.Contributions[0].ChildName)
+	assert.Equal(t, int64(3000), updated.Contributions[0].SavedCents)
+	assert.Equal(t, "Saver", updated.Contributions[1].ChildName)
+	assert.Equal(t, int64(2000), updated.Contributions[1].SavedCents)
+
+	// Shared contributions are reserved out of each child's own available balance
+	goalRepo := NewSavingsGoalRepo(db)
+	available, err := goalRepo.GetAvailableBalance(child.ID)
+	require.NoError(t, err)
+	assert.Equal(t, int64(3000), available)
+	saved, err := goalRepo.GetTotalSavedByChild(sibling.ID)
+	require.NoError(t, err)
+	assert.Equal(t, int64(3000), saved)
+}
+
+func TestSharedGoalRepo_AllocateCapsAtRemainingAndCompletes(t *testing.T) {
+	db := testDB(t)
+	fam, parent, child := createTestFamilyWithParentAndChild(t, db)
+	sibling := createSibling(t, db, fam.ID, "Sib")
+	depositForChild(t, db, child.ID, parent.ID, 5000)
+	depositForChild(t, db, sibling.ID, parent.ID, 5000)
+	repo := NewSharedGoalRepo(db)
+
+	goal, err := repo.Create(fam.ID, "Trampoline", 4000, nil, nil)
+	require.NoError(t, err)
+	_, err = repo.Allocate(goal.ID, fam.ID, sibling.ID, 3000)
+	require.NoError(t, err)
+
+	updated, err := repo.Allocate(goal.ID, fam.ID, child.ID, 2500)
+	require.NoError(t, err)
+	assert.Equal(t, "completed", updated.Status)
+	assert.NotNil(t, updated.CompletedAt)
+	assert.Equal(t, int64(4000), updated.SavedCents)
+	for _, c := range updated.Contributions {
+		if c.ChildID == child.ID {
+			assert.Equal(t, int64(1000), c.SavedCents)
+		}
+	}
+
+	// Completed goals no longer take allocations
+	_, err = repo.Allocate(goal.ID, fam.ID, child.ID, 100)
+	assert.Equal(t, ErrGoalNotFound, err)
+}
+
+func TestSharedGoalRepo_AllocateErrors(t *testing.T) {
+	db := testDB(t)
+	fam, parent, child := createTestFamilyWithParentAndChild(t, db)
+	sibling := createSibling(t, db, fam.ID, "Sib")
+	depositForChild(t, db, child.ID, parent.ID, 1000)
+	depositForChild(t, db, sibling.ID, parent.ID, 1000)
+	repo := NewSharedGoalRepo(db)
+
+	goal, err := repo.Create(fam.ID, "Trampoline", 10000, nil, nil)
+	require.NoError(t, err)
+
+	_, err = repo.Allocate(goal.ID, fam.ID, child.ID, 1500)
+	assert.Equal(t, ErrInsufficientAvailable, err)
+
+	_, err = repo.Allocate(goal.ID, fam.ID, sibling.ID, 800)
+	require.NoError(t, err)
+
+	// A child can only take back what they put in themselves
+	_, err = repo.Allocate(goal.ID, fam.ID, child.ID, -100)
+	assert.Equal(t, ErrDeallocationExceedsSaved, err)
+
+	_, err = repo.Allocate(goal.ID, fam.ID+1, child.ID, 100)
+	assert.Equal(t, ErrGoalNotFound, err)
+
+	_, err = repo.Allocate(goal.ID, fam.ID, child.ID, 0)
+	assert.Equal(t, ErrZeroAllocation, err)
+}
+
+func TestSharedGoalRepo_WithdrawalReleasesOnlyOwnContribution(t *testing.T) {
+	db := testDB(t)
+	fam, parent, child := createTestFamilyWithParentAndChild(t, db)
+	sibling := createSibling(t, db, fam.ID, "Sib")
+	depositForChild(t, db, child.ID, parent.ID, 4000)
+	depositForChild(t, db, sibling.ID, parent.ID, 4000)
+	repo := NewSharedGoalRepo(db)
+	goalRepo := NewSavingsGoalRepo(db)
+
+	shared, err := repo.Create(fam.ID, "Trampoline", 10000, nil, nil)
+	require.NoError(t, err)
+	_, err = repo.Allocate(shared.ID, fam.ID, child.ID, 2000)
+	require.NoError(t, err)
+	_, err = repo.Allocate(shared.ID, fam.ID, sibling.ID, 3000)
+	require.NoError(t, err)
+
+	personal, err := goalRepo.Create(child.ID, "Bike", 5000, nil)
+	require.NoError(t, err)
+	_, err = goalRepo.Allocate(personal.ID, child.ID, 2000)
+	require.NoError(t, err)
+
+	affected, err := goalRepo.GetAffectedGoals(child.ID, 2000)
+	require.NoError(t, err)
+	require.Len(t, affected, 2)
+
+	require.NoError(t, goalRepo.ReduceGoalsProportionally(child.ID, 2000))
+
+	updatedShared, err := repo.GetByID(shared.ID, fam.ID)
+	require.NoError(t, err)
+	assert.Equal(t, int64(4000), updatedShared.SavedCents)
+	for _, c := range updatedShared.Contributions {
+		switch c.ChildID {
+		case child.ID:
+			assert.Equal(t, int64(1000), c.SavedCents)
+		case sibling.ID:
+			assert.Equal(t, int64(3000), c.SavedCents)
+		}
+	}
+
+	updatedPersonal, err := goalRepo.GetByID(personal.ID)
+	require.NoError(t, err)
+	assert.Equal(t, int64(1000), updatedPersonal.SavedCents)
+}
+
+func TestSharedGoalRepo_DeleteReleasesContributions(t *testing.T) {
+	db := testDB(t)
+	fam, parent, child := createTestFamilyWithParentAndChild(t, db)
+	depositForChild(t, db, child.ID, parent.ID, 3000)
+	repo := NewSharedGoalRepo(db)
+
+	goal, err := repo.Create(fam.ID, "Trampoline", 10000, nil, &child.ID)
+	require.NoError(t, err)
+	_, err = repo.Allocate(goal.ID, fam.ID, child.ID, 2000)
+	require.NoError(t, err)
+
+	require.NoError(t, repo.Delete(goal.ID, fam.ID, &child.ID))
+
+	available, err := NewSavingsGoalRepo(db).GetAvailableBalance(child.ID)
+	require.NoError(t, err)
+	assert.Equal(t, int64(3000), available)
+
+	assert.Equal(t, ErrGoalNotFound, repo.Delete(goal.ID, fam.ID, nil))
+}
+
+func TestSharedGoalRepo_ChildCannotDeleteWithOtherContributors(t *testing.T) {
+	db := testDB(t)
+	fam, parent, child := createTestFamilyWithParentAndChild(t, db)
+	sibling := createSibling(t, db, fam.ID, "Sib")
+	depositForChild(t, db, sibling.ID, parent.ID, 3000)
+	repo := NewSharedGoalRepo(db)
+
+	goal, err := repo.Create(fam.ID, "Trampoline", 10000, nil, &child.ID)
+	require.NoError(t, err)
+	_, err = repo.Allocate(goal.ID, fam.ID, sibling.ID, 1000)
+	require.NoError(t, err)
+
+	assert.Equal(t, ErrSharedGoalHasContributors, repo.Delete(goal.ID, fam.ID, &child.ID))
+	kept, err := repo.GetByID(goal.ID, fam.ID)
+	require.NoError(t, err)
+	require.NotNil(t, kept)
+	assert.Equal(t, int64(1000), kept.SavedCents)
+
+	// A parent can delete it regardless
+	require.NoError(t, repo.Delete(goal.ID, fam.ID, nil))
+}
+
+func TestSharedGoalRepo_DeletingChildReleasesContribution(t *testing.T) {
+	db := testDB(t)
+	fam, parent, child := createTestFamilyWithParentAndChild(t, db)
+	sibling := createSibling(t, db, fam.ID, "Sib")
+	depositForChild(t, db, child.ID, parent.ID, 5000)
+	depositForChild(t, db, sibling.ID, parent.ID, 5000)
+	repo := NewSharedGoalRepo(db)
+
+	goal, err := repo.Create(fam.ID, "Trampoline", 5000, nil, nil)
+	require.NoError(t, err)
+	_, err = repo.Allocate(goal.ID, fam.ID, child.ID, 2000)
+	require.NoError(t, err)
+	completed, err := repo.Allocate(goal.ID, fam.ID, sibling.ID, 3000)
+	require.NoError(t, err)
+	require.Equal(t, "completed", completed.Status)
+
+	require.NoError(t, NewChildRepo(db).Delete(sibling.ID))
+
+	updated, err := repo.GetByID(goal.ID, fam.ID)
+	require.NoError(t, err)
+	assert.Equal(t, int64(2000), updated.SavedCents)
+	assert.Equal(t, "active", updated.Status)
+	assert.Nil(t, updated.CompletedAt)
+	require.Len(t, updated.Contributions, 1)
+	assert.Equal(t, child.ID, updated.Contributions[0].ChildID)
+}
+
+func TestSharedGoalRepo_RedeemWithdrawsEachShare(t *testing.T) {
+	db := testDB(t)
+	fam, parent, child := createTestFamilyWithParentAndChild(t, db)
+	sibling := createSibling(t, db, fam.ID, "Sib")
+	depositForChild(t, db, child.ID, parent.ID, 5000)
+	depositForChild(t, db, sibling.ID, parent.ID, 5000)
+	repo := NewSharedGoalRepo(db)
+
+	goal, err := repo.Create(fam.ID, "Trampoline", 6000, nil, nil)
+	require.NoError(t, err)
+	_, err = repo.Allocate(goal.ID, fam.ID, child.ID, 2000)
+	require.NoError(t, err)
+
+	_, _, err = repo.Redeem(goal.ID, fam.ID, parent.ID)
+	assert.Equal(t, ErrGoalNotRedeemable, err)
+
+	_, err = repo.Allocate(goal.ID, fam.ID, sibling.ID, 4000)
+	require.NoError(t, err)
+
+	redeemed, transactions, err := repo.Redeem(goal.ID, fam.ID, parent.ID)
+	require.NoError(t, err)
+	assert.Equal(t, "purchased", redeemed.Status)
+	assert.NotNil(t, redeemed.PurchasedAt)
+	require.Len(t, transactions, 2)
+	for _, tx := range transactions {
+		assert.Equal(t, models.TransactionTypeWithdrawal, tx.TransactionType)
+		assert.Equal(t, "Shared goal purchase: Trampoline", *tx.Note)
+	}
+
+	var childAfter, siblingAfter models.Child
+	require.NoError(t, db.First(&childAfter, child.ID).Error)
+	require.NoError(t, db.First(&siblingAfter, sibling.ID).Error)
+	assert.Equal(t, int64(3000), childAfter.BalanceCents)
+	assert.Equal(t, int64(1000), siblingAfter.BalanceCents)
+
+	// Purchased contributions no longer reserve anything
+	available, err := NewSavingsGoalRepo(db).GetAvailableBalance(sibling.ID)
+	require.NoError(t, err)
+	assert.Equal(t, int64(1000), available)
+
+	_, _, err = repo.Redeem(goal.ID, fam.ID, parent.ID)
+	assert.Equal(t, ErrGoalNotRedeemable, err)
+}
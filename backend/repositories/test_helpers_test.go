@@ -36,7 +36,7 @@ func testDB(t *testing.T) *gorm.DB {
 		sharedDB = db
 	})
 
-	result := sharedDB.Exec(`TRUNCATE chore_instances, chore_assignments, chores, goal_allocations, savings_goals, stripe_webhook_events, interest_schedules, transactions, allowance_schedules, auth_events, refresh_tokens, children, parents, families RESTART IDENTITY CASCADE`)
+	result := sharedDB.Exec(`TRUNCATE notifications, withdrawal_request_approvals, withdrawal_policy_approvals, withdrawal_policies, chore_review_rounds, chore_photos, chore_streak_awards, chore_streak_bonuses, chore_instances, chore_assignments, chores, shared_goal_allocations, shared_goal_contributions, shared_goals, goal_allocation_rules, goal_allocations, savings_goals, stripe_webhook_events, interest_schedules, transactions, allowance_overrides, allowance_raises, allowance_age_steps, allowance_splits, allowance_schedules, auth_events, refresh_tokens, children, parents, families RESTART IDENTITY CASCADE`)
 	require.NoError(t, result.Error)
 
 	return sharedDB
@@ -52,6 +52,10 @@ func (r *TransactionRepo) Deposit(childID, parentID, amountCents int64, note str
 			return fmt.Errorf("update balance: %w", err)
 		}
 
+		if err := applyAllocationRules(tx, &transaction); err != nil {
+			return err
+		}
+
 		// Get new balance
 		var child models.Child
 		if err := tx.Select("balance_cents").First(&child, childID).Error; err != nil {
@@ -179,29 +183,14 @@ func (r *TransactionRepo) DepositAllowance(childID, parentID, amountCents, sched
 		return nil, 0, fmt.Errorf("amount must be positive")
 	}
 
-	var transaction models.Transaction
+	var transaction *models.Transaction
 	var newBalance int64
 
 	err := r.db.Transaction(func(tx *gorm.DB) error {
-		notePtr := nullableString(note)
-		transaction = models.Transaction{
-			ChildID:         childID,
-			ParentID:        parentID,
-			AmountCents:     amountCents,
-			TransactionType: models.TransactionTypeAllowance,
-			Note:            notePtr,
-			ScheduleID:      &scheduleID,
-		}
-		if err := tx.Create(&transaction).Error; err != nil {
-			return fmt.Errorf("insert transaction: %w", err)
-		}
-
-		// Update balance
-		if err := tx.Exec(
-			`UPDATE children SET balance_cents = balance_cents + ?, updated_at = NOW() WHERE id = ?`,
-			amountCents, childID,
-		).Error; err != nil {
-			return fmt.Errorf("update balance: %w", err)
+		var err error
+		transaction, err = depositAllowance(tx, childID, parentID, amountCents, scheduleID, note)
+		if err != nil {
+			return err
 		}
 
 		// Get new balance
@@ -217,7 +206,36 @@ func (r *TransactionRepo) DepositAllowance(childID, parentID, amountCents, sched
 		return nil, 0, err
 	}
 
-	return &transaction, newBalance, nil
+	return transaction, newBalance, nil
+}
+
+// depositAllowance inserts an allowance transaction within tx, credits the child's balance and
+// applies the schedule's splits and the child's allocation rules.
+func depositAllowance(tx *gorm.DB, childID, parentID, amountCents, scheduleID int64, note string) (*models.Transaction, error) {
+	transaction := models.Transaction{
+		ChildID:         childID,
+		ParentID:        parentID,
+		AmountCents:     amountCents,
+		TransactionType: models.TransactionTypeAllowance,
+		Note:            nullableString(note),
+		ScheduleID:      &scheduleID,
+	}
+	if err := tx.Create(&transaction).Error; err != nil {
+		return nil, fmt.Errorf("insert transaction: %w", err)
+	}
+
+	// Update balance
+	if err := tx.Exec(
+		`UPDATE children SET balance_cents = balance_cents + ?, updated_at = NOW() WHERE id = ?`,
+		amountCents, childID,
+	).Error; err != nil {
+		return nil, fmt.Errorf("update balance: %w", err)
+	}
+
+	if err := applyAllocationRules(tx, &transaction); err != nil {
+		return nil, err
+	}
+	return &transaction, nil
 }
 
 // DepositChore adds money to a child's account as a chore reward transaction.
@@ -253,6 +271,10 @@ func (r *TransactionRepo) DepositChore(childID, parentID int64, amountCents int6
 			}
 		}
 
+		if err := applyAllocationRules(tx, &transaction); err != nil {
+			return err
+		}
+
 		// Get current balance
 		var child models.Child
 		if err := tx.Select("balance_cents").First(&child, childID).Error; err != nil {
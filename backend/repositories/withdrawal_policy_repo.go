@@ -0,0 +1,165 @@
+package repositories
+
+import (
+	"errors"
+	"fmt"
+
+	"bank-of-dad/models"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// ErrPolicyNotSatisfied is returned when a withdrawal request falls outside the child's policy
+// and must wait for a parent.
+var ErrPolicyNotSatisfied = errors.New("withdrawal request not covered by policy")
+
+// WithdrawalPolicyRepo handles database operations for withdrawal policies using GORM.
+type WithdrawalPolicyRepo struct {
+	db *gorm.DB
+}
+
+// NewWithdrawalPolicyRepo creates a new WithdrawalPolicyRepo.
+func NewWithdrawalPolicyRepo(db *gorm.DB) *WithdrawalPolicyRepo {
+	return &WithdrawalPolicyRepo{db: db}
+}
+
+// GetByChild retrieves a child's withdrawal policy. Returns (nil, nil) if the child has none.
+func (r *WithdrawalPolicyRepo) GetByChild(childID int64) (*models.WithdrawalPolicy, error) {
+	var policy models.WithdrawalPolicy
+	err := r.db.Where("child_id = ?", childID).First(&policy).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("get withdrawal policy: %w", err)
+	}
+	return &policy, nil
+}
+
+// Upsert creates the child's withdrawal policy or replaces its limits.
+func (r *WithdrawalPolicyRepo) Upsert(policy *models.WithdrawalPolicy) (*models.WithdrawalPolicy, error) {
+	err := r.db.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "child_id"}},
+		DoUpdates: clause.AssignmentColumns([]string{"max_amount_cents", "weekly_cap_cents", "updated_by_parent_id", "updated_at"}),
+	}).Create(policy).Error
+	if err != nil {
+		return nil, fmt.Errorf("upsert withdrawal policy: %w", err)
+	}
+	return r.GetByChild(policy.ChildID)
+}
+
+// Delete removes a child's withdrawal policy. Past policy approvals keep their audit records.
+func (r *WithdrawalPolicyRepo) Delete(childID int64) error {
+	if err := r.db.Where("child_id = ?", childID).Delete(&models.WithdrawalPolicy{}).Error; err != nil {
+		return fmt.Errorf("delete withdrawal policy: %w", err)
+	}
+	return nil
+}
+
+// ListApprovals returns a child's policy approvals, newest first.
+func (r *WithdrawalPolicyRepo) ListApprovals(childID int64, limit int) ([]models.WithdrawalPolicyApproval, error) {
+	var approvals []models.WithdrawalPolicyApproval
+	err := r.db.Where("child_id = ?", childID).
+		Order("created_at DESC, id DESC").
+		Limit(limit).
+		Find(&approvals).Error
+	if err != nil {
+		return nil, fmt.Errorf("list withdrawal policy approvals: %w", err)
+	}
+	return approvals, nil
+}
+
+// Apply approves a pending withdrawal request under its child's policy and withdraws the money,
+// recording an audit approval, all in one transaction. The request must be within the policy's
+// amount and weekly cap, leave enough balance to cover the child's savings goal allocations, and
+// not be large enough to need the approval of several parents under the family's quorum.
+// The withdrawal transaction is recorded on behalf of the parent who last set the policy.
+// Returns ErrPolicyNotSatisfied if the request is not covered, leaving it pending.
+func (r *WithdrawalPolicyRepo) Apply(requestID int64, note string) (*models.Transaction, int64, error) {
+	var transaction models.Transaction
+	var newBalance int64
+
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		req, child, err := lockPendingRequest(tx, requestID)
+		if err != nil {
+			return err
+		}
+
+		var policy models.WithdrawalPolicy
+		err = tx.Where("child_id = ?", req.ChildID).First(&policy).Error
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return ErrPolicyNotSatisfied
+		}
+		if err != nil {
+			return fmt.Errorf("get withdrawal policy: %w", err)
+		}
+		if req.AmountCents > policy.MaxAmountCents {
+			return ErrPolicyNotSatisfied
+		}
+		required, err := requiredApprovals(tx, &req)
+		if err != nil {
+			return err
+		}
+		if required > 1 {
+			return ErrPolicyNotSatisfied
+		}
+
+		var weekTotal int64
+		err = tx.Model(&models.WithdrawalPolicyApproval{}).
+			Where("child_id = ? AND created_at > NOW() - INTERVAL '7 days'", req.ChildID).
+			Select("COALESCE(SUM(amount_cents), 0)").
+			Scan(&weekTotal).Error
+		if err != nil {
+			return fmt.Errorf("sum weekly policy approvals: %w", err)
+		}
+		if policy.WeeklyCapCents != nil && weekTotal+int64(req.AmountCents) > int64(*policy.WeeklyCapCents) {
+			return ErrPolicyNotSatisfied
+		}
+
+		totalSaved, err := reservedCents(tx, req.ChildID)
+		if err != nil {
+			return err
+		}
+		if child.BalanceCents-int64(req.AmountCents) < totalSaved {
+			return ErrPolicyNotSatisfied
+		}
+
+		transaction, err = withdrawForRequest(tx, &req, policy.UpdatedByParentID, note)
+		if err != nil {
+			return err
+		}
+		newBalance = child.BalanceCents - int64(req.AmountCents)
+
+		err = tx.Model(&models.WithdrawalRequest{}).
+			Where("id = ?", requestID).
+			Updates(map[string]interface{}{
+				"status":         models.WithdrawalRequestStatusApproved,
+				"reviewed_at":    gorm.Expr("NOW()"),
+				"transaction_id": transaction.ID,
+				"auto_approved":  true,
+				"updated_at":     gorm.Expr("NOW()"),
+			}).Error
+		if err != nil {
+			return fmt.Errorf("approve withdrawal request: %w", err)
+		}
+
+		approval := &models.WithdrawalPolicyApproval{
+			WithdrawalRequestID: requestID,
+			PolicyID:            &policy.ID,
+			ChildID:             req.ChildID,
+			AmountCents:         req.AmountCents,
+			MaxAmountCents:      policy.MaxAmountCents,
+			WeeklyCapCents:      policy.WeeklyCapCents,
+			WeekTotalCents:      weekTotal,
+		}
+		if err := tx.Create(approval).Error; err != nil {
+			return fmt.Errorf("insert withdrawal policy approval: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, 0, err
+	}
+	return &transaction, newBalance, nil
+}
@@ -7,17 +7,22 @@ import (
 	"bank-of-dad/models"
 
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 var (
 	ErrWithdrawalRequestNotFound = errors.New("withdrawal request not found")
 	ErrPendingRequestExists      = errors.New("child already has a pending withdrawal request")
+	ErrAlreadyApproved           = errors.New("parent has already approved this withdrawal request")
 )
 
-// WithdrawalRequestWithChild extends WithdrawalRequest with the child's first name.
+// WithdrawalRequestWithChild extends WithdrawalRequest with the child's first name and how many
+// parents have approved it out of the number its family requires.
 type WithdrawalRequestWithChild struct {
 	models.WithdrawalRequest
-	ChildName string `json:"child_name" gorm:"column:child_name"`
+	ChildName         string `json:"child_name" gorm:"column:child_name"`
+	ApprovalCount     int    `json:"approval_count" gorm:"column:approval_count"`
+	ApprovalsRequired int    `json:"approvals_required" gorm:"column:approvals_required"`
 }
 
 // WithdrawalRequestRepo handles database operations for withdrawal requests using GORM.
@@ -89,6 +94,189 @@ func (r *WithdrawalRequestRepo) Approve(id int64, parentID int64, transactionID
 	return nil
 }
 
+// ApproveWithWithdrawal records a parent's approval of a pending withdrawal request. Once the request
+// has as many distinct parent approvals as its family requires, it is approved and its amount
+// withdrawn from the child's balance as one atomic operation, so a request is never paid out twice.
+// Returns a nil transaction and the unchanged balance while the request awaits more approvals.
+// Returns ErrInvalidStatusTransition if the request is not pending, ErrAlreadyApproved if the
+// parent has already approved it, or models.ErrInsufficientFunds if the approval completes the
+// quorum but the child's balance no longer covers the amount.
+func (r *WithdrawalRequestRepo) ApproveWithWithdrawal(id int64, parentID int64, note string) (*models.Transaction, int64, error) {
+	var transaction *models.Transaction
+	var newBalance int64
+
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		req, child, err := lockPendingRequest(tx, id)
+		if err != nil {
+			return err
+		}
+		newBalance = child.BalanceCents
+
+		approval := models.WithdrawalRequestApproval{WithdrawalRequestID: id, ParentID: parentID}
+		result := tx.Clauses(clause.OnConflict{DoNothing: true}).Create(&approval)
+		if result.Error != nil {
+			return fmt.Errorf("insert withdrawal request approval: %w", result.Error)
+		}
+		if result.RowsAffected == 0 {
+			return ErrAlreadyApproved
+		}
+
+		required, err := requiredApprovals(tx, &req)
+		if err != nil {
+			return err
+		}
+		var approvals int64
+		err = tx.Model(&models.WithdrawalRequestApproval{}).
+			Where("withdrawal_request_id = ?", id).
+			Count(&approvals).Error
+		if err != nil {
+			return fmt.Errorf("count withdrawal request approvals: %w", err)
+		}
+		if approvals < int64(required) {
+			return nil
+		}
+
+		// Only the approval that completes the quorum moves money, so only it needs the funds
+		amount := int64(req.AmountCents)
+		if child.BalanceCents < amount {
+			return models.ErrInsufficientFunds
+		}
+		t, err := withdrawForRequest(tx, &req, parentID, note)
+		if err != nil {
+			return err
+		}
+		transaction = &t
+		newBalance = child.BalanceCents - amount
+
+		err = tx.Model(&models.WithdrawalRequest{}).
+			Where("id = ?", id).
+			Updates(map[string]interface{}{
+				"status":                models.WithdrawalRequestStatusApproved,
+				"reviewed_at":           gorm.Expr("NOW()"),
+				"reviewed_by_parent_id": parentID,
+				"transaction_id":        transaction.ID,
+				"updated_at":            gorm.Expr("NOW()"),
+			}).Error
+		if err != nil {
+			return fmt.Errorf("approve withdrawal request: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, 0, err
+	}
+	return transaction, newBalance, nil
+}
+
+// CompletesQuorum reports whether parentID's approval would be the last one a withdrawal request
+// needs, so that approving it withdraws the money. It is false if the parent has already approved.
+func (r *WithdrawalRequestRepo) CompletesQuorum(id, parentID int64) (bool, error) {
+	var req models.WithdrawalRequest
+	if err := r.db.First(&req, id).Error; err != nil {
+		return false, fmt.Errorf("get withdrawal request: %w", err)
+	}
+	required, err := requiredApprovals(r.db, &req)
+	if err != nil {
+		return false, err
+	}
+	approvals, err := r.ListApprovals(id)
+	if err != nil {
+		return false, err
+	}
+	for _, a := range approvals {
+		if a.ParentID == parentID {
+			return false, nil
+		}
+	}
+	return len(approvals)+1 >= required, nil
+}
+
+// requiredApprovals returns how many distinct parents must approve a withdrawal request under its
+// family's current quorum setting.
+func requiredApprovals(tx *gorm.DB, req *models.WithdrawalRequest) (int, error) {
+	var family models.Family
+	err := tx.Select("id, withdrawal_quorum_threshold_cents, withdrawal_quorum_approvals").
+		First(&family, req.FamilyID).Error
+	if err != nil {
+		return 0, fmt.Errorf("get withdrawal quorum: %w", err)
+	}
+	return family.RequiredWithdrawalApprovals(req.AmountCents), nil
+}
+
+// ListApprovals returns the parent approvals recorded for a withdrawal request, oldest first.
+func (r *WithdrawalRequestRepo) ListApprovals(id int64) ([]models.WithdrawalRequestApproval, error) {
+	var approvals []models.WithdrawalRequestApproval
+	err := r.db.Where("withdrawal_request_id = ?", id).
+		Order("created_at ASC, id ASC").
+		Find(&approvals).Error
+	if err != nil {
+		return nil, fmt.Errorf("list withdrawal request approvals: %w", err)
+	}
+	return approvals, nil
+}
+
+// lockPendingRequest locks a pending withdrawal request and its child's balance for approval.
+// Returns ErrInvalidStatusTransition if the request is not pending.
+func lockPendingRequest(tx *gorm.DB, id int64) (models.WithdrawalRequest, models.Child, error) {
+	var req models.WithdrawalRequest
+	var child models.Child
+	err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+		Where("id = ? AND status = ?", id, models.WithdrawalRequestStatusPending).
+		First(&req).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return req, child, ErrInvalidStatusTransition
+	}
+	if err != nil {
+		return req, child, fmt.Errorf("lock withdrawal request: %w", err)
+	}
+
+	err = tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+		Select("id, balance_cents").
+		First(&child, req.ChildID).Error
+	if err != nil {
+		return req, child, fmt.Errorf("get current balance: %w", err)
+	}
+	return req, child, nil
+}
+
+// withdrawForRequest records a withdrawal request's transaction, on behalf of parentID, and takes
+// its amount from the child's balance. A request to redeem a savings goal also marks the goal
+// purchased, failing with ErrGoalNotRedeemable if it no longer can be. The caller must hold the
+// child's row lock.
+func withdrawForRequest(tx *gorm.DB, req *models.WithdrawalRequest, parentID int64, note string) (models.Transaction, error) {
+	if req.SavingsGoalID != nil {
+		if _, err := lockCompletedGoal(tx, *req.SavingsGoalID, req.ChildID); err != nil {
+			if err == ErrGoalNotFound {
+				return models.Transaction{}, ErrGoalNotRedeemable
+			}
+			return models.Transaction{}, err
+		}
+	}
+
+	transaction := models.Transaction{
+		ChildID:         req.ChildID,
+		ParentID:        parentID,
+		AmountCents:     int64(req.AmountCents),
+		TransactionType: models.TransactionTypeWithdrawalRequest,
+		Note:            nullableString(note),
+	}
+	if err := tx.Create(&transaction).Error; err != nil {
+		return transaction, fmt.Errorf("insert transaction: %w", err)
+	}
+	if err := tx.Exec(
+		`UPDATE children SET balance_cents = balance_cents - ?, updated_at = NOW() WHERE id = ?`,
+		req.AmountCents, req.ChildID,
+	).Error; err != nil {
+		return transaction, fmt.Errorf("update balance: %w", err)
+	}
+	if req.SavingsGoalID != nil {
+		if err := markGoalPurchased(tx, *req.SavingsGoalID, transaction.ID); err != nil {
+			return transaction, err
+		}
+	}
+	return transaction, nil
+}
+
 // Deny transitions a withdrawal request from pending to denied.
 // Sets reviewed_by_parent_id, reviewed_at, and optional denial_reason.
 func (r *WithdrawalRequestRepo) Deny(id int64, parentID int64, reason string) error {
@@ -152,8 +340,12 @@ func (r *WithdrawalRequestRepo) ListByChild(childID int64, status string) ([]mod
 // Ordered by created_at desc.
 func (r *WithdrawalRequestRepo) ListByFamily(familyID int64, status string, childID int64) ([]WithdrawalRequestWithChild, error) {
 	query := r.db.Table("withdrawal_requests").
-		Select("withdrawal_requests.*, children.first_name as child_name").
+		Select(`withdrawal_requests.*, children.first_name as child_name,
+			(SELECT COUNT(*) FROM withdrawal_request_approvals a WHERE a.withdrawal_request_id = withdrawal_requests.id) as approval_count,
+			CASE WHEN withdrawal_requests.amount_cents > families.withdrawal_quorum_threshold_cents
+				THEN families.withdrawal_quorum_approvals ELSE 1 END as approvals_required`).
 		Joins("JOIN children ON children.id = withdrawal_requests.child_id").
+		Joins("JOIN families ON families.id = withdrawal_requests.family_id").
 		Where("withdrawal_requests.family_id = ?", familyID)
 
 	if status != "" {
@@ -170,3 +362,119 @@ func (r *WithdrawalRequestRepo) ListByFamily(familyID int64, status string, chil
 	}
 	return results, nil
 }
+
+// PendingWithdrawalRequest is a pending withdrawal request with its child's first name, how many
+// days the family gives parents to respond and the family's timezone.
+type PendingWithdrawalRequest struct {
+	models.WithdrawalRequest
+	ChildName            string `json:"child_name" gorm:"column:child_name"`
+	WithdrawalExpiryDays int    `json:"withdrawal_expiry_days" gorm:"column:withdrawal_expiry_days"`
+	Timezone             string `json:"timezone" gorm:"column:timezone"`
+}
+
+// ListPending returns all pending withdrawal requests across families, oldest first.
+func (r *WithdrawalRequestRepo) ListPending() ([]PendingWithdrawalRequest, error) {
+	var results []PendingWithdrawalRequest
+	err := r.db.Table("withdrawal_requests").
+		Select("withdrawal_requests.*, children.first_name as child_name, families.withdrawal_expiry_days, families.timezone").
+		Joins("JOIN children ON children.id = withdrawal_requests.child_id").
+		Joins("JOIN families ON families.id = withdrawal_requests.family_id").
+		Where("withdrawal_requests.status = ?", models.WithdrawalRequestStatusPending).
+		Order("withdrawal_requests.created_at ASC").
+		Find(&results).Error
+	if err != nil {
+		return nil, fmt.Errorf("list pending withdrawal requests: %w", err)
+	}
+	return results, nil
+}
+
+// Remind raises a pending withdrawal request's reminder level and notifies each of the family's
+// parents, in one transaction. Returns false without notifying anyone if the request is no longer
+// pending or parents were already sent a reminder at this level.
+func (r *WithdrawalRequestRepo) Remind(id int64, level int, kind models.NotificationKind, message string) (bool, error) {
+	reminded := false
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		result := tx.Model(&models.WithdrawalRequest{}).
+			Where("id = ? AND status = ? AND reminder_level < ?", id, models.WithdrawalRequestStatusPending, level).
+			Updates(map[string]interface{}{
+				"reminder_level": level,
+				"updated_at":     gorm.Expr("NOW()"),
+			})
+		if result.Error != nil {
+			return fmt.Errorf("update reminder level: %w", result.Error)
+		}
+		if result.RowsAffected == 0 {
+			return nil
+		}
+
+		err := tx.Exec(`INSERT INTO notifications (family_id, parent_id, kind, message, withdrawal_request_id)
+			SELECT p.family_id, p.id, ?, ?, wr.id
+			FROM withdrawal_requests wr
+			JOIN parents p ON p.family_id = wr.family_id
+			WHERE wr.id = ?`, kind, message, id).Error
+		if err != nil {
+			return fmt.Errorf("insert parent notifications: %w", err)
+		}
+		reminded = true
+		return nil
+	})
+	if err != nil {
+		return false, fmt.Errorf("remind withdrawal request: %w", err)
+	}
+	return reminded, nil
+}
+
+// ListStale returns pending withdrawal requests that have waited longer than their family's
+// withdrawal_expiry_days, oldest first.
+func (r *WithdrawalRequestRepo) ListStale() ([]models.WithdrawalRequest, error) {
+	var stale []models.WithdrawalRequest
+	err := r.db.Table("withdrawal_requests").
+		Select("withdrawal_requests.*").
+		Joins("JOIN families ON families.id = withdrawal_requests.family_id").
+		Where("withdrawal_requests.status = ?", models.WithdrawalRequestStatusPending).
+		Where("withdrawal_requests.created_at <= NOW() - families.withdrawal_expiry_days * INTERVAL '1 day'").
+		Order("withdrawal_requests.created_at ASC").
+		Find(&stale).Error
+	if err != nil {
+		return nil, fmt.Errorf("list stale withdrawal requests: %w", err)
+	}
+	return stale, nil
+}
+
+// Expire marks a pending withdrawal request expired and notifies its child with message, in one
+// transaction. Returns false without notifying anyone if the request is no longer pending.
+func (r *WithdrawalRequestRepo) Expire(id int64, message string) (bool, error) {
+	expired := false
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		var wr models.WithdrawalRequest
+		err := tx.Raw(`UPDATE withdrawal_requests
+			SET status = ?, expired_at = NOW(), updated_at = NOW()
+			WHERE id = ? AND status = ?
+			RETURNING *`,
+			models.WithdrawalRequestStatusExpired, id, models.WithdrawalRequestStatusPending).
+			Scan(&wr).Error
+		if err != nil {
+			return fmt.Errorf("update status: %w", err)
+		}
+		if wr.ID == 0 {
+			return nil
+		}
+
+		notification := models.Notification{
+			FamilyID:            wr.FamilyID,
+			ChildID:             &wr.ChildID,
+			Kind:                models.NotificationKindWithdrawalExpired,
+			Message:             message,
+			WithdrawalRequestID: &wr.ID,
+		}
+		if err := tx.Create(&notification).Error; err != nil {
+			return fmt.Errorf("insert child notification: %w", err)
+		}
+		expired = true
+		return nil
+	})
+	if err != nil {
+		return false, fmt.Errorf("expire withdrawal request: %w", err)
+	}
+	return expired, nil
+}
@@ -61,6 +61,7 @@ const typeConfig: Record<string, { icon: typeof ArrowDownCircle; color: string;
   deposit: { icon: ArrowDownCircle, color: "text-[#2D5A3D]", amountColor: "text-[#2D5A3D]" },
   withdrawal: { icon: ArrowUpCircle, color: "text-[#C4704B]", amountColor: "text-[#C4704B]" },
   withdrawal_request: { icon: ArrowUpCircle, color: "text-[#C4704B]", amountColor: "text-[#C4704B]" },
+  chore_penalty: { icon: ArrowUpCircle, color: "text-[#C4704B]", amountColor: "text-[#C4704B]" },
   allowance: { icon: Calendar, color: "text-[#2D5A3D]", amountColor: "text-[#2D5A3D]" },
   interest: { icon: TrendingUp, color: "text-[#D4A84B]", amountColor: "text-[#2D5A3D]" },
 };
@@ -77,7 +78,7 @@ function formatRecentDate(dateStr: string, timeZone: string): string {
 
 function formatRecentAmount(cents: number, type: string): string {
   const dollars = (cents / 100).toFixed(2);
-  return (type === "withdrawal" || type === "withdrawal_request") ? `-$${dollars}` : `+$${dollars}`;
+  return (type === "withdrawal" || type === "withdrawal_request" || type === "chore_penalty") ? `-$${dollars}` : `+$${dollars}`;
 }
 
 function getTypeLabel(type: string): string {
@@ -88,6 +89,7 @@ function getTypeLabel(type: string): string {
     case "allowance": return "Allowance";
     case "interest": return "Interest earned";
     case "chore": return "Deposit";
+    case "chore_penalty": return "Missed chore";
     default: return type;
   }
 }
@@ -79,7 +79,7 @@ export interface ChildListResponse {
 
 // Account Balances Feature (002-account-balances)
 
-export type TransactionType = 'deposit' | 'withdrawal' | 'allowance' | 'interest' | 'chore' | 'withdrawal_request';
+export type TransactionType = 'deposit' | 'withdrawal' | 'allowance' | 'interest' | 'chore' | 'withdrawal_request' | 'chore_penalty';
 
 export interface Transaction {
   id: number;